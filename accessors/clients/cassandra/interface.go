@@ -20,15 +20,29 @@ import (
 
 type GocqlSessionInterface interface {
 	KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMetadata, error)
+	Iter(stmt string, values ...interface{}) IterInterface
 	Close()
 }
 
+// IterInterface is the subset of *gocql.Iter used to page through query results.
+type IterInterface interface {
+	Scan(dest ...interface{}) bool
+	Close() error
+}
+
+// QueryInterface is implemented by types that can execute a CQL query and
+// return an iterator over its results.
+type QueryInterface interface {
+	Iter(stmt string, values ...interface{}) IterInterface
+}
+
 type KeyspaceMetadataInterface interface {
 	Tables() map[string]*gocql.TableMetadata
 }
 
 type CassandraClusterInterface interface {
 	KeyspaceMetadata(keyspace string) (KeyspaceMetadataInterface, error)
+	Iter(stmt string, values ...interface{}) IterInterface
 	Close() 
 }
 
@@ -51,6 +65,10 @@ func (gs *GocqlSessionImpl) KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMe
 	return ks, nil
 }
 
+func (gs *GocqlSessionImpl) Iter(stmt string, values ...interface{}) IterInterface {
+	return gs.session.Query(stmt, values...).Iter()
+}
+
 func (gs *GocqlSessionImpl) Close() {
 	if gs.session != nil {
 		gs.session.Close()
@@ -80,6 +98,10 @@ func (c *CassandraClusterImpl) KeyspaceMetadata(keyspace string) (KeyspaceMetada
 	return &CassandraKeyspaceMetadataImpl{keyspaceMetadata: ks}, nil
 }
 
+func (c *CassandraClusterImpl) Iter(stmt string, values ...interface{}) IterInterface {
+	return c.session.Iter(stmt, values...)
+}
+
 func (c *CassandraClusterImpl) Close() {
 	c.session.Close()
 }
@@ -14,6 +14,8 @@
 package cassandraclient
 
 import (
+	"reflect"
+
 	"github.com/gocql/gocql"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,6 +32,11 @@ func (m *MockGocqlSession) KeyspaceMetadata(keyspace string) (*gocql.KeyspaceMet
 	return nil, args.Error(1)
 }
 
+func (m *MockGocqlSession) Iter(stmt string, values ...interface{}) IterInterface {
+	args := m.Called(stmt, values)
+	return args.Get(0).(IterInterface)
+}
+
 func (m *MockGocqlSession) Close() {
 	m.Called()
 }
@@ -59,6 +66,37 @@ func (m *MockCassandraCluster) KeyspaceMetadata(keyspace string) (KeyspaceMetada
 	return nil, args.Error(1)
 }
 
+func (m *MockCassandraCluster) Iter(stmt string, values ...interface{}) IterInterface {
+	args := m.Called(stmt, values)
+	return args.Get(0).(IterInterface)
+}
+
 func (m *MockCassandraCluster) Close() {
 	m.Called()
 }
+
+// MockIter is an in-memory IterInterface. Each call to Scan copies the
+// values of the next row into dest, matching row entries to dest by
+// position. Err is returned from Close.
+type MockIter struct {
+	Rows [][]interface{}
+	Err  error
+	pos  int
+}
+
+func (m *MockIter) Scan(dest ...interface{}) bool {
+	if m.pos >= len(m.Rows) {
+		return false
+	}
+	for i, v := range m.Rows[m.pos] {
+		if i < len(dest) && v != nil {
+			reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+		}
+	}
+	m.pos++
+	return true
+}
+
+func (m *MockIter) Close() error {
+	return m.Err
+}
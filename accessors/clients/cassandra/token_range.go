@@ -0,0 +1,109 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cassandraclient
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// TokenRange represents a range of the Cassandra token ring. Start is
+// inclusive and End is exclusive. An empty Start or End means the range
+// is unbounded on that side.
+type TokenRange struct {
+	Start string
+	End   string
+}
+
+// Where returns the CQL predicate restricting rows to the token range for
+// the given partition key columns, together with its bind values. The
+// predicate is empty when the range covers the entire ring.
+func (tr TokenRange) Where(partitionKeys []string) (string, []interface{}) {
+	token := fmt.Sprintf("TOKEN(%s)", strings.Join(partitionKeys, ", "))
+	var clauses []string
+	var args []interface{}
+	if tr.Start != "" {
+		clauses = append(clauses, token+" >= ?")
+		args = append(args, tr.Start)
+	}
+	if tr.End != "" {
+		clauses = append(clauses, token+" < ?")
+		args = append(args, tr.End)
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// GetClusterTokenRanges retrieves the tokens owned by the local and peer
+// nodes of the cluster, deduplicates and sorts them, and returns a list of
+// token ranges that together cover the entire token ring.
+// TODO: Consider creating configurable number of partitions instead of system ranges.
+func GetClusterTokenRanges(q QueryInterface) ([]TokenRange, error) {
+	localTokens, err := scanTokens(q, "SELECT tokens FROM system.local")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get local node tokens: %w", err)
+	}
+	// Use peers_v2 for modern Cassandra.
+	peerTokens, err := scanTokens(q, "SELECT tokens FROM system.peers_v2")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get peer node tokens: %w", err)
+	}
+	return buildTokenRanges(append(localTokens, peerTokens...))
+}
+
+// buildTokenRanges turns an unordered list of ring tokens into contiguous,
+// non-overlapping token ranges.
+func buildTokenRanges(tokens []string) ([]TokenRange, error) {
+	seen := make(map[string]bool)
+	var bigIntTokens []*big.Int
+	for _, tokenStr := range tokens {
+		if seen[tokenStr] {
+			continue
+		}
+		seen[tokenStr] = true
+		bigIntToken := new(big.Int)
+		if _, ok := bigIntToken.SetString(tokenStr, 10); !ok {
+			return nil, fmt.Errorf("invalid token string: %s", tokenStr)
+		}
+		bigIntTokens = append(bigIntTokens, bigIntToken)
+	}
+	if len(bigIntTokens) == 0 {
+		return []TokenRange{{}}, nil
+	}
+	sort.Slice(bigIntTokens, func(i, j int) bool {
+		return bigIntTokens[i].Cmp(bigIntTokens[j]) < 0
+	})
+
+	tokenRanges := make([]TokenRange, 0, len(bigIntTokens)+1)
+	tokenRanges = append(tokenRanges, TokenRange{End: bigIntTokens[0].String()})
+	for i := 1; i < len(bigIntTokens); i++ {
+		tokenRanges = append(tokenRanges, TokenRange{Start: bigIntTokens[i-1].String(), End: bigIntTokens[i].String()})
+	}
+	tokenRanges = append(tokenRanges, TokenRange{Start: bigIntTokens[len(bigIntTokens)-1].String()})
+	return tokenRanges, nil
+}
+
+func scanTokens(q QueryInterface, stmt string) ([]string, error) {
+	var allTokens []string
+	var tokens []string
+	iter := q.Iter(stmt)
+	for iter.Scan(&tokens) {
+		allTokens = append(allTokens, tokens...)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return allTokens, nil
+}
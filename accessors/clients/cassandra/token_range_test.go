@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cassandraclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetClusterTokenRanges(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		client := new(MockCassandraCluster)
+		client.On("Iter", "SELECT tokens FROM system.local", mock.Anything).Return(&MockIter{Rows: [][]interface{}{{[]string{"100", "-50"}}}})
+		client.On("Iter", "SELECT tokens FROM system.peers_v2", mock.Anything).Return(&MockIter{Rows: [][]interface{}{{[]string{"7", "100"}}}})
+
+		ranges, err := GetClusterTokenRanges(client)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []TokenRange{
+			{End: "-50"},
+			{Start: "-50", End: "7"},
+			{Start: "7", End: "100"},
+			{Start: "100"},
+		}, ranges)
+	})
+
+	t.Run("No tokens", func(t *testing.T) {
+		client := new(MockCassandraCluster)
+		client.On("Iter", mock.Anything, mock.Anything).Return(&MockIter{})
+
+		ranges, err := GetClusterTokenRanges(client)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []TokenRange{{}}, ranges)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		client := new(MockCassandraCluster)
+		client.On("Iter", mock.Anything, mock.Anything).Return(&MockIter{Rows: [][]interface{}{{[]string{"abc"}}}})
+
+		_, err := GetClusterTokenRanges(client)
+
+		assert.EqualError(t, err, "invalid token string: abc")
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		client := new(MockCassandraCluster)
+		client.On("Iter", mock.Anything, mock.Anything).Return(&MockIter{Err: errors.New("unavailable")})
+
+		_, err := GetClusterTokenRanges(client)
+
+		assert.EqualError(t, err, "failed to get local node tokens: unavailable")
+	})
+}
+
+func TestTokenRangeWhere(t *testing.T) {
+	where, args := TokenRange{Start: "1", End: "2"}.Where([]string{"a", "b"})
+	assert.Equal(t, "TOKEN(a, b) >= ? AND TOKEN(a, b) < ?", where)
+	assert.Equal(t, []interface{}{"1", "2"}, args)
+
+	where, args = TokenRange{}.Where([]string{"a"})
+	assert.Equal(t, "", where)
+	assert.Nil(t, args)
+}
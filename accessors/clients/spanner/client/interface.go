@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
 )
 
 type SpannerClient interface {
@@ -12,10 +13,12 @@ type SpannerClient interface {
 	DatabaseName() string
 	Refresh(ctx context.Context, dbURI string) error
 	Apply(ctx context.Context, ms []*spanner.Mutation, opts ...spanner.ApplyOption) (commitTimestamp time.Time, err error)
+	BatchWrite(ctx context.Context, mgs []*spanner.MutationGroup) BatchWriteIterator
 }
 
 type ReadOnlyTransaction interface {
 	Query(ctx context.Context, stmt spanner.Statement) RowIterator
+	Read(ctx context.Context, table string, keys spanner.KeySet, columns []string) RowIterator
 }
 
 type RowIterator interface {
@@ -23,6 +26,12 @@ type RowIterator interface {
 	Stop()
 }
 
+// BatchWriteIterator iterates over the responses of a BatchWrite call, each
+// of which reports whether some of the mutation groups were applied.
+type BatchWriteIterator interface {
+	Do(f func(r *sppb.BatchWriteResponse) error) error
+}
+
 // This implements the SpannerClient interface. This is the primary implementation that should be used in all places other than tests.
 type SpannerClientImpl struct {
 	spannerClient *spanner.Client
@@ -36,6 +45,12 @@ func NewSpannerClientImpl(ctx context.Context, dbURI string) (*SpannerClientImpl
 	return &SpannerClientImpl{spannerClient: c}, nil
 }
 
+// NewSpannerClientImplWithClient wraps an existing client, which the caller
+// closes.
+func NewSpannerClientImplWithClient(c *spanner.Client) *SpannerClientImpl {
+	return &SpannerClientImpl{spannerClient: c}
+}
+
 func (c *SpannerClientImpl) Refresh(ctx context.Context, dbURI string) error {
 	var err error
 	c.spannerClient, err = CreateClient(ctx, dbURI)
@@ -58,6 +73,10 @@ func (c *SpannerClientImpl) Apply(ctx context.Context, ms []*spanner.Mutation, o
 	return c.spannerClient.Apply(ctx, ms, opts...)
 }
 
+func (c *SpannerClientImpl) BatchWrite(ctx context.Context, mgs []*spanner.MutationGroup) BatchWriteIterator {
+	return c.spannerClient.BatchWrite(ctx, mgs)
+}
+
 type ReadOnlyTransactionImpl struct {
 	rotxn *spanner.ReadOnlyTransaction
 }
@@ -67,6 +86,11 @@ func (ro *ReadOnlyTransactionImpl) Query(ctx context.Context, stmt spanner.State
 	return &RowIteratorImpl{ri: ri}
 }
 
+func (ro *ReadOnlyTransactionImpl) Read(ctx context.Context, table string, keys spanner.KeySet, columns []string) RowIterator {
+	ri := ro.rotxn.Read(ctx, table, keys, columns)
+	return &RowIteratorImpl{ri: ri}
+}
+
 type RowIteratorImpl struct {
 	ri *spanner.RowIterator
 }
@@ -12,6 +12,7 @@ type SpannerClientMock struct {
 	DatabaseNameMock func() string
 	RefreshMock      func(ctx context.Context, dbURI string) error
 	ApplyMock        func(ctx context.Context, ms []*spanner.Mutation, opts ...spanner.ApplyOption) (commitTimestamp time.Time, err error)
+	BatchWriteMock   func(ctx context.Context, mgs []*spanner.MutationGroup) BatchWriteIterator
 }
 
 func (scm SpannerClientMock) Refresh(ctx context.Context, dbURI string) error {
@@ -20,6 +21,7 @@ func (scm SpannerClientMock) Refresh(ctx context.Context, dbURI string) error {
 
 type ReadOnlyTransactionMock struct {
 	QueryMock func(ctx context.Context, stmt spanner.Statement) RowIterator
+	ReadMock  func(ctx context.Context, table string, keys spanner.KeySet, columns []string) RowIterator
 }
 
 type RowIteratorMock struct {
@@ -39,10 +41,18 @@ func (scm SpannerClientMock) Apply(ctx context.Context, ms []*spanner.Mutation,
 	return scm.ApplyMock(ctx, ms, opts...)
 }
 
+func (scm SpannerClientMock) BatchWrite(ctx context.Context, mgs []*spanner.MutationGroup) BatchWriteIterator {
+	return scm.BatchWriteMock(ctx, mgs)
+}
+
 func (rom ReadOnlyTransactionMock) Query(ctx context.Context, stmt spanner.Statement) RowIterator {
 	return rom.QueryMock(ctx, stmt)
 }
 
+func (rom ReadOnlyTransactionMock) Read(ctx context.Context, table string, keys spanner.KeySet, columns []string) RowIterator {
+	return rom.ReadMock(ctx, table, keys, columns)
+}
+
 func (rim RowIteratorMock) Next() (*spanner.Row, error) {
 	return rim.NextMock()
 }
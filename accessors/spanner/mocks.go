@@ -15,6 +15,7 @@ package spanneraccessor
 
 import (
 	"context"
+	"io"
 
 	spanneradmin "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/admin"
 	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
@@ -38,6 +39,9 @@ type SpannerAccessorMock struct {
 	VerifyDbMock                    func(ctx context.Context, dbURI string, conv *internal.Conv, tablesExistingOnSpanner []string) (dbExists bool, err error)
 	ValidateDDLMock                 func(ctx context.Context, conv *internal.Conv, tablesExistingOnSpanner []string) error
 	UpdateDDLForeignKeysMock        func(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string)
+	UpdateDDLIndexesMock            func(ctx context.Context, dbURI string, conv *internal.Conv, driver string)
+	UpdateDDLMock                   func(ctx context.Context, dbURI string, statements []string) error
+	CheckForeignKeyOrphansMock      func(ctx context.Context, conv *internal.Conv, driver string, orphans io.Writer) error
 	DropDatabaseMock                func(ctx context.Context, dbURI string) error
 	ValidateDMLMock                 func(ctx context.Context, query string) (bool, error)
 	TableExistsMock                 func(ctx context.Context, tableName string) (bool, error)
@@ -93,6 +97,14 @@ func (sam *SpannerAccessorMock) ValidateDDL(ctx context.Context, conv *internal.
 }
 func (sam *SpannerAccessorMock) UpdateDDLForeignKeys(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string) {
 }
+func (sam *SpannerAccessorMock) UpdateDDLIndexes(ctx context.Context, dbURI string, conv *internal.Conv, driver string) {
+}
+func (sam *SpannerAccessorMock) UpdateDDL(ctx context.Context, dbURI string, statements []string) error {
+	return sam.UpdateDDLMock(ctx, dbURI, statements)
+}
+func (sam *SpannerAccessorMock) CheckForeignKeyOrphans(ctx context.Context, conv *internal.Conv, driver string, orphans io.Writer) error {
+	return sam.CheckForeignKeyOrphansMock(ctx, conv, driver, orphans)
+}
 
 // DropDatabase implements SpannerAccessor.
 func (sam *SpannerAccessorMock) DropDatabase(ctx context.Context, dbURI string) error {
@@ -15,7 +15,9 @@ package spanneraccessor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -44,6 +46,16 @@ var (
 	// AdminQuota limits are mentioned here: https://cloud.google.com/spanner/quotas#administrative_limits
 	// If facing a quota limit error, consider reducing this value.
 	MaxWorkers = 50
+
+	// Set the number of secondary indexes created by each schema update when
+	// indexes are created after the data migration. Spanner backfills the
+	// indexes of a schema update together, but a failing statement stops the
+	// statements after it.
+	IndexBatchSize = 10
+
+	// Set the number of orphaned rows of each foreign key that are recorded
+	// for the conversion report.
+	MaxOrphanSamples = 5
 )
 
 // The SpannerAccessor provides methods that internally use a spanner client (can be adminClient/databaseclient/instanceclient etc).
@@ -76,6 +88,12 @@ type SpannerAccessor interface {
 	ValidateDDL(ctx context.Context, conv *internal.Conv, tablesExistingOnSpanner []string) error
 	// UpdateDDLForeignKeys updates the Spanner database with foreign key constraints using ALTER TABLE statements.
 	UpdateDDLForeignKeys(ctx context.Context, dbURI string, conv *internal.Conv, driver string, migrationType string)
+	// CheckForeignKeyOrphans finds the rows that don't satisfy the foreign keys of conv, which UpdateDDLForeignKeys then doesn't create.
+	CheckForeignKeyOrphans(ctx context.Context, conv *internal.Conv, driver string, orphans io.Writer) error
+	// UpdateDDLIndexes updates the Spanner database with the secondary indexes that were deferred until after the data migration.
+	UpdateDDLIndexes(ctx context.Context, dbURI string, conv *internal.Conv, driver string)
+	// UpdateDDL applies DDL statements to the Spanner database as one schema update.
+	UpdateDDL(ctx context.Context, dbURI string, statements []string) error
 	// Deletes a database.
 	DropDatabase(ctx context.Context, dbURI string) error
 	//Runs a query against the provided spanner database and returns if the executed DML is validate or not
@@ -296,7 +314,8 @@ func (sp *SpannerAccessorImpl) CreateDatabase(ctx context.Context, dbURI string,
 	// The schema we send to Spanner excludes comments (since Cloud
 	// Spanner DDL doesn't accept them), and protects table and col names
 	// using backticks (to avoid any issues with Spanner reserved words).
-	// Foreign Keys are set to false since we create them post data migration.
+	// Foreign Keys are set to false since we create them post data migration,
+	// as are secondary indexes when conv.Audit.DeferIndexes is set.
 	req := &adminpb.CreateDatabaseRequest{
 		Parent: fmt.Sprintf("projects/%s/instances/%s", project, instance),
 	}
@@ -308,8 +327,10 @@ func (sp *SpannerAccessorImpl) CreateDatabase(ctx context.Context, dbURI string,
 		if migrationType == constants.DATAFLOW_MIGRATION {
 			req.ExtraStatements = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
 		} else {
-			req.ExtraStatements = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+			req.ExtraStatements = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SkipIndexes: conv.Audit.DeferIndexes, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
 		}
+		// Views only read from tables, so they are created along with them.
+		req.ExtraStatements = append(req.ExtraStatements, ddl.GetViewDDL(ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpViews)...)
 
 	}
 
@@ -349,8 +370,11 @@ func (sp *SpannerAccessorImpl) UpdateDatabase(ctx context.Context, dbURI string,
 	// The schema we send to Spanner excludes comments (since Cloud
 	// Spanner DDL doesn't accept them), and protects table and col names
 	// using backticks (to avoid any issues with Spanner reserved words).
-	// Foreign Keys are set to false since we create them post data migration.
-	schema := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	// Foreign Keys are set to false since we create them post data migration,
+	// as are secondary indexes when they are deferred.
+	config := ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: false, SkipIndexes: conv.Audit.DeferIndexes, SpDialect: conv.SpDialect, Source: driver}
+	schema := ddl.GetDDL(config, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	schema = append(schema, ddl.GetViewDDL(config, conv.SpViews)...)
 	if len(schema) == 0 {
 		return nil
 	}
@@ -482,7 +506,10 @@ func (sp *SpannerAccessorImpl) UpdateDDLForeignKeys(ctx context.Context, dbURI s
 	// using backticks (to avoid any issues with Spanner reserved words).
 	// Sequences will not be passed as they have already been created.
 	// Database options will not be passed since they have also already been set.
-	fkStmts := ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: false, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, make(map[string]ddl.Sequence), ddl.DatabaseOptions{})
+	// Foreign keys with orphaned rows are left out since they can't be
+	// created.
+	config := ddl.Config{Comments: false, ProtectIds: true, Tables: false, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}
+	fkStmts := skipForeignKeysWithOrphans(ddl.GetDDL(config, conv.SpSchema, make(map[string]ddl.Sequence), ddl.DatabaseOptions{}), conv, config)
 	if len(fkStmts) == 0 {
 		return
 	}
@@ -552,6 +579,217 @@ func (sp *SpannerAccessorImpl) UpdateDDLForeignKeys(ctx context.Context, dbURI s
 	conv.Audit.Progress.Done()
 }
 
+// skipForeignKeysWithOrphans returns fkStmts without the statements of the
+// foreign keys in conv.Audit.ForeignKeyOrphans.
+func skipForeignKeysWithOrphans(fkStmts []string, conv *internal.Conv, c ddl.Config) []string {
+	skip := make(map[string]bool)
+	for _, o := range conv.Audit.ForeignKeyOrphans {
+		for _, fk := range conv.SpSchema[o.TableId].ForeignKeys {
+			if fk.Id == o.ForeignKeyId {
+				skip[fk.PrintForeignKeyAlterTable(conv.SpSchema, c, o.TableId)] = true
+			}
+		}
+	}
+	var stmts []string
+	for _, stmt := range fkStmts {
+		if !skip[stmt] {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+// orphanRow is an orphaned row of a foreign key, as written by
+// CheckForeignKeyOrphans.
+type orphanRow struct {
+	Table      string        `json:"table"`
+	ForeignKey string        `json:"foreignKey"`
+	Columns    []string      `json:"columns"`
+	Values     []interface{} `json:"values"`
+}
+
+// CheckForeignKeyOrphans looks for orphaned rows of the foreign keys in
+// conv.SpSchema, i.e. rows whose foreign key columns are all set but don't
+// match a row of the referenced table. Spanner can't create a foreign key
+// that has orphaned rows. Each foreign key with orphaned rows is recorded in
+// conv.Audit.ForeignKeyOrphans, along with the primary and foreign key
+// columns of up to MaxOrphanSamples of the rows. If orphans isn't nil, all
+// orphaned rows are written to it as JSON lines.
+func (sp *SpannerAccessorImpl) CheckForeignKeyOrphans(ctx context.Context, conv *internal.Conv, driver string, orphans io.Writer) error {
+	c := ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect, Source: driver}
+	for _, tableId := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		table := conv.SpSchema[tableId]
+		for _, fk := range table.ForeignKeys {
+			from, cols := foreignKeyOrphansQuery(conv.SpSchema, c, tableId, fk)
+			internal.VerbosePrintf("Checking foreign key %s of table %s for orphaned rows\n", fk.Name, table.Name)
+			var count int64
+			err := sp.query(ctx, "SELECT COUNT(*) "+from, func(row *spanner.Row) error {
+				return row.Columns(&count)
+			})
+			if err != nil {
+				return fmt.Errorf("can't count orphaned rows of foreign key %s of table %s: %v", fk.Name, table.Name, err)
+			}
+			if count == 0 {
+				continue
+			}
+			found := internal.ForeignKeyOrphans{TableId: tableId, ForeignKeyId: fk.Id, Orphans: count}
+			query := fmt.Sprintf("SELECT %s %s", strings.Join(cols, ", "), from)
+			if orphans == nil {
+				query += fmt.Sprintf(" LIMIT %d", MaxOrphanSamples)
+			}
+			err = sp.query(ctx, query, func(row *spanner.Row) error {
+				r := orphanRow{Table: table.QualifiedName(), ForeignKey: fk.Name, Columns: row.ColumnNames()}
+				var sample []string
+				for i := range r.Columns {
+					var v spanner.GenericColumnValue
+					if err := row.Column(i, &v); err != nil {
+						return err
+					}
+					r.Values = append(r.Values, v.Value.AsInterface())
+					sample = append(sample, fmt.Sprintf("%s=%v", r.Columns[i], r.Values[i]))
+				}
+				if len(found.Samples) < MaxOrphanSamples {
+					found.Samples = append(found.Samples, strings.Join(sample, ", "))
+				}
+				if orphans == nil {
+					return nil
+				}
+				b, err := json.Marshal(r)
+				if err != nil {
+					return err
+				}
+				_, err = orphans.Write(append(b, '\n'))
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("can't read orphaned rows of foreign key %s of table %s: %v", fk.Name, table.Name, err)
+			}
+			conv.Audit.ForeignKeyOrphans = append(conv.Audit.ForeignKeyOrphans, found)
+		}
+	}
+	return nil
+}
+
+// foreignKeyOrphansQuery returns the FROM and WHERE clauses of a query for
+// the orphaned rows of foreign key fk of table tableId, and the primary and
+// foreign key columns of the table for the query to select.
+func foreignKeyOrphansQuery(s ddl.Schema, c ddl.Config, tableId string, fk ddl.Foreignkey) (string, []string) {
+	table := s[tableId]
+	var cols, set, match []string
+	selected := make(map[string]bool)
+	addCol := func(colId string) {
+		if !selected[colId] {
+			selected[colId] = true
+			cols = append(cols, "c."+c.QuoteIdentifier(table.ColDefs[colId].Name))
+		}
+	}
+	for _, pk := range table.PrimaryKeys {
+		addCol(pk.ColId)
+	}
+	for i, colId := range fk.ColIds {
+		addCol(colId)
+		col := "c." + c.QuoteIdentifier(table.ColDefs[colId].Name)
+		referCol := "p." + c.QuoteIdentifier(s[fk.ReferTableId].ColDefs[fk.ReferColumnIds[i]].Name)
+		// Spanner doesn't check rows with a NULL foreign key column.
+		set = append(set, col+" IS NOT NULL")
+		match = append(match, referCol+" = "+col)
+	}
+	from := fmt.Sprintf("FROM %s AS c WHERE %s AND NOT EXISTS (SELECT 1 FROM %s AS p WHERE %s)",
+		c.QuoteQualifiedName(table.QualifiedName()), strings.Join(set, " AND "), c.QuoteQualifiedName(s[fk.ReferTableId].QualifiedName()), strings.Join(match, " AND "))
+	return from, cols
+}
+
+// query runs the SQL query stmt, calling f for each row of the result.
+func (sp *SpannerAccessorImpl) query(ctx context.Context, stmt string, f func(row *spanner.Row) error) error {
+	iter := sp.SpannerClient.Single().Query(ctx, spanner.Statement{SQL: stmt})
+	defer iter.Stop()
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(row); err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateDDLIndexes updates the Spanner database with the secondary indexes
+// that CreateDatabase and UpdateDatabase left out because
+// conv.Audit.DeferIndexes is set. Backfilling the indexes once after the data
+// migration is much faster than maintaining them while rows are written.
+// Indexes are created in batches of IndexBatchSize. Indexes that already
+// exist, e.g. because a resumed migration created them, are skipped, and
+// indexes that can't be created are reported as unexpected conditions.
+func (sp *SpannerAccessorImpl) UpdateDDLIndexes(ctx context.Context, dbURI string, conv *internal.Conv, driver string) {
+	indexStmts := ddl.GetIndexDDL(ddl.Config{Comments: false, ProtectIds: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema)
+	if len(indexStmts) == 0 {
+		return
+	}
+	msg := fmt.Sprintf("Updating schema of database %s with secondary indexes ...", dbURI)
+	conv.Audit.Progress = *internal.NewProgress(int64(len(indexStmts)), msg, internal.Verbose(), true, int(internal.IndexUpdateInProgress))
+	for i := 0; i < len(indexStmts); i += IndexBatchSize {
+		batch := indexStmts[i:min(i+IndexBatchSize, len(indexStmts))]
+		if err := sp.updateDDLIndexes(ctx, dbURI, batch); err != nil {
+			// Statements of the batch before the failing one have been
+			// applied, so create the indexes one by one to find the ones
+			// that fail.
+			logger.Log.Debug("Can't add batch of indexes, adding them one at a time", zap.Error(err))
+			for _, stmt := range batch {
+				if err := sp.updateDDLIndexes(ctx, dbURI, []string{stmt}); err != nil {
+					logger.Log.Debug("Can't add index with statement:" + stmt + "\n due to error:" + err.Error() + " Skipping this index...\n")
+					conv.Unexpected(fmt.Sprintf("Can't add index with statement %s: %s", stmt, err))
+				}
+			}
+		}
+		conv.Audit.Progress.MaybeReport(int64(i + len(batch)))
+	}
+	conv.Audit.Progress.UpdateProgress("Secondary index update complete.", 100, internal.IndexUpdateComplete)
+	conv.Audit.Progress.Done()
+}
+
+// updateDDLIndexes runs one schema update that creates indexes. An index that
+// already exists isn't an error.
+func (sp *SpannerAccessorImpl) updateDDLIndexes(ctx context.Context, dbURI string, indexStmts []string) error {
+	internal.VerbosePrintf("Submitting new index create request: %s\n", strings.Join(indexStmts, ";\n"))
+	op, err := sp.AdminClient.UpdateDatabaseDdl(ctx, &adminpb.UpdateDatabaseDdlRequest{
+		Database:   dbURI,
+		Statements: indexStmts,
+	})
+	if err == nil {
+		err = op.Wait(ctx)
+	}
+	if err != nil && !(len(indexStmts) == 1 && strings.Contains(err.Error(), "Duplicate name in schema")) {
+		return err
+	}
+	logger.Log.Debug("Updated schema with index statements", zap.Strings("indexStmts", indexStmts))
+	return nil
+}
+
+// UpdateDDL applies statements to the Spanner database as one schema
+// update. Spanner applies the statements in order, and a failing statement
+// stops the statements after it, but the statements before it stay applied.
+func (sp *SpannerAccessorImpl) UpdateDDL(ctx context.Context, dbURI string, statements []string) error {
+	if len(statements) == 0 {
+		return nil
+	}
+	op, err := sp.AdminClient.UpdateDatabaseDdl(ctx, &adminpb.UpdateDatabaseDdlRequest{
+		Database:   dbURI,
+		Statements: statements,
+	})
+	if err != nil {
+		return fmt.Errorf("can't build UpdateDatabaseDdlRequest: %w", parse.AnalyzeError(err, dbURI))
+	}
+	if err := op.Wait(ctx); err != nil {
+		return fmt.Errorf("UpdateDatabaseDdl call failed: %w", parse.AnalyzeError(err, dbURI))
+	}
+	logger.Log.Debug("Updated schema", zap.Strings("statements", statements))
+	return nil
+}
+
 func (sp *SpannerAccessorImpl) DropDatabase(ctx context.Context, dbURI string) error {
 
 	err := sp.AdminClient.DropDatabase(ctx, &adminpb.DropDatabaseRequest{Database: dbURI})
@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -1196,6 +1197,176 @@ func TestSpannerAccessorImpl_UpdateDDLForeignKey(t *testing.T) {
 	}
 }
 
+func TestSpannerAccessorImpl_CreateDatabase_DeferIndexes(t *testing.T) {
+	var statements []string
+	acm := spanneradmin.AdminClientMock{
+		CreateDatabaseMock: func(ctx context.Context, req *databasepb.CreateDatabaseRequest, opts ...gax.CallOption) (spanneradmin.CreateDatabaseOperation, error) {
+			statements = req.ExtraStatements
+			return &spanneradmin.CreateDatabaseOperationMock{
+				WaitMock: func(ctx context.Context, opts ...gax.CallOption) (*databasepb.Database, error) { return nil, nil },
+			}, nil
+		},
+	}
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:        "table1",
+			Id:          "t1",
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+			ColIds:      []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "col1", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "col2", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+			},
+			Indexes: []ddl.CreateIndex{{Name: "idx1", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2"}}}},
+		},
+	}
+	conv.Audit.DeferIndexes = true
+	spA := SpannerAccessorImpl{AdminClient: &acm}
+	err := spA.CreateDatabase(context.Background(), "projects/project-id/instances/instance-id/databases/database-id", conv, "", constants.BULK_MIGRATION)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(statements))
+	assert.True(t, strings.HasPrefix(statements[0], "CREATE TABLE"))
+}
+
+func TestSpannerAccessorImpl_UpdateDDLIndexes(t *testing.T) {
+	defer func(n int) { IndexBatchSize = n }(IndexBatchSize)
+	IndexBatchSize = 2
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	table := ddl.CreateTable{
+		Name:        "table1",
+		Id:          "t1",
+		PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+		ColIds:      []string{"c1", "c2"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "col1", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "col2", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+		},
+	}
+	for _, name := range []string{"idx1", "idx2", "idx3"} {
+		table.Indexes = append(table.Indexes, ddl.CreateIndex{Name: name, TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2"}}})
+	}
+	conv.SpSchema["t1"] = table
+	var requests [][]string
+	acm := spanneradmin.AdminClientMock{
+		UpdateDatabaseDdlMock: func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+			requests = append(requests, req.Statements)
+			var err error
+			if len(req.Statements) > 1 && strings.Contains(req.Statements[0], "idx1") {
+				// idx1 is created before idx2 fails.
+				err = fmt.Errorf("error")
+			} else if strings.Contains(req.Statements[0], "idx1") {
+				err = fmt.Errorf("Duplicate name in schema: idx1")
+			} else if strings.Contains(req.Statements[0], "idx2") {
+				err = fmt.Errorf("error")
+			}
+			return &spanneradmin.UpdateDatabaseDdlOperationMock{
+				WaitMock: func(ctx context.Context, opts ...gax.CallOption) error { return err },
+			}, nil
+		},
+	}
+	spA := SpannerAccessorImpl{AdminClient: &acm}
+	spA.UpdateDDLIndexes(context.Background(), "projects/project-id/instances/instance-id/databases/database-id", conv, "")
+	assert.Equal(t, [][]string{
+		{"CREATE INDEX `idx1` ON `table1` (`col2`)", "CREATE INDEX `idx2` ON `table1` (`col2`)"},
+		{"CREATE INDEX `idx1` ON `table1` (`col2`)"},
+		{"CREATE INDEX `idx2` ON `table1` (`col2`)"},
+		{"CREATE INDEX `idx3` ON `table1` (`col2`)"},
+	}, requests)
+	assert.Equal(t, int64(1), conv.Stats.Unexpected["Can't add index with statement CREATE INDEX `idx2` ON `table1` (`col2`): error"])
+	assert.Equal(t, internal.IndexUpdateComplete, conv.Audit.Progress.ProgressStatus)
+}
+
+func TestSpannerAccessorImpl_UpdateDDL(t *testing.T) {
+	var requests [][]string
+	var waitErr error
+	acm := spanneradmin.AdminClientMock{
+		UpdateDatabaseDdlMock: func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+			requests = append(requests, req.Statements)
+			return &spanneradmin.UpdateDatabaseDdlOperationMock{
+				WaitMock: func(ctx context.Context, opts ...gax.CallOption) error { return waitErr },
+			}, nil
+		},
+	}
+	spA := SpannerAccessorImpl{AdminClient: &acm}
+	dbURI := "projects/project-id/instances/instance-id/databases/database-id"
+	assert.Nil(t, spA.UpdateDDL(context.Background(), dbURI, nil))
+	assert.Nil(t, requests)
+
+	stmts := []string{"DROP INDEX `idx1`", "ALTER TABLE `table1` ADD COLUMN `col3` INT64"}
+	assert.Nil(t, spA.UpdateDDL(context.Background(), dbURI, stmts))
+	assert.Equal(t, [][]string{stmts}, requests)
+
+	waitErr = fmt.Errorf("error")
+	assert.ErrorContains(t, spA.UpdateDDL(context.Background(), dbURI, stmts), "UpdateDatabaseDdl call failed")
+}
+
+func TestSpannerAccessorImpl_CheckForeignKeyOrphans(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:        "orders",
+			SchemaName:  "sales",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "id", Id: "c1"}, "c2": {Name: "customer_id", Id: "c2"}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+			ForeignKeys: []ddl.Foreignkey{{Name: "fk_customer", Id: "f1", ColIds: []string{"c2"}, ReferTableId: "t2", ReferColumnIds: []string{"c3"}}},
+		},
+		"t2": {
+			Name:        "customers",
+			Id:          "t2",
+			ColIds:      []string{"c3"},
+			ColDefs:     map[string]ddl.ColumnDef{"c3": {Name: "id", Id: "c3"}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c3"}},
+		},
+	}
+	var queries []string
+	rows := func(names []string, values ...[]interface{}) spannerclient.RowIterator {
+		i := 0
+		return &spannerclient.RowIteratorMock{
+			NextMock: func() (*spanner.Row, error) {
+				if i == len(values) {
+					return nil, iterator.Done
+				}
+				i++
+				return spanner.NewRow(names, values[i-1])
+			},
+			StopMock: func() {},
+		}
+	}
+	spA := SpannerAccessorImpl{SpannerClient: spannerclient.SpannerClientMock{
+		SingleMock: func() spannerclient.ReadOnlyTransaction {
+			return &spannerclient.ReadOnlyTransactionMock{
+				QueryMock: func(ctx context.Context, stmt spanner.Statement) spannerclient.RowIterator {
+					queries = append(queries, stmt.SQL)
+					if strings.HasPrefix(stmt.SQL, "SELECT COUNT(*)") {
+						return rows([]string{""}, []interface{}{int64(2)})
+					}
+					return rows([]string{"id", "customer_id"}, []interface{}{int64(1), int64(7)}, []interface{}{int64(2), int64(8)})
+				},
+			}
+		},
+	}}
+	var orphans strings.Builder
+	err := spA.CheckForeignKeyOrphans(context.Background(), conv, "", &orphans)
+	assert.Nil(t, err)
+	from := "FROM `sales`.`orders` AS c WHERE c.`customer_id` IS NOT NULL AND NOT EXISTS (SELECT 1 FROM `customers` AS p WHERE p.`id` = c.`customer_id`)"
+	assert.Equal(t, []string{"SELECT COUNT(*) " + from, "SELECT c.`id`, c.`customer_id` " + from}, queries)
+	assert.Equal(t, []internal.ForeignKeyOrphans{{TableId: "t1", ForeignKeyId: "f1", Orphans: 2, Samples: []string{"id=1, customer_id=7", "id=2, customer_id=8"}}}, conv.Audit.ForeignKeyOrphans)
+	assert.Equal(t, `{"table":"sales.orders","foreignKey":"fk_customer","columns":["id","customer_id"],"values":["1","7"]}`+"\n"+
+		`{"table":"sales.orders","foreignKey":"fk_customer","columns":["id","customer_id"],"values":["2","8"]}`+"\n", orphans.String())
+
+	// Foreign keys with orphaned rows aren't created.
+	c := ddl.Config{ProtectIds: true, ForeignKeys: true, SpDialect: conv.SpDialect}
+	stmts := ddl.GetDDL(c, conv.SpSchema, map[string]ddl.Sequence{}, ddl.DatabaseOptions{})
+	assert.Equal(t, 1, len(stmts))
+	assert.Empty(t, skipForeignKeysWithOrphans(stmts, conv, c))
+}
+
 func TestValidateDML(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Valid DML", func(t *testing.T) {
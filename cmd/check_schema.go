@@ -0,0 +1,170 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/conversion"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/google/subcommands"
+)
+
+const schemaDiffFile = ".schema-diff.json"
+
+// CheckSchemaCmd is the command for checking that the schema of a Spanner
+// database conforms to the schema of a session file, e.g. after a migration
+// or after the database was changed by hand.
+type CheckSchemaCmd struct {
+	targetProfile string
+	sessionJSON   string
+	filePrefix    string
+	logLevel      string
+}
+
+// Name returns the name of operation.
+func (cmd *CheckSchemaCmd) Name() string {
+	return "check-schema"
+}
+
+// Synopsis returns summary of operation.
+func (cmd *CheckSchemaCmd) Synopsis() string {
+	return "check-schema compares the schema of the Spanner database with the schema of the session file"
+}
+
+// Usage returns usage info of the command.
+func (cmd *CheckSchemaCmd) Usage() string {
+	return fmt.Sprintf(`%v check-schema --target-profile="instance=i1,dbName=db1" --session=session.json ...
+
+Compare the schema of the Spanner database with the Spanner schema of the
+session file, or with the schema of a file of Spanner DDL statements if the
+--session file name ends in .sql: tables in all named schemas, columns and their types and
+nullability, primary keys, interleaving, row deletion policies, indexes,
+search and vector indexes, foreign keys, check constraints, sequences and
+views. The options of vector indexes aren't compared. The differences are
+printed and written to PREFIX%s. The command fails if there are any
+differences.
+`, path.Base(os.Args[0]), schemaDiffFile)
+}
+
+// SetFlags sets the flags.
+func (cmd *CheckSchemaCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&cmd.targetProfile, "target-profile", "", "Flag for specifying connection profile for target database e.g., \"instance=my-instance,dbName=my-db\"")
+	f.StringVar(&cmd.sessionJSON, "session", "", "Specifies the file we restore session state from, or a .sql file of Spanner DDL statements")
+	f.StringVar(&cmd.filePrefix, "prefix", "", "File prefix for generated files")
+	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+}
+
+func (cmd *CheckSchemaCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := logger.InitializeLogger(cmd.logLevel)
+	if err != nil {
+		fmt.Println("Error initialising logger, did you specify a valid log-level? [DEBUG, INFO, WARN, ERROR, FATAL]", err)
+		return subcommands.ExitFailure
+	}
+	defer logger.Log.Sync()
+	targetProfile, err := cmd.validate()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Input validation failed. Reason %v", err))
+		return subcommands.ExitUsageError
+	}
+	conv := internal.MakeConv()
+	if err = readExpectedSchema(conv, cmd.sessionJSON, targetProfile); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read session file %s: %v", cmd.sessionJSON, err))
+		return subcommands.ExitUsageError
+	}
+	if cmd.filePrefix == "" {
+		cmd.filePrefix = targetProfile.Conn.Sp.Dbname
+	}
+
+	ioHelper := utils.NewIOStreams(conv.Source, "")
+	adminClient, client, dbURI, err := CreateDatabaseClient(ctx, targetProfile, conv.Source, "", ioHelper)
+	if adminClient != nil {
+		defer adminClient.Close()
+	}
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't connect to the Spanner database: %v", err))
+		return subcommands.ExitFailure
+	}
+	defer client.Close()
+
+	live, err := validation.ReadLiveSchema(spanner.InfoSchemaImpl{Client: client, Ctx: ctx, SpDialect: conv.SpDialect})
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read the schema of database %s: %v", dbURI, err))
+		return subcommands.ExitFailure
+	}
+	diffs := validation.DiffSchema(conv, live)
+	writeSchemaDiff(diffs, os.Stdout)
+	if diffs == nil {
+		diffs = []validation.SchemaDifference{}
+	}
+	if err = writeJSONFile(cmd.filePrefix+schemaDiffFile, diffs); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't write schema differences: %v", err))
+		return subcommands.ExitFailure
+	}
+	fmt.Printf("Wrote schema differences to %s for database %s\n", cmd.filePrefix+schemaDiffFile, dbURI)
+	if len(diffs) > 0 {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+func (cmd *CheckSchemaCmd) validate() (profiles.TargetProfile, error) {
+	if cmd.sessionJSON == "" {
+		return profiles.TargetProfile{}, fmt.Errorf("Please specify the session file using the --session parameter")
+	}
+	targetProfile, err := profiles.NewTargetProfile(cmd.targetProfile)
+	if err != nil {
+		return profiles.TargetProfile{}, err
+	}
+	if targetProfile.Conn.Sp.Dbname == "" {
+		return targetProfile, fmt.Errorf("Please specify the Spanner database using dbName in the --target-profile parameter")
+	}
+	return targetProfile, nil
+}
+
+// readExpectedSchema reads the schema that the database is compared with
+// into conv: a file of Spanner DDL statements if name ends in .sql, parsed
+// in the dialect of the target profile, and a session file otherwise.
+func readExpectedSchema(conv *internal.Conv, name string, targetProfile profiles.TargetProfile) error {
+	if !strings.HasSuffix(strings.ToLower(name), ".sql") {
+		return conversion.ReadSessionFile(conv, name)
+	}
+	conv.SpDialect = targetProfile.Conn.Sp.Dialect
+	return conversion.ReadSpannerSchemaFile(conv, name)
+}
+
+// writeSchemaDiff writes the differences between the schemas to w, one per
+// line.
+func writeSchemaDiff(diffs []validation.SchemaDifference, w io.Writer) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "The schema of the database matches the session file")
+		return
+	}
+	for _, d := range diffs {
+		fmt.Fprintln(w, d.String())
+	}
+	fmt.Fprintf(w, "\nFound %d differences between the schema of the database and the session file\n", len(diffs))
+}
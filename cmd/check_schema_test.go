@@ -0,0 +1,94 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSchemaCmd_SetFlags(t *testing.T) {
+	cmd := &CheckSchemaCmd{}
+	fs := flag.NewFlagSet("check-schema", flag.ContinueOnError)
+	cmd.SetFlags(fs)
+	assert.Nil(t, fs.Parse([]string{"--target-profile=instance=i1,dbName=db1", "--session=session.json", "--prefix=p"}))
+	assert.Equal(t, CheckSchemaCmd{
+		targetProfile: "instance=i1,dbName=db1",
+		sessionJSON:   "session.json",
+		filePrefix:    "p",
+		logLevel:      "DEBUG",
+	}, *cmd)
+}
+
+func TestCheckSchemaCmd_Validate(t *testing.T) {
+	cmd := CheckSchemaCmd{}
+	_, err := cmd.validate()
+	assert.ErrorContains(t, err, "Please specify the session file")
+	cmd.sessionJSON = "session.json"
+	cmd.targetProfile = "instance=i1"
+	_, err = cmd.validate()
+	assert.ErrorContains(t, err, "Please specify the Spanner database")
+	cmd.targetProfile = "instance=i1,dbName=db1"
+	targetProfile, err := cmd.validate()
+	assert.Nil(t, err)
+	assert.Equal(t, "db1", targetProfile.Conn.Sp.Dbname)
+}
+
+func TestWriteSchemaDiff(t *testing.T) {
+	var out bytes.Buffer
+	writeSchemaDiff(nil, &out)
+	assert.Equal(t, "The schema of the database matches the session file\n", out.String())
+
+	out.Reset()
+	writeSchemaDiff([]validation.SchemaDifference{
+		{Kind: validation.KindTable, Table: "orders", Name: "orders", Expected: "orders"},
+		{Kind: validation.KindColumn, Table: "customers", Name: "name", Property: "nullability", Expected: "NOT NULL", Actual: "NULL"},
+	}, &out)
+	assert.Equal(t, `table "orders" is missing from the database
+customers: column "name" nullability: expected NOT NULL, found NULL
+
+Found 2 differences between the schema of the database and the session file
+`, out.String())
+}
+
+func TestReadExpectedSchema(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "schema.SQL")
+	assert.Nil(t, os.WriteFile(name, []byte("CREATE TABLE orders (\n  id bigint NOT NULL,\n  PRIMARY KEY (id)\n);\nCREATE INDEX idx ON orders (id);\n"), 0644))
+	targetProfile, err := profiles.NewTargetProfile("instance=i1,dbName=db1,dialect=postgresql")
+	assert.Nil(t, err)
+	conv := internal.MakeConv()
+	assert.Nil(t, readExpectedSchema(conv, name, targetProfile))
+	assert.Equal(t, constants.DIALECT_POSTGRESQL, conv.SpDialect)
+	assert.Equal(t, 1, len(conv.SpSchema))
+	for _, ct := range conv.SpSchema {
+		assert.Equal(t, "orders", ct.Name)
+		assert.Equal(t, 1, len(ct.Indexes))
+	}
+	assert.True(t, conv.UsedNames["orders"])
+	assert.True(t, conv.UsedNames["idx"])
+
+	// Other files are read as session files.
+	assert.NotNil(t, readExpectedSchema(internal.MakeConv(), filepath.Join(dir, "missing.json"), targetProfile))
+}
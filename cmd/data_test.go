@@ -38,6 +38,9 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "",
                                 filePrefix:       "",
                                 WriteLimit:       DefaultWritersLimit,
+                                ReadWorkers:      DefaultReadWorkers,
+                                writeMode:        "insert",
+                                orphanPolicy:     "skip",
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -55,6 +58,9 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "",
                                 filePrefix:       "",
                                 WriteLimit:       DefaultWritersLimit,
+                                ReadWorkers:      DefaultReadWorkers,
+                                writeMode:        "insert",
+                                orphanPolicy:     "skip",
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -72,6 +78,9 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "target.json",
                                 filePrefix:       "",
                                 WriteLimit:       DefaultWritersLimit,
+                                ReadWorkers:      DefaultReadWorkers,
+                                writeMode:        "insert",
+                                orphanPolicy:     "skip",
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -89,6 +98,9 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "",
                                 filePrefix:       "test",
                                 WriteLimit:       100,
+                                ReadWorkers:      DefaultReadWorkers,
+                                writeMode:        "insert",
+                                orphanPolicy:     "skip",
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -106,6 +118,9 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "",
                                 filePrefix:       "",
                                 WriteLimit:       DefaultWritersLimit,
+                                ReadWorkers:      DefaultReadWorkers,
+                                writeMode:        "insert",
+                                orphanPolicy:     "skip",
                                 dryRun:           true,
                                 logLevel:         "INFO",
                                 SkipForeignKeys:  false,
@@ -123,6 +138,9 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "",
                                 filePrefix:       "",
                                 WriteLimit:       DefaultWritersLimit,
+                                ReadWorkers:      DefaultReadWorkers,
+                                writeMode:        "insert",
+                                orphanPolicy:     "skip",
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  true,
@@ -140,6 +158,9 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "",
                                 filePrefix:       "",
                                 WriteLimit:       DefaultWritersLimit,
+                                ReadWorkers:      DefaultReadWorkers,
+                                writeMode:        "insert",
+                                orphanPolicy:     "skip",
                                 dryRun:           false,
                                 logLevel:         "DEBUG",
                                 SkipForeignKeys:  false,
@@ -156,6 +177,14 @@ func TestDataSetFlags(t *testing.T) {
                                 "--target-profile=spanner.json",
                                 "--prefix=output",
                                 "--write-limit=50",
+                                "--read-workers=8",
+                                "--resume",
+                                "--write-mode=insert-or-update",
+                                "--foreign-key-orphans=file",
+                                "--adaptive-writes",
+                                "--max-rows-per-second=500",
+                                "--batch-write",
+                                "--count-updated-rows",
                                 "--dry-run",
                                 "--log-level=WARN",
                                 "--skip-foreign-keys",
@@ -169,6 +198,14 @@ func TestDataSetFlags(t *testing.T) {
                                 targetProfile:    "spanner.json",
                                 filePrefix:       "output",
                                 WriteLimit:       50,
+                                ReadWorkers:      8,
+                                Resume:           true,
+                                writeMode:        "insert-or-update",
+                                orphanPolicy:     "file",
+                                adaptiveWrites:   true,
+                                maxRowsPerSecond: 500,
+                                batchWrite:       true,
+                                countUpdatedRows: true,
                                 dryRun:           true,
                                 logLevel:         "WARN",
                                 SkipForeignKeys:  true,
@@ -37,6 +37,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/csv"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/google/subcommands"
 )
 
@@ -52,6 +53,8 @@ type ImportDataCmd struct {
 	project           string
 	databaseDialect   string
 	logLevel          string
+	writeMode         string
+	countUpdatedRows  bool
 }
 
 func (cmd *ImportDataCmd) SetFlags(set *flag.FlagSet) {
@@ -60,12 +63,14 @@ func (cmd *ImportDataCmd) SetFlags(set *flag.FlagSet) {
 	set.StringVar(&cmd.tableName, "table-name", "", "Spanner table name. Optional. If not specified, source-uri name will be used")
 	set.StringVar(&cmd.sourceUri, "source-uri", "", "URI of the file to import")
 	set.StringVar(&cmd.sourceFormat, "source-format", "", fmt.Sprintf("Format of the file to import. Valid values {%s, %s, %s}", constants.MYSQLDUMP, constants.PGDUMP, constants.CSV))
-	set.StringVar(&cmd.schemaUri, "schema-uri", "", "URI of the file with schema for the csv to import. Only non-optional for csv format.")
+	set.StringVar(&cmd.schemaUri, "schema-uri", "", "URI of the file with schema for the csv to import: a JSON list of column definitions, or a .sql file of Spanner DDL statements. Only non-optional for csv format.")
 	set.StringVar(&cmd.csvLineDelimiter, "csv-line-delimiter", "\n", "Token to be used as line delimiter for csv format. Optional. Defaults to '\\n'. Only used for csv format.")
 	set.StringVar(&cmd.csvFieldDelimiter, "csv-field-delimiter", ",", "Token to be used as field delimiter for csv format. Optional. Defaults to ','. Only used for csv format.")
 	set.StringVar(&cmd.project, "project", "", "Project id for all resources related to this import. Optional")
 	set.StringVar(&cmd.databaseDialect, "database-dialect", constants.DIALECT_GOOGLESQL, fmt.Sprintf("Spanner database dialect. Defaults to %s. Valid values {%s, %s}", constants.DIALECT_GOOGLESQL, constants.DIALECT_GOOGLESQL, constants.DIALECT_POSTGRESQL))
 	set.StringVar(&cmd.logLevel, "log-level", "INFO", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+	set.StringVar(&cmd.writeMode, "write-mode", string(writer.WriteModeInsert), fmt.Sprintf("How rows that already exist in Spanner are written. Valid values {%s, %s, %s}", writer.WriteModeInsert, writer.WriteModeInsertOrUpdate, writer.WriteModeReplace))
+	set.BoolVar(&cmd.countUpdatedRows, "count-updated-rows", false, "With --write-mode insert-or-update or replace, read the primary keys of each batch before writing it to count the rows that update an existing row in Spanner. This adds a read to every write")
 }
 
 func (cmd *ImportDataCmd) Execute(ctx context.Context, f *flag.FlagSet, args ...interface{}) subcommands.ExitStatus {
@@ -225,6 +230,10 @@ func validateInputLocal(input *ImportDataCmd) error {
 		return fmt.Errorf("Please specify schemaUri using the --schema-uri parameter. Received  schemaUri: %v", input.sourceFormat)
 	}
 
+	if _, err := writer.ParseWriteMode(input.writeMode); err != nil {
+		return fmt.Errorf("Please specify a valid writeMode using the --write-mode parameter. Received writeMode: %v", input.writeMode)
+	}
+
 	return err
 }
 
@@ -253,15 +262,36 @@ func (cmd *ImportDataCmd) handleCsv(ctx context.Context, dbURI, dialect string,
 
 	csvData := import_file.NewCsvData(cmd.project, cmd.instance,
 		cmd.database, cmd.tableName, cmd.sourceUri, cmd.csvFieldDelimiter, sourceReader)
-	err = csvData.ImportData(ctx, infoSchema, dialect, internal.MakeConv(), &common.InfoSchemaImpl{}, &csv.CsvImpl{})
+	conv := internal.MakeConv()
+	conv.Audit.WriteMode = cmd.writeMode
+	conv.Audit.CountUpdatedRows = cmd.countUpdatedRows
+	deadLetter, err := openDeadLetterFile(cmd.database+deadLetterFile, false)
+	if err != nil {
+		return err
+	}
+	defer closeDeadLetterFile(deadLetter, nil, os.Stdout)
+	conv.Audit.DeadLetter = deadLetter
+	err = csvData.ImportData(ctx, infoSchema, dialect, conv, &common.InfoSchemaImpl{}, &csv.CsvImpl{})
 
 	endTime2 := time.Now()
 	elapsedTime = endTime2.Sub(endTime1)
 	logger.Log.Info(fmt.Sprintf("Data import took %f secs", elapsedTime.Seconds()))
+	logUpdatedRows(conv)
 	return err
 
 }
 
+// logUpdatedRows logs the number of imported rows that updated an existing
+// row, which are only counted with --count-updated-rows.
+func logUpdatedRows(conv *internal.Conv) {
+	if !conv.Audit.CountUpdatedRows {
+		return
+	}
+	for table, n := range conv.Stats.UpdatedRows {
+		logger.Log.Info(fmt.Sprintf("Updated %d existing rows of table %s", n, table))
+	}
+}
+
 func getDBUri(projectId, instanceId, databaseName string) string {
 	return fmt.Sprintf("projects/%s/instances/%s/databases/%s", projectId, instanceId, databaseName)
 }
@@ -342,11 +372,20 @@ func (cmd *ImportDataCmd) handleDatabaseDumpFile(ctx context.Context, dbUri, sou
 	elapsedTime := schemaEndTime.Sub(schemaStartTime)
 	logger.Log.Info(fmt.Sprintf("Schema creation took %f secs", elapsedTime.Seconds()))
 
+	conv.Audit.WriteMode = cmd.writeMode
+	conv.Audit.CountUpdatedRows = cmd.countUpdatedRows
+	deadLetter, err := openDeadLetterFile(cmd.database+deadLetterFile, false)
+	if err != nil {
+		return err
+	}
+	defer closeDeadLetterFile(deadLetter, nil, os.Stdout)
+	conv.Audit.DeadLetter = deadLetter
 	err = importDump.ImportData(ctx, conv)
 
 	dataEndTime := time.Now()
 	elapsedTime = dataEndTime.Sub(schemaEndTime)
 	logger.Log.Info(fmt.Sprintf("Data import took %f secs", elapsedTime.Seconds()))
+	logUpdatedRows(conv)
 
 	if err != nil {
 		return fmt.Errorf("can't import data: %v", err)
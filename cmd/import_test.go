@@ -72,6 +72,8 @@ func TestImportDataCmd_SetFlags(t *testing.T) {
 	assert.NotNil(t, fs.Lookup("csv-line-delimiter"))
 	assert.NotNil(t, fs.Lookup("csv-field-delimiter"))
 	assert.NotNil(t, fs.Lookup("project"))
+	assert.NotNil(t, fs.Lookup("write-mode"))
+	assert.NotNil(t, fs.Lookup("count-updated-rows"))
 }
 
 func TestValidateInputLocal_MissingInstanceID(t *testing.T) {
@@ -109,6 +111,13 @@ func TestValidateInputLocal_CSVMissingSchemaURI(t *testing.T) {
 	assert.Contains(t, err.Error(), "Please specify schemaUri")
 }
 
+func TestValidateInputLocal_InvalidWriteMode(t *testing.T) {
+	input := &ImportDataCmd{instance: "test-instance", database: "test-db", sourceUri: "gs://bucket/data.avro", sourceFormat: "avro", writeMode: "upsert"}
+	err := validateInputLocal(input)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Please specify a valid writeMode")
+}
+
 func TestValidateInputLocal_SuccessCSV(t *testing.T) {
 	input := &ImportDataCmd{
 		instance:        "test-instance",
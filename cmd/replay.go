@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
+	"github.com/google/subcommands"
+)
+
+// ReplayCmd is the command for writing the rows of a dead-letter file to
+// Spanner again, typically after they have been fixed by hand.
+type ReplayCmd struct {
+	project        string
+	instance       string
+	database       string
+	deadLetterFile string
+	writeMode      string
+	writeLimit     int64
+	logLevel       string
+}
+
+// Name returns the name of operation.
+func (cmd *ReplayCmd) Name() string {
+	return "replay"
+}
+
+// Synopsis returns summary of operation.
+func (cmd *ReplayCmd) Synopsis() string {
+	return "replay writes the rows of a dead-letter file to Spanner"
+}
+
+// Usage returns usage info of the command.
+func (cmd *ReplayCmd) Usage() string {
+	return fmt.Sprintf(`%v replay --instance=i1 --database=db1 --dead-letter-file=db1%s ...
+
+Write the rows of a dead-letter file to Spanner. Data migrations write the
+rows that Spanner rejects to PREFIX%s; fix the rows in that file
+and replay them. Rows that are rejected again are written to a new
+dead-letter file, with the extension %s.
+`, path.Base(os.Args[0]), deadLetterFile, deadLetterFile, replayFailedFile)
+}
+
+// SetFlags sets the flags.
+func (cmd *ReplayCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&cmd.project, "project", "", "Project id of the Spanner instance. Optional. Defaults to the project configured in the gcloud CLI")
+	f.StringVar(&cmd.instance, "instance", "", "Spanner instance Id")
+	f.StringVar(&cmd.database, "database", "", "Spanner database name")
+	f.StringVar(&cmd.deadLetterFile, "dead-letter-file", "", "Dead-letter file with the rows to write")
+	f.StringVar(&cmd.writeMode, "write-mode", string(writer.WriteModeInsert), fmt.Sprintf("How rows that already exist in Spanner are written. Valid values {%s, %s, %s}", writer.WriteModeInsert, writer.WriteModeInsertOrUpdate, writer.WriteModeReplace))
+	f.Int64Var(&cmd.writeLimit, "write-limit", DefaultWritersLimit, "Number of parallel writers to Cloud Spanner during bulk data migrations")
+	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+}
+
+func (cmd *ReplayCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := logger.InitializeLogger(cmd.logLevel)
+	if err != nil {
+		fmt.Println("Error initialising logger, did you specify a valid log-level? [DEBUG, INFO, WARN, ERROR, FATAL]", err)
+		return subcommands.ExitFailure
+	}
+	defer logger.Log.Sync()
+	writeMode, err := cmd.validate()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Input validation failed. Reason %v", err))
+		return subcommands.ExitUsageError
+	}
+	if cmd.project == "" {
+		getInfo := &utils.GetUtilInfoImpl{}
+		cmd.project, err = getInfo.GetProject()
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("Could not get project id from gcloud environment or --project flag: %v", err))
+			return subcommands.ExitUsageError
+		}
+	}
+	in, err := os.Open(cmd.deadLetterFile)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't open dead-letter file: %v", err))
+		return subcommands.ExitFailure
+	}
+	defer in.Close()
+	// Check the whole file before writing anything, so that a row that
+	// can't be decoded doesn't leave the file partially replayed.
+	rows := int64(0)
+	err = writer.ReadDeadLetters(in, func(string, []string, []interface{}) { rows++ })
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read dead-letter file %s: %v", cmd.deadLetterFile, err))
+		return subcommands.ExitFailure
+	}
+	if _, err = in.Seek(0, io.SeekStart); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read dead-letter file %s: %v", cmd.deadLetterFile, err))
+		return subcommands.ExitFailure
+	}
+	// Rows that are dropped again are recorded with the types of the
+	// dead-letter file, since UUID and INTERVAL values are strings.
+	columnType, err := writer.DeadLetterColumnTypes(in)
+	if err == nil {
+		_, err = in.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read dead-letter file %s: %v", cmd.deadLetterFile, err))
+		return subcommands.ExitFailure
+	}
+
+	dbURI := getDBUri(cmd.project, cmd.instance, cmd.database)
+	client, err := utils.GetClient(ctx, dbURI)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't create client for db %s: %v", dbURI, err))
+		return subcommands.ExitFailure
+	}
+	defer client.Close()
+	failedFileName := strings.TrimSuffix(cmd.deadLetterFile, deadLetterFile) + replayFailedFile
+	failed, err := os.Create(failedFileName)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't create dead-letter file %s: %v", failedFileName, err))
+		return subcommands.ExitFailure
+	}
+	bw := writer.NewBatchWriter(writer.BatchWriterConfig{
+		BytesLimit: 100 * 1000 * 1000,
+		WriteLimit: cmd.writeLimit,
+		RetryLimit: 1000,
+		Verbose:    internal.Verbose(),
+		WriteMode:  writeMode,
+		Write: func(m []*sp.Mutation) error {
+			_, err := client.Apply(ctx, m)
+			return err
+		},
+		DeadLetter: failed,
+		ColumnType: columnType,
+	})
+	err = replayDeadLetters(in, bw)
+	closeDeadLetterFile(failed, bw, os.Stdout)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read dead-letter file %s: %v", cmd.deadLetterFile, err))
+		return subcommands.ExitFailure
+	}
+	dropped := utils.SumMapValues(bw.DroppedRowsByTable())
+	fmt.Printf("Replayed %d rows from %s: %d written, %d dropped\n", rows, cmd.deadLetterFile, rows-dropped, dropped)
+	if dropped > 0 {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+func (cmd *ReplayCmd) validate() (writer.WriteMode, error) {
+	if cmd.instance == "" {
+		return "", fmt.Errorf("Please specify instance using the --instance parameter")
+	}
+	if cmd.database == "" {
+		return "", fmt.Errorf("Please specify database using the --database parameter")
+	}
+	if cmd.deadLetterFile == "" {
+		return "", fmt.Errorf("Please specify the dead-letter file using the --dead-letter-file parameter")
+	}
+	writeMode, err := writer.ParseWriteMode(cmd.writeMode)
+	if err != nil {
+		return "", fmt.Errorf("Please specify a valid writeMode using the --write-mode parameter. Received writeMode: %v", cmd.writeMode)
+	}
+	return writeMode, nil
+}
+
+// replayDeadLetters writes the rows of the dead-letter file read from r
+// using bw, and waits for the writes to complete.
+func replayDeadLetters(r io.Reader, bw *writer.BatchWriter) error {
+	err := writer.ReadDeadLetters(r, bw.AddRow)
+	bw.Flush()
+	return err
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayCmd_SetFlags(t *testing.T) {
+	cmd := &ReplayCmd{}
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	cmd.SetFlags(fs)
+	assert.Nil(t, fs.Parse([]string{"--instance=i1", "--database=db1", "--dead-letter-file=db1.dead-letter.jsonl"}))
+	assert.Equal(t, ReplayCmd{
+		instance:       "i1",
+		database:       "db1",
+		deadLetterFile: "db1.dead-letter.jsonl",
+		writeMode:      "insert",
+		writeLimit:     DefaultWritersLimit,
+		logLevel:       "DEBUG",
+	}, *cmd)
+}
+
+func TestReplayCmd_Validate(t *testing.T) {
+	cmd := ReplayCmd{}
+	_, err := cmd.validate()
+	assert.ErrorContains(t, err, "Please specify instance")
+	cmd.instance = "i1"
+	_, err = cmd.validate()
+	assert.ErrorContains(t, err, "Please specify database")
+	cmd.database = "db1"
+	_, err = cmd.validate()
+	assert.ErrorContains(t, err, "Please specify the dead-letter file")
+	cmd.deadLetterFile = "db1.dead-letter.jsonl"
+	cmd.writeMode = "upsert"
+	_, err = cmd.validate()
+	assert.ErrorContains(t, err, "Please specify a valid writeMode")
+	cmd.writeMode = "replace"
+	writeMode, err := cmd.validate()
+	assert.Nil(t, err)
+	assert.Equal(t, writer.WriteModeReplace, writeMode)
+}
+
+func TestReplayDeadLetters(t *testing.T) {
+	in := `{"table":"t","columns":["id","v"],"types":["INT64","UUID"],"values":[1,"fixed"],"error":"x"}
+{"table":"t","columns":["id","v"],"types":["INT64","UUID"],"values":[2,"still bad"],"error":"x"}
+`
+	columnType, err := writer.DeadLetterColumnTypes(strings.NewReader(in))
+	assert.Nil(t, err)
+	var written []*sp.Mutation
+	var failed bytes.Buffer
+	bw := writer.NewBatchWriter(writer.BatchWriterConfig{
+		BytesLimit: 100 << 20,
+		WriteLimit: 1,
+		RetryLimit: 10,
+		WriteMode:  writer.WriteModeInsertOrUpdate,
+		Write: func(m []*sp.Mutation) error {
+			for _, x := range m {
+				if strings.Contains(fmt.Sprintf("%v", x), "still bad") {
+					return errors.New("bad data")
+				}
+			}
+			written = append(written, m...)
+			return nil
+		},
+		DeadLetter: &failed,
+		ColumnType: columnType,
+	})
+	assert.Nil(t, replayDeadLetters(strings.NewReader(in), bw))
+	assert.Equal(t, []*sp.Mutation{sp.InsertOrUpdate("t", []string{"id", "v"}, []interface{}{int64(1), "fixed"})}, written)
+	assert.Equal(t, map[string]int64{"t": 1}, bw.DroppedRowsByTable())
+	assert.Equal(t, `{"table":"t","columns":["id","v"],"types":["INT64","UUID"],"values":[2,"still bad"],"error":"bad data"}`+"\n", failed.String())
+}
+
+func TestDeadLetterFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "db1"+deadLetterFile)
+	var out bytes.Buffer
+
+	// Empty dead-letter files are removed.
+	f, err := openDeadLetterFile(name, false)
+	assert.Nil(t, err)
+	closeDeadLetterFile(f, nil, &out)
+	assert.NoFileExists(t, name)
+	assert.Empty(t, out.String())
+
+	f, err = openDeadLetterFile(name, false)
+	assert.Nil(t, err)
+	f.WriteString("row 1\n")
+	closeDeadLetterFile(f, nil, &out)
+	assert.Contains(t, out.String(), name)
+
+	// A resumed migration appends to the dead-letter file.
+	f, err = openDeadLetterFile(name, true)
+	assert.Nil(t, err)
+	f.WriteString("row 2\n")
+	closeDeadLetterFile(f, nil, &out)
+	b, err := os.ReadFile(name)
+	assert.Nil(t, err)
+	assert.Equal(t, "row 1\nrow 2\n", string(b))
+}
@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -44,9 +45,18 @@ type SchemaAndDataCmd struct {
 	target           string
 	targetProfile    string
 	SkipForeignKeys  bool
+	orphanPolicy     string
+	deferIndexes     bool
 	filePrefix       string // TODO: move filePrefix to global flags
 	project          string
 	WriteLimit       int64
+	ReadWorkers      int
+	Resume           bool
+	writeMode        string
+	adaptiveWrites   bool
+	maxRowsPerSecond int64
+	batchWrite       bool
+	countUpdatedRows bool
 	dryRun           bool
 	logLevel         string
 	validate         bool
@@ -82,9 +92,18 @@ func (cmd *SchemaAndDataCmd) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&cmd.target, "target", "Spanner", "Specifies the target DB, defaults to Spanner (accepted values: `Spanner`)")
 	f.StringVar(&cmd.targetProfile, "target-profile", "", "Flag for specifying connection profile for target database e.g., \"dialect=postgresql\"")
 	f.BoolVar(&cmd.SkipForeignKeys, "skip-foreign-keys", false, "Skip creating foreign keys after data migration is complete (ddl statements for foreign keys can still be found in the downloaded schema.ddl.txt file and the same can be applied separately)")
+	f.StringVar(&cmd.orphanPolicy, "foreign-key-orphans", orphanPolicySkip, "What to do with foreign keys that rows loaded into Spanner don't satisfy, which are checked for before foreign keys are created (accepted values: `skip`, `fail`, `file`). skip doesn't create such foreign keys, fail fails the migration and file also writes the orphaned rows to <prefix>.orphans.jsonl")
+	f.BoolVar(&cmd.deferIndexes, "defer-indexes", false, "Create tables without their secondary indexes and create the indexes in batches after data migration is complete, which is faster than maintaining them while rows are written. Not supported for minimal downtime migrations")
 	f.StringVar(&cmd.filePrefix, "prefix", "", "File prefix for generated files")
 	f.StringVar(&cmd.project, "project", "", "Flag spcifying default project id for all the generated resources for the migration")
 	f.Int64Var(&cmd.WriteLimit, "write-limit", DefaultWritersLimit, "Write limit for writes to spanner")
+	f.IntVar(&cmd.ReadWorkers, "read-workers", DefaultReadWorkers, "Number of concurrent readers per table for direct-connect data migration. Values above 1 split each table into primary key ranges that are read in parallel")
+	f.StringVar(&cmd.writeMode, "write-mode", string(writer.WriteModeInsert), "How rows that already exist in Spanner are written during bulk data migration (accepted values: `insert`, `insert-or-update`, `replace`). insert fails such rows, insert-or-update overwrites the migrated columns and replace rewrites the whole row")
+	f.BoolVar(&cmd.adaptiveWrites, "adaptive-writes", false, "Adapt the size of write batches and the number of parallel writers (up to --write-limit) to the commit latency and errors of Spanner during bulk data migration")
+	f.Int64Var(&cmd.maxRowsPerSecond, "max-rows-per-second", 0, "Maximum number of rows written to Spanner per second during bulk data migration, e.g. to share the instance with production traffic. 0 means no limit")
+	f.BoolVar(&cmd.countUpdatedRows, "count-updated-rows", false, "With --write-mode insert-or-update or replace, read the primary keys of each batch before writing it to count the rows that update an existing row in Spanner. This adds a read to every write")
+	f.BoolVar(&cmd.batchWrite, "batch-write", false, "Write rows during bulk data migration as mutation groups with the non-atomic BatchWrite API, grouping rows with the rows they are interleaved in, instead of as transactions of many rows")
+	f.BoolVar(&cmd.Resume, "resume", false, "Resume a direct-connect data migration that failed partway, skipping the tables and primary key ranges recorded as complete in its checkpoint file (<prefix>.checkpoint.json)")
 	f.BoolVar(&cmd.dryRun, "dry-run", false, "Flag for generating DDL and schema conversion report without creating a spanner database")
 	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
 	f.BoolVar(&cmd.validate, "validate", false, "Flag for validating if all the required input parameters are present")
@@ -122,6 +141,21 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 			return subcommands.ExitUsageError
 		}
 	}
+	writeMode, err := writer.ParseWriteMode(cmd.writeMode)
+	if err != nil {
+		return subcommands.ExitUsageError
+	}
+	if cmd.maxRowsPerSecond < 0 {
+		err = fmt.Errorf("--max-rows-per-second can't be negative: %d", cmd.maxRowsPerSecond)
+		return subcommands.ExitUsageError
+	}
+	if err = validateOrphanPolicy(cmd.orphanPolicy); err != nil {
+		return subcommands.ExitUsageError
+	}
+	if cmd.deferIndexes && sourceProfile.Ty == profiles.SourceProfileTypeConfig && sourceProfile.Config.ConfigType == constants.DATAFLOW_MIGRATION {
+		err = fmt.Errorf("--defer-indexes isn't supported for minimal downtime migrations")
+		return subcommands.ExitUsageError
+	}
 	if cmd.validate {
 		return subcommands.ExitSuccess
 	}
@@ -165,11 +199,33 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 	// Generate overrides file for schema mapping information
 	conversion.WriteOverridesFile(conv, cmd.filePrefix+overridesFile, ioHelper.Out)
 	conv.Audit.SkipMetricsPopulation = os.Getenv("SKIP_METRICS_POPULATION") == "true"
+	conv.Audit.ReadWorkers = cmd.ReadWorkers
+	conv.Audit.WriteMode = string(effectiveWriteMode(writeMode, cmd.Resume, cmd.batchWrite))
+	conv.Audit.AdaptiveWrites = cmd.adaptiveWrites
+	conv.Audit.MaxRowsPerSecond = cmd.maxRowsPerSecond
+	conv.Audit.BatchWrite = cmd.batchWrite
+	conv.Audit.CountUpdatedRows = cmd.countUpdatedRows
+	conv.Audit.DeferIndexes = cmd.deferIndexes
 	reportImpl := conversion.ReportImpl{}
 	if !cmd.dryRun {
+		conv.Audit.Checkpoint, err = getCheckpoint(sourceProfile, cmd.filePrefix, cmd.Resume)
+		if err != nil {
+			return subcommands.ExitUsageError
+		}
+		var deadLetter *os.File
+		deadLetter, err = openDeadLetterFile(cmd.filePrefix+deadLetterFile, cmd.Resume)
+		if err != nil {
+			return subcommands.ExitFailure
+		}
+		defer func() { closeDeadLetterFile(deadLetter, bw, ioHelper.Out) }()
+		conv.Audit.DeadLetter = deadLetter
 		reportImpl.GenerateReport(sourceProfile.Driver, nil, ioHelper.BytesRead, "", conv, cmd.filePrefix, dbName, ioHelper.Out)
 		bw, err = MigrateDatabase(ctx, cmd.project, targetProfile, sourceProfile, dbName, &ioHelper, cmd, conv, nil)
 		if err != nil {
+			// The report lists the orphaned rows that failed the migration.
+			if errors.Is(err, errForeignKeyOrphans) {
+				writeDataReport(bw, conv, sourceProfile.Driver, ioHelper.BytesRead, utils.GetBanner(schemaConversionStartTime, dbURI), cmd.filePrefix, dbName, ioHelper.Out)
+			}
 			err = fmt.Errorf("can't finish database migration for db %s: %v", dbName, err)
 			return subcommands.ExitFailure
 		}
@@ -199,8 +255,7 @@ func (cmd *SchemaAndDataCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...
 		conv.Audit.DataConversionDuration = dataCoversionEndTime.Sub(schemaCoversionEndTime)
 		banner = utils.GetBanner(schemaConversionStartTime, dbName)
 	}
-	reportImpl.GenerateReport(sourceProfile.Driver, bw.DroppedRowsByTable(), ioHelper.BytesRead, banner, conv, cmd.filePrefix, dbName, ioHelper.Out)
-	conversion.WriteBadData(bw, conv, banner, cmd.filePrefix+badDataFile, ioHelper.Out)
+	writeDataReport(bw, conv, sourceProfile.Driver, ioHelper.BytesRead, banner, cmd.filePrefix, dbName, ioHelper.Out)
 
 	// Cleanup smt tmp data directory.
 	os.RemoveAll(filepath.Join(os.TempDir(), constants.SMT_TMP_DIR))
@@ -38,6 +38,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "",
 				filePrefix:       "",
 				WriteLimit:       DefaultWritersLimit,
+				ReadWorkers:      DefaultReadWorkers,
+				writeMode:        "insert",
+				orphanPolicy:     "skip",
 				dryRun:           false,
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
@@ -56,6 +59,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "",
 				filePrefix:       "",
 				WriteLimit:       DefaultWritersLimit,
+				ReadWorkers:      DefaultReadWorkers,
+				writeMode:        "insert",
+				orphanPolicy:     "skip",
 				dryRun:           false,
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
@@ -74,6 +80,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "target.json",
 				filePrefix:       "",
 				WriteLimit:       DefaultWritersLimit,
+				ReadWorkers:      DefaultReadWorkers,
+				writeMode:        "insert",
+				orphanPolicy:     "skip",
 				dryRun:           false,
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
@@ -92,6 +101,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "",
 				filePrefix:       "test",
 				WriteLimit:       100,
+				ReadWorkers:      DefaultReadWorkers,
+				writeMode:        "insert",
+				orphanPolicy:     "skip",
 				dryRun:           false,
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
@@ -110,6 +122,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "",
 				filePrefix:       "",
 				WriteLimit:       DefaultWritersLimit,
+				ReadWorkers:      DefaultReadWorkers,
+				writeMode:        "insert",
+				orphanPolicy:     "skip",
 				dryRun:           true,
 				logLevel:         "INFO",
 				SkipForeignKeys:  false,
@@ -128,6 +143,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "",
 				filePrefix:       "",
 				WriteLimit:       DefaultWritersLimit,
+				ReadWorkers:      DefaultReadWorkers,
+				writeMode:        "insert",
+				orphanPolicy:     "skip",
 				dryRun:           false,
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  true,
@@ -146,6 +164,9 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "",
 				filePrefix:       "",
 				WriteLimit:       DefaultWritersLimit,
+				ReadWorkers:      DefaultReadWorkers,
+				writeMode:        "insert",
+				orphanPolicy:     "skip",
 				dryRun:           false,
 				logLevel:         "DEBUG",
 				SkipForeignKeys:  false,
@@ -163,9 +184,18 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				"--target-profile=spanner.json",
 				"--prefix=output",
 				"--write-limit=50",
+				"--read-workers=8",
+				"--resume",
+				"--write-mode=insert-or-update",
+				"--foreign-key-orphans=file",
+				"--adaptive-writes",
+				"--max-rows-per-second=500",
+				"--batch-write",
+				"--count-updated-rows",
 				"--dry-run",
 				"--log-level=WARN",
 				"--skip-foreign-keys",
+				"--defer-indexes",
 				"--validate",
 				"--dataflow-template=gs://custom/template",
 				"--session-file-name=my_session_file",
@@ -177,9 +207,18 @@ func TestSchemaAndDataSetFlags(t *testing.T) {
 				targetProfile:    "spanner.json",
 				filePrefix:       "output",
 				WriteLimit:       50,
+				ReadWorkers:      8,
+				Resume:           true,
+				writeMode:        "insert-or-update",
+				orphanPolicy:     "file",
+				adaptiveWrites:   true,
+				maxRowsPerSecond: 500,
+				batchWrite:       true,
+				countUpdatedRows: true,
 				dryRun:           true,
 				logLevel:         "WARN",
 				SkipForeignKeys:  true,
+				deferIndexes:     true,
 				validate:         true,
 				dataflowTemplate: "gs://custom/template",
 				sessionFileName:  "my_session_file",
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/google/subcommands"
+)
+
+const (
+	schemaUpdateFile     = ".schema-update.sql"
+	schemaUpdateJSONFile = ".schema-update.json"
+)
+
+// UpdateSchemaCmd is the command for updating the schema of an existing
+// Spanner database to the schema of a session file, e.g. after a column
+// was changed or an index added in the session file.
+type UpdateSchemaCmd struct {
+	targetProfile string
+	sessionJSON   string
+	filePrefix    string
+	apply         bool
+	logLevel      string
+}
+
+// Name returns the name of operation.
+func (cmd *UpdateSchemaCmd) Name() string {
+	return "update-schema"
+}
+
+// Synopsis returns summary of operation.
+func (cmd *UpdateSchemaCmd) Synopsis() string {
+	return "update-schema plans, and optionally applies, the DDL that updates a Spanner database to the schema of the session file"
+}
+
+// Usage returns usage info of the command.
+func (cmd *UpdateSchemaCmd) Usage() string {
+	return fmt.Sprintf(`%v update-schema --target-profile="instance=i1,dbName=db1" --session=session.json [--apply] ...
+
+Compare the schema of an existing Spanner database with the Spanner schema
+of the session file, or with the schema of a file of Spanner DDL statements
+if the --session file name ends in .sql, and plan the CREATE, ALTER and
+DROP statements of tables, indexes, sequences and views that update the
+database to the session file. The plan is printed and written to PREFIX%s and
+PREFIX%s.
+Changes that Spanner can't make in place, and tables and columns that
+aren't in the session file, are listed but not planned.
+
+With --apply, the planned statements are applied to the database.
+`, path.Base(os.Args[0]), schemaUpdateFile, schemaUpdateJSONFile)
+}
+
+// SetFlags sets the flags.
+func (cmd *UpdateSchemaCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&cmd.targetProfile, "target-profile", "", "Flag for specifying connection profile for target database e.g., \"instance=my-instance,dbName=my-db\"")
+	f.StringVar(&cmd.sessionJSON, "session", "", "Specifies the file we restore session state from, or a .sql file of Spanner DDL statements")
+	f.StringVar(&cmd.filePrefix, "prefix", "", "File prefix for generated files")
+	f.BoolVar(&cmd.apply, "apply", false, "Apply the planned statements to the database. By default the plan is only written out")
+	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+}
+
+func (cmd *UpdateSchemaCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := logger.InitializeLogger(cmd.logLevel)
+	if err != nil {
+		fmt.Println("Error initialising logger, did you specify a valid log-level? [DEBUG, INFO, WARN, ERROR, FATAL]", err)
+		return subcommands.ExitFailure
+	}
+	defer logger.Log.Sync()
+	// The flags are the same as those of check-schema.
+	targetProfile, err := (&CheckSchemaCmd{targetProfile: cmd.targetProfile, sessionJSON: cmd.sessionJSON}).validate()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Input validation failed. Reason %v", err))
+		return subcommands.ExitUsageError
+	}
+	conv := internal.MakeConv()
+	if err = readExpectedSchema(conv, cmd.sessionJSON, targetProfile); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read session file %s: %v", cmd.sessionJSON, err))
+		return subcommands.ExitUsageError
+	}
+	if cmd.filePrefix == "" {
+		cmd.filePrefix = targetProfile.Conn.Sp.Dbname
+	}
+
+	ioHelper := utils.NewIOStreams(conv.Source, "")
+	adminClient, client, dbURI, err := CreateDatabaseClient(ctx, targetProfile, conv.Source, "", ioHelper)
+	if adminClient != nil {
+		defer adminClient.Close()
+	}
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't connect to the Spanner database: %v", err))
+		return subcommands.ExitFailure
+	}
+	defer client.Close()
+
+	live, err := validation.ReadLiveSchema(spanner.InfoSchemaImpl{Client: client, Ctx: ctx, SpDialect: conv.SpDialect})
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read the schema of database %s: %v", dbURI, err))
+		return subcommands.ExitFailure
+	}
+	plan := validation.PlanSchemaUpdate(conv, live)
+	writeSchemaPlan(plan, os.Stdout)
+	if err = writeSchemaPlanFiles(plan, cmd.filePrefix); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't write schema update: %v", err))
+		return subcommands.ExitFailure
+	}
+	fmt.Printf("Wrote schema update to %s and %s for database %s\n", cmd.filePrefix+schemaUpdateFile, cmd.filePrefix+schemaUpdateJSONFile, dbURI)
+	if !cmd.apply || len(plan.Statements) == 0 {
+		return subcommands.ExitSuccess
+	}
+
+	spA, err := spanneraccessor.NewSpannerAccessorClientImpl(ctx)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't create Spanner admin client: %v", err))
+		return subcommands.ExitFailure
+	}
+	fmt.Printf("Applying %d statements to database %s ...\n", len(plan.Statements), dbURI)
+	if err = spA.UpdateDDL(ctx, dbURI, plan.DDL()); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't update the schema of database %s: %v", dbURI, err))
+		return subcommands.ExitFailure
+	}
+	fmt.Println("Schema update complete.")
+	return subcommands.ExitSuccess
+}
+
+// writeSchemaPlan writes the plan to w as DDL, preceded by comments listing
+// the changes that the plan doesn't make.
+func writeSchemaPlan(plan validation.SchemaPlan, w io.Writer) {
+	for _, u := range plan.Unapplied {
+		fmt.Fprintf(w, "-- Not applied: %s (%s)\n", u.Difference, u.Reason)
+	}
+	if len(plan.Unapplied) > 0 {
+		fmt.Fprintln(w)
+	}
+	if len(plan.Statements) == 0 {
+		fmt.Fprintln(w, "-- The schema of the database is up to date")
+		return
+	}
+	fmt.Fprintln(w, strings.Join(plan.DDL(), ";\n\n")+";")
+}
+
+func writeSchemaPlanFiles(plan validation.SchemaPlan, filePrefix string) error {
+	f, err := os.Create(filePrefix + schemaUpdateFile)
+	if err != nil {
+		return err
+	}
+	writeSchemaPlan(plan, f)
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return writeJSONFile(filePrefix+schemaUpdateJSONFile, plan)
+}
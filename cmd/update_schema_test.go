@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSchemaCmd_SetFlags(t *testing.T) {
+	cmd := &UpdateSchemaCmd{}
+	fs := flag.NewFlagSet("update-schema", flag.ContinueOnError)
+	cmd.SetFlags(fs)
+	assert.Nil(t, fs.Parse([]string{"--target-profile=instance=i1,dbName=db1", "--session=session.json", "--apply"}))
+	assert.Equal(t, UpdateSchemaCmd{
+		targetProfile: "instance=i1,dbName=db1",
+		sessionJSON:   "session.json",
+		apply:         true,
+		logLevel:      "DEBUG",
+	}, *cmd)
+}
+
+func TestWriteSchemaPlan(t *testing.T) {
+	var out bytes.Buffer
+	writeSchemaPlan(validation.SchemaPlan{}, &out)
+	assert.Equal(t, "-- The schema of the database is up to date\n", out.String())
+
+	plan := validation.SchemaPlan{
+		Statements: []validation.PlannedStatement{
+			{Statement: "DROP INDEX `by_name`"},
+			{Statement: "CREATE INDEX `by_name` ON `singers` (`name` DESC)"},
+		},
+		Unapplied: []validation.UnappliedChange{{
+			Difference: validation.SchemaDifference{Kind: validation.KindColumn, Table: "singers", Name: "old", Actual: "old"},
+			Reason:     "dropping the column would delete its data",
+		}},
+	}
+	out.Reset()
+	writeSchemaPlan(plan, &out)
+	assert.Equal(t, "-- Not applied: singers: column \"old\" isn't in the session file (dropping the column would delete its data)\n"+
+		"\n"+
+		"DROP INDEX `by_name`;\n"+
+		"\n"+
+		"CREATE INDEX `by_name` ON `singers` (`name` DESC);\n", out.String())
+
+	prefix := filepath.Join(t.TempDir(), "db1")
+	assert.Nil(t, writeSchemaPlanFiles(plan, prefix))
+	b, err := os.ReadFile(prefix + schemaUpdateFile)
+	assert.Nil(t, err)
+	assert.Equal(t, out.String(), string(b))
+	assert.FileExists(t, prefix+schemaUpdateJSONFile)
+}
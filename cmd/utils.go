@@ -17,7 +17,10 @@ package cmd
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -25,6 +28,7 @@ import (
 	sp "cloud.google.com/go/spanner"
 	database "cloud.google.com/go/spanner/admin/database/apiv1"
 	datastreamclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/datastream"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
 	storageclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/storage"
 	datastream_accessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/datastream"
 	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
@@ -35,23 +39,42 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/conversion"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/helpers"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 )
 
 var (
-	badDataFile   = ".dropped.txt"
-	schemaFile    = ".schema.txt"
-	sessionFile   = ".session.json"
-	overridesFile = ".overrides.json"
+	badDataFile    = ".dropped.txt"
+	schemaFile     = ".schema.txt"
+	sessionFile    = ".session.json"
+	overridesFile  = ".overrides.json"
+	checkpointFile = ".checkpoint.json"
+	deadLetterFile = ".dead-letter.jsonl"
+	// Rows that fail again when a dead-letter file is replayed.
+	replayFailedFile = ".replay-failed.jsonl"
+	orphansFile      = ".orphans.jsonl"
+)
+
+// errForeignKeyOrphans fails a migration with orphanPolicyFail.
+var errForeignKeyOrphans = errors.New("foreign keys have orphaned rows")
+
+// Policies for foreign keys with orphaned rows, i.e. rows that reference a
+// row that doesn't exist.
+const (
+	orphanPolicySkip = "skip" // Don't create the foreign key.
+	orphanPolicyFail = "fail" // Fail the migration.
+	orphanPolicyFile = "file" // Don't create the foreign key, and write the orphaned rows to a file.
 )
 
 const (
 	DefaultWritersLimit  = 40
+	DefaultReadWorkers   = 1
 	completionPercentage = 100
 )
 
@@ -177,7 +200,12 @@ func MigrateDatabase(ctx context.Context, migrationProjectId string, targetProfi
 		bw, err = migrateSchemaAndData(ctx, migrationProjectId, targetProfile, sourceProfile, ioHelper, conv, dbURI, adminClient, client, v)
 	}
 	if err != nil {
-		err = fmt.Errorf("can't migrate database: %v", err)
+		err = fmt.Errorf("can't migrate database: %w", err)
+		// The data of a migration that fails because of orphaned rows has
+		// been migrated, and is reported.
+		if errors.Is(err, errForeignKeyOrphans) {
+			return bw, err
+		}
 		return nil, err
 	}
 	return bw, nil
@@ -239,6 +267,15 @@ func migrateData(ctx context.Context, migrationProjectId string, targetProfile p
 		if err != nil {
 			return bw, err
 		}
+		if sourceProfile.Config.ConfigType != constants.DATAFLOW_MIGRATION {
+			// The orphans are looked for with the client of the migration,
+			// which MigrateDatabase closes.
+			spA.SpannerClient = spannerclient.NewSpannerClientImplWithClient(client)
+			err = checkForeignKeyOrphans(ctx, spA, conv, sourceProfile.Driver, cmd.orphanPolicy, cmd.filePrefix, ioHelper.Out)
+			if err != nil {
+				return bw, err
+			}
+		}
 		spA.UpdateDDLForeignKeys(ctx, dbURI, conv, sourceProfile.Driver, sourceProfile.Config.ConfigType)
 	}
 	return bw, nil
@@ -254,10 +291,13 @@ func migrateSchemaAndData(ctx context.Context, migrationProjectId string, target
 	if err != nil {
 		return nil, err
 	}
-	err = spA.CreateOrUpdateDatabase(ctx, dbURI, sourceProfile.Driver, conv, sourceProfile.Config.ConfigType, tablesExistingOnSpanner)
-	if err != nil {
-		err = fmt.Errorf("can't create/update database: %v", err)
-		return nil, err
+	// When resuming, the schema was created by the migration being resumed.
+	if !cmd.Resume {
+		err = spA.CreateOrUpdateDatabase(ctx, dbURI, sourceProfile.Driver, conv, sourceProfile.Config.ConfigType, tablesExistingOnSpanner)
+		if err != nil {
+			err = fmt.Errorf("can't create/update database: %v", err)
+			return nil, err
+		}
 	}
 	metricsPopulation(ctx, sourceProfile.Driver, conv)
 	conv.Audit.Progress.UpdateProgress("Schema migration complete.", completionPercentage, internal.SchemaMigrationComplete)
@@ -279,12 +319,167 @@ func migrateSchemaAndData(ctx context.Context, migrationProjectId string, target
 	}
 
 	conv.Audit.Progress.UpdateProgress("Data migration complete.", completionPercentage, internal.DataMigrationComplete)
+	// Indexes are created before foreign keys, so that foreign keys can use
+	// them instead of creating backing indexes of their own.
+	if conv.Audit.DeferIndexes {
+		spA.UpdateDDLIndexes(ctx, dbURI, conv, sourceProfile.Driver)
+	}
 	if !cmd.SkipForeignKeys {
+		if sourceProfile.Config.ConfigType != constants.DATAFLOW_MIGRATION {
+			// The orphans are looked for with the client of the migration,
+			// which MigrateDatabase closes.
+			spA.SpannerClient = spannerclient.NewSpannerClientImplWithClient(client)
+			err = checkForeignKeyOrphans(ctx, spA, conv, sourceProfile.Driver, cmd.orphanPolicy, cmd.filePrefix, ioHelper.Out)
+			if err != nil {
+				return bw, err
+			}
+		}
 		spA.UpdateDDLForeignKeys(ctx, dbURI, conv, sourceProfile.Driver, sourceProfile.Config.ConfigType)
 	}
 	return bw, nil
 }
 
+// validateOrphanPolicy checks the policy for foreign keys with orphaned rows
+// given with --foreign-key-orphans.
+func validateOrphanPolicy(policy string) error {
+	switch policy {
+	case orphanPolicySkip, orphanPolicyFail, orphanPolicyFile:
+		return nil
+	}
+	return fmt.Errorf("invalid --foreign-key-orphans %q: accepted values are %s, %s and %s", policy, orphanPolicySkip, orphanPolicyFail, orphanPolicyFile)
+}
+
+// checkForeignKeyOrphans looks for orphaned rows of the foreign keys of conv
+// before they are created, and applies policy to the foreign keys that have
+// them. These foreign keys are left out by UpdateDDLForeignKeys, and with
+// orphanPolicyFail the migration fails instead. With orphanPolicyFile, the
+// orphaned rows are written to <filePrefix>.orphans.jsonl. Since the check
+// is only a safeguard, a failure to run it is reported but doesn't stop the
+// foreign keys from being created.
+func checkForeignKeyOrphans(ctx context.Context, spA spanneraccessor.SpannerAccessor, conv *internal.Conv, driver, policy, filePrefix string, out io.Writer) error {
+	var orphans io.Writer
+	var f *os.File
+	if policy == orphanPolicyFile {
+		var err error
+		f, err = os.Create(filePrefix + orphansFile)
+		if err != nil {
+			return fmt.Errorf("can't create orphaned rows file %s: %v", filePrefix+orphansFile, err)
+		}
+		defer f.Close()
+		orphans = f
+	}
+	fmt.Fprintf(out, "Checking foreign keys for orphaned rows ...\n")
+	if err := spA.CheckForeignKeyOrphans(ctx, conv, driver, orphans); err != nil {
+		logger.Log.Warn("Can't check foreign keys for orphaned rows", zap.Error(err))
+		conv.Unexpected(fmt.Sprintf("Can't check foreign keys for orphaned rows: %s", err))
+	}
+	if len(conv.Audit.ForeignKeyOrphans) == 0 {
+		if f != nil {
+			os.Remove(f.Name())
+		}
+		return nil
+	}
+	for _, o := range conv.Audit.ForeignKeyOrphans {
+		table := conv.SpSchema[o.TableId]
+		for _, fk := range table.ForeignKeys {
+			if fk.Id == o.ForeignKeyId {
+				fmt.Fprintf(out, "Foreign key %s of table %s has %d orphaned rows, e.g. %s\n", fk.Name, table.Name, o.Orphans, o.Samples[0])
+			}
+		}
+	}
+	switch policy {
+	case orphanPolicyFail:
+		return fmt.Errorf("%d %w", len(conv.Audit.ForeignKeyOrphans), errForeignKeyOrphans)
+	case orphanPolicyFile:
+		fmt.Fprintf(out, "Wrote orphaned rows to %s\n", f.Name())
+	}
+	fmt.Fprintf(out, "Foreign keys with orphaned rows won't be created\n")
+	return nil
+}
+
+// writeDataReport writes the report of a data migration and the rows that
+// couldn't be written.
+func writeDataReport(bw *writer.BatchWriter, conv *internal.Conv, driver string, bytesRead int64, banner, filePrefix, dbName string, out *os.File) {
+	conv.Stats.UpdatedRows = bw.UpdatedRowsByTable()
+	reportImpl := conversion.ReportImpl{}
+	reportImpl.GenerateReport(driver, bw.DroppedRowsByTable(), bytesRead, banner, conv, filePrefix, dbName, out)
+	conversion.WriteBadData(bw, conv, banner, filePrefix+badDataFile, out)
+}
+
+// getCheckpoint returns the checkpoint recording the progress of a direct
+// connect bulk data migration. When resume is set, the checkpoint saved by an
+// earlier migration with the same file prefix is loaded, otherwise a new one
+// is started. Other kinds of migration aren't checkpointed.
+func getCheckpoint(sourceProfile profiles.SourceProfile, filePrefix string, resume bool) (*internal.Checkpoint, error) {
+	switch sourceProfile.Driver {
+	case constants.MYSQL, constants.POSTGRES, constants.SQLSERVER, constants.ORACLE:
+		if sourceProfile.Ty == profiles.SourceProfileTypeConnection {
+			if resume {
+				return internal.LoadCheckpoint(filePrefix + checkpointFile)
+			}
+			return internal.NewCheckpoint(filePrefix + checkpointFile), nil
+		}
+	}
+	if resume {
+		return nil, fmt.Errorf("resuming is only supported for bulk data migrations from MySQL, PostgreSQL, SQL Server or Oracle databases in direct connect mode")
+	}
+	return nil, nil
+}
+
+// effectiveWriteMode returns the write mode of a bulk data migration. A
+// resumed migration writes again the rows committed after the last
+// checkpoint of the migration it resumes, and BatchWrite may apply a
+// mutation group more than once, so both write with insert-or-update
+// rather than failing those rows with insert.
+func effectiveWriteMode(writeMode writer.WriteMode, resume, batchWrite bool) writer.WriteMode {
+	if writeMode != writer.WriteModeInsert {
+		return writeMode
+	}
+	if resume {
+		logger.Log.Info("writing rows with insert-or-update, since rows after the checkpoint may have been written by the resumed migration")
+		return writer.WriteModeInsertOrUpdate
+	}
+	if batchWrite {
+		logger.Log.Info("writing rows with insert-or-update, since BatchWrite may apply a mutation group more than once")
+		return writer.WriteModeInsertOrUpdate
+	}
+	return writeMode
+}
+
+// openDeadLetterFile opens the file that receives the rows a bulk data
+// migration can't write to Spanner. A resumed migration appends to the file
+// of the migration it resumes, since rows dropped before the failure are
+// not read again.
+func openDeadLetterFile(name string, resume bool) (*os.File, error) {
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(name, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("can't open dead-letter file %s: %v", name, err)
+	}
+	return f, nil
+}
+
+// closeDeadLetterFile closes dead-letter file f, and removes it if it is
+// empty. Otherwise it reports where the dropped rows were written, and
+// whether bw (if known) failed to write some of them.
+func closeDeadLetterFile(f *os.File, bw *writer.BatchWriter, out io.Writer) {
+	info, err := f.Stat()
+	f.Close()
+	if err == nil && info.Size() == 0 {
+		os.Remove(f.Name())
+		return
+	}
+	if err == nil {
+		fmt.Fprintf(out, "Rows that couldn't be written to Spanner were written to %s. Fix them and write them to Spanner with the replay command.\n", f.Name())
+	}
+	if bw != nil && bw.DeadLetterError() != nil {
+		fmt.Fprintf(out, "Some rows that couldn't be written to Spanner are missing from %s: %v\n", f.Name(), bw.DeadLetterError())
+	}
+}
+
 func ValidateResourceGenerationHelper(ctx context.Context, migrationProjectId string, instanceId string, sourceProfile profiles.SourceProfile, conv *internal.Conv) error {
 	spanneraccessor, err := spanneraccessor.NewSpannerAccessorClientImpl(ctx)
 	if err != nil {
@@ -15,8 +15,17 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
+	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,3 +81,52 @@ func TestGetSessionFileName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateOrphanPolicy(t *testing.T) {
+	for _, policy := range []string{"skip", "fail", "file"} {
+		assert.Nil(t, validateOrphanPolicy(policy))
+	}
+	assert.ErrorContains(t, validateOrphanPolicy("delete"), "invalid --foreign-key-orphans")
+}
+
+func TestEffectiveWriteMode(t *testing.T) {
+	assert.Equal(t, writer.WriteModeInsert, effectiveWriteMode(writer.WriteModeInsert, false, false))
+	assert.Equal(t, writer.WriteModeInsertOrUpdate, effectiveWriteMode(writer.WriteModeInsert, true, false))
+	assert.Equal(t, writer.WriteModeInsertOrUpdate, effectiveWriteMode(writer.WriteModeInsert, false, true))
+	assert.Equal(t, writer.WriteModeReplace, effectiveWriteMode(writer.WriteModeReplace, true, true))
+}
+
+func TestCheckForeignKeyOrphans(t *testing.T) {
+	spA := &spanneraccessor.SpannerAccessorMock{
+		CheckForeignKeyOrphansMock: func(ctx context.Context, conv *internal.Conv, driver string, orphans io.Writer) error {
+			if orphans != nil {
+				orphans.Write([]byte("orphan\n"))
+			}
+			conv.Audit.ForeignKeyOrphans = []internal.ForeignKeyOrphans{{TableId: "t1", ForeignKeyId: "f1", Orphans: 1, Samples: []string{"id=1, customer_id=7"}}}
+			return nil
+		},
+	}
+	newConv := func() *internal.Conv {
+		conv := internal.MakeConv()
+		conv.SpSchema["t1"] = ddl.CreateTable{Name: "orders", Id: "t1", ForeignKeys: []ddl.Foreignkey{{Name: "fk_customer", Id: "f1"}}}
+		return conv
+	}
+	prefix := filepath.Join(t.TempDir(), "db1")
+	var out bytes.Buffer
+
+	assert.Nil(t, checkForeignKeyOrphans(context.Background(), spA, newConv(), "", orphanPolicySkip, prefix, &out))
+	assert.Contains(t, out.String(), "Foreign key fk_customer of table orders has 1 orphaned rows, e.g. id=1, customer_id=7")
+	assert.NoFileExists(t, prefix+orphansFile)
+
+	conv := newConv()
+	err := checkForeignKeyOrphans(context.Background(), spA, conv, "", orphanPolicyFail, prefix, &out)
+	assert.ErrorContains(t, err, "1 foreign keys have orphaned rows")
+	assert.ErrorIs(t, err, errForeignKeyOrphans)
+	// The orphans stay recorded for the report of the failed migration.
+	assert.Len(t, conv.Audit.ForeignKeyOrphans, 1)
+
+	assert.Nil(t, checkForeignKeyOrphans(context.Background(), spA, newConv(), "", orphanPolicyFile, prefix, &out))
+	b, err := os.ReadFile(prefix + orphansFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "orphan\n", string(b))
+}
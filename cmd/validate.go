@@ -0,0 +1,336 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"text/tabwriter"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/conversion"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/google/subcommands"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	validationTextFile    = ".validation.txt"
+	validationJSONFile    = ".validation.json"
+	rowValidationJSONFile = ".row-validation.json"
+	rowMismatchesFile     = ".row-mismatches.jsonl"
+)
+
+// ValidateCmd is the command for checking that a migration copied every row,
+// by comparing the number of rows of each source table with the number of
+// rows of the Spanner table it was migrated to.
+type ValidateCmd struct {
+	source        string
+	sourceProfile string
+	targetProfile string
+	sessionJSON   string
+	filePrefix    string
+	project       string
+	rows          bool
+	sampleRate    float64
+	batchSize     int
+	logLevel      string
+}
+
+// Name returns the name of operation.
+func (cmd *ValidateCmd) Name() string {
+	return "validate"
+}
+
+// Synopsis returns summary of operation.
+func (cmd *ValidateCmd) Synopsis() string {
+	return "validate compares the row counts of the source database and the Spanner database"
+}
+
+// Usage returns usage info of the command.
+func (cmd *ValidateCmd) Usage() string {
+	return fmt.Sprintf(`%v validate --source=[source] --source-profile="key1=value1,key2=value2" --target-profile="instance=i1,dbName=db1" --session=session.json ...
+
+Compare the number of rows of each table of the source database with the
+number of rows of the Spanner table it was migrated to, using the table
+names of the session file. A report of the tables is written to
+PREFIX%s and PREFIX%s. The command fails if the counts
+of any table don't match.
+
+With --rows, the values of every row are compared as well: source rows are
+converted as a data migration converts them and compared with the Spanner
+rows with the same primary key. The rows that don't match are written to
+PREFIX%s.
+`, path.Base(os.Args[0]), validationTextFile, validationJSONFile, rowMismatchesFile)
+}
+
+// SetFlags sets the flags.
+func (cmd *ValidateCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&cmd.source, "source", "", "Flag for specifying source DB, (e.g., `PostgreSQL`, `MySQL`, `DynamoDB`)")
+	f.StringVar(&cmd.sourceProfile, "source-profile", "", "Flag for specifying connection profile for source database e.g., \"host=localhost,port=5432,user=abc,password=pwd,dbName=db\"")
+	f.StringVar(&cmd.targetProfile, "target-profile", "", "Flag for specifying connection profile for target database e.g., \"instance=my-instance,dbName=my-db\"")
+	f.StringVar(&cmd.sessionJSON, "session", "", "Specifies the file we restore session state from")
+	f.StringVar(&cmd.filePrefix, "prefix", "", "File prefix for generated files")
+	f.StringVar(&cmd.project, "project", "", "Flag spcifying default project id for all the generated resources for the migration")
+	f.BoolVar(&cmd.rows, "rows", false, "Also compare the values of the rows, by converting each source row as a data migration does and comparing it with the Spanner row with the same primary key")
+	f.Float64Var(&cmd.sampleRate, "sample-rate", 1, "Fraction of the rows compared with --rows, between 0 and 1. Every row is still read from the source")
+	f.IntVar(&cmd.batchSize, "batch-size", validation.DefaultBatchSize, "Number of rows looked up in Spanner at a time with --rows")
+	f.StringVar(&cmd.logLevel, "log-level", "DEBUG", "Configure the logging level for the command (INFO, DEBUG), defaults to DEBUG")
+}
+
+func (cmd *ValidateCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	err := logger.InitializeLogger(cmd.logLevel)
+	if err != nil {
+		fmt.Println("Error initialising logger, did you specify a valid log-level? [DEBUG, INFO, WARN, ERROR, FATAL]", err)
+		return subcommands.ExitFailure
+	}
+	defer logger.Log.Sync()
+	sourceProfile, targetProfile, err := cmd.validate()
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Input validation failed. Reason %v", err))
+		return subcommands.ExitUsageError
+	}
+	if cmd.project == "" {
+		getInfo := &utils.GetUtilInfoImpl{}
+		cmd.project, err = getInfo.GetProject()
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("Could not get project id from gcloud environment or --project flag: %v", err))
+			return subcommands.ExitUsageError
+		}
+	}
+	conv := internal.MakeConv()
+	if err = conversion.ReadSessionFile(conv, cmd.sessionJSON); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't read session file %s: %v", cmd.sessionJSON, err))
+		return subcommands.ExitUsageError
+	}
+	if cmd.filePrefix == "" {
+		cmd.filePrefix = targetProfile.Conn.Sp.Dbname
+	}
+
+	infoSchema, err := (&conversion.GetInfoImpl{}).GetInfoSchema(cmd.project, sourceProfile, targetProfile)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't connect to the source database: %v", err))
+		return subcommands.ExitFailure
+	}
+	ioHelper := utils.NewIOStreams(sourceProfile.Driver, "")
+	adminClient, client, dbURI, err := CreateDatabaseClient(ctx, targetProfile, sourceProfile.Driver, "", ioHelper)
+	if adminClient != nil {
+		defer adminClient.Close()
+	}
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't connect to the Spanner database: %v", err))
+		return subcommands.ExitFailure
+	}
+	defer client.Close()
+
+	config := ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect, Source: sourceProfile.Driver}
+	counts := conversion.ValidateRowCounts(conv, infoSchema, func(table string) (int64, error) {
+		return spannerRowCount(ctx, client, config, table)
+	})
+	var rows []validation.TableResult
+	if cmd.rows {
+		rows, err = cmd.validateRows(ctx, conv, infoSchema, client)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("Can't compare rows: %v", err))
+			return subcommands.ExitFailure
+		}
+	}
+	ok := writeRowCountReport(counts, os.Stdout)
+	if cmd.rows && !writeRowValidationReport(rows, os.Stdout) {
+		ok = false
+	}
+	if err = writeValidationFiles(counts, rows, cmd.rows, cmd.filePrefix); err != nil {
+		logger.Log.Error(fmt.Sprintf("Can't write validation report: %v", err))
+		return subcommands.ExitFailure
+	}
+	fmt.Printf("Wrote validation report to %s and %s for database %s\n", cmd.filePrefix+validationTextFile, cmd.filePrefix+validationJSONFile, dbURI)
+	if cmd.rows {
+		fmt.Printf("Wrote row validation report to %s\n", cmd.filePrefix+rowValidationJSONFile)
+	}
+	if !ok {
+		return subcommands.ExitFailure
+	}
+	return subcommands.ExitSuccess
+}
+
+func (cmd *ValidateCmd) validate() (profiles.SourceProfile, profiles.TargetProfile, error) {
+	if cmd.sessionJSON == "" {
+		return profiles.SourceProfile{}, profiles.TargetProfile{}, fmt.Errorf("Please specify the session file using the --session parameter")
+	}
+	if cmd.sampleRate <= 0 || cmd.sampleRate > 1 {
+		return profiles.SourceProfile{}, profiles.TargetProfile{}, fmt.Errorf("Please specify a --sample-rate greater than 0 and at most 1. Received sample-rate: %v", cmd.sampleRate)
+	}
+	targetProfile, err := profiles.NewTargetProfile(cmd.targetProfile)
+	if err != nil {
+		return profiles.SourceProfile{}, profiles.TargetProfile{}, err
+	}
+	if targetProfile.Conn.Sp.Dbname == "" {
+		return profiles.SourceProfile{}, targetProfile, fmt.Errorf("Please specify the Spanner database using dbName in the --target-profile parameter")
+	}
+	sourceProfile, err := profiles.NewSourceProfile(cmd.sourceProfile, cmd.source, &profiles.NewSourceProfileImpl{})
+	if err != nil {
+		return profiles.SourceProfile{}, targetProfile, err
+	}
+	if sourceProfile.Ty != profiles.SourceProfileTypeConnection {
+		return profiles.SourceProfile{}, targetProfile, fmt.Errorf("validate needs a connection to the source database, dump files and configs are not supported")
+	}
+	sourceProfile.Driver, err = sourceProfile.ToLegacyDriver(cmd.source)
+	if err != nil {
+		return profiles.SourceProfile{}, targetProfile, err
+	}
+	return sourceProfile, targetProfile, nil
+}
+
+// validateRows compares the rows of the source database with the rows of
+// the Spanner database, and writes the rows that don't match to the
+// mismatches file. The file is removed if every row matches.
+func (cmd *ValidateCmd) validateRows(ctx context.Context, conv *internal.Conv, infoSchema common.InfoSchema, client *sp.Client) ([]validation.TableResult, error) {
+	name := cmd.filePrefix + rowMismatchesFile
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	results := validation.ValidateRows(conv, infoSchema, validation.Config{
+		BatchSize:  cmd.batchSize,
+		SampleRate: cmd.sampleRate,
+		Read:       validation.SpannerReader(ctx, client),
+		Mismatches: f,
+	})
+	f.Close()
+	if fi, err := os.Stat(name); err == nil && fi.Size() == 0 {
+		os.Remove(name)
+	} else {
+		fmt.Printf("Wrote rows that don't match to %s\n", name)
+	}
+	return results, nil
+}
+
+// spannerRowCount returns the number of rows of a Spanner table, whose name
+// is qualified by its named schema.
+func spannerRowCount(ctx context.Context, client *sp.Client, config ddl.Config, table string) (int64, error) {
+	iter := client.Single().Query(ctx, sp.Statement{SQL: "SELECT COUNT(*) FROM " + config.QuoteQualifiedName(table)})
+	defer iter.Stop()
+	row, err := iter.Next()
+	if err == iterator.Done {
+		return 0, fmt.Errorf("COUNT(*) returned no rows")
+	}
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	err = row.Columns(&count)
+	return count, err
+}
+
+// writeRowCountReport writes a table of the row counts to w, and returns
+// whether the counts of every table match.
+func writeRowCountReport(counts []conversion.TableRowCount, w io.Writer) bool {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "Source table\tSpanner table\tSource rows\tSpanner rows\tResult")
+	mismatches := 0
+	for _, c := range counts {
+		result := "MATCH"
+		switch {
+		case c.Error != "":
+			result = "ERROR: " + c.Error
+		case !c.Match:
+			result = "MISMATCH"
+		}
+		if !c.Match {
+			mismatches++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\n", c.SourceTable, c.SpannerTable, c.SourceRows, c.SpannerRows, result)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\n%d of %d tables match\n", len(counts)-mismatches, len(counts))
+	return mismatches == 0
+}
+
+// writeRowValidationReport writes a table of the outcome of comparing the
+// rows of each table to w, and returns whether every row matches.
+func writeRowValidationReport(results []validation.TableResult, w io.Writer) bool {
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "Spanner table\tCompared\tMatched\tMismatched\tMissing\tErrors\tBad rows\tResult")
+	failed := 0
+	for _, r := range results {
+		result := "MATCH"
+		switch {
+		case r.Skipped != "":
+			result = "SKIPPED: " + r.Skipped
+		case r.Error != "":
+			result = "ERROR: " + r.Error
+		case r.Matched == r.Compared && r.BadRows > 0:
+			result = "BAD ROWS"
+		case !r.Ok():
+			result = "MISMATCH"
+		}
+		if !r.Ok() {
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n", r.Table, r.Compared, r.Matched, r.Mismatched, r.Missing, r.Errors, r.BadRows, result)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\nRows of %d of %d tables match\n", len(results)-failed, len(results))
+	return failed == 0
+}
+
+// writeValidationFiles writes the row counts, and the outcome of comparing
+// rows if rows were compared, to the text and JSON report files.
+func writeValidationFiles(counts []conversion.TableRowCount, rows []validation.TableResult, compareRows bool, filePrefix string) error {
+	f, err := os.Create(filePrefix + validationTextFile)
+	if err != nil {
+		return err
+	}
+	writeRowCountReport(counts, f)
+	if compareRows {
+		writeRowValidationReport(rows, f)
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if counts == nil {
+		counts = []conversion.TableRowCount{}
+	}
+	if err = writeJSONFile(filePrefix+validationJSONFile, counts); err != nil {
+		return err
+	}
+	if !compareRows {
+		return nil
+	}
+	if rows == nil {
+		rows = []validation.TableResult{}
+	}
+	return writeJSONFile(filePrefix+rowValidationJSONFile, rows)
+}
+
+func writeJSONFile(name string, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0644)
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/conversion"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCmd_SetFlags(t *testing.T) {
+	cmd := &ValidateCmd{}
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	cmd.SetFlags(fs)
+	assert.Nil(t, fs.Parse([]string{"--source=mysql", "--source-profile=host=localhost", "--target-profile=instance=i1,dbName=db1", "--session=session.json", "--prefix=p"}))
+	assert.Equal(t, ValidateCmd{
+		source:        "mysql",
+		sourceProfile: "host=localhost",
+		targetProfile: "instance=i1,dbName=db1",
+		sessionJSON:   "session.json",
+		filePrefix:    "p",
+		sampleRate:    1,
+		batchSize:     validation.DefaultBatchSize,
+		logLevel:      "DEBUG",
+	}, *cmd)
+}
+
+func TestValidateCmd_Validate(t *testing.T) {
+	cmd := ValidateCmd{source: "mysql", sampleRate: 1}
+	_, _, err := cmd.validate()
+	assert.ErrorContains(t, err, "Please specify the session file")
+	cmd.sessionJSON = "session.json"
+	cmd.sampleRate = 1.5
+	_, _, err = cmd.validate()
+	assert.ErrorContains(t, err, "Please specify a --sample-rate")
+	cmd.sampleRate = 0.1
+	cmd.targetProfile = "instance=i1"
+	_, _, err = cmd.validate()
+	assert.ErrorContains(t, err, "Please specify the Spanner database")
+	cmd.targetProfile = "instance=i1,dbName=db1"
+	cmd.sourceProfile = "config=config.json"
+	_, _, err = cmd.validate()
+	assert.Error(t, err)
+}
+
+func TestWriteRowCountReport(t *testing.T) {
+	counts := []conversion.TableRowCount{
+		{SourceTable: "customers", SpannerTable: "customers", SourceRows: 5, SpannerRows: 4},
+		{SourceTable: "missing", SpannerTable: "missing", Error: "table not found"},
+		{SourceTable: "Orders", SpannerTable: "orders", SourceRows: 10, SpannerRows: 10, Match: true},
+	}
+	var out bytes.Buffer
+	assert.False(t, writeRowCountReport(counts, &out))
+	assert.Equal(t, `Source table  Spanner table  Source rows  Spanner rows  Result
+customers     customers      5            4             MISMATCH
+missing       missing        0            0             ERROR: table not found
+Orders        orders         10           10            MATCH
+
+1 of 3 tables match
+`, out.String())
+	out.Reset()
+	assert.True(t, writeRowCountReport(counts[2:], &out))
+
+	prefix := filepath.Join(t.TempDir(), "db1")
+	assert.Nil(t, writeValidationFiles(counts, nil, false, prefix))
+	b, err := os.ReadFile(prefix + validationJSONFile)
+	assert.Nil(t, err)
+	var got []conversion.TableRowCount
+	assert.Nil(t, json.Unmarshal(b, &got))
+	assert.Equal(t, counts, got)
+	assert.FileExists(t, prefix+validationTextFile)
+	assert.NoFileExists(t, prefix+rowValidationJSONFile)
+}
+
+func TestWriteRowValidationReport(t *testing.T) {
+	results := []validation.TableResult{
+		{Table: "logs", Skipped: "synthetic primary key"},
+		{Table: "orders", Compared: 3, Matched: 1, Mismatched: 1, Missing: 1},
+		{Table: "singers", Compared: 4, Matched: 4, BadRows: 1},
+		{Table: "users", Compared: 2, Matched: 2},
+	}
+	var out bytes.Buffer
+	assert.False(t, writeRowValidationReport(results, &out))
+	assert.Equal(t, `
+Spanner table  Compared  Matched  Mismatched  Missing  Errors  Bad rows  Result
+logs           0         0        0           0        0       0         SKIPPED: synthetic primary key
+orders         3         1        1           1        0       0         MISMATCH
+singers        4         4        0           0        0       1         BAD ROWS
+users          2         2        0           0        0       0         MATCH
+
+Rows of 1 of 4 tables match
+`, out.String())
+
+	prefix := filepath.Join(t.TempDir(), "db1")
+	assert.Nil(t, writeValidationFiles(nil, results, true, prefix))
+	b, err := os.ReadFile(prefix + rowValidationJSONFile)
+	assert.Nil(t, err)
+	var got []validation.TableResult
+	assert.Nil(t, json.Unmarshal(b, &got))
+	assert.Equal(t, results, got)
+	b, err = os.ReadFile(prefix + validationTextFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "Rows of 1 of 4 tables match")
+}
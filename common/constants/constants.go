@@ -128,11 +128,13 @@ const (
 	DLQ_GCS     string = "dlq"
 
 	// VerifyExpresions API
-	CHECK_EXPRESSION   = "CHECK"
-	DEFAULT_EXPRESSION = "DEFAULT"
-	DEFAULT_GENERATED  = "DEFAULT_GENERATED"
-	TEMP_DB            = "smt-staging-db"
-	DB_URI             = "projects/%s/instances/%s/databases/%s"
+	CHECK_EXPRESSION     = "CHECK"
+	DEFAULT_EXPRESSION   = "DEFAULT"
+	GENERATED_EXPRESSION = "GENERATED"
+	VIEW_EXPRESSION      = "VIEW"
+	DEFAULT_GENERATED    = "DEFAULT_GENERATED"
+	TEMP_DB              = "smt-staging-db"
+	DB_URI               = "projects/%s/instances/%s/databases/%s"
 
 	// Regex for matching database collation
 	DB_COLLATION_REGEX = `(_[a-zA-Z0-9]+\\|\\)`
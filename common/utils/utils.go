@@ -397,6 +397,8 @@ func GetBanner(now time.Time, db string) string {
 // ReadSpannerSchema fills conv by querying Spanner infoschema treating Spanner as both the source and dest.
 func ReadSpannerSchema(ctx context.Context, conv *internal.Conv, client *sp.Client) error {
 	infoSchema := spanner.InfoSchemaImpl{Client: client, Ctx: ctx, SpDialect: conv.SpDialect}
+	// Named schemas of the source database are Spanner named schemas already.
+	conv.NamedSchemas = true
 	processSchema := common.ProcessSchemaImpl{}
 	expressionVerificationAccessor, _ := expressions_api.NewExpressionVerificationAccessorImpl(ctx, conv.SpProjectId, conv.SpInstanceId)
 	ddlVerifier, err := expressions_api.NewDDLVerifierImpl(ctx, conv.SpProjectId, conv.SpInstanceId)
@@ -420,7 +422,7 @@ func ReadSpannerSchema(ctx context.Context, conv *internal.Conv, client *sp.Clie
 	}
 	// Assign parents if any.
 	for tableName, parentTable := range parentTables {
-		tableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, tableName)
+		tableId, _ := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, tableName)
 		spTable := conv.SpSchema[tableId]
 		spTable.ParentTable.Id = parentTable.Id
 		spTable.ParentTable.OnDelete = parentTable.OnDelete
@@ -436,16 +438,16 @@ func CompareSchema(sessionFileConv, actualSpannerConv *internal.Conv) error {
 		return fmt.Errorf("spanner dialect don't match: session dialect %v, spanner dialect %v", sessionFileConv.SpDialect, actualSpannerConv.SpDialect)
 	}
 	for _, sessionTable := range sessionFileConv.SpSchema {
-		spannerTableId, err := internal.GetTableIdFromSpName(actualSpannerConv.SpSchema, sessionTable.Name)
+		spannerTableId, err := internal.GetTableIdFromSpQualifiedName(actualSpannerConv.SpSchema, sessionTable.QualifiedName())
 		if err != nil {
 			return fmt.Errorf("table %v not found in the spanner database schema but found in the session file. If this table does not need to be migrated, please exclude it during the schema conversion and migration process", sessionTable.Name)
 		}
 		spannerTable := actualSpannerConv.SpSchema[spannerTableId]
-		sessionTableParentName := sessionFileConv.SpSchema[sessionTable.ParentTable.Id].Name
-		spannerTableParentName := actualSpannerConv.SpSchema[spannerTable.ParentTable.Id].Name
+		sessionTableParentName := sessionFileConv.SpSchema[sessionTable.ParentTable.Id].QualifiedName()
+		spannerTableParentName := actualSpannerConv.SpSchema[spannerTable.ParentTable.Id].QualifiedName()
 
 		//table names should match
-		if sessionTable.Name != spannerTable.Name {
+		if sessionTable.QualifiedName() != spannerTable.QualifiedName() {
 			return fmt.Errorf("table name don't match: session table %v, spanner table %v", sessionTable.Name, spannerTable.Name)
 		}
 
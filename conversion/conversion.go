@@ -98,13 +98,17 @@ func (ci *ConvImpl) SchemaConv(migrationProjectId string, sourceProfile profiles
 // The SourceProfile param provides the connection details to use the go SQL library.
 func (ci *ConvImpl) DataConv(ctx context.Context, migrationProjectId string, sourceProfile profiles.SourceProfile, targetProfile profiles.TargetProfile, ioHelper *utils.IOStreams, client *sp.Client, conv *internal.Conv, dataOnly bool, writeLimit int64, dataFromSource DataFromSourceInterface) (*writer.BatchWriter, error) {
 	config := writer.BatchWriterConfig{
-		BytesLimit: 100 * 1000 * 1000,
-		WriteLimit: writeLimit,
-		RetryLimit: 1000,
-		Verbose:    internal.Verbose(),
+		BytesLimit:    100 * 1000 * 1000,
+		WriteLimit:    writeLimit,
+		RetryLimit:    1000,
+		Verbose:       internal.Verbose(),
+		WriteMode:     writer.WriteMode(conv.Audit.WriteMode),
+		DeadLetter:    conv.Audit.DeadLetter,
+		Adaptive:      conv.Audit.AdaptiveWrites,
+		RowsPerSecond: conv.Audit.MaxRowsPerSecond,
 	}
 	switch sourceProfile.Driver {
-	case constants.POSTGRES, constants.MYSQL, constants.DYNAMODB, constants.SQLSERVER, constants.ORACLE:
+	case constants.POSTGRES, constants.MYSQL, constants.DYNAMODB, constants.SQLSERVER, constants.ORACLE, constants.CASSANDRA:
 		return dataFromSource.dataFromDatabase(ctx, migrationProjectId, sourceProfile, targetProfile, config, conv, client, &GetInfoImpl{}, &DataFromDatabaseImpl{}, &SnapshotMigrationImpl{})
 	case constants.PGDUMP, constants.MYSQLDUMP:
 		if conv.SpSchema.CheckInterleaved() {
@@ -61,6 +61,7 @@ func (sads *SchemaFromSourceImpl) schemaFromDatabase(migrationProjectId string,
 	conv.SpProjectId = targetProfile.Conn.Sp.Project
 	conv.SpInstanceId = targetProfile.Conn.Sp.Instance
 	conv.Source = sourceProfile.Driver
+	conv.NamedSchemas = targetProfile.Conn.Sp.NamedSchemas
 	conv.DefaultIdentityOptions = ddl.IdentityOptions{
 		SkipRangeMin: targetProfile.DefaultIdentityOptions.SkipRangeMin,
 		SkipRangeMax: targetProfile.DefaultIdentityOptions.SkipRangeMax,
@@ -103,7 +103,7 @@ func (pdd *ProcessDumpByDialectImpl) ProcessDump(driver string, conv *internal.C
 
 func (pdc *PopulateDataConvImpl) populateDataConv(conv *internal.Conv, config writer.BatchWriterConfig, client *sp.Client) *writer.BatchWriter {
 	rows := int64(0)
-	config.Write = func(m []*sp.Mutation) error {
+	writeContext := func() context.Context {
 		ctx := context.Background()
 		if !conv.Audit.SkipMetricsPopulation {
 			migrationData := metrics.GetMigrationData(conv, "", constants.DataConv)
@@ -111,7 +111,10 @@ func (pdc *PopulateDataConvImpl) populateDataConv(conv *internal.Conv, config wr
 			migrationMetadataValue := base64.StdEncoding.EncodeToString(serializedMigrationData)
 			ctx = metadata.AppendToOutgoingContext(context.Background(), constants.MigrationMetadataKey, migrationMetadataValue)
 		}
-		_, err := client.Apply(ctx, m)
+		return ctx
+	}
+	config.Write = func(m []*sp.Mutation) error {
+		_, err := client.Apply(writeContext(), m)
 		if err != nil {
 			return err
 		}
@@ -119,6 +122,26 @@ func (pdc *PopulateDataConvImpl) populateDataConv(conv *internal.Conv, config wr
 		conv.Audit.Progress.MaybeReport(atomic.LoadInt64(&rows))
 		return nil
 	}
+	if conv.Audit.BatchWrite {
+		config.BatchWrite = func(groups [][]*sp.Mutation) []error {
+			errs := writer.MutationGroupErrors(client.BatchWrite(writeContext(), writer.MutationGroups(groups)), len(groups))
+			for i, err := range errs {
+				if err == nil {
+					atomic.AddInt64(&rows, int64(len(groups[i])))
+				}
+			}
+			conv.Audit.Progress.MaybeReport(atomic.LoadInt64(&rows))
+			return errs
+		}
+		config.ParentTable = writer.InterleaveParents(conv)
+	}
+	config.KeyColumns = writer.PrimaryKeyColumns(conv)
+	config.ColumnType = writer.ColumnTypes(conv)
+	if conv.Audit.CountUpdatedRows {
+		config.CountExisting = func(table string, keyCols []string, keys []sp.Key) (int64, error) {
+			return writer.CountRows(client.Single().Read(context.Background(), table, sp.KeySetFromKeys(keys...), keyCols))
+		}
+	}
 	batchWriter := writer.NewBatchWriter(config)
 	conv.SetDataMode()
 	if !conv.Audit.DryRun {
@@ -200,12 +200,13 @@ func (gi *GetInfoImpl) GetInfoSchema(migrationProjectId string, sourceProfile pr
 		}
 		return oracle.InfoSchemaImpl{DbName: strings.ToUpper(dbName), Db: db, MigrationProjectId: migrationProjectId, SourceProfile: sourceProfile, TargetProfile: targetProfile}, nil
 	case constants.CASSANDRA:
-		_, ksMetadata, err := ca.NewCassandraAccessor(sourceProfile)
+		accessor, ksMetadata, err := ca.NewCassandraAccessor(sourceProfile)
 		if err != nil {
 			return nil, err
 		}
 		return cassandra.InfoSchemaImpl{
-			KeyspaceMetadata: ksMetadata, 
+			Client:           accessor.Client(),
+			KeyspaceMetadata: ksMetadata,
 			SourceProfile:    sourceProfile,
 			TargetProfile:    targetProfile,
 		}, nil
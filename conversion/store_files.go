@@ -24,6 +24,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 )
@@ -42,7 +43,9 @@ func WriteSchemaFile(conv *internal.Conv, now time.Time, name string, out *os.Fi
 	// and doesn't add backticks around table and column names. This file is
 	// intended for explanatory and documentation purposes, and is not strictly
 	// legal Cloud Spanner DDL (Cloud Spanner doesn't currently support comments).
-	spDDL := ddl.GetDDL(ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	config := ddl.Config{Comments: true, ProtectIds: false, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}
+	spDDL := ddl.GetDDL(config, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	spDDL = append(spDDL, ddl.GetViewDDL(config, conv.SpViews)...)
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -69,7 +72,9 @@ func WriteSchemaFile(conv *internal.Conv, now time.Time, name string, out *os.Fi
 
 	// We change 'Comments' to false and 'ProtectIds' to true below to write out a
 	// schema file that is a legal Cloud Spanner DDL.
-	spDDL = ddl.GetDDL(ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	config = ddl.Config{Comments: false, ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: conv.SpDialect, Source: driver}
+	spDDL = ddl.GetDDL(config, conv.SpSchema, conv.SpSequences, conv.DatabaseOptions)
+	spDDL = append(spDDL, ddl.GetViewDDL(config, conv.SpViews)...)
 	if len(spDDL) == 0 {
 		spDDL = []string{"\n-- Schema is empty -- no tables found\n"}
 	}
@@ -142,6 +147,41 @@ func ReadSessionFile(conv *internal.Conv, sessionJSON string) error {
 	return nil
 }
 
+// ReadSpannerSchemaFile reads a file of Spanner DDL statements, in the
+// dialect conv.SpDialect, into the Spanner schema, sequences and views of
+// conv.
+func ReadSpannerSchemaFile(conv *internal.Conv, name string) error {
+	s, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	parsed, err := ddl.ParseDDL(string(s), conv.SpDialect, internal.GenerateId)
+	if err != nil {
+		return fmt.Errorf("can't parse Spanner schema file %s: %w", name, err)
+	}
+	for _, stmt := range parsed.Skipped {
+		logger.Log.Warn(fmt.Sprintf("Ignoring statement of Spanner schema file %s: %s", name, stmt))
+	}
+	conv.SpSchema = parsed.Tables
+	conv.SpSequences = parsed.Sequences
+	conv.SpViews = parsed.Views
+	for _, v := range parsed.Views {
+		conv.UsedNames[strings.ToLower(v.Name)] = true
+	}
+	for _, ct := range parsed.Tables {
+		conv.UsedNames[strings.ToLower(ct.Name)] = true
+		for _, idx := range ct.Indexes {
+			conv.UsedNames[strings.ToLower(idx.Name)] = true
+		}
+		for _, fk := range ct.ForeignKeys {
+			if fk.Name != "" {
+				conv.UsedNames[strings.ToLower(fk.Name)] = true
+			}
+		}
+	}
+	return nil
+}
+
 // WriteBadData prints summary stats about bad rows and writes detailed info
 // to file 'name'.
 func WriteBadData(bw *writer.BatchWriter, conv *internal.Conv, banner, name string, out *os.File) {
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -255,3 +256,34 @@ func TestWriteOverridesFile(t *testing.T) {
 		})
 	}
 }
+
+func TestReadSpannerSchemaFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "schema.sql")
+	assert.Nil(t, os.WriteFile(name, []byte(`
+CREATE SEQUENCE seq OPTIONS (sequence_kind = 'bit_reversed_positive');
+CREATE TABLE singers (
+	id INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE seq)),
+	name STRING(100),
+) PRIMARY KEY (id);
+CREATE INDEX by_name ON singers (name);
+CREATE VIEW singer_names SQL SECURITY INVOKER AS SELECT name FROM singers;
+`), 0644))
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	assert.Nil(t, ReadSpannerSchemaFile(conv, name))
+	assert.Equal(t, 1, len(conv.SpSchema))
+	for _, ct := range conv.SpSchema {
+		assert.Equal(t, "singers", ct.Name)
+		assert.Equal(t, 2, len(ct.ColIds))
+		assert.Equal(t, "by_name", ct.Indexes[0].Name)
+	}
+	assert.Equal(t, 1, len(conv.SpSequences))
+	assert.Equal(t, 1, len(conv.SpViews))
+	for _, v := range conv.SpViews {
+		assert.Equal(t, "singer_names", v.Name)
+		assert.Equal(t, "SELECT name FROM singers", v.Query)
+	}
+	assert.Equal(t, map[string]bool{"singers": true, "by_name": true, "singer_names": true}, conv.UsedNames)
+
+	assert.NotNil(t, ReadSpannerSchemaFile(conv, filepath.Join(t.TempDir(), "missing.sql")))
+}
@@ -16,8 +16,11 @@ package conversion
 
 import (
 	"context"
+	"sort"
 
 	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
 )
 
@@ -40,3 +43,54 @@ func ValidateTables(ctx context.Context, client *sp.Client, spDialect string) (s
 	}
 	return "", nil
 }
+
+// TableRowCount is the outcome of comparing the number of rows of a source
+// table with the number of rows of the Spanner table it was migrated to.
+type TableRowCount struct {
+	SourceTable  string `json:"sourceTable"`
+	SpannerTable string `json:"spannerTable"`
+	SourceRows   int64  `json:"sourceRows"`
+	SpannerRows  int64  `json:"spannerRows"`
+	Match        bool   `json:"match"`
+	// Error is set if either table couldn't be counted, in which case
+	// Match is false.
+	Error string `json:"error,omitempty"`
+}
+
+// ValidateRowCounts counts the rows of each table of conv that has both a
+// source and a Spanner schema, using infoSchema for the source table and
+// spannerCount for the Spanner table, which is passed the Spanner table
+// name qualified by its named schema. Source tables are counted exactly if
+// infoSchema implements common.ExactRowCounter. Results are sorted by
+// Spanner table name.
+func ValidateRowCounts(conv *internal.Conv, infoSchema common.InfoSchema, spannerCount func(table string) (int64, error)) []TableRowCount {
+	var counts []TableRowCount
+	for tableId, spTable := range conv.SpSchema {
+		srcTable, ok := conv.SrcSchema[tableId]
+		if !ok {
+			continue
+		}
+		c := TableRowCount{
+			SourceTable:  infoSchema.GetTableName(srcTable.Schema, srcTable.Name),
+			SpannerTable: spTable.QualifiedName(),
+		}
+		var err error
+		table := common.SchemaAndName{Schema: srcTable.Schema, Name: srcTable.Name, Id: tableId}
+		if counter, ok := infoSchema.(common.ExactRowCounter); ok {
+			c.SourceRows, err = counter.GetExactRowCount(table)
+		} else {
+			c.SourceRows, err = infoSchema.GetRowCount(table)
+		}
+		if err == nil {
+			c.SpannerRows, err = spannerCount(spTable.QualifiedName())
+		}
+		if err != nil {
+			c.Error = err.Error()
+		} else {
+			c.Match = c.SourceRows == c.SpannerRows
+		}
+		counts = append(counts, c)
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].SpannerTable < counts[j].SpannerTable })
+	return counts
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+// rowCountInfoSchema is a common.InfoSchema that only implements
+// GetTableName and GetRowCount.
+type rowCountInfoSchema struct {
+	common.InfoSchema
+	rows map[string]int64
+}
+
+func (is rowCountInfoSchema) GetTableName(schema string, tableName string) string {
+	return schema + "." + tableName
+}
+
+func (is rowCountInfoSchema) GetRowCount(table common.SchemaAndName) (int64, error) {
+	n, ok := is.rows[table.Name]
+	if !ok {
+		return 0, fmt.Errorf("table %s not found", table.Name)
+	}
+	return n, nil
+}
+
+// exactRowCountInfoSchema is a rowCountInfoSchema whose row counts are
+// estimates, and that counts rows exactly with GetExactRowCount.
+type exactRowCountInfoSchema struct {
+	rowCountInfoSchema
+	exactRows map[string]int64
+}
+
+func (is exactRowCountInfoSchema) GetExactRowCount(table common.SchemaAndName) (int64, error) {
+	return is.exactRows[table.Name], nil
+}
+
+func TestValidateRowCounts(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "Orders", Schema: "shop"},
+		"t2": {Name: "customers", Schema: "shop"},
+		"t3": {Name: "missing", Schema: "shop"},
+		"t4": {Name: "dropped", Schema: "shop"},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {Name: "orders", Id: "t1"},
+		"t2": {Name: "customers", SchemaName: "shop", Id: "t2"},
+		"t3": {Name: "missing", Id: "t3"},
+		// Tables that the session only has a Spanner schema for are skipped.
+		"t5": {Name: "added", Id: "t5"},
+	}
+	infoSchema := rowCountInfoSchema{rows: map[string]int64{"Orders": 10, "customers": 5}}
+	spannerRows := map[string]int64{"orders": 10, "shop.customers": 4, "missing": 0}
+	counts := ValidateRowCounts(conv, infoSchema, func(table string) (int64, error) {
+		return spannerRows[table], nil
+	})
+	assert.Equal(t, []TableRowCount{
+		{SourceTable: "shop.missing", SpannerTable: "missing", Error: "table missing not found"},
+		{SourceTable: "shop.Orders", SpannerTable: "orders", SourceRows: 10, SpannerRows: 10, Match: true},
+		// Spanner tables in named schemas are counted by their qualified name.
+		{SourceTable: "shop.customers", SpannerTable: "shop.customers", SourceRows: 5, SpannerRows: 4},
+	}, counts)
+
+	counts = ValidateRowCounts(conv, infoSchema, func(table string) (int64, error) {
+		return 0, fmt.Errorf("spanner unavailable")
+	})
+	assert.Equal(t, "spanner unavailable", counts[1].Error)
+	assert.False(t, counts[1].Match)
+
+	// Sources whose row counts are estimates are counted exactly.
+	exact := exactRowCountInfoSchema{rowCountInfoSchema: infoSchema, exactRows: map[string]int64{"Orders": 12, "customers": 4, "missing": 0}}
+	counts = ValidateRowCounts(conv, exact, func(table string) (int64, error) {
+		return spannerRows[table], nil
+	})
+	assert.Equal(t, []TableRowCount{
+		{SourceTable: "shop.missing", SpannerTable: "missing", Match: true},
+		{SourceTable: "shop.Orders", SpannerTable: "orders", SourceRows: 12, SpannerRows: 10},
+		{SourceTable: "shop.customers", SpannerTable: "shop.customers", SourceRows: 4, SpannerRows: 4, Match: true},
+	}, counts)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	mtrand "math/rand"
+	"strings"
 	"sync"
 	"time"
 
@@ -55,6 +56,7 @@ type DDLVerifier interface {
 	VerifySpannerDDL(conv *internal.Conv, expressionDetails []internal.ExpressionDetail) (internal.VerifyExpressionsOutput, error)
 	GetSourceExpressionDetails(conv *internal.Conv, tableIds []string) []internal.ExpressionDetail
 	GetSpannerExpressionDetails(conv *internal.Conv, tableIds []string) []internal.ExpressionDetail
+	GetViewExpressionDetails(conv *internal.Conv, viewIds []string) []internal.ExpressionDetail
 	RefreshSpannerClient(ctx context.Context, project string, instance string) error
 }
 type DDLVerifierImpl struct {
@@ -129,6 +131,14 @@ func (ev *ExpressionVerificationAccessorImpl) verifyExpressionInternal(expressio
 		sqlStatement = fmt.Sprintf("SELECT 1 from %s where %s;", expressionDetail.ReferenceElement.Name, expressionDetail.Expression)
 	case constants.DEFAULT_EXPRESSION:
 		sqlStatement = fmt.Sprintf("SELECT CAST(%s as %s)", expressionDetail.Expression, expressionDetail.ReferenceElement.Name)
+	case constants.GENERATED_EXPRESSION:
+		// Generated columns can refer to other columns of the table, so the
+		// expression is evaluated against the table in the staging database.
+		sqlStatement = fmt.Sprintf("SELECT CAST(%s as %s) from %s", expressionDetail.Expression, expressionDetail.Metadata["Type"], expressionDetail.ReferenceElement.Name)
+	case constants.VIEW_EXPRESSION:
+		// The query of a view is verified by running it against the tables
+		// in the staging database.
+		sqlStatement = fmt.Sprintf("SELECT 1 FROM (%s) AS v", expressionDetail.Expression)
 	default:
 		return task.TaskResult[internal.ExpressionVerificationOutput]{Result: internal.ExpressionVerificationOutput{Result: false, Err: fmt.Errorf("invalid expression type requested")}, Err: nil}
 	}
@@ -164,13 +174,28 @@ func (ev *ExpressionVerificationAccessorImpl) removeExpressions(inputConv *inter
 	//Set sequences as nil
 	//TODO: Implement similar checks for DEFAULT and CHECK constraints as well
 	convCopy.SpSequences = nil
+	// Views are verified against the tables only (see GetViewExpressionDetails).
+	convCopy.SpViews = nil
 	for _, table := range convCopy.SpSchema {
 		table.CheckConstraints = []ddl.CheckConstraint{}
+		// TOKENLIST columns must be generated, so they are dropped along with
+		// the search indexes on them.
+		table.SearchIndexes = nil
+		var colIds []string
+		for _, colId := range table.ColIds {
+			if table.ColDefs[colId].T.Name == ddl.TokenList {
+				delete(table.ColDefs, colId)
+				continue
+			}
+			colIds = append(colIds, colId)
+		}
+		table.ColIds = colIds
 		convCopy.SpSchema[table.Id] = table
 
 		for colName, colDef := range table.ColDefs {
 			colDef.AutoGen = ddl.AutoGenCol{}
 			colDef.DefaultValue = ddl.DefaultValue{}
+			colDef.Generated = ddl.GeneratedColumn{}
 			table.ColDefs[colName] = colDef
 		}
 	}
@@ -213,6 +238,9 @@ func (ddlv *DDLVerifierImpl) GetSourceExpressionDetails(conv *internal.Conv, tab
 				}
 				expressionDetails = append(expressionDetails, defaultValueExp)
 			}
+			if srcCol.Generated.IsPresent {
+				expressionDetails = append(expressionDetails, generatedColumnExpressionDetail(conv, tableId, srcColId, srcCol.Generated))
+			}
 		}
 	}
 	return expressionDetails
@@ -241,11 +269,85 @@ func (ddlv *DDLVerifierImpl) GetSpannerExpressionDetails(conv *internal.Conv, ta
 				}
 				expressionDetails = append(expressionDetails, defaultValueExp)
 			}
+			// The TOKENLIST columns of search indexes are generated by the
+			// conversion, and there are no TOKENLIST values to cast to.
+			if spCol.Generated.IsPresent && spCol.T.Name != ddl.TokenList {
+				expressionDetails = append(expressionDetails, generatedColumnExpressionDetail(conv, tableId, spColId, spCol.Generated))
+			}
 		}
 	}
 	return expressionDetails
 }
 
+// generatedColumnExpressionDetail returns the expression detail used to
+// verify the generation expression of a column against its Spanner table.
+func generatedColumnExpressionDetail(conv *internal.Conv, tableId, colId string, generated ddl.GeneratedColumn) internal.ExpressionDetail {
+	tyName := conv.SpSchema[tableId].ColDefs[colId].T.Name
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		tyName = ddl.GetPGType(conv.SpSchema[tableId].ColDefs[colId].T)
+	}
+	return internal.ExpressionDetail{
+		ReferenceElement: internal.ReferenceElement{
+			Name: conv.SpSchema[tableId].Name,
+		},
+		ExpressionId: generated.Value.ExpressionId,
+		Expression:   generated.Value.Statement,
+		Type:         constants.GENERATED_EXPRESSION,
+		Metadata:     map[string]string{"TableId": tableId, "ColId": colId, "Type": tyName},
+	}
+}
+
+// GetViewExpressionDetails returns the expression details used to verify the
+// queries of views in conv.SpViews. The staging database has no views, so
+// the views that a query refers to, directly or indirectly, are defined by
+// a WITH clause ahead of it.
+func (ddlv *DDLVerifierImpl) GetViewExpressionDetails(conv *internal.Conv, viewIds []string) []internal.ExpressionDetail {
+	expressionDetails := []internal.ExpressionDetail{}
+	for _, viewId := range viewIds {
+		view := conv.SpViews[viewId]
+		expressionDetails = append(expressionDetails, internal.ExpressionDetail{
+			ReferenceElement: internal.ReferenceElement{
+				Name: view.Name,
+			},
+			ExpressionId: viewId,
+			Expression:   viewQueryWithRefs(conv.SpViews, viewId),
+			Type:         constants.VIEW_EXPRESSION,
+			Metadata:     map[string]string{"ViewId": viewId},
+		})
+	}
+	return expressionDetails
+}
+
+// viewQueryWithRefs returns the query of view viewId, preceded by a WITH
+// clause that defines the views it refers to as common table expressions.
+func viewQueryWithRefs(views map[string]ddl.CreateView, viewId string) string {
+	var ctes []string
+	added := map[string]bool{viewId: true}
+	var add func(id string)
+	add = func(id string) {
+		for _, refId := range views[id].RefIds {
+			ref, ok := views[refId]
+			if !ok || added[refId] {
+				continue
+			}
+			added[refId] = true
+			// Common table expressions can only refer to the ones
+			// before them.
+			add(refId)
+			ctes = append(ctes, fmt.Sprintf("%s AS (%s)", ref.Name, ref.Query))
+		}
+	}
+	add(viewId)
+	query := views[viewId].Query
+	if len(ctes) == 0 {
+		return query
+	}
+	if len(query) > 5 && strings.EqualFold(query[:5], "WITH ") {
+		return "WITH " + strings.Join(ctes, ", ") + ", " + query[5:]
+	}
+	return "WITH " + strings.Join(ctes, ", ") + " " + query
+}
+
 func (ddlv *DDLVerifierImpl) RefreshSpannerClient(ctx context.Context, project string, instance string) error {
 	return ddlv.Expressions.RefreshSpannerClient(ctx, project, instance)
 }
@@ -446,6 +446,37 @@ func TestGetSpannerExpressionDetails(t *testing.T) {
 				},
 			},
 		},
+		"table2": {
+			Name:   "orders",
+			ColIds: []string{"col3", "col4", "col5"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"col3": {
+					T: ddl.Type{Name: ddl.Int64},
+				},
+				"col5": {
+					T: ddl.Type{Name: ddl.TokenList},
+					Generated: ddl.GeneratedColumn{
+						IsPresent: true,
+						Value: ddl.Expression{
+							ExpressionId: "expr3",
+							Statement:    "TOKENIZE_FULLTEXT(col3)",
+						},
+						Virtual: true,
+					},
+					Hidden: true,
+				},
+				"col4": {
+					T: ddl.Type{Name: ddl.Int64},
+					Generated: ddl.GeneratedColumn{
+						IsPresent: true,
+						Value: ddl.Expression{
+							ExpressionId: "expr2",
+							Statement:    "col3 * 2",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	testCases := []struct {
@@ -470,6 +501,22 @@ func TestGetSpannerExpressionDetails(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "table with generated column",
+			conv:     conv,
+			tableIds: []string{"table2"},
+			expectedDetails: []internal.ExpressionDetail{
+				{
+					ReferenceElement: internal.ReferenceElement{
+						Name: "orders",
+					},
+					ExpressionId: "expr2",
+					Expression:   "col3 * 2",
+					Type:         "GENERATED",
+					Metadata:     map[string]string{"TableId": "table2", "ColId": "col4", "Type": ddl.Int64},
+				},
+			},
+		},
 		{
 			name:            "no tables",
 			conv:            conv,
@@ -486,3 +533,39 @@ func TestGetSpannerExpressionDetails(t *testing.T) {
 		})
 	}
 }
+
+func TestGetViewExpressionDetails(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpViews = map[string]ddl.CreateView{
+		"vw1": {Name: "totals", Query: "SELECT s FROM sums", RefIds: []string{"vw2"}, Id: "vw1"},
+		"vw2": {Name: "sums", Query: "SELECT SUM(a) AS s FROM items", RefIds: []string{"t1", "vw3"}, Id: "vw2"},
+		"vw3": {Name: "items", Query: "SELECT a FROM orders", RefIds: []string{"t1"}, Id: "vw3"},
+		"vw4": {Name: "recent", Query: "WITH r AS (SELECT a FROM items) SELECT a FROM r", RefIds: []string{"vw3"}, Id: "vw4"},
+	}
+	testCases := []struct {
+		name       string
+		viewId     string
+		expression string
+	}{
+		{"view on tables", "vw3", "SELECT a FROM orders"},
+		{"view on views", "vw1", "WITH items AS (SELECT a FROM orders), sums AS (SELECT SUM(a) AS s FROM items) SELECT s FROM sums"},
+		{"view with a WITH clause", "vw4", "WITH items AS (SELECT a FROM orders), r AS (SELECT a FROM items) SELECT a FROM r"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ddlv := &expressions_api.DDLVerifierImpl{}
+			expected := []internal.ExpressionDetail{
+				{
+					ReferenceElement: internal.ReferenceElement{
+						Name: conv.SpViews[tc.viewId].Name,
+					},
+					ExpressionId: tc.viewId,
+					Expression:   tc.expression,
+					Type:         "VIEW",
+					Metadata:     map[string]string{"ViewId": tc.viewId},
+				},
+			}
+			assert.Equal(t, expected, ddlv.GetViewExpressionDetails(conv, []string{tc.viewId}))
+		})
+	}
+}
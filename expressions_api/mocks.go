@@ -37,6 +37,7 @@ type MockDDLVerifier struct {
 	VerifySpannerDDLMock            func(conv *internal.Conv, expressionDetails []internal.ExpressionDetail) (internal.VerifyExpressionsOutput, error)
 	GetSpannerExpressionDetailsMock func(conv *internal.Conv, tableIds []string) []internal.ExpressionDetail
 	GetSourceExpressionDetailsMock  func(conv *internal.Conv, tableIds []string) []internal.ExpressionDetail
+	GetViewExpressionDetailsMock    func(conv *internal.Conv, viewIds []string) []internal.ExpressionDetail
 	RefreshSpannerClientMock        func(ctx context.Context, project string, instance string) error
 }
 
@@ -61,6 +62,13 @@ func (m *MockDDLVerifier) GetSourceExpressionDetails(conv *internal.Conv, tableI
 	return []internal.ExpressionDetail{}
 }
 
+func (m *MockDDLVerifier) GetViewExpressionDetails(conv *internal.Conv, viewIds []string) []internal.ExpressionDetail {
+	if m.GetViewExpressionDetailsMock != nil {
+		return m.GetViewExpressionDetailsMock(conv, viewIds)
+	}
+	return []internal.ExpressionDetail{}
+}
+
 func (m *MockDDLVerifier) RefreshSpannerClient(ctx context.Context, project string, instance string) error {
 	if m.RefreshSpannerClientMock != nil {
 		return m.RefreshSpannerClientMock(ctx, project, instance)
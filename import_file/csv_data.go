@@ -57,7 +57,7 @@ func (source *CsvDataImpl) ImportData(ctx context.Context, spannerInfoSchema *sp
 		return err
 	}
 
-	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, source.TableName)
+	tableId, err := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, source.TableName)
 	if err != nil {
 		logger.Log.Error(fmt.Sprintf("Table %s not found in Spanner", source.TableName))
 		return err
@@ -73,6 +73,7 @@ func (source *CsvDataImpl) ImportData(ctx context.Context, spannerInfoSchema *sp
 		return err
 	}
 	batchWriter.Flush()
+	conv.Stats.UpdatedRows = batchWriter.UpdatedRowsByTable()
 	return err
 }
 
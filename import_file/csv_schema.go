@@ -13,7 +13,9 @@ import (
 	spanneraccessor "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/parse"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	adminpb "google.golang.org/genproto/googleapis/spanner/admin/database/v1"
 )
 
@@ -66,6 +68,10 @@ func (source *CsvSchemaImpl) CreateSchema(ctx context.Context, dialect string, s
 		return err
 	}
 
+	if strings.HasSuffix(strings.ToLower(source.SchemaUri), ".sql") {
+		return source.createSchemaFromDDL(ctx, dbURI, string(schemaFile), dialect, sp)
+	}
+
 	colDef, err := parseSchema(schemaFile)
 	if err != nil {
 		logger.Log.Error(fmt.Sprintf("Unable to parse schema URI %v", err))
@@ -104,6 +110,91 @@ func (source *CsvSchemaImpl) CreateSchema(ctx context.Context, dialect string, s
 	return nil
 }
 
+// createSchemaFromDDL creates the tables, with their indexes and foreign
+// keys, and the sequences defined by a file of Spanner DDL statements.
+// Tables that already exist are left as they are.
+func (source *CsvSchemaImpl) createSchemaFromDDL(ctx context.Context, dbURI, text, dialect string, sp spanneraccessor.SpannerAccessor) error {
+	parsed, err := ddl.ParseDDL(text, dialect, internal.GenerateId)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Unable to parse schema URI %v", err))
+		return err
+	}
+	found := false
+	for _, ct := range parsed.Tables {
+		found = found || ct.Name == source.TableName
+	}
+	if !found {
+		return fmt.Errorf("schema file %s doesn't define table %s", source.SchemaUri, source.TableName)
+	}
+	for _, stmt := range parsed.Skipped {
+		logger.Log.Warn(fmt.Sprintf("Ignoring statement of schema file: %s", stmt))
+	}
+	for _, v := range parsed.Views {
+		logger.Log.Warn(fmt.Sprintf("Ignoring view %s of schema file", v.QualifiedName()))
+	}
+
+	stmts, err := getCreateSchemaStmts(ctx, parsed, dialect, sp)
+	if err != nil {
+		return err
+	}
+	if err := sp.UpdateDDL(ctx, dbURI, stmts); err != nil {
+		return err
+	}
+	logger.Log.Info(fmt.Sprintf("Created schema of table %v successfully\n", source.TableName))
+	return nil
+}
+
+// getCreateSchemaStmts returns the statements that create the sequences,
+// and the tables that don't exist yet along with their indexes and foreign
+// keys.
+func getCreateSchemaStmts(ctx context.Context, parsed ddl.ParsedSchema, dialect string, sp spanneraccessor.SpannerAccessor) ([]string, error) {
+	config := ddl.Config{ProtectIds: true, Tables: true, ForeignKeys: true, SpDialect: dialect}
+	var stmts []string
+	var seqNames []string
+	seqs := make(map[string]ddl.Sequence)
+	for _, seq := range parsed.Sequences {
+		seqNames = append(seqNames, seq.Name)
+		seqs[seq.Name] = seq
+	}
+	sort.Strings(seqNames)
+	for _, name := range seqNames {
+		stmt := seqs[name].PrintSequence(config)
+		if dialect == constants.DIALECT_POSTGRESQL {
+			stmt = seqs[name].PGPrintSequence(config)
+		}
+		stmts = append(stmts, strings.Replace(stmt, "CREATE SEQUENCE ", "CREATE SEQUENCE IF NOT EXISTS ", 1))
+	}
+
+	tableIds := ddl.GetSortedTableIdsBySpName(parsed.Tables)
+	created := make(map[string]bool)
+	for _, id := range tableIds {
+		ct := parsed.Tables[id]
+		exists, err := sp.TableExists(ctx, ct.Name)
+		if err != nil {
+			logger.Log.Error(fmt.Sprintf("Unable to check existing schema %v", err))
+			return nil, err
+		}
+		if exists {
+			logger.Log.Info(fmt.Sprintf("table %s exists ", ct.Name))
+			continue
+		}
+		created[id] = true
+		stmts = append(stmts, ct.PrintCreateTable(parsed.Tables, config))
+		for _, idx := range ct.Indexes {
+			stmts = append(stmts, idx.PrintCreateIndex(ct, parsed.Tables, config))
+		}
+	}
+	for _, id := range tableIds {
+		if !created[id] {
+			continue
+		}
+		for _, fk := range parsed.Tables[id].ForeignKeys {
+			stmts = append(stmts, fk.PrintForeignKeyAlterTable(parsed.Tables, config, id))
+		}
+	}
+	return stmts, nil
+}
+
 func parseSchema(schemaFile []byte) ([]ColumnDefinition, error) {
 
 	var schema []ColumnDefinition
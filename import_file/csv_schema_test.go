@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/file_reader"
@@ -91,6 +92,20 @@ func TestCsvSchemaImpl_CreateSchema(t *testing.T) {
 			adminClientMock:   getSpannerAdminClientMock(errors.New("update error")),
 			wantErr:           true,
 		},
+		{
+			name: "successful schema creation from DDL file",
+			source: CsvSchemaImpl{
+				ProjectId:  "test-project",
+				InstanceId: "test-instance",
+				DbName:     "test-db",
+				TableName:  "basic_csv",
+				SchemaUri:  "../test_data/basic_csv_schema.sql",
+			},
+			dialect:           constants.DIALECT_GOOGLESQL,
+			spannerClientMock: getSpannerClientMock(getDefaultRowIteratoMock()),
+			adminClientMock:   getSpannerAdminClientMock(nil),
+			wantErr:           false,
+		},
 		// Add other test cases here...
 	}
 
@@ -305,3 +320,116 @@ func TestStringToBool(t *testing.T) {
 		})
 	}
 }
+
+func TestCsvSchemaImpl_CreateSchemaFromDDL(t *testing.T) {
+	ctx := context.Background()
+	text := `
+CREATE SEQUENCE seq OPTIONS (sequence_kind = 'bit_reversed_positive');
+CREATE TABLE singers (
+	id INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE seq)),
+	name STRING(MAX),
+) PRIMARY KEY (id);
+CREATE TABLE albums (
+	id INT64 NOT NULL,
+	singer_id INT64,
+	CONSTRAINT fk_singer FOREIGN KEY (singer_id) REFERENCES singers (id),
+) PRIMARY KEY (id);
+CREATE INDEX by_name ON singers (name);
+ALTER DATABASE db SET OPTIONS (version_retention_period = '7d');
+`
+	tests := []struct {
+		name          string
+		tableName     string
+		existing      []string
+		text          string
+		wantStmts     []string
+		wantErrString string
+	}{
+		{
+			name:      "new tables",
+			tableName: "albums",
+			text:      text,
+			wantStmts: []string{
+				"CREATE SEQUENCE IF NOT EXISTS `seq` OPTIONS (sequence_kind='bit_reversed_positive') ",
+				"CREATE TABLE `albums` (\n\t`id` INT64 NOT NULL ,\n\t`singer_id` INT64,\n) PRIMARY KEY (`id`)",
+				"CREATE TABLE `singers` (\n\t`id` INT64 NOT NULL  DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE seq)),\n\t`name` STRING(MAX),\n) PRIMARY KEY (`id`)",
+				"CREATE INDEX `by_name` ON `singers` (`name`)",
+				"ALTER TABLE `albums` ADD CONSTRAINT `fk_singer` FOREIGN KEY (`singer_id`) REFERENCES `singers` (`id`)",
+			},
+		},
+		{
+			name:      "existing table",
+			tableName: "albums",
+			existing:  []string{"singers"},
+			text:      text,
+			wantStmts: []string{
+				"CREATE SEQUENCE IF NOT EXISTS `seq` OPTIONS (sequence_kind='bit_reversed_positive') ",
+				"CREATE TABLE `albums` (\n\t`id` INT64 NOT NULL ,\n\t`singer_id` INT64,\n) PRIMARY KEY (`id`)",
+				"ALTER TABLE `albums` ADD CONSTRAINT `fk_singer` FOREIGN KEY (`singer_id`) REFERENCES `singers` (`id`)",
+			},
+		},
+		{
+			name:          "table not defined",
+			tableName:     "concerts",
+			text:          text,
+			wantErrString: "doesn't define table concerts",
+		},
+		{
+			name:          "invalid DDL",
+			tableName:     "albums",
+			text:          "CREATE TABLE albums (id INT64)",
+			wantErrString: "expected PRIMARY KEY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotStmts []string
+			spannerAccessor := &spanneraccessor.SpannerAccessorImpl{
+				SpannerClient: spannerclient.SpannerClientMock{
+					SingleMock: func() spannerclient.ReadOnlyTransaction {
+						return &spannerclient.ReadOnlyTransactionMock{
+							QueryMock: func(ctx context.Context, stmt spanner.Statement) spannerclient.RowIterator {
+								for _, table := range tt.existing {
+									if strings.Contains(stmt.SQL, "'"+table+"'") {
+										return &spannerclient.RowIteratorMock{
+											NextMock: func() (*spanner.Row, error) { return &spanner.Row{}, nil },
+											StopMock: func() {},
+										}
+									}
+								}
+								return getDefaultRowIteratoMock()
+							},
+						}
+					},
+				},
+				AdminClient: &spanneradmin.AdminClientMock{
+					UpdateDatabaseDdlMock: func(ctx context.Context, req *databasepb.UpdateDatabaseDdlRequest, opts ...gax.CallOption) (spanneradmin.UpdateDatabaseDdlOperation, error) {
+						gotStmts = req.Statements
+						return &spanneradmin.UpdateDatabaseDdlOperationMock{
+							WaitMock: func(ctx context.Context, opts ...gax.CallOption) error { return nil },
+						}, nil
+					},
+				},
+			}
+			source := CsvSchemaImpl{
+				ProjectId:  "test-project",
+				InstanceId: "test-instance",
+				DbName:     "test-db",
+				TableName:  tt.tableName,
+				SchemaUri:  "gs://bucket/schema.sql",
+				SchemaFileReader: &file_reader.MockFileReader{
+					ReadAllFn: func(ctx context.Context) ([]byte, error) {
+						return []byte(tt.text), nil
+					},
+				},
+			}
+			err := source.CreateSchema(ctx, constants.DIALECT_GOOGLESQL, spannerAccessor)
+			if tt.wantErrString != "" {
+				assert.ErrorContains(t, err, tt.wantErrString)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantStmts, gotStmts)
+		})
+	}
+}
@@ -117,6 +117,7 @@ func (source *ImportFromDumpImpl) ImportData(ctx context.Context, conv *internal
 		return err
 	}
 	batchWriter.Flush()
+	conv.Stats.UpdatedRows = batchWriter.UpdatedRowsByTable()
 
 	return nil
 }
@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// Checkpoint records the progress of a bulk data migration, so that a
+// migration that fails partway can be resumed without rewriting the data
+// that has already been committed to Spanner. Tables are keyed by source
+// table name, since table ids are regenerated by each schema conversion.
+type Checkpoint struct {
+	Tables  map[string]*TableCheckpoint `json:"tables"`
+	path    string
+	resumed map[string]bool
+	lock    sync.Mutex
+}
+
+// TableCheckpoint records the progress of the data migration of a table.
+type TableCheckpoint struct {
+	// Completed is set once all rows of the table have been written.
+	Completed bool `json:"completed"`
+	// Watermark is the value of the first primary key column below which
+	// all rows of the table have been written. nil means that no key range
+	// of the table has been completed yet.
+	Watermark interface{} `json:"watermark,omitempty"`
+	// SplitPoints are the boundaries of the key ranges above Watermark that
+	// remain to be read. They are kept so that a resumed migration reads the
+	// same key ranges as the original one.
+	SplitPoints []interface{} `json:"splitPoints,omitempty"`
+}
+
+// NewCheckpoint returns an empty checkpoint that is saved to path.
+func NewCheckpoint(path string) *Checkpoint {
+	return &Checkpoint{
+		Tables:  make(map[string]*TableCheckpoint),
+		path:    path,
+		resumed: make(map[string]bool),
+	}
+}
+
+// LoadCheckpoint reads the checkpoint saved to path by an earlier migration.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't read checkpoint file %s: %w", path, err)
+	}
+	c := NewCheckpoint(path)
+	// Decode numbers as json.Number so that integer keys above 2^53 keep
+	// their precision when they are passed back to the source database.
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(c); err != nil {
+		return nil, fmt.Errorf("can't parse checkpoint file %s: %w", path, err)
+	}
+	if c.Tables == nil {
+		c.Tables = make(map[string]*TableCheckpoint)
+	}
+	return c, nil
+}
+
+// Get returns the recorded progress of table, and whether there is any.
+func (c *Checkpoint) Get(table string) (TableCheckpoint, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tc, ok := c.Tables[table]
+	if !ok {
+		return TableCheckpoint{}, false
+	}
+	return *tc, true
+}
+
+// Update records the progress of table and saves the checkpoint.
+func (c *Checkpoint) Update(table string, tc TableCheckpoint) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	tc.Watermark = checkpointValue(tc.Watermark)
+	splitPoints := make([]interface{}, len(tc.SplitPoints))
+	for i, p := range tc.SplitPoints {
+		splitPoints[i] = checkpointValue(p)
+	}
+	tc.SplitPoints = splitPoints
+	c.Tables[table] = &tc
+	return c.save()
+}
+
+// MarkResumed records that the migration of table continued from the
+// progress recorded by an earlier migration.
+func (c *Checkpoint) MarkResumed(table string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.resumed[table] = true
+}
+
+// ResumedTables returns the sorted names of the tables whose migration was
+// resumed.
+func (c *Checkpoint) ResumedTables() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var tables []string
+	for t := range c.resumed {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// save writes the checkpoint to a temporary file and renames it, so that a
+// crash while saving never leaves a truncated checkpoint behind. Callers
+// must hold c.lock.
+func (c *Checkpoint) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't encode checkpoint: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("can't write checkpoint file %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("can't write checkpoint file %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// checkpointValue converts a key value read from the source database into
+// one that round-trips through JSON. Drivers return text keys as []byte,
+// which JSON would otherwise encode as base64.
+func checkpointValue(v interface{}) interface{} {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return v
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db.checkpoint.json")
+	_, err := LoadCheckpoint(path)
+	assert.Error(t, err)
+
+	c := NewCheckpoint(path)
+	_, ok := c.Get("t1")
+	assert.False(t, ok)
+	assert.Nil(t, c.Update("t1", TableCheckpoint{Completed: true}))
+	assert.Nil(t, c.Update("t2", TableCheckpoint{Watermark: int64(9007199254740993), SplitPoints: []interface{}{[]byte("m"), "z"}}))
+
+	loaded, err := LoadCheckpoint(path)
+	assert.Nil(t, err)
+	tc, ok := loaded.Get("t1")
+	assert.True(t, ok)
+	assert.Equal(t, TableCheckpoint{Completed: true}, tc)
+	tc, ok = loaded.Get("t2")
+	assert.True(t, ok)
+	// Integer keys keep their precision and text keys are read back as text.
+	assert.Equal(t, TableCheckpoint{Watermark: json.Number("9007199254740993"), SplitPoints: []interface{}{"m", "z"}}, tc)
+}
+
+func TestCheckpointResumedTables(t *testing.T) {
+	c := NewCheckpoint(filepath.Join(t.TempDir(), "db.checkpoint.json"))
+	assert.Empty(t, c.ResumedTables())
+	c.MarkResumed("orders")
+	c.MarkResumed("customers")
+	c.MarkResumed("orders")
+	assert.Equal(t, []string{"customers", "orders"}, c.ResumedTables())
+}
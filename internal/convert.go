@@ -16,6 +16,7 @@ package internal
 
 import (
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -35,6 +36,7 @@ type Conv struct {
 	SrcSchema          map[string]schema.Table      // Maps source-DB table name to schema information.
 	SchemaIssues       map[string]TableIssues       // Maps source-DB table/col to list of schema conversion issues.
 	InvalidCheckExp    map[string][]InvalidCheckExp // List of check constraint expressions and corresponding issues.
+	InvalidIndexes     map[string][]InvalidIndex    // Maps table id to the source indexes that Spanner can't fully represent.
 	ToSpanner          map[string]NameAndCols       // Maps from source-DB table name to Spanner name and column mapping.
 	ToSource           map[string]NameAndCols       `json:"-"` // Maps from Spanner table name to source-DB table name and column mapping.
 	UsedNames          map[string]bool              `json:"-"` // Map storing the names that are already assigned to tables, indices or foreign key contraints.
@@ -60,6 +62,11 @@ type Conv struct {
 	Source             string                  // Source Database type being migrated
 	DatabaseOptions    ddl.DatabaseOptions
 	DefaultIdentityOptions ddl.IdentityOptions // Default values to use for IDENTITY columns
+	NamedSchemas       bool                    // If true, source schemas are kept as Spanner named schemas instead of being flattened into table names.
+	SrcViews           map[string]schema.View     // Maps source-DB view id to view information.
+	SpViews            map[string]ddl.CreateView  // Maps view id to the Spanner views that were verified.
+	InvalidViews       map[string]string          // Maps source-DB view id to the reason the view wasn't migrated.
+	statsLock          sync.Mutex              // Guards data conversion stats and bad row samples, which are updated concurrently when tables are read in parallel.
 }
 
 type InvalidCheckExp struct {
@@ -67,6 +74,14 @@ type InvalidCheckExp struct {
 	Expression string
 }
 
+// InvalidIndex is a source index whose predicate or key expression Spanner
+// can't represent.
+type InvalidIndex struct {
+	IssueType  SchemaIssue
+	IndexName  string
+	Expression string // The predicate or key expression that couldn't be converted.
+}
+
 type TableIssues struct {
 	ColumnLevelIssues map[string][]SchemaIssue
 	TableLevelIssues  []SchemaIssue
@@ -77,7 +92,16 @@ type AdditionalSchemaAttributes struct {
 }
 
 type AdditionalDataAttributes struct {
-	ShardId string
+	ShardId  string
+	KeyRange KeyRange // Restricts the rows read from the source table. The zero value reads the whole table.
+}
+
+// KeyRange is a half-open range [Start, End) of values of the first primary
+// key column of a source table. A nil Start or End leaves that side of the
+// range unbounded.
+type KeyRange struct {
+	Start interface{}
+	End   interface{}
 }
 
 type mode int
@@ -158,11 +182,21 @@ const (
 	CassandraMAP
 	PossibleOverflow
 	IdentitySkipRange
+	GeneratedColumn
+	GeneratedColumnError
+	FullTextIndex
+	IndexExpression
+	IndexPredicateDropped
+	IndexNotMigrated
+	RowDeletionPolicy
+	RowDeletionTimeAdded
+	RowDeletionPolicyRounded
 )
 
 const (
-	ShardIdColumn       = "migration_shard_id"
-	SyntheticPrimaryKey = "synth_id"
+	ShardIdColumn         = "migration_shard_id"
+	SyntheticPrimaryKey   = "synth_id"
+	RowDeletionTimeColumn = "last_write_time"
 )
 
 // NameAndCols contains the name of a table and its columns.
@@ -198,12 +232,14 @@ type row struct {
 // c) successfully converted, but an error occurs when writing the row to Spanner.
 // d) unsuccessfully converted (we won't try to write such rows to Spanner).
 type stats struct {
-	Rows       map[string]int64          // Count of rows encountered during processing (a + b + c + d), broken down by source table.
-	GoodRows   map[string]int64          // Count of rows successfully converted (b + c), broken down by source table.
-	BadRows    map[string]int64          // Count of rows where conversion failed (d), broken down by source table.
-	Statement  map[string]*statementStat // Count of processed statements, broken down by statement type.
-	Unexpected map[string]int64          // Count of unexpected conditions, broken down by condition description.
-	Reparsed   int64                     // Count of times we re-parse dump data looking for end-of-statement.
+	Rows        map[string]int64          // Count of rows encountered during processing (a + b + c + d), broken down by source table.
+	GoodRows    map[string]int64          // Count of rows successfully converted (b + c), broken down by source table.
+	BadRows     map[string]int64          // Count of rows where conversion failed (d), broken down by source table.
+	Statement   map[string]*statementStat // Count of processed statements, broken down by statement type.
+	Unexpected  map[string]int64          // Count of unexpected conditions, broken down by condition description.
+	UpdatedRows map[string]int64          // Count of written rows (b) that updated or replaced an existing row, broken down by Spanner table.
+	ResumedRows map[string]int64          // Count of rows (a) written by an earlier migration that this one resumed, broken down by source table.
+	Reparsed    int64                     // Count of times we re-parse dump data looking for end-of-statement.
 }
 
 type statementStat struct {
@@ -224,6 +260,25 @@ type Audit struct {
 	StreamingStats           streamingStats                         `json:"-"` // Stores information related to streaming migration process.
 	Progress                 Progress                               `json:"-"` // Stores information related to progress of the migration progress
 	SkipMetricsPopulation    bool                                   `json:"-"` // Flag to identify if outgoing metrics metadata needs to skipped
+	ReadWorkers              int                                    `json:"-"` // Number of concurrent primary key range reads per table during bulk data migration.
+	Checkpoint               *Checkpoint                            `json:"-"` // Progress of the bulk data migration, used to resume it. nil if progress isn't recorded.
+	WriteMode                string                                 `json:"-"` // How bulk data migration writes rows that already exist: insert, insert-or-update or replace. Empty means insert.
+	DeadLetter               io.Writer                              `json:"-"` // Receives the rows that bulk data migration couldn't write to Spanner. nil if dropped rows aren't recorded.
+	AdaptiveWrites           bool                                   `json:"-"` // Whether bulk data migration adapts batch size and in-progress writes to the commit latency and errors of Spanner.
+	MaxRowsPerSecond         int64                                  `json:"-"` // Limit on rows written per second by bulk data migration. 0 means no limit.
+	BatchWrite               bool                                   `json:"-"` // Whether bulk data migration writes rows as mutation groups with BatchWrite rather than as transactions.
+	CountUpdatedRows         bool                                   `json:"-"` // Whether bulk data migration reads the keys of each batch before writing it, to count the rows that update an existing row.
+	DeferIndexes             bool                                   `json:"-"` // Whether secondary indexes are created after the bulk data migration rather than with their tables.
+	ForeignKeyOrphans        []ForeignKeyOrphans                    `json:"-"` // Foreign keys that weren't created because rows loaded into Spanner don't satisfy them.
+}
+
+// Stores the rows of a table loaded into Spanner that reference a row that
+// doesn't exist through a foreign key, i.e. orphaned rows.
+type ForeignKeyOrphans struct {
+	TableId      string   // Id of the table with the foreign key.
+	ForeignKeyId string   // Id of the foreign key.
+	Orphans      int64    // Number of orphaned rows.
+	Samples      []string // Primary key and foreign key columns of some of the orphaned rows.
 }
 
 // Stores information related to generated Dataflow Resources.
@@ -362,11 +417,12 @@ func MakeConv() *Conv {
 		Location:       time.Local, // By default, use go's local time, which uses $TZ (when set).
 		sampleBadRows:  rowSamples{bytesLimit: 10 * 1000 * 1000},
 		Stats: stats{
-			Rows:       make(map[string]int64),
-			GoodRows:   make(map[string]int64),
-			BadRows:    make(map[string]int64),
-			Statement:  make(map[string]*statementStat),
-			Unexpected: make(map[string]int64),
+			Rows:        make(map[string]int64),
+			GoodRows:    make(map[string]int64),
+			BadRows:     make(map[string]int64),
+			ResumedRows: make(map[string]int64),
+			Statement:   make(map[string]*statementStat),
+			Unexpected:  make(map[string]int64),
 		},
 		TimezoneOffset: "+00:00", // By default, use +00:00 offset which is equal to UTC timezone
 		UniquePKey:     make(map[string][]string),
@@ -377,17 +433,21 @@ func MakeConv() *Conv {
 		Rules:        []Rule{},
 		SpSequences:  make(map[string]ddl.Sequence),
 		SrcSequences: make(map[string]ddl.Sequence),
+		SrcViews:     make(map[string]schema.View),
+		SpViews:      make(map[string]ddl.CreateView),
+		InvalidViews: make(map[string]string),
 		DatabaseOptions: ddl.DatabaseOptions{},
 	}
 }
 
 func (conv *Conv) ResetStats() {
 	conv.Stats = stats{
-		Rows:       make(map[string]int64),
-		GoodRows:   make(map[string]int64),
-		BadRows:    make(map[string]int64),
-		Statement:  make(map[string]*statementStat),
-		Unexpected: make(map[string]int64),
+		Rows:        make(map[string]int64),
+		GoodRows:    make(map[string]int64),
+		BadRows:     make(map[string]int64),
+		ResumedRows: make(map[string]int64),
+		Statement:   make(map[string]*statementStat),
+		Unexpected:  make(map[string]int64),
 	}
 }
 
@@ -420,6 +480,8 @@ func (conv *Conv) SetDataMode() {
 
 // WriteRow calls dataSink and updates row stats.
 func (conv *Conv) WriteRow(srcTable, spTable string, spCols []string, spVals []interface{}) {
+	conv.statsLock.Lock()
+	defer conv.statsLock.Unlock()
 	if conv.Audit.DryRun {
 		conv.statsAddGoodRow(srcTable, conv.DataMode())
 	} else if conv.dataSink == nil {
@@ -427,14 +489,24 @@ func (conv *Conv) WriteRow(srcTable, spTable string, spCols []string, spVals []i
 		VerbosePrintf("%s\n", msg)
 		logger.Log.Debug("Internal error: ProcessDataRow called but dataSink not configured")
 
-		conv.Unexpected(msg)
-		conv.StatsAddBadRow(srcTable, conv.DataMode())
+		conv.unexpected(msg)
+		conv.statsAddBadRow(srcTable, conv.DataMode())
 	} else {
 		conv.dataSink(spTable, spCols, spVals)
 		conv.statsAddGoodRow(srcTable, conv.DataMode())
 	}
 }
 
+// FlushData writes out all rows passed to WriteRow so far and waits for the
+// writes to complete. It doesn't hold statsLock while it waits, so DataFlush
+// must be safe to call concurrently with the data sink, as
+// writer.BatchWriter's Flush is.
+func (conv *Conv) FlushData() {
+	if conv.DataFlush != nil {
+		conv.DataFlush()
+	}
+}
+
 // Rows returns the total count of data rows processed.
 func (conv *Conv) Rows() int64 {
 	n := int64(0)
@@ -481,6 +553,8 @@ func (conv *Conv) Unexpecteds() int64 {
 // CollectBadRow updates the list of bad rows, while respecting
 // the byte limit for bad rows.
 func (conv *Conv) CollectBadRow(srcTable string, srcCols, vals []string) {
+	conv.statsLock.Lock()
+	defer conv.statsLock.Unlock()
 	r := &row{table: srcTable, cols: srcCols, vals: vals}
 	bytes := byteSize(r)
 	// Cap storage used by badRows. Keep at least one bad row.
@@ -605,6 +679,12 @@ func (conv *Conv) buildColumnNameWithBase(tableId, base string) string {
 // be completely reliable due to potential double-counting
 // because we process dump data twice.
 func (conv *Conv) Unexpected(u string) {
+	conv.statsLock.Lock()
+	defer conv.statsLock.Unlock()
+	conv.unexpected(u)
+}
+
+func (conv *Conv) unexpected(u string) {
 	VerbosePrintf("Unexpected condition: %s\n", u)
 	logger.Log.Debug("Unexpected condition", zap.String("condition", u))
 
@@ -624,6 +704,8 @@ func (conv *Conv) Unexpected(u string) {
 // care to ensure that the code actually runs in the mode you specify,
 // otherwise stats will be dropped.
 func (conv *Conv) StatsAddRow(srcTable string, b bool) {
+	conv.statsLock.Lock()
+	defer conv.statsLock.Unlock()
 	if b {
 		conv.Stats.Rows[srcTable]++
 	}
@@ -640,11 +722,28 @@ func (conv *Conv) statsAddGoodRow(srcTable string, b bool) {
 // StatsAddBadRow increments the bad-row stats for 'srcTable' if b is
 // true.  See StatsAddRow comments for context.
 func (conv *Conv) StatsAddBadRow(srcTable string, b bool) {
+	conv.statsLock.Lock()
+	defer conv.statsLock.Unlock()
+	conv.statsAddBadRow(srcTable, b)
+}
+
+func (conv *Conv) statsAddBadRow(srcTable string, b bool) {
 	if b {
 		conv.Stats.BadRows[srcTable]++
 	}
 }
 
+// StatsAddResumedRows counts the rows of 'srcTable' that were not read by
+// this migration as resumed rows, since they were written by an earlier
+// migration that this one resumed.
+func (conv *Conv) StatsAddResumedRows(srcTable string) {
+	conv.statsLock.Lock()
+	defer conv.statsLock.Unlock()
+	if n := conv.Stats.Rows[srcTable] - conv.Stats.GoodRows[srcTable] - conv.Stats.BadRows[srcTable] - conv.Stats.ResumedRows[srcTable]; n > 0 {
+		conv.Stats.ResumedRows[srcTable] += n
+	}
+}
+
 func (conv *Conv) getStatementStat(s string) *statementStat {
 	if conv.Stats.Statement[s] == nil {
 		conv.Stats.Statement[s] = &statementStat{}
@@ -129,6 +129,19 @@ func GetTableIdFromSpName(spSchema ddl.Schema, tableName string) (string, error)
 	return "", fmt.Errorf("table id not found for spanner table %s", tableName)
 }
 
+// GetTableIdFromSpQualifiedName returns the id of the Spanner table whose
+// name, qualified by its named schema, is tableName. Rows are written with
+// qualified names, so lookups in the data path use this rather than
+// GetTableIdFromSpName.
+func GetTableIdFromSpQualifiedName(spSchema ddl.Schema, tableName string) (string, error) {
+	for tableId, table := range spSchema {
+		if tableName == table.QualifiedName() {
+			return tableId, nil
+		}
+	}
+	return "", fmt.Errorf("table id not found for spanner table %s", tableName)
+}
+
 func GetColIdFromSpName(colDefs map[string]ddl.ColumnDef, colName string) (string, error) {
 	for colId, col := range colDefs {
 		if col.Name == colName {
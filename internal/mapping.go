@@ -46,7 +46,14 @@ func GetSpannerTable(conv *Conv, tableId string) (string, error) {
 		return sp.Name, nil
 	}
 	srcTableName := conv.SrcSchema[tableId].Name
-	spTableName := GetSpannerValidName(conv, srcTableName)
+	var spTableName string
+	if schemaName := GetSpannerSchemaName(conv, tableId); schemaName != "" {
+		// Table names only have to be unique within a named schema.
+		name := strings.TrimPrefix(srcTableName, conv.SrcSchema[tableId].Schema+".")
+		spTableName = getSpannerValidName(conv, schemaName+".", name)
+	} else {
+		spTableName = GetSpannerValidName(conv, srcTableName)
+	}
 	if spTableName != srcTableName {
 		VerbosePrintf("Mapping source DB table %s to Spanner table %s\n", srcTableName, spTableName)
 		logger.Log.Debug(fmt.Sprintf("Mapping source DB table %s to Spanner table %s\n", srcTableName, spTableName))
@@ -54,6 +61,91 @@ func GetSpannerTable(conv *Conv, tableId string) (string, error) {
 	return spTableName, nil
 }
 
+// GetSpannerSchemaName returns the Spanner named schema that the source
+// table tableId is migrated to, or "" for the default schema. Source schemas
+// are only kept if conv.NamedSchemas is set, and only for tables whose name
+// is qualified by their schema i.e. tables outside the source's default
+// schema (such as PostgreSQL's public schema).
+func GetSpannerSchemaName(conv *Conv, tableId string) string {
+	if sp, found := conv.SpSchema[tableId]; found {
+		return sp.SchemaName
+	}
+	srcTable := conv.SrcSchema[tableId]
+	if !conv.NamedSchemas || srcTable.Schema == "" || !strings.HasPrefix(srcTable.Name, srcTable.Schema+".") {
+		return ""
+	}
+	schemaName, _ := FixName(srcTable.Schema)
+	return schemaName
+}
+
+// GetSpannerView maps source view viewId to the name and named schema of its
+// Spanner view. Views share their names with tables. A view is only kept in
+// a named schema that tables were migrated to, since those are the named
+// schemas that GetDDL creates.
+func GetSpannerView(conv *Conv, viewId string) (string, string) {
+	if sp, found := conv.SpViews[viewId]; found {
+		return sp.Name, sp.SchemaName
+	}
+	srcView := conv.SrcViews[viewId]
+	if conv.NamedSchemas && srcView.Schema != "" && strings.HasPrefix(srcView.Name, srcView.Schema+".") {
+		schemaName, _ := FixName(srcView.Schema)
+		for _, sp := range conv.SpSchema {
+			if sp.SchemaName == schemaName {
+				name := strings.TrimPrefix(srcView.Name, srcView.Schema+".")
+				return getSpannerValidName(conv, schemaName+".", name), schemaName
+			}
+		}
+	}
+	return GetSpannerValidName(conv, srcView.Name), ""
+}
+
+// InvalidateViews moves the views of conv.SpViews for which invalid returns
+// true to conv.InvalidViews, with reason, once the schema they were verified
+// against changed. Views that refer to them are moved too, since Spanner
+// would reject their queries as well.
+func InvalidateViews(conv *Conv, invalid func(v ddl.CreateView) bool, reason string) {
+	if len(conv.SpViews) == 0 {
+		return
+	}
+	if conv.InvalidViews == nil {
+		conv.InvalidViews = make(map[string]string)
+	}
+	for viewId, v := range conv.SpViews {
+		if invalid(v) {
+			delete(conv.SpViews, viewId)
+			conv.InvalidViews[viewId] = reason
+		}
+	}
+	// We might need multiple iterations for chains of views.
+	for removed := true; removed; {
+		removed = false
+		for viewId, v := range conv.SpViews {
+			for _, refId := range v.RefIds {
+				if _, isView := conv.SrcViews[refId]; !isView {
+					continue
+				}
+				if _, ok := conv.SpViews[refId]; !ok {
+					delete(conv.SpViews, viewId)
+					conv.InvalidViews[viewId] = fmt.Sprintf("the view refers to view %s, which wasn't migrated", conv.SrcViews[refId].Name)
+					removed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// GetSpannerQualifiedTable returns the name of the Spanner table for source
+// table tableId, qualified by its named schema. This is the name that data
+// is written to.
+func GetSpannerQualifiedTable(conv *Conv, tableId string) (string, error) {
+	spTableName, err := GetSpannerTable(conv, tableId)
+	if err != nil {
+		return "", err
+	}
+	return ddl.QualifiedName(GetSpannerSchemaName(conv, tableId), spTableName), nil
+}
+
 // GetSpannerCol maps a source DB table/column into a legal Spanner column
 // name. If mustExist is true, we return error if the column is new.
 // Note that source DB column names can be essentially any string, but
@@ -256,8 +348,14 @@ func ToSpannerCheckConstraintName(conv *Conv, srcCheckConstraintName string) str
 // we map from source dbs to Spanner since Spanner requires all these names to be
 // distinct and should not differ only in case.
 func GetSpannerValidName(conv *Conv, srcName string) string {
+	return getSpannerValidName(conv, "", srcName)
+}
+
+// getSpannerValidName is GetSpannerValidName for names that only have to be
+// unique amongst the names with the given prefix in conv.UsedNames.
+func getSpannerValidName(conv *Conv, prefix, srcName string) string {
 	spKeyName, _ := FixName(srcName)
-	if _, found := conv.UsedNames[strings.ToLower(spKeyName)]; found {
+	if _, found := conv.UsedNames[strings.ToLower(prefix+spKeyName)]; found {
 		// spKeyName has been used before.
 		// Add unique postfix: use number of keys so far.
 		// However, there is a chance this has already been used,
@@ -265,14 +363,14 @@ func GetSpannerValidName(conv *Conv, srcName string) string {
 		id := len(conv.UsedNames)
 		for {
 			c := spKeyName + "_" + strconv.Itoa(id)
-			if _, found := conv.UsedNames[strings.ToLower(c)]; !found {
+			if _, found := conv.UsedNames[strings.ToLower(prefix+c)]; !found {
 				spKeyName = c
 				break
 			}
 			id++
 		}
 	}
-	conv.UsedNames[strings.ToLower(spKeyName)] = true
+	conv.UsedNames[strings.ToLower(prefix+spKeyName)] = true
 	return spKeyName
 }
 
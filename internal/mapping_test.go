@@ -164,6 +164,80 @@ func TestGetSpannerTable(t *testing.T) {
 	}
 }
 
+func TestGetSpannerTableNamedSchemas(t *testing.T) {
+	srcSchema := map[string]schema.Table{
+		"t1": {Name: "sales.orders", Schema: "sales", Id: "t1"},
+		"t2": {Name: "hr.orders", Schema: "hr", Id: "t2"},
+		"t3": {Name: "orders", Schema: "public", Id: "t3"},
+		"t4": {Name: "my-app.orders", Schema: "my-app", Id: "t4"},
+	}
+	tests := []struct {
+		tableId       string
+		namedSchemas  bool
+		spSchemaName  string
+		spTable       string
+		qualifiedName string
+	}{
+		{"t1", true, "sales", "orders", "sales.orders"},
+		{"t2", true, "hr", "orders", "hr.orders"},
+		{"t3", true, "", "orders", "orders"},
+		{"t4", true, "my_app", "orders", "my_app.orders"},
+		{"t1", false, "", "sales_orders", "sales_orders"},
+		{"t2", false, "", "hr_orders", "hr_orders"},
+	}
+	for _, namedSchemas := range []bool{true, false} {
+		conv := MakeConv()
+		conv.SrcSchema = srcSchema
+		conv.NamedSchemas = namedSchemas
+		for _, tc := range tests {
+			if tc.namedSchemas != namedSchemas {
+				continue
+			}
+			spSchemaName := GetSpannerSchemaName(conv, tc.tableId)
+			assert.Equal(t, tc.spSchemaName, spSchemaName, tc.tableId)
+			spTable, err := GetSpannerTable(conv, tc.tableId)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.spTable, spTable, tc.tableId)
+			conv.SpSchema[tc.tableId] = ddl.CreateTable{Name: spTable, SchemaName: spSchemaName, Id: tc.tableId}
+			qualifiedName, err := GetSpannerQualifiedTable(conv, tc.tableId)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.qualifiedName, qualifiedName, tc.tableId)
+		}
+	}
+}
+
+func TestGetSpannerView(t *testing.T) {
+	conv := MakeConv()
+	conv.NamedSchemas = true
+	conv.SpSchema["t1"] = ddl.CreateTable{Name: "orders", SchemaName: "sales", Id: "t1"}
+	conv.UsedNames["orders"] = true
+	conv.SrcViews = map[string]schema.View{
+		"vw1": {Name: "sales.totals", Schema: "sales", Id: "vw1"},
+		"vw2": {Name: "hr.totals", Schema: "hr", Id: "vw2"},
+		"vw3": {Name: "orders", Schema: "public", Id: "vw3"},
+	}
+	tests := []struct {
+		viewId       string
+		spView       string
+		spSchemaName string
+	}{
+		{"vw1", "totals", "sales"},
+		// No tables were migrated to named schema hr.
+		{"vw2", "hr_totals", ""},
+		// Views share their names with tables.
+		{"vw3", "orders_3", ""},
+	}
+	for _, tc := range tests {
+		spView, spSchemaName := GetSpannerView(conv, tc.viewId)
+		assert.Equal(t, tc.spView, spView, tc.viewId)
+		assert.Equal(t, tc.spSchemaName, spSchemaName, tc.viewId)
+		conv.SpViews[tc.viewId] = ddl.CreateView{Name: spView, SchemaName: spSchemaName, Id: tc.viewId}
+		spView, spSchemaName = GetSpannerView(conv, tc.viewId)
+		assert.Equal(t, tc.spView, spView, tc.viewId)
+		assert.Equal(t, tc.spSchemaName, spSchemaName, tc.viewId)
+	}
+}
+
 func TestGetSpannerCol(t *testing.T) {
 	conv := MakeConv()
 	conv.SrcSchema = map[string]schema.Table{
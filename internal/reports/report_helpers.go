@@ -156,6 +156,17 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 			}
 		}
 
+		// table level warnings for indexes that Spanner can't fully represent
+		if p.severity == warning && len(conv.InvalidIndexes[tableId]) != 0 {
+			for _, invalidIndex := range conv.InvalidIndexes[tableId] {
+				toAppend := Issue{
+					Category:    IssueDB[invalidIndex.IssueType].Category,
+					Description: fmt.Sprintf("Table '%s': Index '%s' uses %s, which Spanner can't represent. %s", conv.SpSchema[tableId].Name, invalidIndex.IndexName, invalidIndex.Expression, IssueDB[invalidIndex.IssueType].Brief),
+				}
+				l = append(l, toAppend)
+			}
+		}
+
 		// added if condition to add table level Errors
 		if p.severity == Errors && len(conv.InvalidCheckExp[tableId]) != 0 {
 
@@ -306,7 +317,7 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 				// on case of srcType.
 				spColType = strings.ToLower(spColType)
 				switch i {
-				case internal.DefaultValue:
+				case internal.DefaultValue, internal.GeneratedColumn:
 					toAppend := Issue{
 						Category:    IssueDB[i].Category,
 						Description: fmt.Sprintf("%s for table '%s' e.g. column '%s'", IssueDB[i].Brief, conv.SpSchema[tableId].Name, spColName),
@@ -462,6 +473,38 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 						}
 						l = append(l, toAppend)
 					}
+				case internal.FullTextIndex:
+					search := "SEARCH"
+					if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+						search = "spanner.search"
+					}
+					toAppend := Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("Table '%s': '%s' %s. Full-text queries, such as MATCH ... AGAINST or @@ to_tsquery, must be rewritten to use %s(%s, <query>)", conv.SpSchema[tableId].Name, spColName, IssueDB[i].Brief, search, spColName),
+					}
+					l = append(l, toAppend)
+				case internal.IndexExpression:
+					toAppend := Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("Table '%s': '%s' %s '%s'", conv.SpSchema[tableId].Name, spColName, IssueDB[i].Brief, conv.SpSchema[tableId].ColDefs[colId].Generated.Value.Statement),
+					}
+					l = append(l, toAppend)
+				case internal.RowDeletionPolicy:
+					days := int64(0)
+					if rdp := conv.SpSchema[tableId].RowDeletionPolicy; rdp != nil {
+						days = rdp.Days
+					}
+					toAppend := Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("Table '%s': %s %d days after the time in '%s'", conv.SpSchema[tableId].Name, IssueDB[i].Brief, days, spColName),
+					}
+					l = append(l, toAppend)
+				case internal.RowDeletionTimeAdded, internal.RowDeletionPolicyRounded:
+					toAppend := Issue{
+						Category:    IssueDB[i].Category,
+						Description: fmt.Sprintf("Table '%s': '%s' %s", conv.SpSchema[tableId].Name, spColName, IssueDB[i].Brief),
+					}
+					l = append(l, toAppend)
 				case internal.ShardIdColumnAdded:
 					str := fmt.Sprintf("Table '%s': '%s' %s", conv.SpSchema[tableId].Name, conv.SpSchema[tableId].ColDefs[conv.SpSchema[tableId].ShardIdColumn].Name, IssueDB[i].Brief)
 					toAppend := Issue{
@@ -503,7 +546,7 @@ func buildTableReportBody(conv *internal.Conv, tableId string, issues map[string
 					}
 					l = append(l, toAppend)
 
-				case internal.DefaultValueError:
+				case internal.DefaultValueError, internal.GeneratedColumnError:
 					toAppend := Issue{
 						Category:    IssueDB[i].Category,
 						Description: fmt.Sprintf("%s for table '%s' column '%s'", IssueDB[i].Brief, conv.SpSchema[tableId].Name, spColName),
@@ -592,17 +635,21 @@ func fillRowStats(conv *internal.Conv, srcTable string, badWrites map[string]int
 	rows := conv.Stats.Rows[srcTable]
 	goodConvRows := conv.Stats.GoodRows[srcTable]
 	badConvRows := conv.Stats.BadRows[srcTable]
+	resumedRows := conv.Stats.ResumedRows[srcTable]
 	badRowWrites := badWrites[srcTable]
 	// Note on rows:
 	// rows: all rows we encountered during processing.
 	// goodConvRows: rows we successfully converted.
 	// badConvRows: rows we failed to convert.
+	// resumedRows: rows we didn't read, since a resumed migration wrote them.
 	// badRowWrites: rows we converted, but could not write to Spanner.
-	if rows != goodConvRows+badConvRows || badRowWrites > goodConvRows {
+	if rows != goodConvRows+badConvRows+resumedRows || badRowWrites > goodConvRows {
 		conv.Unexpected(fmt.Sprintf("Inconsistent row counts for table %s: %d %d %d %d\n", srcTable, rows, goodConvRows, badConvRows, badRowWrites))
 	}
 	tr.rows = rows
 	tr.badRows = badConvRows + badRowWrites
+	tr.updatedRows = conv.Stats.UpdatedRows[conv.SpSchema[srcTable].Name]
+	tr.resumedRows = resumedRows
 }
 
 // IssueDB provides a description and severity for each schema issue.
@@ -644,7 +691,7 @@ var IssueDB = map[internal.SchemaIssue]struct {
 	internal.IdentitySkipRange:    {Brief: "Set Skip Range or Start Counter With values to avoid duplicate value errors.", Severity: note, Category: "IDENTITY_SKIP_RANGE_SUGGESTION"},
 	internal.Timestamp:            {Brief: "Spanner timestamp is closer to PostgreSQL timestamptz", Severity: suggestion, batch: true, Category: "TIMESTAMP_SUGGESTION"},
 	internal.Datetime:             {Brief: "Spanner timestamp is closer to MySQL timestamp", Severity: warning, batch: true, Category: "TIMESTAMP_WARNING"},
-	internal.Time:                 {Brief: "Spanner does not support time/year types", Severity: warning, batch: true, Category: "TIME_YEAR_TYPE_USES"},
+	internal.Time:                 {Brief: "Spanner does not support time of day or year types", Severity: warning, batch: true, Category: "TIME_YEAR_TYPE_USES"},
 	internal.Widened:              {Brief: "Some columns will consume more storage in Spanner", Severity: warning, batch: true, Category: "STORAGE_WARNING"},
 	internal.StringOverflow:       {Brief: "String overflow issue might occur as maximum supported length in Spanner is 2621440", Severity: warning, Category: "STRING_OVERFLOW_WARNING"},
 	internal.HotspotTimestamp:     {Brief: "Timestamp Hotspot Occured", Severity: warning, Category: "TIMESTAMP_HOTSPOT"},
@@ -685,6 +732,22 @@ var IssueDB = map[internal.SchemaIssue]struct {
 	internal.CassandraTIMEUUID:            {Brief: "Cassandra TimeUUIDs map to Spanner's BYTES(16). This generic type doesn't validate embedded timestamps.", Severity: warning, Category: "CASSANDRA_TIMEUUID_USES"},
 	internal.CassandraMAP:                 {Brief: "Cassandra MAP type maps to Spanner's JSON. Spanner does not validate internal JSON structure or types, unlike Cassandra's MAP.", Severity: warning, Category: "CASSANDRA_MAP_USES"},
 	internal.PossibleOverflow:             {Brief: "Possible overflow in Spanner. Source type does not entirely fit inside Spanner's type. Please check if the data fits within the target type's limits.", Severity: warning, Category: "POSSIBLE_OVERFLOW"},
+	internal.GeneratedColumn:              {Brief: "Some generated columns have expressions not supported by Spanner and were migrated as regular columns. Please add the generation expressions manually after the migration is complete", Severity: warning, batch: true, Category: "MISSING_GENERATED_COLUMN_EXPRESSIONS"},
+	internal.GeneratedColumnError:         {Brief: "Some generated columns have expressions not supported by Spanner. Please fix them to continue migration.", Severity: Errors, batch: true, Category: "INCOMPATIBLE_GENERATED_COLUMN_EXPRESSIONS"},
+	internal.FullTextIndex: {Brief: "column was added to build a search index in place of a full-text index", Severity: note, Category: "FULL_TEXT_INDEX_CONVERTED",
+		CategoryDescription: "Full-text indexes were converted to search indexes on added TOKENLIST columns, and queries that use them must be rewritten to use SEARCH"},
+	internal.IndexExpression: {Brief: "column was added to index the expression", Severity: note, Category: "INDEX_EXPRESSION_CONVERTED",
+		CategoryDescription: "Index expressions were converted to indexes on added generated columns"},
+	internal.IndexPredicateDropped: {Brief: "The index was migrated without its predicate, and indexes all rows.", Severity: warning, Category: "PARTIAL_INDEX_PREDICATE_DROPPED",
+		CategoryDescription: "Partial indexes whose predicates Spanner can't represent were migrated as indexes on all rows"},
+	internal.IndexNotMigrated: {Brief: "The index was not migrated.", Severity: warning, Category: "INDEX_NOT_MIGRATED",
+		CategoryDescription: "Indexes whose predicates or expressions Spanner can't represent were not migrated"},
+	internal.RowDeletionPolicy: {Brief: "rows are deleted by a row deletion policy", Severity: note, Category: "ROW_DELETION_POLICY",
+		CategoryDescription: "Tables whose rows expire in the source database were given row deletion policies. Spanner deletes expired rows in the background, typically within three days"},
+	internal.RowDeletionTimeAdded: {Brief: "column was added to hold the time each row was last written, which its row deletion policy uses. It defaults to the commit time of inserts, but applications must set it when they update a row", Severity: note, Category: "ROW_DELETION_TIME_ADDED",
+		CategoryDescription: "Columns holding the time rows were last written were added for row deletion policies"},
+	internal.RowDeletionPolicyRounded: {Brief: "expiry was rounded up to whole days, since Spanner row deletion policies are set in days. Rows are deleted later than in the source database", Severity: warning, Category: "ROW_DELETION_POLICY_ROUNDED",
+		CategoryDescription: "Row deletion policies were rounded up to whole days"},
 }
 
 type Severity int
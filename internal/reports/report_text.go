@@ -24,6 +24,14 @@ func (r *ReportImpl) GenerateTextReport(structuredReport StructuredReport, w *bu
 			strings.Join(getStatementsFromIgnoredStatements(structuredReport.IgnoredStatements), ", ")), 80, 0)
 		w.WriteString("\n\n")
 	}
+	if len(structuredReport.ResumedTables) > 0 {
+		justifyLines(w, fmt.Sprintf("Note that data migration of the following tables "+
+			"was resumed from the checkpoint of an earlier migration: %s. "+
+			"Rows written by the earlier migration are counted as migrated, and are "+
+			"listed separately in the data conversion of each table.",
+			strings.Join(structuredReport.ResumedTables, ", ")), 80, 0)
+		w.WriteString("\n\n")
+	}
 	statementsMsg := ""
 	var isDump bool
 	if strings.Contains(structuredReport.StatementStats.DriverName, "dump") {
@@ -43,10 +51,46 @@ func (r *ReportImpl) GenerateTextReport(structuredReport StructuredReport, w *bu
 	}
 	writeNameChanges(structuredReport, w)
 	writeTableReports(structuredReport, w)
+	writeForeignKeyOrphans(structuredReport, w)
+	writeUnmigratedViews(structuredReport, w)
 	writeUnexpectedConditionsv2(structuredReport, w)
 
 }
 
+func writeForeignKeyOrphans(structuredReport StructuredReport, w *bufio.Writer) {
+	if len(structuredReport.ForeignKeyOrphans) == 0 {
+		return
+	}
+	writeHeading(w, "Foreign Keys With Orphaned Rows")
+	justifyLines(w, "The following foreign keys were not created because some rows "+
+		"reference a row that does not exist in the referenced table. Spanner can't "+
+		"create a foreign key that existing rows don't satisfy. Fix or delete the "+
+		"orphaned rows and add the foreign keys using the statements in the schema file.", 80, 0)
+	w.WriteString("\n\n")
+	for _, o := range structuredReport.ForeignKeyOrphans {
+		fmt.Fprintf(w, "Foreign key %s of table %s (references %s): %d orphaned rows, including\n", o.ForeignKey, o.Table, o.ReferTable, o.Orphans)
+		for _, sample := range o.Samples {
+			fmt.Fprintf(w, "  %s\n", sample)
+		}
+		w.WriteString("\n")
+	}
+}
+
+func writeUnmigratedViews(structuredReport StructuredReport, w *bufio.Writer) {
+	if len(structuredReport.UnmigratedViews) == 0 {
+		return
+	}
+	writeHeading(w, "Views Not Migrated")
+	justifyLines(w, "The following views were not created. Spanner only creates views "+
+		"whose query is valid against the Spanner schema. Rewrite the queries using "+
+		"Spanner SQL and create the views manually.", 80, 0)
+	w.WriteString("\n\n")
+	for _, v := range structuredReport.UnmigratedViews {
+		fmt.Fprintf(w, "View %s: %s\n", v.View, v.Reason)
+	}
+	w.WriteString("\n")
+}
+
 func writeUnexpectedConditionsv2(structuredReport StructuredReport, w *bufio.Writer) {
 	reparseInfo := func() {
 		if structuredReport.UnexpectedConditions.Reparsed > 0 {
@@ -128,6 +172,15 @@ func writeTableReports(structuredReport StructuredReport, w *bufio.Writer) {
 				dataRatingText = "written"
 			}
 			s := fmt.Sprintf(" (%s%% of %d rows %s to Spanner)", pct(tableReport.DataReport.TotalRows, tableReport.DataReport.BadRows), tableReport.DataReport.TotalRows, dataRatingText)
+			updated, resumed := tableReport.DataReport.UpdatedRows, tableReport.DataReport.ResumedRows
+			if updated > 0 || resumed > 0 {
+				written := tableReport.DataReport.TotalRows - tableReport.DataReport.BadRows - resumed
+				s = fmt.Sprintf(" (%s%% of %d rows %s to Spanner: %d new, %d updated", pct(tableReport.DataReport.TotalRows, tableReport.DataReport.BadRows), tableReport.DataReport.TotalRows, dataRatingText, written-updated, updated)
+				if resumed > 0 {
+					s += fmt.Sprintf(", %d by the resumed migration", resumed)
+				}
+				s += ")"
+			}
 			dataRatingText = tableReport.DataReport.Rating + s
 			rate = rate + fmt.Sprintf("Data conversion: %s.\n", dataRatingText)
 		}
@@ -16,6 +16,7 @@
 package reports
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -80,9 +81,51 @@ func (r *ReportImpl) GenerateStructuredReport(driverName string, dbName string,
 		smtReport.UnexpectedConditions = fetchUnexceptedConditions(driverName, conv)
 	}
 
+	//10. Tables resumed from a checkpoint
+	if conv.Audit.Checkpoint != nil {
+		smtReport.ResumedTables = conv.Audit.Checkpoint.ResumedTables()
+	}
+
+	//11. Foreign keys with orphaned rows
+	smtReport.ForeignKeyOrphans = fetchForeignKeyOrphans(conv)
+
+	//12. Views that couldn't be migrated
+	smtReport.UnmigratedViews = fetchUnmigratedViews(conv)
+
 	return smtReport
 }
 
+func fetchUnmigratedViews(conv *internal.Conv) (unmigratedViews []UnmigratedView) {
+	for viewId, reason := range conv.InvalidViews {
+		unmigratedViews = append(unmigratedViews, UnmigratedView{
+			View:   conv.SrcViews[viewId].Name,
+			Reason: reason,
+		})
+	}
+	sort.Slice(unmigratedViews, func(i, j int) bool {
+		return unmigratedViews[i].View < unmigratedViews[j].View
+	})
+	return unmigratedViews
+}
+
+func fetchForeignKeyOrphans(conv *internal.Conv) (foreignKeyOrphans []ForeignKeyOrphans) {
+	for _, o := range conv.Audit.ForeignKeyOrphans {
+		table := conv.SpSchema[o.TableId]
+		for _, fk := range table.ForeignKeys {
+			if fk.Id == o.ForeignKeyId {
+				foreignKeyOrphans = append(foreignKeyOrphans, ForeignKeyOrphans{
+					Table:      table.Name,
+					ForeignKey: fk.Name,
+					ReferTable: conv.SpSchema[fk.ReferTableId].Name,
+					Orphans:    o.Orphans,
+					Samples:    o.Samples,
+				})
+			}
+		}
+	}
+	return foreignKeyOrphans
+}
+
 func mapMigrationType(migrationType migration.MigrationData_MigrationType) string {
 	if migrationType == migration.MigrationData_DATA_ONLY {
 		return "DATA"
@@ -179,7 +222,7 @@ func fetchTableReports(inputTableReports []tableReport, conv *internal.Conv) (ta
 		//3. Data Report
 		schemaOnly := conv.SchemaMode()
 		if !schemaOnly {
-			tableReport.DataReport = getDataReport(t.rows, t.badRows, conv.Audit.DryRun)
+			tableReport.DataReport = getDataReport(t.rows, t.badRows, t.updatedRows, t.resumedRows, conv.Audit.DryRun)
 		}
 		//4. Issues
 		for _, x := range t.Body {
@@ -207,10 +250,12 @@ func getSchemaReport(cols, issues, warnings, errors int64, missingPKey bool) (sc
 	return schemaReport
 }
 
-func getDataReport(rows int64, badRows int64, dryRun bool) (dataReport DataReport) {
+func getDataReport(rows int64, badRows int64, updatedRows int64, resumedRows int64, dryRun bool) (dataReport DataReport) {
 	dataReport.DryRun = dryRun
 	dataReport.TotalRows = rows
 	dataReport.BadRows = badRows
+	dataReport.UpdatedRows = updatedRows
+	dataReport.ResumedRows = resumedRows
 	dataReport.Rating, _ = rateData(rows, badRows, dryRun)
 	return dataReport
 }
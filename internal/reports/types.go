@@ -15,6 +15,8 @@ type tableReport struct {
 	SpTable       string
 	rows          int64
 	badRows       int64
+	updatedRows   int64
+	resumedRows   int64
 	Cols          int64
 	Warnings      int64
 	Errors        int64
@@ -83,10 +85,12 @@ type SchemaReport struct {
 }
 
 type DataReport struct {
-	Rating    string `json:"rating"`
-	BadRows   int64  `json:"badRows"`
-	TotalRows int64  `json:"totalRows"`
-	DryRun    bool   `json:"dryRun"`
+	Rating      string `json:"rating"`
+	BadRows     int64  `json:"badRows"`
+	TotalRows   int64  `json:"totalRows"`
+	UpdatedRows int64  `json:"updatedRows,omitempty"` // Rows written that updated or replaced an existing row.
+	ResumedRows int64  `json:"resumedRows,omitempty"` // Rows written by an earlier migration that this one resumed.
+	DryRun      bool   `json:"dryRun"`
 }
 
 type TableReport struct {
@@ -107,6 +111,19 @@ type UnexpectedConditions struct {
 	UnexpectedConditions []UnexpectedCondition `json:"unexpectedConditions"`
 }
 
+type ForeignKeyOrphans struct {
+	Table      string   `json:"table"`
+	ForeignKey string   `json:"foreignKey"`
+	ReferTable string   `json:"referTable"`
+	Orphans    int64    `json:"orphans"`
+	Samples    []string `json:"samples"`
+}
+
+type UnmigratedView struct {
+	View   string `json:"view"`
+	Reason string `json:"reason"`
+}
+
 type StructuredReport struct {
 	Summary              Summary              `json:"summary"`
 	IsSharded            bool                 `json:"isSharded"`
@@ -117,6 +134,9 @@ type StructuredReport struct {
 	NameChanges          []NameChange         `json:"nameChanges"`
 	TableReports         []TableReport        `json:"tableReports"`
 	UnexpectedConditions UnexpectedConditions `json:"unexpectedConditions"`
+	ResumedTables        []string             `json:"resumedTables,omitempty"`
+	ForeignKeyOrphans    []ForeignKeyOrphans  `json:"foreignKeyOrphans,omitempty"`
+	UnmigratedViews      []UnmigratedView     `json:"unmigratedViews,omitempty"`
 	SchemaOnly           bool                 `json:"-"`
 }
 
@@ -56,6 +56,10 @@ func main() {
 	subcommands.Register(&cmd.AssessmentCmd{}, "")
 	subcommands.Register(&webv2.WebCmd{DistDir: distDir}, "")
 	subcommands.Register(&cmd.ImportDataCmd{}, "")
+	subcommands.Register(&cmd.ReplayCmd{}, "")
+	subcommands.Register(&cmd.ValidateCmd{}, "")
+	subcommands.Register(&cmd.CheckSchemaCmd{}, "")
+	subcommands.Register(&cmd.UpdateSchemaCmd{}, "")
 	flag.Parse()
 	os.Exit(int(subcommands.Execute(ctx)))
 }
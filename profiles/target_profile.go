@@ -48,6 +48,7 @@ type TargetProfileConnectionSpanner struct {
 	Dbname   string
 	Dialect  string
 	DefaultTimezone string
+	NamedSchemas bool // If true, source schemas are migrated to Spanner named schemas.
 }
 
 type TargetProfileConnection struct {
@@ -158,6 +159,12 @@ func NewTargetProfile(s string) (TargetProfile, error) {
 	if defaultTimezone, ok := params["defaultTimezone"]; ok {
 		sp.DefaultTimezone = defaultTimezone
 	}
+	if namedSchemas, ok := params["namedSchemas"]; ok {
+		sp.NamedSchemas, err = strconv.ParseBool(namedSchemas)
+		if err != nil {
+			return TargetProfile{}, fmt.Errorf("invalid value for namedSchemas: %s, expected true or false", namedSchemas)
+		}
+	}
 	if sp.Dialect == "" {
 		sp.Dialect = constants.DIALECT_GOOGLESQL
 	} else if sp.Dialect != constants.DIALECT_POSTGRESQL && sp.Dialect != constants.DIALECT_GOOGLESQL {
@@ -80,6 +80,15 @@ func TestNewTargetProfile(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			targetProfileString: "instance=test-instance,namedSchemas=true",
+			expectedTargetProfileDetails: TargetProfileConnectionSpanner{
+				Instance: "test-instance",
+				Dialect: constants.DIALECT_GOOGLESQL,
+				NamedSchemas: true,
+			},
+			expectedErr: false,
+		},
 		{
 			targetProfileString: "project=test-project",
 			expectedErr: true,
@@ -100,6 +109,10 @@ func TestNewTargetProfile(t *testing.T) {
 			targetProfileString: "instance=test-instance,defaultIdentityStartCounterWith=",
 			expectedErr: true,
 		},
+		{
+			targetProfileString: "instance=test-instance,namedSchemas=maybe",
+			expectedErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
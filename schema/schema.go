@@ -45,6 +45,16 @@ type Table struct {
 	CheckConstraints []CheckConstraint
 	Indexes          []Index
 	Id               string
+	// RowDeletionPolicy is set for tables whose rows expire automatically.
+	RowDeletionPolicy *RowDeletionPolicy `json:",omitempty"`
+}
+
+// RowDeletionPolicy represents the automatic expiry of the rows of a table.
+// Rows expire Seconds seconds after the time held by column ColId or, when
+// ColId is empty, after they were last written.
+type RowDeletionPolicy struct {
+	ColId   string
+	Seconds int64
 }
 
 // Column represents a database column.
@@ -57,6 +67,7 @@ type Column struct {
 	Id           string
 	AutoGen      ddl.AutoGenCol
 	DefaultValue ddl.DefaultValue
+	Generated    ddl.GeneratedColumn
 }
 
 // ForeignKey represents a foreign key.
@@ -91,6 +102,10 @@ type Key struct {
 	ColId string
 	Desc  bool // By default, order is ASC. Set to true to specifiy DESC.
 	Order int
+	// Expression is the source expression of index key parts that index an
+	// expression, such as lower(email), rather than a column. ColId is
+	// empty for such key parts.
+	Expression string
 }
 
 // Index represents a database index.
@@ -110,6 +125,27 @@ type Index struct {
 	Keys            []Key
 	Id              string
 	StoredColumnIds []string
+	// FullText is true for full-text indexes, such as MySQL FULLTEXT
+	// indexes and PostgreSQL GIN indexes on to_tsvector.
+	FullText bool
+	// VectorDistanceType is the Spanner distance type of vector indexes,
+	// such as pgvector HNSW and IVFFlat indexes, and empty for other
+	// indexes.
+	VectorDistanceType string
+	// Predicate is the source condition of partial indexes, which only
+	// index the rows that satisfy it, and empty for other indexes.
+	Predicate string
+}
+
+// View represents a database view.
+type View struct {
+	Name   string
+	Schema string
+	Id     string
+	// Query is the SELECT statement that defines the view.
+	Query string
+	// Refs are the names of the tables and views that the query refers to.
+	Refs []string
 }
 
 // Type represents the type of a column.
@@ -0,0 +1,307 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassandra
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/gocql/gocql"
+)
+
+// ProcessDataRow converts a row of Cassandra data and writes it to Spanner.
+// vals holds the values read for colIds, in the same order; a nil entry
+// represents a CQL null. srcTypes holds the CQL type of each column.
+// writeTime is when the row was last written, and is only set for tables
+// whose row deletion policy uses a column added during the migration.
+func ProcessDataRow(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, srcTypes []gocql.TypeInfo, vals []interface{}, writeTime time.Time, additionalAttributes internal.AdditionalDataAttributes) {
+	srcTableName := srcSchema.Name
+	spTableName, cvtCols, cvtVals, err := ConvertData(conv, tableId, colIds, srcSchema, spSchema, srcTypes, vals, writeTime, additionalAttributes)
+	if err != nil {
+		var srcCols, srcStrVals []string
+		for i, colId := range colIds {
+			srcCols = append(srcCols, srcSchema.ColDefs[colId].Name)
+			srcStrVals = append(srcStrVals, fmt.Sprintf("%v", vals[i]))
+		}
+		conv.Unexpected(fmt.Sprintf("Error while converting data: %s\n", err))
+		conv.StatsAddBadRow(srcTableName, conv.DataMode())
+		conv.CollectBadRow(srcTableName, srcCols, srcStrVals)
+	} else {
+		conv.WriteRow(srcTableName, spTableName, cvtCols, cvtVals)
+	}
+}
+
+// ConvertData maps the source values of a row to Spanner values, based on
+// the Spanner and source schemas. Null values are skipped.
+func ConvertData(conv *internal.Conv, tableId string, colIds []string, srcSchema schema.Table, spSchema ddl.CreateTable, srcTypes []gocql.TypeInfo, vals []interface{}, writeTime time.Time, additionalAttributes internal.AdditionalDataAttributes) (string, []string, []interface{}, error) {
+	var c []string
+	var v []interface{}
+	if len(colIds) != len(vals) || len(colIds) != len(srcTypes) {
+		return "", []string{}, []interface{}{}, fmt.Errorf("ConvertData: colIds, srcTypes and vals don't all have the same lengths: len(colIds)=%d, len(srcTypes)=%d, len(vals)=%d", len(colIds), len(srcTypes), len(vals))
+	}
+	for i, colId := range colIds {
+		if vals[i] == nil {
+			continue
+		}
+		spColDef, ok1 := spSchema.ColDefs[colId]
+		_, ok2 := srcSchema.ColDefs[colId]
+		if !ok1 || !ok2 {
+			return "", []string{}, []interface{}{}, fmt.Errorf("can't find Spanner and source-db schema for colId %s", colId)
+		}
+		var x interface{}
+		var err error
+		if spColDef.T.IsArray {
+			x, err = convArray(conv, spColDef.T, srcTypes[i], vals[i])
+		} else {
+			x, err = convScalar(conv, spColDef.T, srcTypes[i], vals[i])
+		}
+		if err != nil {
+			return "", []string{}, []interface{}{}, fmt.Errorf("column %s: %w", spColDef.Name, err)
+		}
+		v = append(v, x)
+		c = append(c, spColDef.Name)
+	}
+	colId := conv.SpSchema[tableId].ShardIdColumn
+	if colId != "" {
+		c = append(c, conv.SpSchema[tableId].ColDefs[colId].Name)
+		v = append(v, additionalAttributes.ShardId)
+	}
+	if rdp := conv.SpSchema[tableId].RowDeletionPolicy; rdp != nil && !writeTime.IsZero() {
+		if _, ok := srcSchema.ColDefs[rdp.ColId]; !ok {
+			c = append(c, conv.SpSchema[tableId].ColDefs[rdp.ColId].Name)
+			v = append(v, writeTime)
+		}
+	}
+	return conv.SpSchema[tableId].Name, c, v, nil
+}
+
+// convScalar converts a single value returned by gocql into the Go type
+// expected by the Spanner client for spannerType.
+func convScalar(conv *internal.Conv, spannerType ddl.Type, srcType gocql.TypeInfo, val interface{}) (interface{}, error) {
+	switch spannerType.Name {
+	case ddl.Bool:
+		if b, ok := val.(bool); ok {
+			return b, nil
+		}
+	case ddl.Bytes:
+		if b, ok := val.([]byte); ok {
+			return b, nil
+		}
+		// Use Cassandra's own binary encoding for non-blob types, e.g. the
+		// 16 bytes of a uuid or the two's complement bytes of a varint.
+		return gocql.Marshal(srcType, val)
+	case ddl.Date:
+		if t, ok := val.(time.Time); ok {
+			return civil.DateOf(t.UTC()), nil
+		}
+	case ddl.Float32:
+		switch f := val.(type) {
+		case float32:
+			return f, nil
+		case float64:
+			return float32(f), nil
+		}
+	case ddl.Float64:
+		switch f := val.(type) {
+		case float32:
+			return float64(f), nil
+		case float64:
+			return f, nil
+		}
+	case ddl.Int64:
+		return convInt64(val)
+	case ddl.Numeric:
+		if s, ok := val.(fmt.Stringer); ok {
+			return convNumeric(conv, s.String())
+		}
+	case ddl.String:
+		return convString(srcType, val), nil
+	case ddl.Timestamp:
+		if t, ok := val.(time.Time); ok {
+			return t.UTC(), nil
+		}
+	case ddl.JSON:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return nil, fmt.Errorf("can't convert %v to JSON: %w", val, err)
+		}
+		return string(b), nil
+	case ddl.UUID:
+		if u, ok := val.(gocql.UUID); ok {
+			return u.String(), nil
+		}
+	default:
+		return nil, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
+	}
+	return nil, fmt.Errorf("can't convert value of type %T to %s", val, spannerType.Name)
+}
+
+// convArray converts a Cassandra list or set to a Spanner array. The
+// Spanner client does not accept []interface{} for arrays, so we build a
+// slice of the specific element type.
+func convArray(conv *internal.Conv, spannerType ddl.Type, srcType gocql.TypeInfo, val interface{}) (interface{}, error) {
+	collectionInfo, ok := srcType.(gocql.CollectionType)
+	if !ok {
+		return nil, fmt.Errorf("can't convert non-collection type %s to an array", srcType.Type())
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("can't convert value of type %T to an array", val)
+	}
+	elemType := spannerType
+	elemType.IsArray = false
+	elems := make([]interface{}, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		e, err := convScalar(conv, elemType, collectionInfo.Elem, rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = e
+	}
+	switch spannerType.Name {
+	case ddl.Bool:
+		r := []sp.NullBool{}
+		for _, e := range elems {
+			r = append(r, sp.NullBool{Bool: e.(bool), Valid: true})
+		}
+		return r, nil
+	case ddl.Bytes:
+		r := [][]byte{}
+		for _, e := range elems {
+			r = append(r, e.([]byte))
+		}
+		return r, nil
+	case ddl.Date:
+		r := []sp.NullDate{}
+		for _, e := range elems {
+			r = append(r, sp.NullDate{Date: e.(civil.Date), Valid: true})
+		}
+		return r, nil
+	case ddl.Float32:
+		r := []sp.NullFloat32{}
+		for _, e := range elems {
+			r = append(r, sp.NullFloat32{Float32: e.(float32), Valid: true})
+		}
+		return r, nil
+	case ddl.Float64:
+		r := []sp.NullFloat64{}
+		for _, e := range elems {
+			r = append(r, sp.NullFloat64{Float64: e.(float64), Valid: true})
+		}
+		return r, nil
+	case ddl.Int64:
+		r := []sp.NullInt64{}
+		for _, e := range elems {
+			r = append(r, sp.NullInt64{Int64: e.(int64), Valid: true})
+		}
+		return r, nil
+	case ddl.Numeric:
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			r := []sp.PGNumeric{}
+			for _, e := range elems {
+				r = append(r, e.(sp.PGNumeric))
+			}
+			return r, nil
+		}
+		r := []sp.NullNumeric{}
+		for _, e := range elems {
+			r = append(r, sp.NullNumeric{Numeric: *e.(*big.Rat), Valid: true})
+		}
+		return r, nil
+	case ddl.String, ddl.JSON, ddl.UUID:
+		r := []sp.NullString{}
+		for _, e := range elems {
+			r = append(r, sp.NullString{StringVal: e.(string), Valid: true})
+		}
+		return r, nil
+	case ddl.Timestamp:
+		r := []sp.NullTime{}
+		for _, e := range elems {
+			r = append(r, sp.NullTime{Time: e.(time.Time), Valid: true})
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("array type conversion not implemented for type ARRAY<%v>", spannerType.Name)
+}
+
+func convInt64(val interface{}) (int64, error) {
+	switch i := val.(type) {
+	case bool:
+		if i {
+			return 1, nil
+		}
+		return 0, nil
+	case *big.Int:
+		if !i.IsInt64() {
+			return 0, fmt.Errorf("value %s overflows INT64", i.String())
+		}
+		return i.Int64(), nil
+	}
+	// Covers all signed integer types, including time.Duration which is
+	// how gocql represents the CQL time type (nanoseconds since midnight).
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	}
+	return 0, fmt.Errorf("can't convert value of type %T to INT64", val)
+}
+
+func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		return sp.PGNumeric{Numeric: val, Valid: true}, nil
+	}
+	r := new(big.Rat)
+	if _, ok := r.SetString(val); !ok {
+		return nil, fmt.Errorf("can't convert %q to big.Rat", val)
+	}
+	return r, nil
+}
+
+// convString renders a Cassandra value using the literal syntax CQL uses
+// for its type.
+func convString(srcType gocql.TypeInfo, val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case net.IP:
+		return v.String()
+	case time.Time:
+		if srcType != nil && srcType.Type() == gocql.TypeDate {
+			return v.UTC().Format("2006-01-02")
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	case time.Duration:
+		// CQL time values are nanoseconds since midnight.
+		return fmt.Sprintf("%02d:%02d:%02d.%09d", v/time.Hour, v%time.Hour/time.Minute, v%time.Minute/time.Second, v%time.Second)
+	case gocql.Duration:
+		return fmt.Sprintf("%dmo%dd%dns", v.Months, v.Days, v.Nanoseconds)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprintf("%v", val)
+}
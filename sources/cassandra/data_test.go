@@ -0,0 +1,140 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cassandra
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+func nativeType(t gocql.Type) gocql.TypeInfo {
+	return gocql.NewNativeType(4, t, "")
+}
+
+func TestConvScalar(t *testing.T) {
+	conv := internal.MakeConv()
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)
+	uuid, _ := gocql.ParseUUID("123e4567-e89b-12d3-a456-426614174000")
+	tests := []struct {
+		name     string
+		spType   ddl.Type
+		srcType  gocql.TypeInfo
+		val      interface{}
+		expected interface{}
+	}{
+		{"boolean", ddl.Type{Name: ddl.Bool}, nativeType(gocql.TypeBoolean), true, true},
+		{"boolean to int", ddl.Type{Name: ddl.Int64}, nativeType(gocql.TypeBoolean), true, int64(1)},
+		{"tinyint", ddl.Type{Name: ddl.Int64}, nativeType(gocql.TypeTinyInt), int8(-3), int64(-3)},
+		{"int", ddl.Type{Name: ddl.Int64}, nativeType(gocql.TypeInt), 42, int64(42)},
+		{"time", ddl.Type{Name: ddl.Int64}, nativeType(gocql.TypeTime), 90 * time.Second, int64(90 * time.Second)},
+		{"varint", ddl.Type{Name: ddl.Numeric}, nativeType(gocql.TypeVarint), big.NewInt(12), big.NewRat(12, 1)},
+		{"float", ddl.Type{Name: ddl.Float32}, nativeType(gocql.TypeFloat), float32(1.5), float32(1.5)},
+		{"float to double", ddl.Type{Name: ddl.Float64}, nativeType(gocql.TypeFloat), float32(1.5), float64(1.5)},
+		{"text", ddl.Type{Name: ddl.String}, nativeType(gocql.TypeText), "abc", "abc"},
+		{"uuid", ddl.Type{Name: ddl.String}, nativeType(gocql.TypeUUID), uuid, "123e4567-e89b-12d3-a456-426614174000"},
+		{"uuid to bytes", ddl.Type{Name: ddl.Bytes}, nativeType(gocql.TypeUUID), uuid, uuid.Bytes()},
+		{"uuid to uuid", ddl.Type{Name: ddl.UUID}, nativeType(gocql.TypeUUID), uuid, "123e4567-e89b-12d3-a456-426614174000"},
+		{"inet", ddl.Type{Name: ddl.String}, nativeType(gocql.TypeInet), net.ParseIP("10.0.0.1"), "10.0.0.1"},
+		{"time to text", ddl.Type{Name: ddl.String}, nativeType(gocql.TypeTime), 13*time.Hour + 5*time.Second + 7, "13:00:05.000000007"},
+		{"date", ddl.Type{Name: ddl.Date}, nativeType(gocql.TypeDate), ts, civil.Date{Year: 2024, Month: 5, Day: 6}},
+		{"date to text", ddl.Type{Name: ddl.String}, nativeType(gocql.TypeDate), ts, "2024-05-06"},
+		{"timestamp", ddl.Type{Name: ddl.Timestamp}, nativeType(gocql.TypeTimestamp), ts, ts},
+		{"blob", ddl.Type{Name: ddl.Bytes}, nativeType(gocql.TypeBlob), []byte{1, 2}, []byte{1, 2}},
+		{"duration", ddl.Type{Name: ddl.String}, nativeType(gocql.TypeDuration), gocql.Duration{Months: 1, Days: 2, Nanoseconds: 3}, "1mo2d3ns"},
+		{"map", ddl.Type{Name: ddl.JSON}, gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeMap, ""), Key: nativeType(gocql.TypeText), Elem: nativeType(gocql.TypeInt)}, map[string]int{"a": 1}, `{"a":1}`},
+	}
+	for _, tc := range tests {
+		got, err := convScalar(conv, tc.spType, tc.srcType, tc.val)
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expected, got, tc.name)
+	}
+
+	_, err := convScalar(conv, ddl.Type{Name: ddl.Int64}, nativeType(gocql.TypeVarint), new(big.Int).Lsh(big.NewInt(1), 70))
+	assert.Error(t, err)
+	_, err = convScalar(conv, ddl.Type{Name: ddl.Bool}, nativeType(gocql.TypeText), "true")
+	assert.Error(t, err)
+
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	got, err := convScalar(conv, ddl.Type{Name: ddl.Numeric}, nativeType(gocql.TypeVarint), big.NewInt(12))
+	assert.NoError(t, err)
+	assert.Equal(t, sp.PGNumeric{Numeric: "12", Valid: true}, got)
+}
+
+func TestConvArray(t *testing.T) {
+	conv := internal.MakeConv()
+	listOf := func(elem gocql.Type) gocql.TypeInfo {
+		return gocql.CollectionType{NativeType: gocql.NewNativeType(4, gocql.TypeList, ""), Elem: nativeType(elem)}
+	}
+	got, err := convArray(conv, ddl.Type{Name: ddl.Int64, IsArray: true}, listOf(gocql.TypeInt), []int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []sp.NullInt64{{Int64: 1, Valid: true}, {Int64: 2, Valid: true}}, got)
+
+	got, err = convArray(conv, ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, listOf(gocql.TypeText), []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []sp.NullString{}, got)
+
+	_, err = convArray(conv, ddl.Type{Name: ddl.Int64, IsArray: true}, nativeType(gocql.TypeInt), 1)
+	assert.Error(t, err)
+}
+
+func TestConvertData(t *testing.T) {
+	conv := internal.MakeConv()
+	srcSchema := schema.Table{
+		Name:   "t",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "int"}},
+			"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "text"}},
+		},
+	}
+	spSchema := ddl.CreateTable{
+		Name:   "t",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c3": {Name: "migration_shard_id", Id: "c3", T: ddl.Type{Name: ddl.String, Len: 50}},
+		},
+		ShardIdColumn: "c3",
+	}
+	conv.SpSchema["t1"] = spSchema
+	srcTypes := []gocql.TypeInfo{nativeType(gocql.TypeInt), nativeType(gocql.TypeText)}
+
+	table, cols, vals, err := ConvertData(conv, "t1", []string{"c1", "c2"}, srcSchema, spSchema, srcTypes, []interface{}{7, nil}, time.Time{}, internal.AdditionalDataAttributes{ShardId: "s1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "t", table)
+	assert.Equal(t, []string{"a", "migration_shard_id"}, cols)
+	assert.Equal(t, []interface{}{int64(7), "s1"}, vals)
+
+	_, _, _, err = ConvertData(conv, "t1", []string{"c1"}, srcSchema, spSchema, srcTypes, []interface{}{7, "x"}, time.Time{}, internal.AdditionalDataAttributes{})
+	assert.Error(t, err)
+}
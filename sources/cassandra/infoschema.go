@@ -17,9 +17,15 @@ package cassandra
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	sp "cloud.google.com/go/spanner"
 	cc "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/cassandra"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/task"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
@@ -30,6 +36,7 @@ import (
 
 // InfoSchemaImpl is Cassandra specific implementation for InfoSchema
 type InfoSchemaImpl struct {
+	Client           cc.CassandraClusterInterface
 	KeyspaceMetadata cc.KeyspaceMetadataInterface
 	SourceProfile    profiles.SourceProfile
 	TargetProfile    profiles.TargetProfile
@@ -233,20 +240,264 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 	return indexes, nil
 }
 
-// Data Migration Related Methods (Stubs for Schema Migration)
+// GetRowDeletionPolicy returns a policy that deletes the rows of a table
+// once its default TTL has passed since they were last written. Rows written
+// with a TTL of their own expire at other times, which the policy doesn't
+// represent.
+func (isi InfoSchemaImpl) GetRowDeletionPolicy(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) (*schema.RowDeletionPolicy, error) {
+	if isi.Client == nil {
+		return nil, nil
+	}
+	var ttl int
+	iter := isi.Client.Iter("SELECT default_time_to_live FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?", table.Schema, table.Name)
+	iter.Scan(&ttl)
+	if err := iter.Close(); err != nil {
+		return nil, fmt.Errorf("couldn't read default_time_to_live of table '%s': %w", table.Name, err)
+	}
+	if ttl <= 0 {
+		return nil, nil
+	}
+	return &schema.RowDeletionPolicy{Seconds: int64(ttl)}, nil
+}
+
+// getWriteTimeColumns returns the columns whose WRITETIME is read to find
+// when a row was last written, which are the regular columns other than
+// collections and user defined types, for which WRITETIME isn't supported.
+func getWriteTimeColumns(tableMetadata *gocql.TableMetadata) []string {
+	keyCols := make(map[string]bool)
+	for _, col := range tableMetadata.PartitionKey {
+		keyCols[col.Name] = true
+	}
+	for _, col := range tableMetadata.ClusteringColumns {
+		keyCols[col.Name] = true
+	}
+	var cols []string
+	for name, colMeta := range tableMetadata.Columns {
+		switch colMeta.Type.Type() {
+		case gocql.TypeList, gocql.TypeSet, gocql.TypeMap, gocql.TypeUDT:
+			continue
+		}
+		if !keyCols[name] {
+			cols = append(cols, name)
+		}
+	}
+	sort.Strings(cols)
+	return cols
+}
+
+// lastWriteTime returns the latest of the write times, given in
+// microseconds since the epoch, with nil for columns that are null. Rows
+// whose columns are all null are given the current time.
+func lastWriteTime(writeTimes []*int64) time.Time {
+	var last *int64
+	for _, wt := range writeTimes {
+		if wt != nil && (last == nil || *wt > *last) {
+			last = wt
+		}
+	}
+	if last == nil {
+		return time.Now().UTC()
+	}
+	return time.UnixMicro(*last).UTC()
+}
 
 var errNotSupported = fmt.Errorf("operation not supported")
 
+// quoteIdentifier quotes a keyspace, table or column name so that case and
+// reserved words are preserved in CQL queries.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// getPartitionKeys returns the quoted partition key columns of a table, in
+// the order used by the TOKEN function.
+func getPartitionKeys(tableMetadata *gocql.TableMetadata) []string {
+	var partitionKeys []string
+	for _, colMeta := range tableMetadata.PartitionKey {
+		partitionKeys = append(partitionKeys, quoteIdentifier(colMeta.Name))
+	}
+	return partitionKeys
+}
+
+// getRowsInRange returns an iterator over the rows of a table whose
+// partition key token falls within tr, selecting the columns srcCols
+// followed by the write times of the columns writeTimeCols.
+func (isi InfoSchemaImpl) getRowsInRange(tableMetadata *gocql.TableMetadata, srcCols, writeTimeCols []string, tr cc.TokenRange) cc.IterInterface {
+	var quotedCols []string
+	for _, col := range srcCols {
+		quotedCols = append(quotedCols, quoteIdentifier(col))
+	}
+	for _, col := range writeTimeCols {
+		quotedCols = append(quotedCols, fmt.Sprintf("WRITETIME(%s)", quoteIdentifier(col)))
+	}
+	q := fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(quotedCols, ", "), quoteIdentifier(tableMetadata.Keyspace), quoteIdentifier(tableMetadata.Name))
+	where, args := tr.Where(getPartitionKeys(tableMetadata))
+	if where != "" {
+		q += " WHERE " + where
+	}
+	return isi.Client.Iter(q, args...)
+}
+
+// GetRowsFromTable returns an iterator over all rows of a table.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
-	return nil, errNotSupported
+	if isi.Client == nil {
+		return nil, fmt.Errorf("cassandra client not initialized")
+	}
+	srcSchema := conv.SrcSchema[tableId]
+	tableMetadata, ok := isi.getTableMetadata(srcSchema.Name)
+	if !ok {
+		return nil, fmt.Errorf("table '%s' not found in keyspace metadata", srcSchema.Name)
+	}
+	var srcCols []string
+	for _, colId := range srcSchema.ColIds {
+		srcCols = append(srcCols, srcSchema.ColDefs[colId].Name)
+	}
+	return isi.getRowsInRange(tableMetadata, srcCols, nil, cc.TokenRange{}), nil
 }
 
+// GetRowCount returns the number of rows in a table. Rows are counted in
+// parallel over the token ranges of the cluster, since an unrestricted
+// COUNT(*) on a large table typically times out.
 func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
-	return 0, errNotSupported
+	if isi.Client == nil {
+		return 0, fmt.Errorf("cassandra client not initialized")
+	}
+	tableMetadata, ok := isi.getTableMetadata(table.Name)
+	if !ok {
+		return 0, fmt.Errorf("table '%s' not found in keyspace metadata", table.Name)
+	}
+	tokenRanges, err := cc.GetClusterTokenRanges(isi.Client)
+	if err != nil {
+		return 0, err
+	}
+	countInRange := func(tr cc.TokenRange, mutex *sync.Mutex) task.TaskResult[int64] {
+		q := fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", quoteIdentifier(tableMetadata.Keyspace), quoteIdentifier(tableMetadata.Name))
+		where, args := tr.Where(getPartitionKeys(tableMetadata))
+		if where != "" {
+			q += " WHERE " + where
+		}
+		var count int64
+		iter := isi.Client.Iter(q, args...)
+		iter.Scan(&count)
+		if err := iter.Close(); err != nil {
+			return task.TaskResult[int64]{Err: fmt.Errorf("range query failed: %w", err)}
+		}
+		return task.TaskResult[int64]{Result: count}
+	}
+	r := task.RunParallelTasksImpl[cc.TokenRange, int64]{}
+	res, err := r.RunParallelTasks(tokenRanges, common.DefaultWorkers, countInRange, true)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, c := range res {
+		total += c.Result
+	}
+	return total, nil
 }
 
-func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
-	return errNotSupported
+// ProcessData performs data conversion for a Cassandra table. The table is
+// read in parallel over the token ranges of the cluster; conversion and
+// writes are serialized since conv and the underlying BatchWriter are not
+// thread-safe. A failed token range is reported and the remaining ranges
+// are still processed.
+func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	if isi.Client == nil {
+		return fmt.Errorf("cassandra client not initialized")
+	}
+	tableMetadata, ok := isi.getTableMetadata(srcSchema.Name)
+	if !ok {
+		err := fmt.Errorf("table '%s' not found in keyspace metadata", srcSchema.Name)
+		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+	var srcCols []string
+	var srcTypes []gocql.TypeInfo
+	for _, colId := range commonColIds {
+		colName := srcSchema.ColDefs[colId].Name
+		colMeta, ok := tableMetadata.Columns[colName]
+		if !ok {
+			err := fmt.Errorf("column '%s' not found in metadata for table '%s'", colName, srcSchema.Name)
+			conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcSchema.Name, err))
+			return err
+		}
+		srcCols = append(srcCols, colName)
+		srcTypes = append(srcTypes, colMeta.Type)
+	}
+	if len(srcCols) == 0 {
+		conv.Unexpected(fmt.Sprintf("Couldn't get source columns for table %s ", srcSchema.Name))
+		return nil
+	}
+	// A row deletion policy on a column that isn't in the source table
+	// expires rows by when they were last written.
+	var writeTimeCols []string
+	useWriteTime := false
+	if rdp := spSchema.RowDeletionPolicy; rdp != nil {
+		if _, ok := srcSchema.ColDefs[rdp.ColId]; !ok {
+			writeTimeCols = getWriteTimeColumns(tableMetadata)
+			useWriteTime = true
+		}
+	}
+	tokenRanges, err := cc.GetClusterTokenRanges(isi.Client)
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Couldn't get token ranges for table %s : err = %s", srcSchema.Name, err))
+		return err
+	}
+
+	processRange := func(tr cc.TokenRange, mutex *sync.Mutex) task.TaskResult[cc.TokenRange] {
+		iter := isi.getRowsInRange(tableMetadata, srcCols, writeTimeCols, tr)
+		for {
+			// Scan into pointers so that CQL nulls can be told apart
+			// from zero values.
+			dest := newScanDest(srcTypes)
+			writeTimes := make([]*int64, len(writeTimeCols))
+			for i := range writeTimes {
+				dest = append(dest, &writeTimes[i])
+			}
+			if !iter.Scan(dest...) {
+				break
+			}
+			vals := derefScanDest(dest[:len(srcTypes)])
+			var writeTime time.Time
+			if useWriteTime {
+				writeTime = lastWriteTime(writeTimes)
+			}
+			mutex.Lock()
+			ProcessDataRow(conv, tableId, commonColIds, srcSchema, spSchema, srcTypes, vals, writeTime, additionalAttributes)
+			mutex.Unlock()
+		}
+		return task.TaskResult[cc.TokenRange]{Result: tr, Err: iter.Close()}
+	}
+	r := task.RunParallelTasksImpl[cc.TokenRange, cc.TokenRange]{}
+	res, _ := r.RunParallelTasks(tokenRanges, common.DefaultWorkers, processRange, false)
+	for _, tr := range res {
+		if tr.Err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't read token range [%s, %s) of table %s : err = %s", tr.Result.Start, tr.Result.End, srcSchema.Name, tr.Err))
+		}
+	}
+	return nil
+}
+
+// newScanDest allocates a **T destination for each column, where T is the
+// Go type gocql uses for the column's CQL type.
+func newScanDest(srcTypes []gocql.TypeInfo) []interface{} {
+	dest := make([]interface{}, len(srcTypes))
+	for i, ti := range srcTypes {
+		dest[i] = reflect.New(reflect.PointerTo(reflect.TypeOf(ti.New()).Elem())).Interface()
+	}
+	return dest
+}
+
+// derefScanDest returns the values held by dest, with nil for CQL nulls.
+func derefScanDest(dest []interface{}) []interface{} {
+	vals := make([]interface{}, len(dest))
+	for i, d := range dest {
+		p := reflect.ValueOf(d).Elem()
+		if !p.IsNil() {
+			vals[i] = p.Elem().Interface()
+		}
+	}
+	return vals
 }
 
 func (isi InfoSchemaImpl) StartChangeDataCapture(ctx context.Context, conv *internal.Conv) (map[string]interface{}, error) {
@@ -15,8 +15,10 @@ package cassandra
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	cc "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/cassandra"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -26,6 +28,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/gocql/gocql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestGetToDdl(t *testing.T) {
@@ -395,23 +398,191 @@ func TestGetIndexes(t *testing.T) {
 	mockKeyspace.AssertExpectations(t)
 }
 
-func TestDataMigrationStubs(t *testing.T) {
+func TestStreamingStubs(t *testing.T) {
 	isi := InfoSchemaImpl{}
 	ctx := context.Background()
 	conv := internal.MakeConv()
 
-	_, err := isi.GetRowsFromTable(conv, "table1")
+	_, err := isi.StartChangeDataCapture(ctx, conv)
 	assert.ErrorIs(t, err, errNotSupported)
 
-	_, err = isi.GetRowCount(common.SchemaAndName{Name: "table1"})
+	_, err = isi.StartStreamingMigration(ctx, "", nil, conv, nil)
 	assert.ErrorIs(t, err, errNotSupported)
+}
 
-	err = isi.ProcessData(conv, "table1", schema.Table{}, nil, ddl.CreateTable{}, internal.AdditionalDataAttributes{})
-	assert.ErrorIs(t, err, errNotSupported)
+func getDataTestMetadata() (*cc.MockKeyspaceMetadata, *gocql.TableMetadata) {
+	pkCol := &gocql.ColumnMetadata{Name: "id", Type: gocql.NewNativeType(4, gocql.TypeBigInt, "")}
+	nameCol := &gocql.ColumnMetadata{Name: "name", Type: gocql.NewNativeType(4, gocql.TypeText, "")}
+	tm := &gocql.TableMetadata{
+		Keyspace:     "ks",
+		Name:         "users",
+		PartitionKey: []*gocql.ColumnMetadata{pkCol},
+		Columns:      map[string]*gocql.ColumnMetadata{"id": pkCol, "name": nameCol},
+	}
+	mockKeyspace := &cc.MockKeyspaceMetadata{}
+	mockKeyspace.On("Tables").Return(map[string]*gocql.TableMetadata{"users": tm})
+	return mockKeyspace, tm
+}
 
-	_, err = isi.StartChangeDataCapture(ctx, conv)
-	assert.ErrorIs(t, err, errNotSupported)
+func mockTokenQueries(client *cc.MockCassandraCluster) {
+	client.On("Iter", "SELECT tokens FROM system.local", mock.Anything).Return(&cc.MockIter{Rows: [][]interface{}{{[]string{"0"}}}})
+	client.On("Iter", "SELECT tokens FROM system.peers_v2", mock.Anything).Return(&cc.MockIter{})
+}
 
-	_, err = isi.StartStreamingMigration(ctx, "", nil, conv, nil)
-	assert.ErrorIs(t, err, errNotSupported)
+func TestGetRowCount(t *testing.T) {
+	mockKeyspace, _ := getDataTestMetadata()
+	client := &cc.MockCassandraCluster{}
+	mockTokenQueries(client)
+	client.On("Iter", `SELECT COUNT(*) FROM "ks"."users" WHERE TOKEN("id") < ?`, []interface{}{"0"}).Return(&cc.MockIter{Rows: [][]interface{}{{int64(3)}}})
+	client.On("Iter", `SELECT COUNT(*) FROM "ks"."users" WHERE TOKEN("id") >= ?`, []interface{}{"0"}).Return(&cc.MockIter{Rows: [][]interface{}{{int64(4)}}})
+
+	isi := InfoSchemaImpl{Client: client, KeyspaceMetadata: mockKeyspace}
+	count, err := isi.GetRowCount(common.SchemaAndName{Name: "users"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+
+	// Range query error.
+	client = &cc.MockCassandraCluster{}
+	mockTokenQueries(client)
+	client.On("Iter", mock.Anything, mock.Anything).Return(&cc.MockIter{Err: fmt.Errorf("timeout")})
+	isi = InfoSchemaImpl{Client: client, KeyspaceMetadata: mockKeyspace}
+	_, err = isi.GetRowCount(common.SchemaAndName{Name: "users"})
+	assert.ErrorContains(t, err, "timeout")
+
+	// Client not initialized.
+	_, err = InfoSchemaImpl{KeyspaceMetadata: mockKeyspace}.GetRowCount(common.SchemaAndName{Name: "users"})
+	assert.Error(t, err)
+}
+
+func TestProcessData(t *testing.T) {
+	mockKeyspace, _ := getDataTestMetadata()
+	client := &cc.MockCassandraCluster{}
+	mockTokenQueries(client)
+	id1, id2, name1 := int64(1), int64(2), "alice"
+	client.On("Iter", `SELECT "id", "name" FROM "ks"."users" WHERE TOKEN("id") < ?`, []interface{}{"0"}).Return(&cc.MockIter{Rows: [][]interface{}{{&id1, &name1}}})
+	// A null name is returned as a nil pointer.
+	client.On("Iter", `SELECT "id", "name" FROM "ks"."users" WHERE TOKEN("id") >= ?`, []interface{}{"0"}).Return(&cc.MockIter{Rows: [][]interface{}{{&id2, nil}}})
+
+	conv := internal.MakeConv()
+	conv.SetDataMode()
+	srcSchema := schema.Table{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+			"c2": {Name: "name", Id: "c2", Type: schema.Type{Name: "text"}},
+		},
+	}
+	spSchema := ddl.CreateTable{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		},
+	}
+	conv.SrcSchema["t1"] = srcSchema
+	conv.SpSchema["t1"] = spSchema
+	type row struct {
+		cols []string
+		vals []interface{}
+	}
+	var rows []row
+	conv.SetDataSink(func(table string, cols []string, vals []interface{}) {
+		rows = append(rows, row{cols, vals})
+	})
+
+	isi := InfoSchemaImpl{Client: client, KeyspaceMetadata: mockKeyspace}
+	err := isi.ProcessData(conv, "t1", srcSchema, []string{"c1", "c2"}, spSchema, internal.AdditionalDataAttributes{})
+	assert.NoError(t, err)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].vals[0].(int64) < rows[j].vals[0].(int64) })
+	assert.Equal(t, []row{
+		{cols: []string{"id", "name"}, vals: []interface{}{int64(1), "alice"}},
+		{cols: []string{"id"}, vals: []interface{}{int64(2)}},
+	}, rows)
+	assert.Equal(t, int64(2), conv.Stats.GoodRows["users"])
+}
+
+func TestGetRowDeletionPolicy(t *testing.T) {
+	query := "SELECT default_time_to_live FROM system_schema.tables WHERE keyspace_name = ? AND table_name = ?"
+	client := &cc.MockCassandraCluster{}
+	client.On("Iter", query, []interface{}{"ks", "sessions"}).Return(&cc.MockIter{Rows: [][]interface{}{{86400}}})
+	client.On("Iter", query, []interface{}{"ks", "users"}).Return(&cc.MockIter{Rows: [][]interface{}{{0}}})
+	client.On("Iter", query, []interface{}{"ks", "broken"}).Return(&cc.MockIter{Err: fmt.Errorf("timeout")})
+	isi := InfoSchemaImpl{Client: client}
+	conv := internal.MakeConv()
+
+	rdp, err := isi.GetRowDeletionPolicy(conv, common.SchemaAndName{Schema: "ks", Name: "sessions"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &schema.RowDeletionPolicy{Seconds: 86400}, rdp)
+
+	rdp, err = isi.GetRowDeletionPolicy(conv, common.SchemaAndName{Schema: "ks", Name: "users"}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rdp)
+
+	_, err = isi.GetRowDeletionPolicy(conv, common.SchemaAndName{Schema: "ks", Name: "broken"}, nil)
+	assert.ErrorContains(t, err, "timeout")
+
+	// Without a client, such as when the schema is read from a session,
+	// there's no policy.
+	rdp, err = InfoSchemaImpl{}.GetRowDeletionPolicy(conv, common.SchemaAndName{Schema: "ks", Name: "sessions"}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rdp)
+}
+
+func TestProcessData_RowDeletionTime(t *testing.T) {
+	mockKeyspace, _ := getDataTestMetadata()
+	client := &cc.MockCassandraCluster{}
+	mockTokenQueries(client)
+	id1, id2, name1 := int64(1), int64(2), "alice"
+	written := int64(1700000000000000)
+	client.On("Iter", `SELECT "id", "name", WRITETIME("name") FROM "ks"."users" WHERE TOKEN("id") < ?`, []interface{}{"0"}).Return(&cc.MockIter{Rows: [][]interface{}{{&id1, &name1, &written}}})
+	// Rows without any regular values get the time of the migration.
+	client.On("Iter", `SELECT "id", "name", WRITETIME("name") FROM "ks"."users" WHERE TOKEN("id") >= ?`, []interface{}{"0"}).Return(&cc.MockIter{Rows: [][]interface{}{{&id2, nil, nil}}})
+
+	conv := internal.MakeConv()
+	conv.SetDataMode()
+	srcSchema := schema.Table{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+			"c2": {Name: "name", Id: "c2", Type: schema.Type{Name: "text"}},
+		},
+		RowDeletionPolicy: &schema.RowDeletionPolicy{Seconds: 86400},
+	}
+	spSchema := ddl.CreateTable{
+		Id:     "t1",
+		Name:   "users",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			"c3": {Name: "last_write_time", Id: "c3", T: ddl.Type{Name: ddl.Timestamp}},
+		},
+		RowDeletionPolicy: &ddl.RowDeletionPolicy{ColId: "c3", Days: 1},
+	}
+	conv.SrcSchema["t1"] = srcSchema
+	conv.SpSchema["t1"] = spSchema
+	type row struct {
+		cols []string
+		vals []interface{}
+	}
+	var rows []row
+	conv.SetDataSink(func(table string, cols []string, vals []interface{}) {
+		rows = append(rows, row{cols, vals})
+	})
+
+	start := time.Now().UTC()
+	isi := InfoSchemaImpl{Client: client, KeyspaceMetadata: mockKeyspace}
+	err := isi.ProcessData(conv, "t1", srcSchema, []string{"c1", "c2"}, spSchema, internal.AdditionalDataAttributes{})
+	assert.NoError(t, err)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].vals[0].(int64) < rows[j].vals[0].(int64) })
+	assert.Len(t, rows, 2)
+	assert.Equal(t, row{cols: []string{"id", "name", "last_write_time"}, vals: []interface{}{int64(1), "alice", time.UnixMicro(written).UTC()}}, rows[0])
+	assert.Equal(t, []string{"id", "last_write_time"}, rows[1].cols)
+	assert.False(t, rows[1].vals[1].(time.Time).Before(start))
 }
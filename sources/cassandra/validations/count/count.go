@@ -4,14 +4,13 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"math/big"
 	"os"
-	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"cloud.google.com/go/spanner"
+	cc "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/cassandra"
 	"github.com/gocql/gocql"
 	"google.golang.org/api/iterator"
 )
@@ -102,7 +101,7 @@ func main() {
 
 	// Get token ranges for the cluster
 	// TODO: Consider generating custom token ranges based on size estimates instead of relying on cassandra partitions.
-	tokenRanges, err := getClusterTokenRanges(cassSession)
+	tokenRanges, err := cc.GetClusterTokenRanges(cc.NewGocqlSessionImpl(cassSession))
 	if err != nil {
 		fmt.Printf("Error fetching token ranges: %v\n", err)
 		os.Exit(1)
@@ -171,12 +170,6 @@ func getSpannerTables(ctx context.Context, client *spanner.Client) ([]string, er
 	return tables, nil
 }
 
-// TokenRange represents a Cassandra token range
-type TokenRange struct {
-	Start string
-	End   string
-}
-
 // TableCount holds row counts for both Cassandra and Spanner tables
 type TableCount struct {
 	TableName      string
@@ -235,102 +228,6 @@ func countSpannerRows(ctx context.Context, client *spanner.Client, table string)
 	return count, nil
 }
 
-// getClusterTokenRanges retrieves and processes token ranges from all nodes in the Cassandra cluster.
-// It combines tokens from both local and peer nodes, deduplicates them, and creates a sorted list of
-// token ranges that cover the entire token ring.
-// TODO: Consider creating configurable number of partitions instead of system ranges.
-func getClusterTokenRanges(session *gocql.Session) ([]TokenRange, error) {
-	localTokens, err := getLocalNodeTokens(session)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get local node tokens: %w", err)
-	}
-
-	peerTokens, err := getPeerNodeTokens(session)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get peer node tokens: %w", err)
-	}
-
-	// 1. Combine and Deduplicate Tokens
-	allTokens := append(localTokens, peerTokens...)
-	uniqueTokens := distinct(allTokens)
-
-	// 2. Map to Big Ints and Sort
-	bigIntTokens := make([]*big.Int, len(uniqueTokens))
-	for i, tokenStr := range uniqueTokens {
-		bigIntToken := new(big.Int)
-		if _, ok := bigIntToken.SetString(tokenStr, 10); !ok {
-			return nil, fmt.Errorf("invalid token string: %s", tokenStr)
-		}
-		bigIntTokens[i] = bigIntToken
-	}
-	sort.Slice(bigIntTokens, func(i, j int) bool {
-		return bigIntTokens[i].Cmp(bigIntTokens[j]) < 0
-	})
-
-	// 3. Create Token Ranges
-	tokenRanges := make([]TokenRange, len(bigIntTokens)+1)
-	// First range
-	tokenRanges[0] = TokenRange{Start: "nil", End: bigIntTokens[0].String()}
-
-	// Middle ranges
-	for i := 1; i < len(bigIntTokens); i++ {
-		tokenRanges[i].Start = bigIntTokens[i-1].String()
-		tokenRanges[i].End = bigIntTokens[i].String()
-
-	}
-
-	// Last range
-	lastIndex := len(tokenRanges) - 1
-	tokenRanges[lastIndex] = TokenRange{Start: bigIntTokens[lastIndex-1].String(), End: "nil"}
-
-	return tokenRanges, nil
-}
-
-// distinct takes a slice of strings and returns a new slice containing only unique elements,
-// removing any duplicates from the input slice.
-func distinct(tokens []string) []string {
-	seen := make(map[string]bool)
-	unique := []string{}
-	for _, token := range tokens {
-		if _, ok := seen[token]; !ok {
-			seen[token] = true
-			unique = append(unique, token)
-		}
-	}
-	return unique
-}
-
-// getLocalNodeTokens retrieves the token ranges assigned to the local Cassandra node.
-// It queries the system.local table to get the tokens for the current node.
-func getLocalNodeTokens(session *gocql.Session) ([]string, error) {
-	var tokens []string // Corrected: Should be a slice of strings
-	err := session.Query("SELECT tokens FROM system.local").Scan(&tokens)
-	if err != nil {
-		return nil, err
-	}
-
-	// No need to trim or split; the driver handles this for slices
-	return tokens, nil
-}
-
-// getPeerNodeTokens retrieves the token ranges assigned to all peer nodes in the Cassandra cluster.
-// It queries the system.peers_v2 table to get tokens for all other nodes in the cluster.
-func getPeerNodeTokens(session *gocql.Session) ([]string, error) {
-	var allTokens []string
-	iter := session.Query("SELECT tokens FROM system.peers_v2").Iter() // Use peers_v2 for modern Cassandra
-	var tokens []string                                                // Corrected: Scan into a slice
-
-	for iter.Scan(&tokens) {
-		allTokens = append(allTokens, tokens...) // Append directly; no splitting needed
-	}
-
-	if err := iter.Close(); err != nil {
-		return nil, err
-	}
-
-	return allTokens, nil
-}
-
 // getPartitionKeysFromMetadata retrieves the partition key columns for a given table from Cassandra's metadata.
 // It returns the partition key column names in the correct order as defined in the table schema.
 func getPartitionKeysFromMetadata(session *gocql.Session, keyspaceName, tableName string) ([]string, error) {
@@ -361,7 +258,7 @@ func getPartitionKeysFromMetadata(session *gocql.Session, keyspaceName, tableNam
 
 // countTableRows counts rows in both Cassandra and Spanner concurrently
 func countBothDatabases(ctx context.Context, cassSession *gocql.Session, spannerClient *spanner.Client,
-	keyspace, tableName string, tokenRanges []TokenRange, workers int) TableCount {
+	keyspace, tableName string, tokenRanges []cc.TokenRange, workers int) TableCount {
 	result := TableCount{TableName: tableName}
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -388,7 +285,7 @@ func countBothDatabases(ctx context.Context, cassSession *gocql.Session, spanner
 
 // countCassandraRows counts the total number of rows in a Cassandra table by getting partition keys
 // and using token ranges for parallel counting.
-func countCassandraRows(session *gocql.Session, keyspace, tableName string, tokenRanges []TokenRange, workers int) (int64, error) {
+func countCassandraRows(session *gocql.Session, keyspace, tableName string, tokenRanges []cc.TokenRange, workers int) (int64, error) {
 	// Get table metadata including partition keys
 	partitionKeys, err := getPartitionKeysFromMetadata(session, keyspace, tableName)
 	if err != nil {
@@ -403,9 +300,9 @@ func countCassandraRows(session *gocql.Session, keyspace, tableName string, toke
 // and processing them in parallel using a worker pool. It aggregates the results from all workers and handles
 // any errors that occur during the counting process.
 func countTableRows(session *gocql.Session, keyspace, table string, partitionKeys []string,
-	tokenRanges []TokenRange, workers int) (int64, error) {
+	tokenRanges []cc.TokenRange, workers int) (int64, error) {
 	// Create channels for work distribution and results
-	workChan := make(chan TokenRange, len(tokenRanges))
+	workChan := make(chan cc.TokenRange, len(tokenRanges))
 	resultChan := make(chan int64, len(tokenRanges))
 	errChan := make(chan error, workers)
 
@@ -458,33 +355,15 @@ func countTableRows(session *gocql.Session, keyspace, table string, partitionKey
 // countInRange counts the number of rows within a specific token range for a given table.
 // It constructs a query using the TOKEN function to filter rows based on the partition key's token value
 // falling within the specified range.
-func countInRange(session *gocql.Session, keyspace, table string, partitionKeys []string, tr TokenRange) (int64, error) {
+func countInRange(session *gocql.Session, keyspace, table string, partitionKeys []string, tr cc.TokenRange) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
 
-	// Build partition key string for TOKEN function
-	partitionKeyStr := partitionKeys[0]
-	if len(partitionKeys) > 1 {
-		partitionKeyStr = fmt.Sprintf("%s", strings.Join(partitionKeys, ", "))
-	}
-
 	// Build query with dynamic token range filter
-	queryBase := fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", keyspace, table)
-	whereClauses := []string{}
-	var args []interface{}
-
-	if tr.Start != "nil" {
-		whereClauses = append(whereClauses, fmt.Sprintf("TOKEN(%s) >= ?", partitionKeyStr))
-		args = append(args, tr.Start)
-	}
-	if tr.End != "nil" {
-		whereClauses = append(whereClauses, fmt.Sprintf("TOKEN(%s) < ?", partitionKeyStr))
-		args = append(args, tr.End)
-	}
-
-	query := queryBase
-	if len(whereClauses) > 0 {
-		query += " WHERE " + strings.Join(whereClauses, " AND ")
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s.%s", keyspace, table)
+	where, args := tr.Where(partitionKeys)
+	if where != "" {
+		query += " WHERE " + where
 	}
 
 	var count int64
@@ -40,6 +40,9 @@ It supports two modes of operation:
 1. Full validation mode (default): Validates all rows in the specified table
 2. Sampling mode: Validates a random sample of rows, recommended for large datasets
 
+The validate subcommand with --rows compares rows in the same way for every
+source, using the schema mapping of a session file.
+
 Sample usage:
 
 	go run validation.go \
@@ -16,7 +16,9 @@ package common
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -31,6 +33,11 @@ import (
 
 const DefaultWorkers = 20 // Default to 20 - observed diminishing returns above this value
 
+// checkpointRowsPerKeyRange is the approximate number of rows in each key
+// range read when the progress of a data migration is checkpointed. The
+// checkpoint advances as each key range completes.
+const checkpointRowsPerKeyRange = 100000
+
 // InfoSchema contains database information.
 type InfoSchema interface {
 	GetToDdl() ToDdl
@@ -47,6 +54,44 @@ type InfoSchema interface {
 	StartStreamingMigration(ctx context.Context, migrationProjectId string, client *sp.Client, conv *internal.Conv, streamInfo map[string]interface{}) (internal.DataflowOutput, error)
 }
 
+// KeyRangeReader is implemented by InfoSchema implementations that can read
+// a table in ranges of its first primary key column. ProcessData uses it to
+// read large tables with several concurrent workers.
+type KeyRangeReader interface {
+	// GetKeySplitPoints returns at most n-1 ascending values of the first
+	// primary key column of the table that divide it into n ranges holding
+	// roughly the same number of rows.
+	GetKeySplitPoints(conv *internal.Conv, tableId string, n int) ([]interface{}, error)
+	// GetKeyRangeQuery returns the query, and its arguments, that reads the
+	// rows of the table whose first primary key column lies in kr.
+	GetKeyRangeQuery(conv *internal.Conv, tableId string, kr internal.KeyRange) (string, []interface{})
+}
+
+// RowDeletionPolicyReader is implemented by InfoSchema implementations of
+// sources whose rows can expire automatically.
+type RowDeletionPolicyReader interface {
+	// GetRowDeletionPolicy returns the policy that expires the rows of the
+	// table, or nil if they don't expire.
+	GetRowDeletionPolicy(conv *internal.Conv, table SchemaAndName, colNameIdMap map[string]string) (*schema.RowDeletionPolicy, error)
+}
+
+// ViewReader is implemented by InfoSchema implementations of sources whose
+// views are migrated.
+type ViewReader interface {
+	// GetViews returns the views of the source database. The names of views,
+	// and of the tables and views they refer to, are those returned by
+	// GetTableName.
+	GetViews(conv *internal.Conv) ([]schema.View, error)
+}
+
+// ExactRowCounter is implemented by InfoSchema implementations whose
+// GetRowCount is an estimate, such as DynamoDB's.
+type ExactRowCounter interface {
+	// GetExactRowCount counts the rows of the table, which may read the
+	// whole table.
+	GetExactRowCount(table SchemaAndName) (int64, error)
+}
+
 // SchemaAndName contains the schema and name for a table
 type SchemaAndName struct {
 	Schema string
@@ -130,6 +175,17 @@ func (is *InfoSchemaImpl) GenerateSrcSchema(conv *internal.Conv, infoSchema Info
 	}
 
 	internal.ResolveForeignKeyIds(conv.SrcSchema)
+	if viewReader, ok := infoSchema.(ViewReader); ok {
+		// Views aren't essential to the migration, so the tables are still
+		// migrated if their views can't be read.
+		views, err := viewReader.GetViews(conv)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't get views: %s", err))
+		}
+		for _, view := range views {
+			conv.SrcViews[view.Id] = view
+		}
+	}
 	return len(tables), nil
 }
 
@@ -137,13 +193,41 @@ func (is *InfoSchemaImpl) GenerateSrcSchema(conv *internal.Conv, infoSchema Info
 // 'db'. For each table, we extract and convert the data to Spanner data
 // (based on the source and Spanner schemas), and write it to Spanner.
 // If we can't get/process data for a table, we skip that table and process
-// the remaining tables.
+// the remaining tables. When conv.Audit.ReadWorkers is more than one and the
+// source implements KeyRangeReader, each table is split into primary key
+// ranges that are read concurrently. When conv.Audit.Checkpoint is set,
+// tables completed by an earlier migration are skipped, and partially
+// migrated tables continue from their checkpointed primary key watermark.
 func (is *InfoSchemaImpl) ProcessData(conv *internal.Conv, infoSchema InfoSchema, additionalAttributes internal.AdditionalDataAttributes) {
 	// Tables are ordered in alphabetical order with one exception: interleaved
 	// tables appear after the population of their parent table.
 	tableIds := ddl.GetSortedTableIdsBySpName(conv.SpSchema)
+	checkpoint := conv.Audit.Checkpoint
+	if conv.Audit.BatchWrite {
+		// Rows written as mutation groups are grouped with the rows they
+		// are interleaved in, so the tables of an interleave family are
+		// flushed together rather than one at a time.
+		tableIds = groupByInterleaveFamily(conv.SpSchema, tableIds)
+	}
+	// Tables are checkpointed as completed once their rows are flushed.
+	var flushed []string
+	flush := func() {
+		if conv.DataFlush != nil {
+			conv.DataFlush()
+		}
+		for _, key := range flushed {
+			if err := checkpoint.Update(key, internal.TableCheckpoint{Completed: true}); err != nil {
+				conv.Unexpected(fmt.Sprintf("Couldn't checkpoint completion of table %s: %s", key, err))
+			}
+		}
+		flushed = nil
+	}
+	defer flush()
 
-	for _, tableId := range tableIds {
+	for i, tableId := range tableIds {
+		if i > 0 && (!conv.Audit.BatchWrite || rootTableId(conv.SpSchema, tableIds[i-1]) != rootTableId(conv.SpSchema, tableId)) {
+			flush()
+		}
 		srcSchema := conv.SrcSchema[tableId]
 		spSchema, ok := conv.SpSchema[tableId]
 		if !ok {
@@ -152,17 +236,234 @@ func (is *InfoSchemaImpl) ProcessData(conv *internal.Conv, infoSchema InfoSchema
 				srcSchema.Name, ok))
 			continue
 		}
+		key := checkpointKey(srcSchema, additionalAttributes)
+		if checkpoint != nil {
+			if tc, ok := checkpoint.Get(key); ok && tc.Completed {
+				logger.Log.Info(fmt.Sprintf("skipping table %s, which was completed by an earlier migration", srcSchema.Name))
+				checkpoint.MarkResumed(key)
+				conv.StatsAddResumedRows(srcSchema.Name)
+				continue
+			}
+		}
 		// Extract common spColds. We get column ids common to both source and
 		// spanner table so that we can read these records from source
 		colIds := GetCommonColumnIds(conv, tableId, spSchema.ColIds)
-		err := infoSchema.ProcessData(conv, tableId, srcSchema, colIds, spSchema, additionalAttributes)
+		err := processTableData(conv, infoSchema, tableId, srcSchema, colIds, spSchema, additionalAttributes)
 		if err != nil {
 			return
 		}
-		if conv.DataFlush != nil {
-			conv.DataFlush()
+		if checkpoint != nil {
+			flushed = append(flushed, key)
+		}
+	}
+}
+
+// groupByInterleaveFamily reorders tableIds, in which tables appear after
+// the tables they are interleaved in, so that the tables interleaved in the
+// same root table are adjacent. Families keep the order of their root
+// tables, and tables keep their order within a family.
+func groupByInterleaveFamily(spSchema ddl.Schema, tableIds []string) []string {
+	var roots []string
+	families := make(map[string][]string)
+	for _, tableId := range tableIds {
+		root := rootTableId(spSchema, tableId)
+		if _, ok := families[root]; !ok {
+			roots = append(roots, root)
+		}
+		families[root] = append(families[root], tableId)
+	}
+	var sorted []string
+	for _, root := range roots {
+		sorted = append(sorted, families[root]...)
+	}
+	return sorted
+}
+
+// rootTableId returns the id of the table that tableId is interleaved in,
+// directly or through other tables, and that isn't interleaved itself.
+func rootTableId(spSchema ddl.Schema, tableId string) string {
+	// Guard against cycles, which a valid schema can't have.
+	for depth := 0; depth < 16; depth++ {
+		parentId := spSchema[tableId].ParentTable.Id
+		if _, ok := spSchema[parentId]; parentId == "" || !ok {
+			break
 		}
+		tableId = parentId
 	}
+	return tableId
+}
+
+// processTableData reads and converts the data of a single table. Tables are
+// still processed one at a time so that interleaved parent rows are written
+// before their children; only the reads within a table run concurrently.
+func processTableData(conv *internal.Conv, infoSchema InfoSchema, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	numWorkers := conv.Audit.ReadWorkers
+	checkpoint := conv.Audit.Checkpoint
+	keyRangeReader, ok := infoSchema.(KeyRangeReader)
+	_, hasSyntheticPKey := conv.SyntheticPKeys[tableId]
+	// Synthetic primary key values are assigned sequentially while
+	// converting rows, so such tables are always read by a single worker
+	// and can only be checkpointed once complete.
+	if !ok || (numWorkers <= 1 && checkpoint == nil) || len(srcSchema.PrimaryKeys) == 0 || hasSyntheticPKey {
+		return infoSchema.ProcessData(conv, tableId, srcSchema, colIds, spSchema, additionalAttributes)
+	}
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	key := checkpointKey(srcSchema, additionalAttributes)
+	var keyRanges []internal.KeyRange
+	resumed := false
+	if checkpoint != nil {
+		if tc, ok := checkpoint.Get(key); ok {
+			resumed = true
+			checkpoint.MarkResumed(key)
+			keyRanges = BuildKeyRanges(tc.SplitPoints)
+			keyRanges[0].Start = tc.Watermark
+			logger.Log.Info(fmt.Sprintf("resuming table %s from primary key %v", srcSchema.Name, tc.Watermark))
+		}
+	}
+	if !resumed {
+		n := numWorkers
+		if checkpoint != nil {
+			// Use enough key ranges that the checkpoint advances regularly.
+			if m := int(conv.Stats.Rows[srcSchema.Name]/checkpointRowsPerKeyRange) + 1; m > n {
+				n = m
+			}
+		}
+		splitPoints, err := keyRangeReader.GetKeySplitPoints(conv, tableId, n)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Couldn't split table %s into key ranges, reading it with a single worker: %s", srcSchema.Name, err))
+			return infoSchema.ProcessData(conv, tableId, srcSchema, colIds, spSchema, additionalAttributes)
+		}
+		keyRanges = BuildKeyRanges(splitPoints)
+		if checkpoint != nil {
+			if err := checkpoint.Update(key, internal.TableCheckpoint{SplitPoints: splitPoints}); err != nil {
+				conv.Unexpected(fmt.Sprintf("Couldn't checkpoint progress of table %s: %s", srcSchema.Name, err))
+			}
+		}
+	}
+	logger.Log.Info(fmt.Sprintf("reading table %s in %d key ranges", srcSchema.Name, len(keyRanges)))
+
+	// done records the completed key ranges. The checkpoint watermark is the
+	// end of the longest completed prefix of keyRanges.
+	done := make([]bool, len(keyRanges))
+	completedPrefix := 0
+	asyncProcessKeyRange := func(i int, mutex *sync.Mutex) task.TaskResult[internal.KeyRange] {
+		kr := keyRanges[i]
+		attributes := additionalAttributes
+		attributes.KeyRange = kr
+		err := infoSchema.ProcessData(conv, tableId, srcSchema, colIds, spSchema, attributes)
+		if err != nil || checkpoint == nil {
+			return task.TaskResult[internal.KeyRange]{Result: kr, Err: err}
+		}
+		mutex.Lock()
+		done[i] = true
+		prefix := completedPrefix
+		for prefix < len(done) && done[prefix] {
+			prefix++
+		}
+		// Completion of the last key range is recorded once the whole
+		// table has been flushed.
+		advanced := prefix > completedPrefix && prefix < len(keyRanges)
+		mutex.Unlock()
+		if !advanced {
+			return task.TaskResult[internal.KeyRange]{Result: kr}
+		}
+		// The watermark may only advance once the rows below it have been
+		// committed to Spanner. The flush waits without holding mutex, so
+		// that the other workers keep reading.
+		conv.FlushData()
+		mutex.Lock()
+		defer mutex.Unlock()
+		// Another worker may have checkpointed a longer prefix meanwhile.
+		if prefix > completedPrefix {
+			completedPrefix = prefix
+			tc := internal.TableCheckpoint{Watermark: keyRanges[prefix-1].End}
+			for _, r := range keyRanges[prefix : len(keyRanges)-1] {
+				tc.SplitPoints = append(tc.SplitPoints, r.End)
+			}
+			if err := checkpoint.Update(key, tc); err != nil {
+				conv.Unexpected(fmt.Sprintf("Couldn't checkpoint progress of table %s: %s", srcSchema.Name, err))
+			}
+		}
+		return task.TaskResult[internal.KeyRange]{Result: kr}
+	}
+	indexes := make([]int, len(keyRanges))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	r := task.RunParallelTasksImpl[int, internal.KeyRange]{}
+	_, err := r.RunParallelTasks(indexes, numWorkers, asyncProcessKeyRange, true)
+	if err == nil && resumed {
+		conv.StatsAddResumedRows(srcSchema.Name)
+	}
+	return err
+}
+
+// checkpointKey returns the key under which the progress of srcTable is
+// checkpointed. Each shard of a sharded migration is checkpointed separately.
+func checkpointKey(srcTable schema.Table, additionalAttributes internal.AdditionalDataAttributes) string {
+	if additionalAttributes.ShardId != "" {
+		return additionalAttributes.ShardId + "/" + srcTable.Name
+	}
+	return srcTable.Name
+}
+
+// BuildKeyRanges turns ascending split points into contiguous,
+// non-overlapping key ranges that together cover the whole table.
+// Repeated split points are ignored.
+func BuildKeyRanges(splitPoints []interface{}) []internal.KeyRange {
+	var keyRanges []internal.KeyRange
+	var start interface{}
+	for _, p := range splitPoints {
+		if p == nil || (start != nil && reflect.DeepEqual(start, p)) {
+			continue
+		}
+		keyRanges = append(keyRanges, internal.KeyRange{Start: start, End: p})
+		start = p
+	}
+	return append(keyRanges, internal.KeyRange{Start: start})
+}
+
+// GetKeyRangeColumn returns the name of the source column that key ranges of
+// srcTable are defined over, i.e. its first primary key column.
+func GetKeyRangeColumn(srcTable schema.Table) string {
+	if len(srcTable.PrimaryKeys) == 0 {
+		return ""
+	}
+	return srcTable.ColDefs[srcTable.PrimaryKeys[0].ColId].Name
+}
+
+// ScanKeySplitPoints collects the split points returned by a source query,
+// one per row.
+func ScanKeySplitPoints(rows *sql.Rows) ([]interface{}, error) {
+	defer rows.Close()
+	var splitPoints []interface{}
+	for rows.Next() {
+		var p interface{}
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		splitPoints = append(splitPoints, p)
+	}
+	return splitPoints, rows.Err()
+}
+
+// KeyRangeCondition returns a SQL condition restricting col to kr, together
+// with its arguments, or an empty string if kr is unbounded. placeholder
+// returns the source's bind parameter syntax for the i'th (1-based) argument.
+func KeyRangeCondition(col string, kr internal.KeyRange, placeholder func(i int) string) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if kr.Start != nil {
+		args = append(args, kr.Start)
+		conds = append(conds, fmt.Sprintf("%s >= %s", col, placeholder(len(args))))
+	}
+	if kr.End != nil {
+		args = append(args, kr.End)
+		conds = append(conds, fmt.Sprintf("%s < %s", col, placeholder(len(args))))
+	}
+	return strings.Join(conds, " AND "), args
 }
 
 // SetRowStats populates conv with the number of rows in each table.
@@ -211,22 +512,30 @@ func (is *InfoSchemaImpl) ProcessTable(conv *internal.Conv, table SchemaAndName,
 		return t, fmt.Errorf("couldn't get indexes for table %s.%s: %s", table.Schema, table.Name, err)
 	}
 
+	var rowDeletionPolicy *schema.RowDeletionPolicy
+	if rdpReader, ok := infoSchema.(RowDeletionPolicyReader); ok {
+		rowDeletionPolicy, err = rdpReader.GetRowDeletionPolicy(conv, table, colNameIdMap)
+		if err != nil {
+			return t, fmt.Errorf("couldn't get row deletion policy for table %s.%s: %s", table.Schema, table.Name, err)
+		}
+	}
 	name := infoSchema.GetTableName(table.Schema, table.Name)
 	var schemaPKeys []schema.Key
 	for _, k := range primaryKeys {
 		schemaPKeys = append(schemaPKeys, schema.Key{ColId: colNameIdMap[k]})
 	}
 	t = schema.Table{
-		Id:               tblId,
-		Name:             name,
-		Schema:           table.Schema,
-		ColIds:           colIds,
-		ColNameIdMap:     colNameIdMap,
-		ColDefs:          colDefs,
-		PrimaryKeys:      schemaPKeys,
-		CheckConstraints: checkConstraints,
-		Indexes:          indexes,
-		ForeignKeys:      foreignKeys}
+		Id:                tblId,
+		Name:              name,
+		Schema:            table.Schema,
+		ColIds:            colIds,
+		ColNameIdMap:      colNameIdMap,
+		ColDefs:           colDefs,
+		PrimaryKeys:       schemaPKeys,
+		CheckConstraints:  checkConstraints,
+		Indexes:           indexes,
+		ForeignKeys:       foreignKeys,
+		RowDeletionPolicy: rowDeletionPolicy}
 	return t, nil
 }
 
@@ -15,8 +15,15 @@
 package common
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/writer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,3 +52,134 @@ func TestSanitizeDefaultValue(t *testing.T) {
 		assert.Equal(t, test.expectedString, result)
 	}
 }
+
+func TestBuildKeyRanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		splitPoints []interface{}
+		expected    []internal.KeyRange
+	}{
+		{"no split points", nil, []internal.KeyRange{{}}},
+		{"single split point", []interface{}{int64(10)}, []internal.KeyRange{{End: int64(10)}, {Start: int64(10)}}},
+		{
+			"repeated split points",
+			[]interface{}{[]byte("a"), []byte("a"), []byte("c")},
+			[]internal.KeyRange{{End: []byte("a")}, {Start: []byte("a"), End: []byte("c")}, {Start: []byte("c")}},
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, BuildKeyRanges(tc.splitPoints), tc.name)
+	}
+}
+
+func TestKeyRangeCondition(t *testing.T) {
+	placeholder := func(i int) string { return fmt.Sprintf("$%d", i) }
+	tests := []struct {
+		name         string
+		kr           internal.KeyRange
+		expectedCond string
+		expectedArgs []interface{}
+	}{
+		{"unbounded", internal.KeyRange{}, "", nil},
+		{"start only", internal.KeyRange{Start: 5}, `"id" >= $1`, []interface{}{5}},
+		{"end only", internal.KeyRange{End: 9}, `"id" < $1`, []interface{}{9}},
+		{"start and end", internal.KeyRange{Start: 5, End: 9}, `"id" >= $1 AND "id" < $2`, []interface{}{5, 9}},
+	}
+	for _, tc := range tests {
+		cond, args := KeyRangeCondition(`"id"`, tc.kr, placeholder)
+		assert.Equal(t, tc.expectedCond, cond, tc.name)
+		assert.Equal(t, tc.expectedArgs, args, tc.name)
+	}
+}
+
+func TestGetKeyRangeColumn(t *testing.T) {
+	srcTable := schema.Table{
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "a", Id: "c1"},
+			"c2": {Name: "b", Id: "c2"},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c2"}, {ColId: "c1"}},
+	}
+	assert.Equal(t, "b", GetKeyRangeColumn(srcTable))
+	assert.Equal(t, "", GetKeyRangeColumn(schema.Table{}))
+}
+
+// dataInfoSchema is an InfoSchema whose tables hold rows, keyed by table id.
+type dataInfoSchema struct {
+	InfoSchema
+	rows map[string][][]interface{}
+}
+
+func (dis dataInfoSchema) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, spCols []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
+	var cols []string
+	for _, colId := range spCols {
+		cols = append(cols, spSchema.ColDefs[colId].Name)
+	}
+	for _, vals := range dis.rows[tableId] {
+		conv.WriteRow(srcSchema.Name, spSchema.Name, cols, vals)
+	}
+	return nil
+}
+
+func TestProcessDataBatchWrite(t *testing.T) {
+	conv := internal.MakeConv()
+	table := func(id, name, parentId string, cols ...string) {
+		ct := ddl.CreateTable{Name: name, Id: id, ColDefs: map[string]ddl.ColumnDef{}, ParentTable: ddl.InterleavedParent{Id: parentId}}
+		st := schema.Table{Name: name, Id: id, ColDefs: map[string]schema.Column{}}
+		for _, c := range cols {
+			colId := id + c
+			ct.ColIds = append(ct.ColIds, colId)
+			ct.ColDefs[colId] = ddl.ColumnDef{Name: c, Id: colId, T: ddl.Type{Name: ddl.Int64}}
+			st.ColIds = append(st.ColIds, colId)
+			st.ColDefs[colId] = schema.Column{Name: c, Id: colId}
+		}
+		ct.PrimaryKeys = []ddl.IndexKey{{ColId: id + cols[0], Order: 1}}
+		conv.SpSchema[id] = ct
+		conv.SrcSchema[id] = st
+	}
+	// Sorted parents first, the tables are singers, venues, albums and
+	// concerts, so the children aren't next to their parents.
+	table("t1", "singers", "", "id")
+	table("t2", "albums", "t1", "id", "album_id")
+	table("t3", "venues", "", "id")
+	table("t4", "concerts", "t3", "id", "concert_id")
+	conv.Audit.BatchWrite = true
+
+	var mu sync.Mutex
+	var groups [][]*sp.Mutation
+	bw := writer.NewBatchWriter(writer.BatchWriterConfig{
+		BytesLimit: 1 << 20,
+		WriteLimit: 1,
+		RetryLimit: 10,
+		BatchWrite: func(mgs [][]*sp.Mutation) []error {
+			mu.Lock()
+			defer mu.Unlock()
+			groups = append(groups, mgs...)
+			return make([]error, len(mgs))
+		},
+		ParentTable: writer.InterleaveParents(conv),
+		KeyColumns:  writer.PrimaryKeyColumns(conv),
+	})
+	conv.SetDataMode()
+	conv.SetDataSink(bw.AddRow)
+	conv.DataFlush = bw.Flush
+
+	is := &InfoSchemaImpl{}
+	is.ProcessData(conv, dataInfoSchema{rows: map[string][][]interface{}{
+		"t1": {{int64(1)}, {int64(2)}},
+		"t2": {{int64(1), int64(10)}},
+		"t3": {{int64(3)}},
+		"t4": {{int64(3), int64(30)}, {int64(3), int64(31)}},
+	}}, internal.AdditionalDataAttributes{})
+
+	// Each parent row is written in one mutation group with its children.
+	singer := func(id int64) *sp.Mutation { return sp.Insert("singers", []string{"id"}, []interface{}{id}) }
+	child := func(table, col string, id, childId int64) *sp.Mutation {
+		return sp.Insert(table, []string{"id", col}, []interface{}{id, childId})
+	}
+	assert.ElementsMatch(t, [][]*sp.Mutation{
+		{singer(1), child("albums", "album_id", 1, 10)},
+		{singer(2)},
+		{sp.Insert("venues", []string{"id"}, []interface{}{int64(3)}), child("concerts", "concert_id", 3, 30), child("concerts", "concert_id", 3, 31)},
+	}, groups)
+}
@@ -33,6 +33,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -105,6 +108,17 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDL(conv *internal.Conv, toddl ToD
 			return err
 		}
 		spannerSchemaApplyExpressions(conv, expressions)
+	} else if ss.DdlV != nil && conv.SpProjectId != "" && conv.SpInstanceId != "" {
+		// Other sources only carry generated columns over to the Spanner
+		// schema, so only those expressions are verified.
+		expressionDetails := ss.DdlV.GetSpannerExpressionDetails(conv, tableIds)
+		if len(expressionDetails) > 0 {
+			expressions, err := ss.DdlV.VerifySpannerDDL(conv, expressionDetails)
+			if err != nil && !strings.Contains(err.Error(), "expressions either failed verification") {
+				return err
+			}
+			spannerSchemaApplyExpressions(conv, expressions)
+		}
 	}
 
 	if (conv.Source == constants.MYSQL || conv.Source == constants.MYSQLDUMP) && conv.SpProjectId != "" && conv.SpInstanceId != "" {
@@ -117,10 +131,107 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDL(conv *internal.Conv, toddl ToD
 		}
 	}
 
+	if err := ss.schemaToSpannerViews(conv); err != nil {
+		return err
+	}
+
 	internal.ResolveRefs(conv)
 	return nil
 }
 
+// schemaToSpannerViews converts the source views in conv.SrcViews to Spanner
+// views in conv.SpViews. Views are migrated as SQL SECURITY INVOKER views,
+// whose queries run with the privileges of the user querying them. A view
+// is only migrated if its query verifies against the Spanner schema and the
+// views it refers to are migrated too. The other views are recorded in
+// conv.InvalidViews, along with the reason they weren't migrated.
+func (ss *SchemaToSpannerImpl) schemaToSpannerViews(conv *internal.Conv) error {
+	if len(conv.SrcViews) == 0 {
+		return nil
+	}
+	// Maps the names of source tables and views to their ids.
+	srcIds := make(map[string]string)
+	for _, srcTable := range conv.SrcSchema {
+		srcIds[srcTable.Name] = srcTable.Id
+	}
+	var viewIds []string
+	for _, srcView := range conv.SrcViews {
+		srcIds[srcView.Name] = srcView.Id
+		viewIds = append(viewIds, srcView.Id)
+	}
+	sort.Slice(viewIds, func(i, j int) bool {
+		return conv.SrcViews[viewIds[i]].Name < conv.SrcViews[viewIds[j]].Name
+	})
+	spViews := make(map[string]ddl.CreateView)
+	for _, viewId := range viewIds {
+		srcView := conv.SrcViews[viewId]
+		var refIds []string
+		for _, ref := range srcView.Refs {
+			if refId, ok := srcIds[ref]; ok {
+				refIds = append(refIds, refId)
+			}
+		}
+		spViewName, spSchemaName := internal.GetSpannerView(conv, viewId)
+		spViews[viewId] = ddl.CreateView{
+			Name:        spViewName,
+			SchemaName:  spSchemaName,
+			SqlSecurity: ddl.SqlSecurityInvoker,
+			Query:       srcView.Query,
+			RefIds:      refIds,
+			Id:          viewId,
+		}
+	}
+	conv.SpViews = spViews
+	conv.InvalidViews = make(map[string]string)
+	if ss.DdlV == nil || conv.SpProjectId == "" || conv.SpInstanceId == "" {
+		// Spanner rejects the whole schema if a view's query is invalid, so
+		// views that can't be verified aren't migrated.
+		for _, viewId := range viewIds {
+			conv.InvalidViews[viewId] = "the query of the view couldn't be verified without a Spanner instance"
+		}
+		conv.SpViews = make(map[string]ddl.CreateView)
+		return nil
+	}
+	var verifyIds []string
+	for _, viewId := range viewIds {
+		if conv.SpViews[viewId].Query == "" {
+			delete(conv.SpViews, viewId)
+			conv.InvalidViews[viewId] = "the definition of the view couldn't be read"
+			continue
+		}
+		verifyIds = append(verifyIds, viewId)
+	}
+	expressionDetails := ss.DdlV.GetViewExpressionDetails(conv, verifyIds)
+	expressions, err := ss.DdlV.VerifySpannerDDL(conv, expressionDetails)
+	if err != nil && !strings.Contains(err.Error(), "expressions either failed verification") {
+		return err
+	}
+	spannerSchemaApplyExpressions(conv, expressions)
+	// Views that refer to views that weren't migrated can't be migrated
+	// either. We might need multiple iterations for chains of views.
+	for removed := true; removed; {
+		removed = false
+		for _, viewId := range viewIds {
+			spView, ok := conv.SpViews[viewId]
+			if !ok {
+				continue
+			}
+			for _, refId := range spView.RefIds {
+				if _, isView := conv.SrcViews[refId]; !isView {
+					continue
+				}
+				if _, ok := conv.SpViews[refId]; !ok {
+					delete(conv.SpViews, viewId)
+					conv.InvalidViews[viewId] = fmt.Sprintf("the view refers to view %s, which wasn't migrated", conv.SrcViews[refId].Name)
+					removed = true
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // GenerateExpressionDetailList it will generate the expression detail list which is used in verify expression method as a input
 func GenerateExpressionDetailList(spschema ddl.Schema) []internal.ExpressionDetail {
 	expressionDetailList := []internal.ExpressionDetail{}
@@ -331,6 +442,7 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 		conv.Unexpected(fmt.Sprintf("Couldn't map source table %s to Spanner: %s", srcTable.Name, err))
 		return err
 	}
+	spSchemaName := internal.GetSpannerSchemaName(conv, srcTable.Id)
 	var spColIds []string
 	spColDef := make(map[string]ddl.ColumnDef)
 
@@ -346,7 +458,7 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 		conv.ToSpanner = make(map[string]internal.NameAndCols)
 	}
 	conv.ToSpanner[srcTable.Name] = internal.NameAndCols{
-		Name: spTableName,
+		Name: ddl.QualifiedName(spSchemaName, spTableName),
 		Cols: make(map[string]string),
 	}
 
@@ -417,6 +529,9 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 			Comment: "From: " + quoteIfNeeded(srcCol.Name) + " " + srcCol.Type.Print(),
 			Id:      srcColId,
 			AutoGen: *autoGenCol,
+			// Spanner only supports stored generated columns, so virtual
+			// generated columns are migrated as stored ones.
+			Generated: srcCol.Generated,
 		}
 		// Initialise Opts only for Cassandra source
 		if conv.Source == constants.CASSANDRA {
@@ -434,6 +549,14 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 			totalNonKeyColumnSize += getColumnSize(ty.Name, ty.Len)
 		}
 	}
+	spColIds, srcIndexes, nullFiltered := cvtIndexExpressions(conv, srcTable, spColIds, spColDef, columnLevelIssues)
+	spIndexes := cvtIndexes(conv, srcTable.Id, srcIndexes, spColIds, spColDef)
+	for i := range spIndexes {
+		spIndexes[i].NullFiltered = nullFiltered[spIndexes[i].Id]
+		spIndexes[i].SchemaName = spSchemaName
+	}
+	spColIds, searchIndexes := cvtSearchIndexes(conv, srcTable, spColIds, spColDef, columnLevelIssues)
+	spColIds, rowDeletionPolicy := cvtRowDeletionPolicy(conv, srcTable, spColIds, spColDef, columnLevelIssues)
 	if totalNonKeyColumnSize > ddl.MaxNonKeyColumnLength {
 		tableLevelIssues = append(tableLevelIssues, internal.RowLimitExceeded)
 	}
@@ -443,24 +566,33 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerDDLHelper(conv *internal.Conv, tod
 	}
 	comment := "Spanner schema for source table " + quoteIfNeeded(srcTable.Name)
 	conv.SpSchema[srcTable.Id] = ddl.CreateTable{
-		Name:             spTableName,
-		ColIds:           spColIds,
-		ColDefs:          spColDef,
-		PrimaryKeys:      cvtPrimaryKeys(srcTable.PrimaryKeys),
-		ForeignKeys:      cvtForeignKeys(conv, spTableName, srcTable.Id, srcTable.ForeignKeys, isRestore),
-		CheckConstraints: cvtCheckConstraint(conv, srcTable.CheckConstraints),
-		Indexes:          cvtIndexes(conv, srcTable.Id, srcTable.Indexes, spColIds, spColDef),
-		Comment:          comment,
-		Id:               srcTable.Id,
+		Name:              spTableName,
+		SchemaName:        spSchemaName,
+		ColIds:            spColIds,
+		ColDefs:           spColDef,
+		PrimaryKeys:       cvtPrimaryKeys(srcTable.PrimaryKeys),
+		ForeignKeys:       cvtForeignKeys(conv, spTableName, srcTable.Id, srcTable.ForeignKeys, isRestore),
+		CheckConstraints:  cvtCheckConstraint(conv, srcTable.CheckConstraints),
+		Indexes:           spIndexes,
+		SearchIndexes:     searchIndexes,
+		VectorIndexes:     cvtVectorIndexes(conv, srcTable, spColDef),
+		RowDeletionPolicy: rowDeletionPolicy,
+		Comment:           comment,
+		Id:                srcTable.Id,
 	}
 	return nil
 }
 
 func (ss *SchemaToSpannerImpl) SchemaToSpannerSequenceHelper(conv *internal.Conv, srcSequence ddl.Sequence) error {
+	schemaName := ""
+	if conv.NamedSchemas {
+		schemaName = srcSequence.SchemaName
+	}
 	switch srcSequence.SequenceKind {
 	case constants.AUTO_INCREMENT:
 		spSequence := ddl.Sequence{
 			Name:             srcSequence.Name,
+			SchemaName:       schemaName,
 			Id:               srcSequence.Id,
 			SequenceKind:     "BIT REVERSED POSITIVE",
 			SkipRangeMin:     srcSequence.SkipRangeMin,
@@ -471,6 +603,7 @@ func (ss *SchemaToSpannerImpl) SchemaToSpannerSequenceHelper(conv *internal.Conv
 	default:
 		spSequence := ddl.Sequence{
 			Name:             srcSequence.Name,
+			SchemaName:       schemaName,
 			Id:               srcSequence.Id,
 			SequenceKind:     "BIT REVERSED POSITIVE",
 			SkipRangeMin:     srcSequence.SkipRangeMin,
@@ -562,6 +695,9 @@ func CvtForeignKeysHelper(conv *internal.Conv, spTableName string, srcTableId st
 		Id:             srcKey.Id,
 		OnDelete:       spDeleteRule,
 		OnUpdate:       spUpdateRule,
+		// The referenced table may not have been converted yet, so its
+		// schema is looked up from the source table.
+		ReferSchemaName: internal.GetSpannerSchemaName(conv, srcKey.ReferTableId),
 	}
 	return spKey, nil
 }
@@ -569,6 +705,11 @@ func CvtForeignKeysHelper(conv *internal.Conv, spTableName string, srcTableId st
 func cvtIndexes(conv *internal.Conv, tableId string, srcIndexes []schema.Index, spColIds []string, spColDef map[string]ddl.ColumnDef) []ddl.CreateIndex {
 	var spIndexes []ddl.CreateIndex
 	for _, srcIndex := range srcIndexes {
+		// Full-text indexes are converted to search indexes, and vector
+		// indexes to vector indexes.
+		if srcIndex.FullText || srcIndex.VectorDistanceType != "" {
+			continue
+		}
 		spIndex := CvtIndexHelper(conv, tableId, srcIndex, spColIds, spColDef)
 		if (!reflect.DeepEqual(spIndex, ddl.CreateIndex{})) {
 			spIndexes = append(spIndexes, spIndex)
@@ -577,6 +718,348 @@ func cvtIndexes(conv *internal.Conv, tableId string, srcIndexes []schema.Index,
 	return spIndexes
 }
 
+// cvtIndexExpressions rewrites the partial indexes and expression indexes of
+// srcTable into indexes that Spanner supports. Each index expression is
+// stored in a generated column that is added to the table and indexed
+// instead, and shared by the indexes on that expression. Partial indexes
+// whose predicate filters out the rows in which key columns are NULL become
+// NULL_FILTERED indexes. Indexes that can't be rewritten are recorded in
+// conv.InvalidIndexes: partial indexes that aren't unique lose their
+// predicate, and the others aren't migrated. It returns the column ids with
+// the added columns, the rewritten indexes, and the ids of the indexes that
+// are NULL_FILTERED.
+func cvtIndexExpressions(conv *internal.Conv, srcTable schema.Table, spColIds []string, spColDef map[string]ddl.ColumnDef, columnLevelIssues map[string][]internal.SchemaIssue) ([]string, []schema.Index, map[string]bool) {
+	var invalidIndexes []internal.InvalidIndex
+	var srcIndexes []schema.Index
+	nullFiltered := make(map[string]bool)
+	suffix := "_Expr"
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		suffix = "_expr"
+	}
+	colNameIdMap := make(map[string]string)
+	for id, col := range srcTable.ColDefs {
+		colNameIdMap[col.Name] = id
+	}
+	exprColIds := make(map[string]string)
+	for _, srcIndex := range srcTable.Indexes {
+		if srcIndex.FullText || srcIndex.VectorDistanceType != "" {
+			srcIndexes = append(srcIndexes, srcIndex)
+			continue
+		}
+		keys := make([]schema.Key, len(srcIndex.Keys))
+		copy(keys, srcIndex.Keys)
+		var invalidExpression string
+		for i, k := range keys {
+			if k.Expression == "" {
+				continue
+			}
+			ty, ok := indexExpressionType(k.Expression)
+			if !ok {
+				invalidExpression = k.Expression
+				break
+			}
+			exprColId, ok := exprColIds[k.Expression]
+			if !ok {
+				exprColId = internal.GenerateColumnId()
+				name, _ := internal.FixName(srcIndex.Name + suffix)
+				spColDef[exprColId] = ddl.ColumnDef{
+					Name: uniqueColumnName(spColDef, name),
+					T:    ty,
+					Id:   exprColId,
+					Generated: ddl.GeneratedColumn{
+						IsPresent: true,
+						Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: k.Expression},
+					},
+				}
+				spColIds = append(spColIds, exprColId)
+				columnLevelIssues[exprColId] = []internal.SchemaIssue{internal.IndexExpression}
+				exprColIds[k.Expression] = exprColId
+			}
+			keys[i] = schema.Key{ColId: exprColId, Desc: k.Desc, Order: k.Order}
+		}
+		if invalidExpression != "" {
+			invalidIndexes = append(invalidIndexes, internal.InvalidIndex{IssueType: internal.IndexNotMigrated, IndexName: srcIndex.Name, Expression: invalidExpression})
+			continue
+		}
+		srcIndex.Keys = keys
+		if srcIndex.Predicate != "" {
+			if isNullFilter(srcIndex.Predicate, srcIndex.Keys, colNameIdMap) {
+				nullFiltered[srcIndex.Id] = true
+			} else if srcIndex.Unique {
+				// Without its predicate, a unique index would reject rows
+				// that the source database accepts.
+				invalidIndexes = append(invalidIndexes, internal.InvalidIndex{IssueType: internal.IndexNotMigrated, IndexName: srcIndex.Name, Expression: srcIndex.Predicate})
+				continue
+			} else {
+				invalidIndexes = append(invalidIndexes, internal.InvalidIndex{IssueType: internal.IndexPredicateDropped, IndexName: srcIndex.Name, Expression: srcIndex.Predicate})
+			}
+		}
+		srcIndexes = append(srcIndexes, srcIndex)
+	}
+	if len(invalidIndexes) > 0 {
+		if conv.InvalidIndexes == nil {
+			conv.InvalidIndexes = make(map[string][]internal.InvalidIndex)
+		}
+		conv.InvalidIndexes[srcTable.Id] = invalidIndexes
+	} else if conv.InvalidIndexes != nil {
+		delete(conv.InvalidIndexes, srcTable.Id)
+	}
+	return spColIds, srcIndexes, nullFiltered
+}
+
+var (
+	notNullConditionRegex = regexp.MustCompile(`(?i)^\(*\s*([^\s()]+)\s+IS\s+NOT\s+NULL\s*\)*$`)
+	andRegex              = regexp.MustCompile(`(?i)\s+AND\s+`)
+)
+
+// isNullFilter returns whether predicate is a conjunction of IS NOT NULL
+// conditions on exactly the key columns of an index, such as
+// (a IS NOT NULL) AND (b IS NOT NULL), which makes the index equivalent to
+// a NULL_FILTERED index.
+func isNullFilter(predicate string, keys []schema.Key, colNameIdMap map[string]string) bool {
+	notNull := make(map[string]bool)
+	for _, cond := range andRegex.Split(strings.TrimSpace(predicate), -1) {
+		m := notNullConditionRegex.FindStringSubmatch(strings.TrimSpace(cond))
+		if m == nil {
+			return false
+		}
+		colId, ok := colNameIdMap[strings.Trim(m[1], "\"`[]")]
+		if !ok {
+			return false
+		}
+		notNull[colId] = true
+	}
+	if len(notNull) != len(keys) {
+		return false
+	}
+	for _, k := range keys {
+		if !notNull[k.ColId] {
+			return false
+		}
+	}
+	return true
+}
+
+// indexExpressionFunctionTypes are the types of the functions whose results
+// can be stored in generated columns to index them. Spanner and the source
+// databases share these functions.
+var indexExpressionFunctionTypes = map[string]ddl.Type{
+	"lower":  {Name: ddl.String, Len: ddl.MaxLength},
+	"upper":  {Name: ddl.String, Len: ddl.MaxLength},
+	"trim":   {Name: ddl.String, Len: ddl.MaxLength},
+	"ltrim":  {Name: ddl.String, Len: ddl.MaxLength},
+	"rtrim":  {Name: ddl.String, Len: ddl.MaxLength},
+	"substr": {Name: ddl.String, Len: ddl.MaxLength},
+	"concat": {Name: ddl.String, Len: ddl.MaxLength},
+	"date":   {Name: ddl.Date},
+}
+
+// indexExpressionType returns the Spanner type of an index expression, which
+// must be a call to one of indexExpressionFunctionTypes, and whether the type
+// is known.
+func indexExpressionType(expr string) (ddl.Type, bool) {
+	expr = strings.TrimSpace(expr)
+	open := strings.Index(expr, "(")
+	if open <= 0 || !strings.HasSuffix(expr, ")") {
+		return ddl.Type{}, false
+	}
+	ty, ok := indexExpressionFunctionTypes[strings.ToLower(strings.TrimSpace(expr[:open]))]
+	if !ok {
+		return ddl.Type{}, false
+	}
+	// The call must be the whole expression, as in lower(a), rather than
+	// the start of it, as in lower(a) || b.
+	depth := 0
+	for i, r := range expr[open:] {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && open+i != len(expr)-1 {
+				return ddl.Type{}, false
+			}
+		}
+	}
+	return ty, true
+}
+
+// dropIndexExpressionColumn drops a column that cvtIndexExpressions added to
+// index an expression, and the indexes on it, which are recorded in
+// conv.InvalidIndexes.
+func dropIndexExpressionColumn(conv *internal.Conv, tableId, colId string) {
+	spTable := conv.SpSchema[tableId]
+	expr := spTable.ColDefs[colId].Generated.Value.Statement
+	var indexes []ddl.CreateIndex
+	for _, idx := range spTable.Indexes {
+		used := false
+		for _, k := range idx.Keys {
+			used = used || k.ColId == colId
+		}
+		if !used {
+			indexes = append(indexes, idx)
+			continue
+		}
+		if conv.InvalidIndexes == nil {
+			conv.InvalidIndexes = make(map[string][]internal.InvalidIndex)
+		}
+		conv.InvalidIndexes[tableId] = append(conv.InvalidIndexes[tableId], internal.InvalidIndex{IssueType: internal.IndexNotMigrated, IndexName: idx.Name, Expression: expr})
+	}
+	spTable.Indexes = indexes
+	var colIds []string
+	for _, id := range spTable.ColIds {
+		if id != colId {
+			colIds = append(colIds, id)
+		}
+	}
+	spTable.ColIds = colIds
+	delete(spTable.ColDefs, colId)
+	conv.SpSchema[tableId] = spTable
+	delete(conv.SchemaIssues[tableId].ColumnLevelIssues, colId)
+}
+
+// cvtVectorIndexes converts the vector indexes of srcTable to Spanner vector
+// indexes. Spanner vector indexes are built on a single array column whose
+// vector length is set, so the indexes on other columns are dropped.
+func cvtVectorIndexes(conv *internal.Conv, srcTable schema.Table, spColDef map[string]ddl.ColumnDef) []ddl.CreateVectorIndex {
+	var spIndexes []ddl.CreateVectorIndex
+	for _, srcIndex := range srcTable.Indexes {
+		if srcIndex.VectorDistanceType == "" {
+			continue
+		}
+		if len(srcIndex.Keys) != 1 {
+			conv.Unexpected(fmt.Sprintf("Can't map vector index %s of tableId %s: vector indexes have a single key column", srcIndex.Name, srcTable.Id))
+			continue
+		}
+		spCol, ok := spColDef[srcIndex.Keys[0].ColId]
+		if !ok || !spCol.T.IsArray || spCol.T.VectorLength <= 0 {
+			conv.Unexpected(fmt.Sprintf("Can't map vector index key column for tableId %s columnId %s", srcTable.Id, srcIndex.Keys[0].ColId))
+			continue
+		}
+		spIndexes = append(spIndexes, ddl.CreateVectorIndex{
+			Name:         internal.ToSpannerIndexName(conv, srcIndex.Name),
+			TableId:      srcTable.Id,
+			Keys:         []ddl.IndexKey{{ColId: spCol.Id, Order: 1}},
+			DistanceType: srcIndex.VectorDistanceType,
+			Id:           srcIndex.Id,
+		})
+	}
+	return spIndexes
+}
+
+// cvtSearchIndexes converts the full-text indexes of srcTable to search
+// indexes. Search indexes are built on TOKENLIST columns, so a hidden column
+// that tokenizes each indexed column is added to the table, and shared by
+// the indexes on that column. It returns the column ids with the added
+// columns.
+func cvtSearchIndexes(conv *internal.Conv, srcTable schema.Table, spColIds []string, spColDef map[string]ddl.ColumnDef, columnLevelIssues map[string][]internal.SchemaIssue) ([]string, []ddl.CreateSearchIndex) {
+	var spIndexes []ddl.CreateSearchIndex
+	tokenize, suffix := "TOKENIZE_FULLTEXT", "_Tokens"
+	if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+		tokenize, suffix = "spanner.tokenize_fulltext", "_tokens"
+	}
+	config := ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect, Source: conv.Source}
+	tokenColIds := make(map[string]string)
+	for _, srcIndex := range srcTable.Indexes {
+		if !srcIndex.FullText {
+			continue
+		}
+		var spKeys []ddl.IndexKey
+		for _, k := range srcIndex.Keys {
+			spCol, ok := spColDef[k.ColId]
+			if !ok || spCol.T.Name != ddl.String {
+				conv.Unexpected(fmt.Sprintf("Can't map search index key column for tableId %s columnId %s", srcTable.Id, k.ColId))
+				continue
+			}
+			tokenColId, ok := tokenColIds[k.ColId]
+			if !ok {
+				tokenColId = internal.GenerateColumnId()
+				spColDef[tokenColId] = ddl.ColumnDef{
+					Name: uniqueColumnName(spColDef, spCol.Name+suffix),
+					T:    ddl.Type{Name: ddl.TokenList},
+					Id:   tokenColId,
+					Generated: ddl.GeneratedColumn{
+						IsPresent: true,
+						Value:     ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: fmt.Sprintf("%s(%s)", tokenize, config.QuoteIdentifier(spCol.Name))},
+						Virtual:   true,
+					},
+					Hidden: true,
+				}
+				spColIds = append(spColIds, tokenColId)
+				columnLevelIssues[tokenColId] = []internal.SchemaIssue{internal.FullTextIndex}
+				tokenColIds[k.ColId] = tokenColId
+			}
+			spKeys = append(spKeys, ddl.IndexKey{ColId: tokenColId, Order: len(spKeys) + 1})
+		}
+		if len(spKeys) == 0 {
+			continue
+		}
+		if srcIndex.Name == "" {
+			srcIndex.Name = fmt.Sprintf("SearchIndex_%s", srcTable.Name)
+		}
+		spIndexes = append(spIndexes, ddl.CreateSearchIndex{
+			Name:    internal.ToSpannerIndexName(conv, srcIndex.Name),
+			TableId: srcTable.Id,
+			Keys:    spKeys,
+			Id:      srcIndex.Id,
+		})
+	}
+	return spColIds, spIndexes
+}
+
+// cvtRowDeletionPolicy converts the row deletion policy of srcTable. Spanner
+// deletes rows once a timestamp column is older than a whole number of days,
+// so the expiry of the source is rounded up to whole days. Tables whose rows
+// expire some time after they were last written get an added column that
+// holds that time, which is filled in when the data is migrated and defaults
+// to the commit time for rows inserted afterwards.
+func cvtRowDeletionPolicy(conv *internal.Conv, srcTable schema.Table, spColIds []string, spColDef map[string]ddl.ColumnDef, columnLevelIssues map[string][]internal.SchemaIssue) ([]string, *ddl.RowDeletionPolicy) {
+	srcPolicy := srcTable.RowDeletionPolicy
+	if srcPolicy == nil {
+		return spColIds, nil
+	}
+	colId := srcPolicy.ColId
+	if colId == "" {
+		colId = internal.GenerateColumnId()
+		now := "CURRENT_TIMESTAMP()"
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			now = "CURRENT_TIMESTAMP"
+		}
+		spColDef[colId] = ddl.ColumnDef{
+			Name:         uniqueColumnName(spColDef, internal.RowDeletionTimeColumn),
+			T:            ddl.Type{Name: ddl.Timestamp},
+			Id:           colId,
+			DefaultValue: ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{ExpressionId: internal.GenerateExpressionId(), Statement: now}},
+		}
+		spColIds = append(spColIds, colId)
+		columnLevelIssues[colId] = append(columnLevelIssues[colId], internal.RowDeletionTimeAdded)
+	} else if t := spColDef[colId].T; t.Name != ddl.Timestamp || t.IsArray {
+		conv.Unexpected(fmt.Sprintf("Row deletion policy of table %s uses column %s, which isn't mapped to a timestamp", srcTable.Name, srcTable.ColDefs[colId].Name))
+		return spColIds, nil
+	}
+	const secondsPerDay = 24 * 60 * 60
+	days := (srcPolicy.Seconds + secondsPerDay - 1) / secondsPerDay
+	columnLevelIssues[colId] = append(columnLevelIssues[colId], internal.RowDeletionPolicy)
+	if days*secondsPerDay != srcPolicy.Seconds {
+		columnLevelIssues[colId] = append(columnLevelIssues[colId], internal.RowDeletionPolicyRounded)
+	}
+	return spColIds, &ddl.RowDeletionPolicy{ColId: colId, Days: days}
+}
+
+// uniqueColumnName returns name, with a numeric suffix if another column of
+// the table already has that name.
+func uniqueColumnName(colDefs map[string]ddl.ColumnDef, name string) string {
+	used := make(map[string]bool)
+	for _, col := range colDefs {
+		used[strings.ToLower(col.Name)] = true
+	}
+	candidate := name
+	for i := 1; used[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s_%d", name, i)
+	}
+	return candidate
+}
+
 func SrcTableToSpannerDDL(conv *internal.Conv, toddl ToDdl, srcTable schema.Table, ddlVerifier expressions_api.DDLVerifier) error {
 	schemaToSpanner := SchemaToSpannerImpl{
 		DdlV: ddlVerifier,
@@ -696,6 +1179,41 @@ func spannerSchemaApplyExpressions(conv *internal.Conv, expressions internal.Ver
 					conv.SchemaIssues[tableId].ColumnLevelIssues[columnId] = colIssues
 				}
 			}
+		case constants.GENERATED_EXPRESSION:
+			{
+				tableId := expression.ExpressionDetail.Metadata["TableId"]
+				columnId := expression.ExpressionDetail.Metadata["ColId"]
+
+				// Generated columns whose expressions can't be verified are
+				// migrated as regular columns, and their data is copied over.
+				// Columns that were added to index an expression have no
+				// data to copy, so they are dropped along with their indexes.
+				if !expression.Result {
+					if slices.Contains(conv.SchemaIssues[tableId].ColumnLevelIssues[columnId], internal.IndexExpression) {
+						dropIndexExpressionColumn(conv, tableId, columnId)
+						continue
+					}
+					col := conv.SpSchema[tableId].ColDefs[columnId]
+					col.Generated = ddl.GeneratedColumn{}
+					conv.SpSchema[tableId].ColDefs[columnId] = col
+					colIssues := conv.SchemaIssues[tableId].ColumnLevelIssues[columnId]
+					colIssues = append(colIssues, internal.GeneratedColumn)
+					conv.SchemaIssues[tableId].ColumnLevelIssues[columnId] = colIssues
+				}
+			}
+		case constants.VIEW_EXPRESSION:
+			{
+				viewId := expression.ExpressionDetail.Metadata["ViewId"]
+
+				if !expression.Result {
+					reason := "the query of the view isn't valid in Spanner"
+					if expression.Err != nil {
+						reason = fmt.Sprintf("%s: %v", reason, expression.Err)
+					}
+					delete(conv.SpViews, viewId)
+					conv.InvalidViews[viewId] = reason
+				}
+			}
 		}
 	}
 }
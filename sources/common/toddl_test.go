@@ -582,6 +582,99 @@ func TestSpannerSchemaApplyExpressions(t *testing.T) {
 					},
 				}),
 		},
+		{
+			name: "failed generated column expression",
+			conv: func() *internal.Conv {
+				conv := makeConv()
+				conv.SpSchema["table1"].ColDefs["col1"] = ddl.ColumnDef{
+					Generated: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{ExpressionId: "expr1", Statement: "col2 + 1"}},
+				}
+				return conv
+			}(),
+			expressions: internal.VerifyExpressionsOutput{
+				ExpressionVerificationOutputList: []internal.ExpressionVerificationOutput{
+					{
+						Result: false,
+						ExpressionDetail: internal.ExpressionDetail{
+							Type:         "GENERATED",
+							ExpressionId: "expr1",
+							Expression:   "col2 + 1",
+							Metadata:     map[string]string{"TableId": "table1", "ColId": "col1", "Type": "INT64"},
+						},
+					},
+				},
+			},
+			expectedConv: makeResultConv(
+				ddl.Schema{
+					"table1": {
+						ColDefs: map[string]ddl.ColumnDef{
+							"col1": {},
+						},
+					},
+				},
+				map[string]internal.TableIssues{
+					"table1": {
+						ColumnLevelIssues: map[string][]internal.SchemaIssue{
+							"col1": {internal.GeneratedColumn},
+						},
+					},
+				}),
+		},
+		{
+			name: "failed index expression",
+			conv: func() *internal.Conv {
+				conv := makeConv()
+				conv.SpSchema["table1"] = ddl.CreateTable{
+					ColIds: []string{"col1", "col2"},
+					ColDefs: map[string]ddl.ColumnDef{
+						"col1": {},
+						"col2": {Generated: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{ExpressionId: "expr1", Statement: "lower(col1)"}}},
+					},
+					Indexes: []ddl.CreateIndex{
+						{Name: "idx1", Keys: []ddl.IndexKey{{ColId: "col2", Order: 1}}},
+						{Name: "idx2", Keys: []ddl.IndexKey{{ColId: "col1", Order: 1}}},
+					},
+				}
+				conv.SchemaIssues["table1"].ColumnLevelIssues["col2"] = []internal.SchemaIssue{internal.IndexExpression}
+				return conv
+			}(),
+			expressions: internal.VerifyExpressionsOutput{
+				ExpressionVerificationOutputList: []internal.ExpressionVerificationOutput{
+					{
+						Result: false,
+						ExpressionDetail: internal.ExpressionDetail{
+							Type:         "GENERATED",
+							ExpressionId: "expr1",
+							Expression:   "lower(col1)",
+							Metadata:     map[string]string{"TableId": "table1", "ColId": "col2", "Type": "STRING"},
+						},
+					},
+				},
+			},
+			expectedConv: func() *internal.Conv {
+				conv := makeResultConv(
+					ddl.Schema{
+						"table1": {
+							ColIds: []string{"col1"},
+							ColDefs: map[string]ddl.ColumnDef{
+								"col1": {},
+							},
+							Indexes: []ddl.CreateIndex{
+								{Name: "idx2", Keys: []ddl.IndexKey{{ColId: "col1", Order: 1}}},
+							},
+						},
+					},
+					map[string]internal.TableIssues{
+						"table1": {
+							ColumnLevelIssues: map[string][]internal.SchemaIssue{},
+						},
+					})
+				conv.InvalidIndexes = map[string][]internal.InvalidIndex{
+					"table1": {{IssueType: internal.IndexNotMigrated, IndexName: "idx1", Expression: "lower(col1)"}},
+				}
+				return conv
+			}(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -758,3 +851,323 @@ func TestSchemaToSpannerDDLHelper_CassandraOpts(t *testing.T) {
 	mockToddl.AssertCalled(t, "ToSpannerType", mock.Anything, "", mock.AnythingOfType("schema.Type"), mock.AnythingOfType("bool"))
 	mockToddl.AssertCalled(t, "GetTypeOption", "uuid", expectedSpannerType)
 }
+
+func TestSchemaToSpannerDDLHelper_FullTextIndex(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.Source = constants.MYSQL
+	srcTable := schema.Table{
+		Name:   "posts",
+		Id:     "t1",
+		ColIds: []string{"id", "title", "body"},
+		ColDefs: map[string]schema.Column{
+			"id": {Name: "id", Id: "id", Type: schema.Type{Name: "varchar"}},
+			"title": {Name: "title", Id: "title", Type: schema.Type{Name: "varchar"}},
+			"body": {Name: "body", Id: "body", Type: schema.Type{Name: "text"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "id"}},
+		Indexes: []schema.Index{
+			{Name: "ft_title_body", Id: "i1", FullText: true, Keys: []schema.Key{{ColId: "title"}, {ColId: "body"}}},
+			{Name: "ft_body", Id: "i2", FullText: true, Keys: []schema.Key{{ColId: "body"}}},
+			{Name: "idx_title", Id: "i3", Keys: []schema.Key{{ColId: "title"}}},
+		},
+	}
+	conv.SrcSchema["t1"] = srcTable
+	mockToddl := new(MockOptionProvider)
+	mockToddl.On("ToSpannerType", mock.Anything, "", mock.Anything, mock.Anything).Return(ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue(nil))
+
+	ss := SchemaToSpannerImpl{}
+	assert.Nil(t, ss.SchemaToSpannerDDLHelper(conv, mockToddl, srcTable, false))
+
+	spTable := conv.SpSchema["t1"]
+	assert.Equal(t, 5, len(spTable.ColIds))
+	titleTokens, bodyTokens := spTable.ColDefs[spTable.ColIds[3]], spTable.ColDefs[spTable.ColIds[4]]
+	assert.Equal(t, "title_Tokens", titleTokens.Name)
+	assert.Equal(t, ddl.Type{Name: ddl.TokenList}, titleTokens.T)
+	assert.True(t, titleTokens.Hidden)
+	assert.True(t, titleTokens.Generated.Virtual)
+	assert.Equal(t, "TOKENIZE_FULLTEXT(`title`)", titleTokens.Generated.Value.Statement)
+	assert.Equal(t, "body_Tokens", bodyTokens.Name)
+	assert.Equal(t, []internal.SchemaIssue{internal.FullTextIndex}, conv.SchemaIssues["t1"].ColumnLevelIssues[bodyTokens.Id])
+
+	assert.Equal(t, []ddl.CreateSearchIndex{
+		{Name: "ft_title_body", TableId: "t1", Id: "i1", Keys: []ddl.IndexKey{{ColId: titleTokens.Id, Order: 1}, {ColId: bodyTokens.Id, Order: 2}}},
+		{Name: "ft_body", TableId: "t1", Id: "i2", Keys: []ddl.IndexKey{{ColId: bodyTokens.Id, Order: 1}}},
+	}, spTable.SearchIndexes)
+	assert.Equal(t, 1, len(spTable.Indexes))
+	assert.Equal(t, "idx_title", spTable.Indexes[0].Name)
+}
+
+func TestSchemaToSpannerDDLHelper_VectorIndex(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.Source = constants.POSTGRES
+	srcTable := schema.Table{
+		Name:   "items",
+		Id:     "t1",
+		ColIds: []string{"id", "embedding", "name"},
+		ColDefs: map[string]schema.Column{
+			"id":        {Name: "id", Id: "id", Type: schema.Type{Name: "bigint"}},
+			"embedding": {Name: "embedding", Id: "embedding", Type: schema.Type{Name: "vector", Mods: []int64{3}}},
+			"name":      {Name: "name", Id: "name", Type: schema.Type{Name: "text"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "id"}},
+		Indexes: []schema.Index{
+			{Name: "idx_embedding", Id: "i1", VectorDistanceType: ddl.CosineDistance, Keys: []schema.Key{{ColId: "embedding"}}},
+			{Name: "idx_name", Id: "i2", VectorDistanceType: ddl.CosineDistance, Keys: []schema.Key{{ColId: "name"}}},
+		},
+	}
+	conv.SrcSchema["t1"] = srcTable
+	mockToddl := new(MockOptionProvider)
+	mockToddl.On("ToSpannerType", mock.Anything, "", srcTable.ColDefs["id"].Type, mock.Anything).Return(ddl.Type{Name: ddl.Int64}, []internal.SchemaIssue(nil))
+	mockToddl.On("ToSpannerType", mock.Anything, "", srcTable.ColDefs["embedding"].Type, mock.Anything).Return(ddl.Type{Name: ddl.Float32, IsArray: true, VectorLength: 3}, []internal.SchemaIssue(nil))
+	mockToddl.On("ToSpannerType", mock.Anything, "", srcTable.ColDefs["name"].Type, mock.Anything).Return(ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue(nil))
+
+	ss := SchemaToSpannerImpl{}
+	assert.Nil(t, ss.SchemaToSpannerDDLHelper(conv, mockToddl, srcTable, false))
+
+	spTable := conv.SpSchema["t1"]
+	assert.Empty(t, spTable.Indexes)
+	// idx_name is dropped, since vector indexes need a vector column.
+	assert.Equal(t, []ddl.CreateVectorIndex{
+		{Name: "idx_embedding", TableId: "t1", Id: "i1", Keys: []ddl.IndexKey{{ColId: "embedding", Order: 1}}, DistanceType: ddl.CosineDistance},
+	}, spTable.VectorIndexes)
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+}
+
+func TestSchemaToSpannerDDLHelper_PartialAndExpressionIndexes(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.Source = constants.POSTGRES
+	srcTable := schema.Table{
+		Name:   "users",
+		Id:     "t1",
+		ColIds: []string{"id", "email", "active"},
+		ColDefs: map[string]schema.Column{
+			"id":     {Name: "id", Id: "id", Type: schema.Type{Name: "bigint"}},
+			"email":  {Name: "email", Id: "email", Type: schema.Type{Name: "text"}},
+			"active": {Name: "active", Id: "active", Type: schema.Type{Name: "boolean"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "id"}},
+		Indexes: []schema.Index{
+			{Name: "idx_email", Id: "i1", Keys: []schema.Key{{ColId: "email"}}, Predicate: "email IS NOT NULL"},
+			{Name: "idx_active_email", Id: "i2", Keys: []schema.Key{{ColId: "email"}}, Predicate: "active"},
+			{Name: "idx_active_id", Id: "i3", Unique: true, Keys: []schema.Key{{ColId: "id"}}, Predicate: "active"},
+			{Name: "idx_lower_email", Id: "i4", Keys: []schema.Key{{Expression: "lower(email)", Order: 1}, {ColId: "id", Desc: true, Order: 2}}},
+			{Name: "idx_email_len", Id: "i5", Keys: []schema.Key{{Expression: "length(email)"}}},
+		},
+	}
+	conv.SrcSchema["t1"] = srcTable
+	mockToddl := new(MockOptionProvider)
+	mockToddl.On("ToSpannerType", mock.Anything, "", srcTable.ColDefs["id"].Type, mock.Anything).Return(ddl.Type{Name: ddl.Int64}, []internal.SchemaIssue(nil))
+	mockToddl.On("ToSpannerType", mock.Anything, "", srcTable.ColDefs["email"].Type, mock.Anything).Return(ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue(nil))
+	mockToddl.On("ToSpannerType", mock.Anything, "", srcTable.ColDefs["active"].Type, mock.Anything).Return(ddl.Type{Name: ddl.Bool}, []internal.SchemaIssue(nil))
+
+	ss := SchemaToSpannerImpl{}
+	assert.Nil(t, ss.SchemaToSpannerDDLHelper(conv, mockToddl, srcTable, false))
+
+	spTable := conv.SpSchema["t1"]
+	exprColId, err := internal.GetColIdFromSpName(spTable.ColDefs, "idx_lower_email_Expr")
+	assert.Nil(t, err)
+	exprCol := spTable.ColDefs[exprColId]
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, exprCol.T)
+	assert.True(t, exprCol.Generated.IsPresent)
+	assert.Equal(t, "lower(email)", exprCol.Generated.Value.Statement)
+	assert.False(t, exprCol.Generated.Virtual)
+	assert.Equal(t, []internal.SchemaIssue{internal.IndexExpression}, conv.SchemaIssues["t1"].ColumnLevelIssues[exprColId])
+
+	assert.Equal(t, 3, len(spTable.Indexes))
+	assert.Equal(t, "idx_email", spTable.Indexes[0].Name)
+	assert.True(t, spTable.Indexes[0].NullFiltered)
+	assert.Equal(t, "idx_active_email", spTable.Indexes[1].Name)
+	assert.False(t, spTable.Indexes[1].NullFiltered)
+	assert.Equal(t, "idx_lower_email", spTable.Indexes[2].Name)
+	assert.Equal(t, []ddl.IndexKey{{ColId: exprColId, Order: 1}, {ColId: "id", Desc: true, Order: 2}}, spTable.Indexes[2].Keys)
+	assert.Equal(t, []internal.InvalidIndex{
+		{IssueType: internal.IndexPredicateDropped, IndexName: "idx_active_email", Expression: "active"},
+		{IssueType: internal.IndexNotMigrated, IndexName: "idx_active_id", Expression: "active"},
+		{IssueType: internal.IndexNotMigrated, IndexName: "idx_email_len", Expression: "length(email)"},
+	}, conv.InvalidIndexes["t1"])
+}
+
+func TestSchemaToSpannerDDLHelper_NamedSchemas(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.Source = constants.POSTGRES
+	conv.NamedSchemas = true
+	orders := schema.Table{
+		Name:   "sales.orders",
+		Schema: "sales",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "bigint"}},
+			"c2": {Name: "customer_id", Id: "c2", Type: schema.Type{Name: "bigint"}},
+		},
+		PrimaryKeys: []schema.Key{{ColId: "c1"}},
+		ForeignKeys: []schema.ForeignKey{{Name: "fk_customer", Id: "f1", ColIds: []string{"c2"}, ReferTableId: "t2", ReferColumnIds: []string{"c3"}}},
+		Indexes:     []schema.Index{{Name: "idx_customer", Id: "i1", Keys: []schema.Key{{ColId: "c2"}}}},
+	}
+	customers := schema.Table{
+		Name:        "customers",
+		Schema:      "public",
+		Id:          "t2",
+		ColIds:      []string{"c3"},
+		ColDefs:     map[string]schema.Column{"c3": {Name: "id", Id: "c3", Type: schema.Type{Name: "bigint"}}},
+		PrimaryKeys: []schema.Key{{ColId: "c3"}},
+	}
+	conv.SrcSchema["t1"] = orders
+	conv.SrcSchema["t2"] = customers
+	mockToddl := new(MockOptionProvider)
+	mockToddl.On("ToSpannerType", mock.Anything, "", mock.Anything, mock.Anything).Return(ddl.Type{Name: ddl.Int64}, []internal.SchemaIssue(nil))
+
+	ss := SchemaToSpannerImpl{}
+	assert.Nil(t, ss.SchemaToSpannerDDLHelper(conv, mockToddl, orders, false))
+	assert.Nil(t, ss.SchemaToSpannerDDLHelper(conv, mockToddl, customers, false))
+
+	spOrders := conv.SpSchema["t1"]
+	assert.Equal(t, "orders", spOrders.Name)
+	assert.Equal(t, "sales", spOrders.SchemaName)
+	assert.Equal(t, "sales", spOrders.Indexes[0].SchemaName)
+	assert.Equal(t, "", spOrders.ForeignKeys[0].ReferSchemaName)
+	assert.Equal(t, "sales.orders", conv.ToSpanner["sales.orders"].Name)
+	spCustomers := conv.SpSchema["t2"]
+	assert.Equal(t, "customers", spCustomers.Name)
+	assert.Equal(t, "", spCustomers.SchemaName)
+	assert.Equal(t, "customers", conv.ToSpanner["customers"].Name)
+}
+
+func TestSchemaToSpannerViews(t *testing.T) {
+	newConv := func() *internal.Conv {
+		conv := internal.MakeConv()
+		conv.SpProjectId = "project"
+		conv.SpInstanceId = "instance"
+		conv.SrcSchema["t1"] = schema.Table{Name: "orders", Id: "t1"}
+		conv.SpSchema["t1"] = ddl.CreateTable{Name: "orders", Id: "t1"}
+		conv.UsedNames["orders"] = true
+		conv.SrcViews = map[string]schema.View{
+			"vw1": {Name: "big_orders", Id: "vw1", Query: "SELECT id FROM orders WHERE total > 100", Refs: []string{"orders"}},
+			"vw2": {Name: "big_order_ids", Id: "vw2", Query: "SELECT id FROM big_orders", Refs: []string{"big_orders"}},
+			"vw3": {Name: "order_dates", Id: "vw3", Query: "SELECT DATE_FORMAT(created, '%Y') AS y FROM orders", Refs: []string{"orders"}},
+		}
+		return conv
+	}
+	ddlV := &expressions_api.MockDDLVerifier{
+		GetViewExpressionDetailsMock: func(conv *internal.Conv, viewIds []string) []internal.ExpressionDetail {
+			var details []internal.ExpressionDetail
+			for _, viewId := range viewIds {
+				details = append(details, internal.ExpressionDetail{ExpressionId: viewId, Type: constants.VIEW_EXPRESSION, Metadata: map[string]string{"ViewId": viewId}})
+			}
+			return details
+		},
+		VerifySpannerDDLMock: func(conv *internal.Conv, expressionDetails []internal.ExpressionDetail) (internal.VerifyExpressionsOutput, error) {
+			var output internal.VerifyExpressionsOutput
+			for _, detail := range expressionDetails {
+				result := internal.ExpressionVerificationOutput{ExpressionDetail: detail, Result: detail.ExpressionId != "vw3"}
+				if !result.Result {
+					result.Err = errors.New("Function not found: DATE_FORMAT")
+				}
+				output.ExpressionVerificationOutputList = append(output.ExpressionVerificationOutputList, result)
+			}
+			return output, nil
+		},
+	}
+
+	conv := newConv()
+	ss := SchemaToSpannerImpl{DdlV: ddlV}
+	assert.Nil(t, ss.schemaToSpannerViews(conv))
+	assert.Equal(t, map[string]ddl.CreateView{
+		"vw1": {Name: "big_orders", SqlSecurity: ddl.SqlSecurityInvoker, Query: "SELECT id FROM orders WHERE total > 100", RefIds: []string{"t1"}, Id: "vw1"},
+		"vw2": {Name: "big_order_ids", SqlSecurity: ddl.SqlSecurityInvoker, Query: "SELECT id FROM big_orders", RefIds: []string{"vw1"}, Id: "vw2"},
+	}, conv.SpViews)
+	assert.Equal(t, map[string]string{"vw3": "the query of the view isn't valid in Spanner: Function not found: DATE_FORMAT"}, conv.InvalidViews)
+
+	// Views that refer to views that aren't migrated aren't migrated either.
+	conv = newConv()
+	ddlV.VerifySpannerDDLMock = func(conv *internal.Conv, expressionDetails []internal.ExpressionDetail) (internal.VerifyExpressionsOutput, error) {
+		var output internal.VerifyExpressionsOutput
+		for _, detail := range expressionDetails {
+			output.ExpressionVerificationOutputList = append(output.ExpressionVerificationOutputList, internal.ExpressionVerificationOutput{ExpressionDetail: detail, Result: detail.ExpressionId == "vw2"})
+		}
+		return output, nil
+	}
+	assert.Nil(t, ss.schemaToSpannerViews(conv))
+	assert.Empty(t, conv.SpViews)
+	assert.Equal(t, "the view refers to view big_orders, which wasn't migrated", conv.InvalidViews["vw2"])
+
+	// Views aren't migrated if they can't be verified.
+	conv = newConv()
+	conv.SpInstanceId = ""
+	assert.Nil(t, ss.schemaToSpannerViews(conv))
+	assert.Empty(t, conv.SpViews)
+	assert.Len(t, conv.InvalidViews, 3)
+}
+
+func TestIsNullFilter(t *testing.T) {
+	keys := []schema.Key{{ColId: "c1"}, {ColId: "c2"}}
+	colNameIdMap := map[string]string{"a": "c1", "b": "c2", "c": "c3"}
+	testCases := []struct {
+		predicate string
+		expected  bool
+	}{
+		{"a IS NOT NULL AND b IS NOT NULL", true},
+		{"(a IS NOT NULL) AND (b IS NOT NULL)", true},
+		{"`a` IS NOT NULL and `b` is not null", true},
+		{"a IS NOT NULL", false},
+		{"a IS NOT NULL AND c IS NOT NULL", false},
+		{"a IS NOT NULL OR b IS NOT NULL", false},
+		{"a > 0", false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isNullFilter(tc.predicate, keys, colNameIdMap), tc.predicate)
+	}
+}
+
+func TestCvtRowDeletionPolicy(t *testing.T) {
+	srcTable := schema.Table{
+		Name:   "sessions",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2"},
+		ColDefs: map[string]schema.Column{
+			"c1": {Name: "id", Id: "c1"},
+			"c2": {Name: "expires_at", Id: "c2"},
+		},
+	}
+	newColDefs := func() map[string]ddl.ColumnDef {
+		return map[string]ddl.ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+			"c2": {Name: "expires_at", Id: "c2", T: ddl.Type{Name: ddl.Timestamp}},
+		}
+	}
+
+	// Expiry on a column, rounded up to whole days.
+	conv := internal.MakeConv()
+	srcTable.RowDeletionPolicy = &schema.RowDeletionPolicy{ColId: "c2", Seconds: 36 * 60 * 60}
+	issues := map[string][]internal.SchemaIssue{}
+	colIds, rdp := cvtRowDeletionPolicy(conv, srcTable, []string{"c1", "c2"}, newColDefs(), issues)
+	assert.Equal(t, []string{"c1", "c2"}, colIds)
+	assert.Equal(t, &ddl.RowDeletionPolicy{ColId: "c2", Days: 2}, rdp)
+	assert.Equal(t, []internal.SchemaIssue{internal.RowDeletionPolicy, internal.RowDeletionPolicyRounded}, issues["c2"])
+
+	// Expiry after the last write adds a column for the time of the write.
+	srcTable.RowDeletionPolicy = &schema.RowDeletionPolicy{Seconds: 7 * 24 * 60 * 60}
+	colDefs := newColDefs()
+	issues = map[string][]internal.SchemaIssue{}
+	colIds, rdp = cvtRowDeletionPolicy(conv, srcTable, []string{"c1", "c2"}, colDefs, issues)
+	assert.Equal(t, 3, len(colIds))
+	addedCol := colDefs[colIds[2]]
+	assert.Equal(t, internal.RowDeletionTimeColumn, addedCol.Name)
+	assert.Equal(t, ddl.Type{Name: ddl.Timestamp}, addedCol.T)
+	assert.Equal(t, "CURRENT_TIMESTAMP()", addedCol.DefaultValue.Value.Statement)
+	assert.Equal(t, " DEFAULT (CURRENT_TIMESTAMP())", addedCol.DefaultValue.PrintDefaultValue(addedCol.T))
+	assert.Equal(t, &ddl.RowDeletionPolicy{ColId: addedCol.Id, Days: 7}, rdp)
+	assert.Equal(t, []internal.SchemaIssue{internal.RowDeletionTimeAdded, internal.RowDeletionPolicy}, issues[addedCol.Id])
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	colDefs = newColDefs()
+	colIds, _ = cvtRowDeletionPolicy(conv, srcTable, []string{"c1", "c2"}, colDefs, map[string][]internal.SchemaIssue{})
+	assert.Equal(t, " DEFAULT (CURRENT_TIMESTAMP)", colDefs[colIds[2]].DefaultValue.PGPrintDefaultValue(colDefs[colIds[2]].T))
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+
+	// Columns that aren't timestamps in Spanner can't be used.
+	srcTable.RowDeletionPolicy = &schema.RowDeletionPolicy{ColId: "c1", Seconds: 60}
+	colIds, rdp = cvtRowDeletionPolicy(conv, srcTable, []string{"c1", "c2"}, newColDefs(), map[string][]internal.SchemaIssue{})
+	assert.Equal(t, []string{"c1", "c2"}, colIds)
+	assert.Nil(t, rdp)
+	assert.Equal(t, int64(1), conv.Stats.Unexpected["Row deletion policy of table sessions uses column id, which isn't mapped to a timestamp"])
+}
@@ -17,6 +17,9 @@ package common
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
@@ -44,7 +47,7 @@ func ToNotNull(conv *internal.Conv, isNullable string) bool {
 // GetColsAndSchemas provides information about columns and schema for a table.
 func GetColsAndSchemas(conv *internal.Conv, tableId string) (schema.Table, string, []string, ddl.CreateTable, error) {
 	srcSchema := conv.SrcSchema[tableId]
-	spTableName, err1 := internal.GetSpannerTable(conv, tableId)
+	spTableName, err1 := internal.GetSpannerQualifiedTable(conv, tableId)
 	srcCols := []string{}
 	for _, colId := range srcSchema.ColIds {
 		srcCols = append(srcCols, srcSchema.ColDefs[colId].Name)
@@ -122,12 +125,16 @@ func IntersectionOfTwoStringSlices(a []string, b []string) []string {
 	return set
 }
 
+// GetCommonColumnIds returns the ids in colIds of the columns that exist in
+// the source table and that can be written in Spanner. Generated columns are
+// skipped: Spanner computes their values, and rejects writes to them.
 func GetCommonColumnIds(conv *internal.Conv, tableId string, colIds []string) []string {
 	srcSchema := conv.SrcSchema[tableId]
+	spSchema := conv.SpSchema[tableId]
 	var commonColIds []string
 	for i, colId := range colIds {
 		_, found := srcSchema.ColDefs[colId]
-		if found {
+		if found && !spSchema.ColDefs[colId].Generated.IsPresent {
 			commonColIds = append(commonColIds, colIds[i])
 		}
 	}
@@ -144,7 +151,7 @@ func PrepareColumns(conv *internal.Conv, tableId string, srcCols []string) ([]st
 		}
 		srcColIds = append(srcColIds, colId)
 	}
-	commonIds := IntersectionOfTwoStringSlices(spColIds, srcColIds)
+	commonIds := GetCommonColumnIds(conv, tableId, IntersectionOfTwoStringSlices(spColIds, srcColIds))
 	if len(commonIds) == 0 {
 		return []string{}, fmt.Errorf("no common columns between source and spanner table")
 	}
@@ -167,10 +174,6 @@ func PrepareValues[T interface{}](conv *internal.Conv, tableId string, colNameId
 }
 
 func ToPGDialectType(standardType ddl.Type, isPk bool) (ddl.Type, []internal.SchemaIssue) {
-	if standardType.IsArray {
-		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false},
-			[]internal.SchemaIssue{internal.ArrayTypeNotSupported}
-	}
 	if isPk && standardType.Name == ddl.Numeric {
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: false},
 			[]internal.SchemaIssue{internal.NumericPKNotSupported}
@@ -187,6 +190,74 @@ func IsPrimaryKey(colId string, table schema.Table) bool {
 	return false
 }
 
+// ParseIntervalTime parses the [-]HH:MM:SS[.fraction] time part that
+// source databases use in interval (and MySQL TIME) literals. Hours may
+// exceed 24.
+func ParseIntervalTime(val string) (time.Duration, error) {
+	neg := strings.HasPrefix(val, "-")
+	parts := strings.Split(strings.TrimLeft(val, "+-"), ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("can't convert to interval: %q is not of the form HH:MM:SS", val)
+	}
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("can't convert to interval: %w", err)
+	}
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("can't convert to interval: %w", err)
+	}
+	sec, err := time.ParseDuration(parts[2] + "s")
+	if err != nil {
+		return 0, fmt.Errorf("can't convert to interval: %w", err)
+	}
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + sec
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// FormatInterval returns the ISO 8601 form of an interval, e.g.
+// P1Y2M3DT4H5M6.5S, which is how Spanner INTERVAL values are written.
+func FormatInterval(months, days int64, d time.Duration) string {
+	var b strings.Builder
+	b.WriteString("P")
+	if months/12 != 0 {
+		fmt.Fprintf(&b, "%dY", months/12)
+	}
+	if months%12 != 0 {
+		fmt.Fprintf(&b, "%dM", months%12)
+	}
+	if days != 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if d != 0 {
+		b.WriteString("T")
+		if d/time.Hour != 0 {
+			fmt.Fprintf(&b, "%dH", d/time.Hour)
+		}
+		if d%time.Hour/time.Minute != 0 {
+			fmt.Fprintf(&b, "%dM", d%time.Hour/time.Minute)
+		}
+		if sec := d % time.Minute; sec != 0 {
+			if sec < 0 {
+				b.WriteString("-")
+				sec = -sec
+			}
+			fmt.Fprintf(&b, "%d", sec/time.Second)
+			if frac := sec % time.Second; frac != 0 {
+				b.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", frac), "0"))
+			}
+			b.WriteString("S")
+		}
+	}
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
 // Data type sizes are referred from https://cloud.google.com/spanner/docs/reference/standard-sql/data-types#storage_size_for_data_types
 var DATATYPE_TO_STORAGE_SIZE = map[string]int{
 	ddl.Bool:      1,
@@ -197,6 +268,8 @@ var DATATYPE_TO_STORAGE_SIZE = map[string]int{
 	ddl.JSON:      ddl.StringMaxLength,
 	ddl.Numeric:   22,
 	ddl.Timestamp: 12,
+	ddl.UUID:      16,
+	ddl.Interval:  16,
 }
 
 func getColumnSize(dataType string, length int64) int {
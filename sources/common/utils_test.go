@@ -17,6 +17,7 @@ package common
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -183,6 +184,34 @@ func TestPrepareColumns(t *testing.T) {
 			srcCols:        []string{"a", "b"},
 			expectedColIds: []string{"c1"},
 		},
+		{
+			name: "when a column is generated in spanner table",
+			conv: &internal.Conv{
+				SpSchema: map[string]ddl.CreateTable{
+					"t1": {
+						Name:   "t1",
+						ColIds: []string{"c1", "c2"},
+						ColDefs: map[string]ddl.ColumnDef{
+							"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+							"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Int64}, Generated: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{Statement: "a * 2"}}},
+						},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c1"}},
+					}},
+				SrcSchema: map[string]schema.Table{
+					"t1": {
+						Name:   "t1",
+						ColIds: []string{"c1", "c2"},
+						ColDefs: map[string]schema.Column{
+							"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "bigint", Mods: []int64{}}},
+							"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "bigint", Mods: []int64{}}},
+						},
+						PrimaryKeys: []schema.Key{{ColId: "c1"}},
+					}},
+			},
+			tableId:        "t1",
+			srcCols:        []string{"a", "b"},
+			expectedColIds: []string{"c1"},
+		},
 	}
 	for _, tc := range tc {
 		res, err := PrepareColumns(tc.conv, tc.tableId, tc.srcCols)
@@ -191,6 +220,33 @@ func TestPrepareColumns(t *testing.T) {
 	}
 }
 
+func TestGetCommonColumnIds(t *testing.T) {
+	conv := &internal.Conv{
+		SpSchema: map[string]ddl.CreateTable{
+			"t1": {
+				Name:   "t1",
+				ColIds: []string{"c1", "c2", "c3", "c4"},
+				ColDefs: map[string]ddl.ColumnDef{
+					"c1": {Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+					"c2": {Name: "b", Id: "c2", T: ddl.Type{Name: ddl.Int64}, Generated: ddl.GeneratedColumn{IsPresent: true, Value: ddl.Expression{Statement: "a * 2"}}},
+					"c3": {Name: "c", Id: "c3", T: ddl.Type{Name: ddl.String, Len: 6}},
+					"c4": {Name: "synth_id", Id: "c4", T: ddl.Type{Name: ddl.String, Len: 50}},
+				},
+			}},
+		SrcSchema: map[string]schema.Table{
+			"t1": {
+				Name:   "t1",
+				ColIds: []string{"c1", "c2", "c3"},
+				ColDefs: map[string]schema.Column{
+					"c1": {Name: "a", Id: "c1", Type: schema.Type{Name: "bigint"}},
+					"c2": {Name: "b", Id: "c2", Type: schema.Type{Name: "bigint"}},
+					"c3": {Name: "c", Id: "c3", Type: schema.Type{Name: "varchar", Mods: []int64{6}}},
+				},
+			}},
+	}
+	assert.Equal(t, []string{"c1", "c3"}, GetCommonColumnIds(conv, "t1", conv.SpSchema["t1"].ColIds))
+}
+
 func TestPrepareValues(t *testing.T) {
 	tc := []struct {
 		name              string
@@ -300,3 +356,52 @@ func TestGetSortedTableIdsBySpName(t *testing.T) {
 		})
 	}
 }
+
+func TestParseIntervalTime(t *testing.T) {
+	tests := []struct {
+		val  string
+		want time.Duration
+	}{
+		{"04:05:06", 4*time.Hour + 5*time.Minute + 6*time.Second},
+		{"838:59:59.5", 838*time.Hour + 59*time.Minute + 59500*time.Millisecond},
+		{"-00:00:01", -time.Second},
+		{"+12:00:00", 12 * time.Hour},
+	}
+	for _, tc := range tests {
+		d, err := ParseIntervalTime(tc.val)
+		assert.Nil(t, err, tc.val)
+		assert.Equal(t, tc.want, d, tc.val)
+	}
+	_, err := ParseIntervalTime("04:05")
+	assert.NotNil(t, err)
+	_, err = ParseIntervalTime("aa:05:06")
+	assert.NotNil(t, err)
+}
+
+func TestFormatInterval(t *testing.T) {
+	tests := []struct {
+		months int64
+		days   int64
+		d      time.Duration
+		want   string
+	}{
+		{0, 0, 0, "PT0S"},
+		{14, 3, 4*time.Hour + 5*time.Minute + 6500*time.Millisecond, "P1Y2M3DT4H5M6.5S"},
+		{-12, 0, 0, "P-1Y"},
+		{0, 1, -time.Second, "P1DT-1S"},
+		{0, 0, -(838*time.Hour + 59*time.Minute + 59*time.Second), "PT-838H-59M-59S"},
+		{0, 0, 1500 * time.Microsecond, "PT0.0015S"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, FormatInterval(tc.months, tc.days, tc.d))
+	}
+}
+
+func TestToPGDialectType(t *testing.T) {
+	ty, issues := ToPGDialectType(ddl.Type{Name: ddl.Int64, IsArray: true}, false)
+	assert.Equal(t, ddl.Type{Name: ddl.Int64, IsArray: true}, ty)
+	assert.Nil(t, issues)
+	ty, issues = ToPGDialectType(ddl.Type{Name: ddl.Numeric}, true)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, ty)
+	assert.Equal(t, []internal.SchemaIssue{internal.NumericPKNotSupported}, issues)
+}
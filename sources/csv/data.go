@@ -34,6 +34,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/profiles"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 type CsvInterface interface {
@@ -52,7 +53,7 @@ func (c *CsvImpl) GetCSVFiles(conv *internal.Conv, sourceProfile profiles.Source
 	if sourceProfile.Csv.Manifest == "" {
 		fmt.Println("Manifest file not provided, checking for files named `[table_name].csv` in current working directory...")
 		for _, schema := range conv.SpSchema {
-			tables = append(tables, utils.ManifestTable{Table_name: schema.Name, File_patterns: []string{fmt.Sprintf("%s.csv", schema.Name)}})
+			tables = append(tables, utils.ManifestTable{Table_name: schema.QualifiedName(), File_patterns: []string{fmt.Sprintf("%s.csv", schema.QualifiedName())}})
 		}
 	} else {
 		fmt.Println("Manifest file provided, reading csv file paths...")
@@ -140,7 +141,7 @@ func (c *CsvImpl) SetRowStats(conv *internal.Conv, tables []utils.ManifestTable,
 			r := csvReader.NewReader(csvFile)
 			r.Comma = delimiter
 
-			tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, table.Table_name)
+			tableId, err := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, table.Table_name)
 			if err != nil {
 				return fmt.Errorf("table Id not found for spanner table %v", table.Table_name)
 			}
@@ -202,13 +203,13 @@ func (c *CsvImpl) ProcessCSV(conv *internal.Conv, tables []utils.ManifestTable,
 	}
 	orderedTables := []utils.ManifestTable{}
 	for _, id := range tableIds {
-		orderedTables = append(orderedTables, utils.ManifestTable{Table_name: conv.SpSchema[id].Name, File_patterns: nameToFiles[conv.SpSchema[id].Name]})
+		orderedTables = append(orderedTables, utils.ManifestTable{Table_name: conv.SpSchema[id].QualifiedName(), File_patterns: nameToFiles[conv.SpSchema[id].QualifiedName()]})
 	}
 
 	for _, table := range orderedTables {
 		for _, filePath := range table.File_patterns {
 			// Default column order is same as in Spanner schema.
-			tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, table.Table_name)
+			tableId, err := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, table.Table_name)
 			if err != nil {
 				return fmt.Errorf("table Id not found for spanner table %v", table.Table_name)
 			}
@@ -304,7 +305,7 @@ func convertData(dialect, nullStr string, srcCols []string,
 
 		var x interface{}
 		if spColDef.T.IsArray {
-			x, err = convArray(spColDef.T, val)
+			x, err = convArray(dialect, spColDef.T, val)
 		} else {
 			x, err = convScalar(dialect, spColDef.T, val)
 		}
@@ -317,7 +318,7 @@ func convertData(dialect, nullStr string, srcCols []string,
 	return cvtCols, v, nil
 }
 
-func convArray(spannerType ddl.Type, val string) (interface{}, error) {
+func convArray(dialect string, spannerType ddl.Type, val string) (interface{}, error) {
 	val = strings.TrimSpace(val)
 	// Handle empty array. Note that we use an empty NullString array
 	// for all Spanner array types since this will be converted to the
@@ -426,6 +427,21 @@ func convArray(spannerType ddl.Type, val string) (interface{}, error) {
 		}
 		return r, nil
 	case ddl.Numeric:
+		if dialect == constants.DIALECT_POSTGRESQL {
+			var r []spanner.PGNumeric
+			for _, s := range a {
+				if s == "NULL" {
+					r = append(r, spanner.PGNumeric{Valid: false})
+					continue
+				}
+				s, err := processQuote(s)
+				if err != nil {
+					return []spanner.PGNumeric{}, err
+				}
+				r = append(r, spanner.PGNumeric{Numeric: s, Valid: true})
+			}
+			return r, nil
+		}
 		var r []spanner.NullNumeric
 		for _, s := range a {
 			if s == "NULL" {
@@ -522,6 +538,8 @@ func convScalar(dialect string, spannerType ddl.Type, val string) (interface{},
 		return convTimestamp(val)
 	case ddl.JSON:
 		return val, nil
+	case ddl.UUID:
+		return convUUID(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType)
 	}
@@ -591,6 +609,14 @@ func convTimestamp(val string) (t time.Time, err error) {
 	return t, err
 }
 
+func convUUID(val string) (string, error) {
+	u, err := uuid.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("can't convert to uuid: %w", err)
+	}
+	return u.String(), nil
+}
+
 func processQuote(s string) (string, error) {
 	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
 		return strconv.Unquote(s)
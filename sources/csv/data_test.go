@@ -24,6 +24,7 @@ import (
 
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/utils"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
@@ -208,6 +209,7 @@ func TestConvertData(t *testing.T) {
 		{"string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "eh", "eh"},
 		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "2019-10-29 05:30:00", getTime(t, "2019-10-29T05:30:00Z")},
 		{"json", ddl.Type{Name: ddl.JSON}, "{\"key1\": \"value1\"}", "{\"key1\": \"value1\"}"},
+		{"uuid", ddl.Type{Name: ddl.UUID}, "A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
 		{"int_array", ddl.Type{Name: ddl.Int64, IsArray: true}, "{1,2,NULL}", []spanner.NullInt64{{Int64: int64(1), Valid: true}, {Int64: int64(2), Valid: true}, {Valid: false}}},
 		{"string_array", ddl.Type{Name: ddl.String, IsArray: true}, "[ab,cd]", []spanner.NullString{{StringVal: "ab", Valid: true}, {StringVal: "cd", Valid: true}}},
 		{"float32_array", ddl.Type{Name: ddl.Float32, IsArray: true}, "{1.3,2.5}", []spanner.NullFloat32{{Float32: float32(1.3), Valid: true}, {Float32: float32(2.5), Valid: true}}},
@@ -235,6 +237,12 @@ func TestConvertData(t *testing.T) {
 		assert.Equal(t, []interface{}{tc.ev}, av, tc.name+": value mismatch")
 	}
 
+	// The PostgreSQL dialect takes numeric arrays as PG.NUMERIC values.
+	pgColDefs := map[string]ddl.ColumnDef{"c1": ddl.ColumnDef{Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Numeric, IsArray: true}}}
+	_, av, err := convertData(constants.DIALECT_POSTGRESQL, "", []string{"a"}, pgColDefs, []string{"{1.7,NULL}"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{[]spanner.PGNumeric{{Numeric: "1.7", Valid: true}, {Valid: false}}}, av)
+
 	cols := []string{"a", "b", "c"}
 	colDefs := map[string]ddl.ColumnDef{
 		"a": ddl.ColumnDef{Name: "a", T: ddl.Type{Name: ddl.Int64}},
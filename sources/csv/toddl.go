@@ -55,6 +55,8 @@ func ToSpannerType(columnType string) (ddl.Type, error) {
 		return ddl.Type{Name: ddl.Timestamp}, nil
 	case ty == "JSON":
 		return ddl.Type{Name: ddl.JSON}, nil
+	case ty == "UUID":
+		return ddl.Type{Name: ddl.UUID}, nil
 	default:
 		return ddl.Type{}, fmt.Errorf("%v is not a valid Spanner column type", columnType)
 	}
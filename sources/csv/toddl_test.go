@@ -41,6 +41,7 @@ func TestToSpannerType(t *testing.T) {
 		{"string", "STRING", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
 		{"timestamp", "TIMESTAMP", ddl.Type{Name: ddl.Timestamp}},
 		{"json", "JSON", ddl.Type{Name: ddl.JSON}},
+		{"uuid", "UUID", ddl.Type{Name: ddl.UUID}},
 		// Variations in case and field length.
 		{"bool mixed case", "BoOl", ddl.Type{Name: ddl.Bool}},
 		{"NUMERIC mixed case", "numErIC", ddl.Type{Name: ddl.Numeric}},
@@ -18,6 +18,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -162,6 +164,20 @@ func convScalar(attrVal *dynamodb.AttributeValue, srcType string, spType string)
 			}
 			return *val, nil
 		}
+	case ddl.Timestamp:
+		switch srcType {
+		case typeTimeToLive:
+			// DynamoDB doesn't expire items whose TTL attribute isn't a
+			// number, so they are given no expiry time.
+			if attrVal.N == nil {
+				return nil, nil
+			}
+			secs, err := strconv.ParseInt(*attrVal.N, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert '%v' to a TIMESTAMP type", *attrVal.N)
+			}
+			return time.Unix(secs, 0).UTC(), nil
+		}
 	}
 	return nil, fmt.Errorf("can't convert value of type %s to Spanner type %s", attrVal.GoString(), spType)
 }
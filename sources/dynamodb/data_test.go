@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -171,6 +172,7 @@ func TestConvScalar(t *testing.T) {
 	}
 	stringSetVal := []*string{&str}
 	numVal := big.NewRat(123456789, 100000)
+	ttlStr := "1700000000"
 
 	testcases := []struct {
 		name    string
@@ -190,6 +192,8 @@ func TestConvScalar(t *testing.T) {
 		{"number string set", typeNumberStringSet, ddl.String, &dynamodb.AttributeValue{NS: []*string{&numStr}}, "[\"1234.56789\"]"},
 		{"number", typeNumber, ddl.Numeric, &dynamodb.AttributeValue{N: &numStr}, *numVal},
 		{"number set", typeNumberSet, ddl.String, &dynamodb.AttributeValue{NS: []*string{&numStr}}, "[\"1234.56789\"]"},
+		{"time to live", typeTimeToLive, ddl.Timestamp, &dynamodb.AttributeValue{N: &ttlStr}, time.Date(2023, time.November, 14, 22, 13, 20, 0, time.UTC)},
+		{"time to live that isn't a number", typeTimeToLive, ddl.Timestamp, &dynamodb.AttributeValue{S: &str}, nil},
 	}
 
 	for _, tc := range testcases {
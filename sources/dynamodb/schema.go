@@ -46,6 +46,9 @@ const (
 	typeNumberSet       = "NumberSet"
 	typeNumberStringSet = "NumberStringSet"
 	typeBinarySet       = "BinarySet"
+	// typeTimeToLive is the type of the TTL attribute of a table, which
+	// holds the time items expire as a number of seconds since the epoch.
+	typeTimeToLive = "TimeToLive"
 
 	errThreshold      = float64(0.001)
 	conflictThreshold = float64(0.05)
@@ -89,7 +92,71 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	if err != nil {
 		return nil, nil, err
 	}
-	return inferDataTypes(stats, count, primaryKeys)
+	colDefs, colIds, err := inferDataTypes(stats, count, primaryKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Errors are reported by GetRowDeletionPolicy.
+	if attr, _ := isi.getTimeToLiveAttribute(table.Name); attr != "" {
+		colIds = setTimeToLiveColumn(colDefs, colIds, attr)
+	}
+	return colDefs, colIds, nil
+}
+
+// GetRowDeletionPolicy returns a policy that deletes the items of a table
+// whose TTL attribute holds a time that has passed.
+func (isi InfoSchemaImpl) GetRowDeletionPolicy(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) (*schema.RowDeletionPolicy, error) {
+	attr, err := isi.getTimeToLiveAttribute(table.Name)
+	if err != nil {
+		// Reading the TTL settings needs the dynamodb:DescribeTimeToLive
+		// permission, so the migration goes ahead without them.
+		conv.Unexpected(fmt.Sprintf("Couldn't get the TTL settings of table %s, so its rows won't expire: %s", table.Name, err))
+		return nil, nil
+	}
+	colId, ok := colNameIdMap[attr]
+	if attr == "" || !ok {
+		return nil, nil
+	}
+	return &schema.RowDeletionPolicy{ColId: colId}, nil
+}
+
+// getTimeToLiveAttribute returns the TTL attribute of a table, or "" if TTL
+// isn't enabled for it.
+func (isi InfoSchemaImpl) getTimeToLiveAttribute(table string) (string, error) {
+	result, err := isi.DynamoClient.DescribeTimeToLive(&dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(table),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to make a DescribeTimeToLive API call for table %v: %v", table, err)
+	}
+	desc := result.TimeToLiveDescription
+	if desc == nil || aws.StringValue(desc.TimeToLiveStatus) != dynamodb.TimeToLiveStatusEnabled {
+		return "", nil
+	}
+	return aws.StringValue(desc.AttributeName), nil
+}
+
+// setTimeToLiveColumn sets the type of the TTL attribute column attr, adding
+// the column if no sampled item has the attribute. DynamoDB ignores TTL
+// attributes that aren't numbers, so columns of other types are left as
+// they are, and items without a number don't expire, so the column is
+// nullable. It returns the column ids including any added column.
+func setTimeToLiveColumn(colDefs map[string]schema.Column, colIds []string, attr string) []string {
+	for id, col := range colDefs {
+		if col.Name == attr {
+			if col.Type.Name == typeNumber {
+				col.Type.Name = typeTimeToLive
+				col.NotNull = false
+				colDefs[id] = col
+			}
+			return colIds
+		}
+	}
+	colId := internal.GenerateColumnId()
+	colDefs[colId] = schema.Column{Id: colId, Name: attr, Type: schema.Type{Name: typeTimeToLive}}
+	colIds = append(colIds, colId)
+	sort.Strings(colIds)
+	return colIds
 }
 
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, srcTable string) (interface{}, error) {
@@ -129,6 +196,29 @@ func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error)
 	return *result.Table.ItemCount, err
 }
 
+// GetExactRowCount counts the items of a table with a consistent scan,
+// since the item count of DescribeTable is only updated about every six
+// hours.
+func (isi InfoSchemaImpl) GetExactRowCount(table common.SchemaAndName) (int64, error) {
+	params := &dynamodb.ScanInput{
+		TableName:      aws.String(table.Name),
+		Select:         aws.String(dynamodb.SelectCount),
+		ConsistentRead: aws.Bool(true),
+	}
+	var count int64
+	for {
+		result, err := isi.DynamoClient.Scan(params)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count the items of table %v: %v", table.Name, err)
+		}
+		count += aws.Int64Value(result.Count)
+		if result.LastEvaluatedKey == nil {
+			return count, nil
+		}
+		params.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
 func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.SchemaAndName) (primaryKeys []string, checkConstraints []schema.CheckConstraint, constraints map[string][]string, err error) {
 	input := &dynamodb.DescribeTableInput{
 		TableName: aws.String(table.Name),
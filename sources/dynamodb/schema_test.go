@@ -51,6 +51,7 @@ type mockDynamoClient struct {
 	scanOutputs            []dynamodb.ScanOutput
 	updateTableCallCount   int
 	updateTableOutputs     []dynamodb.UpdateTableOutput
+	timeToLiveDescription  *dynamodb.TimeToLiveDescription
 	dynamodbiface.DynamoDBAPI
 }
 
@@ -78,6 +79,10 @@ func (m *mockDynamoClient) Scan(input *dynamodb.ScanInput) (*dynamodb.ScanOutput
 	return &m.scanOutputs[m.scanCallCount-1], nil
 }
 
+func (m *mockDynamoClient) DescribeTimeToLive(input *dynamodb.DescribeTimeToLiveInput) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	return &dynamodb.DescribeTimeToLiveOutput{TimeToLiveDescription: m.timeToLiveDescription}, nil
+}
+
 func (m *mockDynamoClient) UpdateTable(input *dynamodb.UpdateTableInput) (*dynamodb.UpdateTableOutput, error) {
 	if m.updateTableCallCount >= len(m.updateTableOutputs) {
 		return nil, fmt.Errorf("unexpected call to UpdateTable: %v", input)
@@ -756,6 +761,57 @@ func TestInfoSchemaImpl_GetColumns(t *testing.T) {
 	}
 }
 
+func TestInfoSchemaImpl_GetColumns_TimeToLive(t *testing.T) {
+	id := "1"
+	expireAt := "1700000000"
+	scanOutputs := []dynamodb.ScanOutput{
+		{
+			Items: []map[string]*dynamodb.AttributeValue{
+				{"id": {S: &id}, "expire_at": {N: &expireAt}},
+			},
+		},
+	}
+	testCases := []struct {
+		name    string
+		attr    string
+		colName string
+	}{
+		{"sampled attribute", "expire_at", "expire_at"},
+		{"attribute that isn't sampled", "ttl", "ttl"},
+	}
+	for _, tc := range testCases {
+		conv := internal.MakeConv()
+		client := &mockDynamoClient{
+			scanOutputs:           scanOutputs,
+			timeToLiveDescription: &dynamodb.TimeToLiveDescription{AttributeName: &tc.attr, TimeToLiveStatus: aws.String(dynamodb.TimeToLiveStatusEnabled)},
+		}
+		isi := InfoSchemaImpl{client, nil, 10}
+		table := common.SchemaAndName{Name: "test"}
+
+		colDefs, colIds, err := isi.GetColumns(conv, table, nil, []string{"id"})
+		assert.Nil(t, err, tc.name)
+		cnidMap := getSrcColNameIdMap(colDefs)
+		assert.Equal(t, len(colDefs), len(colIds), tc.name)
+		assert.Equal(t, typeTimeToLive, colDefs[cnidMap[tc.colName]].Type.Name, tc.name)
+		assert.False(t, colDefs[cnidMap[tc.colName]].NotNull, tc.name)
+
+		policy, err := isi.GetRowDeletionPolicy(conv, table, cnidMap)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, &schema.RowDeletionPolicy{ColId: cnidMap[tc.colName]}, policy, tc.name)
+	}
+}
+
+func TestInfoSchemaImpl_GetRowDeletionPolicy_Disabled(t *testing.T) {
+	attr := "expire_at"
+	client := &mockDynamoClient{
+		timeToLiveDescription: &dynamodb.TimeToLiveDescription{AttributeName: &attr, TimeToLiveStatus: aws.String(dynamodb.TimeToLiveStatusDisabling)},
+	}
+	isi := InfoSchemaImpl{client, nil, 10}
+	policy, err := isi.GetRowDeletionPolicy(internal.MakeConv(), common.SchemaAndName{Name: "test"}, map[string]string{"expire_at": "c1"})
+	assert.Nil(t, err)
+	assert.Nil(t, policy)
+}
+
 func TestInfoSchemaImpl_GetForeignKeys(t *testing.T) {
 	dySchema := common.SchemaAndName{Name: "test"}
 	conv := internal.MakeConv()
@@ -791,6 +847,22 @@ func TestInfoSchemaImpl_GetRowCount(t *testing.T) {
 	assert.Equal(t, tableItemCountA, rowCount)
 }
 
+func TestInfoSchemaImpl_GetExactRowCount(t *testing.T) {
+	client := &mockDynamoClient{
+		scanOutputs: []dynamodb.ScanOutput{
+			{Count: aws.Int64(3), LastEvaluatedKey: map[string]*dynamodb.AttributeValue{"id": {S: aws.String("c")}}},
+			{Count: aws.Int64(2)},
+		},
+	}
+	isi := InfoSchemaImpl{client, nil, 10}
+	rowCount, err := isi.GetExactRowCount(common.SchemaAndName{Name: "test"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), rowCount)
+
+	_, err = isi.GetExactRowCount(common.SchemaAndName{Name: "test"})
+	assert.ErrorContains(t, err, "failed to count the items of table test")
+}
+
 func TestInfoSchemaImpl_GetRowsFromTable(t *testing.T) {
 	strA := "str-1"
 	numStr1 := "10.1"
@@ -54,6 +54,8 @@ func toSpannerTypeInternal(conv *internal.Conv, srcType schema.Type) (ddl.Type,
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
 	case typeBool:
 		return ddl.Type{Name: ddl.Bool}, nil
+	case typeTimeToLive:
+		return ddl.Type{Name: ddl.Timestamp}, nil
 	case typeBinary:
 		return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil
 	case typeStringSet, typeNumberStringSet:
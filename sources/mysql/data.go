@@ -27,7 +27,9 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 // ProcessDataRow converts a row of data and writes it out to Spanner.
@@ -134,6 +136,10 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, T
 		return convTimestamp(srcTypeName, TimezoneOffset, val)
 	case ddl.JSON:
 		return val, nil
+	case ddl.UUID:
+		return convUUID(srcTypeName, val)
+	case ddl.Interval:
+		return convInterval(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
@@ -178,6 +184,34 @@ func convBytes(val string) ([]byte, error) {
 	return b, nil
 }
 
+// convUUID maps a source database value (representing a UUID) into the
+// canonical form of Spanner UUIDs. binary(16) columns hold the bytes of
+// UUIDs and char(36) columns hold their text.
+func convUUID(srcTypeName string, val string) (string, error) {
+	var u uuid.UUID
+	var err error
+	if srcTypeName == "binary" {
+		u, err = uuid.FromBytes([]byte(val))
+	} else {
+		u, err = uuid.Parse(val)
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't convert to uuid: %w", err)
+	}
+	return u.String(), nil
+}
+
+// convInterval maps a source database TIME value, of the form
+// [-]HHH:MM:SS[.fraction], into the ISO 8601 form that Spanner uses for
+// INTERVAL values.
+func convInterval(val string) (string, error) {
+	d, err := common.ParseIntervalTime(val)
+	if err != nil {
+		return "", err
+	}
+	return common.FormatInterval(0, 0, d), nil
+}
+
 func convDate(val string) (civil.Date, error) {
 	d, err := civil.ParseDate(val)
 	if err != nil {
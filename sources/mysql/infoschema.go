@@ -58,23 +58,52 @@ func (isi InfoSchemaImpl) GetTableName(dbName string, tableName string) string {
 
 // GetRowsFromTable returns a sql Rows object for a table.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	srcSchema := conv.SrcSchema[tableId]
+	if len(srcSchema.ColIds) == 0 {
+		conv.Unexpected(fmt.Sprintf("Couldn't get source columns for table %s ", srcSchema.Name))
+		return nil, nil
+	}
+	q, args := isi.GetKeyRangeQuery(conv, tableId, internal.KeyRange{})
+	rows, err := isi.Db.Query(q, args...)
+	return rows, err
+}
+
+// GetKeyRangeQuery returns the query that reads the rows of a table whose
+// first primary key column lies in kr.
+func (isi InfoSchemaImpl) GetKeyRangeQuery(conv *internal.Conv, tableId string, kr internal.KeyRange) (string, []interface{}) {
 	srcSchema := conv.SrcSchema[tableId]
 	srcCols := []string{}
 
 	for _, srcColId := range srcSchema.ColIds {
 		srcCols = append(srcCols, conv.SrcSchema[tableId].ColDefs[srcColId].Name)
 	}
-	if len(srcCols) == 0 {
-		conv.Unexpected(fmt.Sprintf("Couldn't get source columns for table %s ", srcSchema.Name))
-		return nil, nil
-	}
 	// MySQL schema and name can be arbitrary strings.
 	// Ideally we would pass schema/name as a query parameter,
 	// but MySQL doesn't support this. So we quote it instead.
 	colNameList := buildColNameList(srcSchema, srcCols)
-	q := fmt.Sprintf("SELECT %s FROM `%s`.`%s`;", colNameList, isi.DbName, srcSchema.Name)
+	q := fmt.Sprintf("SELECT %s FROM `%s`.`%s`", colNameList, isi.DbName, srcSchema.Name)
+	col := fmt.Sprintf("`%s`", common.GetKeyRangeColumn(srcSchema))
+	cond, args := common.KeyRangeCondition(col, kr, func(i int) string { return "?" })
+	if cond != "" {
+		q += " WHERE " + cond
+	}
+	return q + ";", args
+}
+
+// GetKeySplitPoints returns values of the first primary key column that
+// split a table into n ranges of similar size.
+func (isi InfoSchemaImpl) GetKeySplitPoints(conv *internal.Conv, tableId string, n int) ([]interface{}, error) {
+	srcSchema := conv.SrcSchema[tableId]
+	col := fmt.Sprintf("`%s`", common.GetKeyRangeColumn(srcSchema))
+	q := fmt.Sprintf(`SELECT k FROM (
+			SELECT k, tile, LAG(tile) OVER (ORDER BY k) AS prev_tile FROM (
+				SELECT %s AS k, NTILE(%d) OVER (ORDER BY %s) AS tile FROM `+"`%s`.`%s`"+`) AS t) AS b
+		WHERE tile <> prev_tile ORDER BY k;`, col, n, col, isi.DbName, srcSchema.Name)
 	rows, err := isi.Db.Query(q)
-	return rows, err
+	if err != nil {
+		return nil, err
+	}
+	return common.ScanKeySplitPoints(rows)
 }
 
 // Building list of column names to support mysql spatial datatypes instead of
@@ -100,12 +129,12 @@ func buildColNameList(srcSchema schema.Table, srcColName []string) string {
 // ProcessData performs data conversion for source database.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
 	srcTableName := conv.SrcSchema[tableId].Name
-	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
+	q, args := isi.GetKeyRangeQuery(conv, tableId, additionalAttributes.KeyRange)
+	rows, err := isi.Db.Query(q, args...)
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTableName, err))
 		return err
 	}
-	rows := rowsInterface.(*sql.Rows)
 	defer rows.Close()
 	srcCols, _ := rows.Columns()
 	v, scanArgs := buildVals(len(srcCols))
@@ -174,9 +203,48 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	return tables, nil
 }
 
+// GetViews returns the views in the selected database. MySQL qualifies the
+// tables and columns in view definitions with the database name, which we
+// drop since Spanner views refer to tables in the same database.
+// VIEW_TABLE_USAGE requires MySQL 8.0.13 or later.
+func (isi InfoSchemaImpl) GetViews(conv *internal.Conv) ([]schema.View, error) {
+	q := `SELECT v.TABLE_NAME, v.VIEW_DEFINITION, u.TABLE_NAME
+		FROM INFORMATION_SCHEMA.VIEWS AS v
+		LEFT JOIN INFORMATION_SCHEMA.VIEW_TABLE_USAGE AS u
+			ON u.VIEW_SCHEMA = v.TABLE_SCHEMA
+			AND u.VIEW_NAME = v.TABLE_NAME
+			AND u.TABLE_SCHEMA = v.TABLE_SCHEMA
+		WHERE v.TABLE_SCHEMA = ?
+		ORDER BY v.TABLE_NAME, u.TABLE_NAME`
+	rows, err := isi.Db.Query(q, isi.DbName)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var viewName string
+	var definition, refTable sql.NullString
+	var views []schema.View
+	for rows.Next() {
+		err := rows.Scan(&viewName, &definition, &refTable)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		if len(views) == 0 || views[len(views)-1].Name != viewName {
+			query := strings.ReplaceAll(definition.String, "`"+isi.DbName+"`.", "")
+			views = append(views, schema.View{Name: viewName, Schema: isi.DbName, Id: internal.GenerateViewId(), Query: query})
+		}
+		if refTable.Valid {
+			view := &views[len(views)-1]
+			view.Refs = append(view.Refs, isi.GetTableName(isi.DbName, refTable.String))
+		}
+	}
+	return views, nil
+}
+
 // GetColumns returns a list of Column objects and names// ProcessColumns
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
-	q := `SELECT c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.extra
+	q := `SELECT c.column_name, c.data_type, c.column_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.extra, c.generation_expression
               FROM information_schema.COLUMNS c
               where table_schema = ? and table_name = ? ORDER BY c.ordinal_position;`
 	cols, err := isi.Db.Query(q, table.Schema, table.Name)
@@ -187,11 +255,11 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	colDefs := make(map[string]schema.Column)
 	var colIds []string
 	var colName, dataType, isNullable, columnType string
-	var colDefault, colExtra sql.NullString
+	var colDefault, colExtra, colGenerated sql.NullString
 	var charMaxLen, numericPrecision, numericScale sql.NullInt64
 	var colAutoGen ddl.AutoGenCol
 	for cols.Next() {
-		err := cols.Scan(&colName, &dataType, &columnType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colExtra)
+		err := cols.Scan(&colName, &dataType, &columnType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colExtra, &colGenerated)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
@@ -223,6 +291,19 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 			}
 		}
 
+		// Generated columns report their expression in generation_expression
+		// and are marked as VIRTUAL GENERATED or STORED GENERATED in extra.
+		generated := ddl.GeneratedColumn{}
+		if colGenerated.Valid && (colExtra.String == "VIRTUAL GENERATED" || colExtra.String == "STORED GENERATED") {
+			generated = ddl.GeneratedColumn{
+				IsPresent: true,
+				Value: ddl.Expression{
+					ExpressionId: internal.GenerateExpressionId(),
+					Statement:    common.SanitizeDefaultValue(colGenerated.String, dataType, true),
+				},
+			}
+		}
+
 		c := schema.Column{
 			Id:           colId,
 			Name:         colName,
@@ -231,6 +312,7 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 			Ignored:      ignored,
 			AutoGen:      colAutoGen,
 			DefaultValue: defaultVal,
+			Generated:    generated,
 		}
 		colDefs[colId] = c
 		colIds = append(colIds, colId)
@@ -428,7 +510,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 
 // GetIndexes return a list of all indexes for the specified table.
 func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
-	q := `SELECT DISTINCT INDEX_NAME,COLUMN_NAME,SEQ_IN_INDEX,COLLATION,NON_UNIQUE
+	q := `SELECT DISTINCT INDEX_NAME,COLUMN_NAME,SEQ_IN_INDEX,COLLATION,NON_UNIQUE,INDEX_TYPE,EXPRESSION
 		FROM INFORMATION_SCHEMA.STATISTICS 
 		WHERE TABLE_SCHEMA = ?
 			AND TABLE_NAME = ?
@@ -436,32 +518,43 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		ORDER BY INDEX_NAME, SEQ_IN_INDEX;`
 	rows, err := isi.Db.Query(q, table.Schema, table.Name)
 	if err != nil {
-		return nil, err
+		// MySQL versions before 8.0.13 have no functional key parts, and
+		// no EXPRESSION column.
+		rows, err = isi.Db.Query(strings.Replace(q, "EXPRESSION", "NULL AS EXPRESSION", 1), table.Schema, table.Name)
+		if err != nil {
+			return nil, err
+		}
 	}
 	defer rows.Close()
-	var name, column, sequence, nonUnique string
-	var collation sql.NullString
+	var name, sequence, nonUnique, indexType string
+	// Functional key parts have an expression rather than a column.
+	var column, collation, expression sql.NullString
 	indexMap := make(map[string]schema.Index)
 	var indexNames []string
 	var indexes []schema.Index
 	for rows.Next() {
-		if err := rows.Scan(&name, &column, &sequence, &collation, &nonUnique); err != nil {
+		if err := rows.Scan(&name, &column, &sequence, &collation, &nonUnique, &indexType, &expression); err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
 		}
 		if _, found := indexMap[name]; !found {
 			indexNames = append(indexNames, name)
 			indexMap[name] = schema.Index{
-				Id:     internal.GenerateIndexesId(),
-				Name:   name,
-				Unique: (nonUnique == "0"),
+				Id:       internal.GenerateIndexesId(),
+				Name:     name,
+				Unique:   (nonUnique == "0"),
+				FullText: (indexType == "FULLTEXT"),
 			}
 		}
 		index := indexMap[name]
-		index.Keys = append(index.Keys, schema.Key{
-			ColId: colNameIdMap[column],
+		key := schema.Key{
+			ColId: colNameIdMap[column.String],
 			Desc:  (collation.Valid && collation.String == "D"),
-		})
+		}
+		if !column.Valid && expression.Valid {
+			key.Expression = expression.String
+		}
+		index.Keys = append(index.Keys, key)
 		indexMap[name] = index
 	}
 	for _, k := range indexNames {
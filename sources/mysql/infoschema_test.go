@@ -84,17 +84,17 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "user"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
 			rows: [][]driver.Value{
-				{"user_id", "text", "text", "NO", "uuid()", nil, nil, nil, constants.DEFAULT_GENERATED},
-				{"name", "text", "text", "NO", "default_name", nil, nil, nil, nil},
-				{"ref", "bigint", "bigint", "NO", nil, nil, nil, nil, nil}},
+				{"user_id", "text", "text", "NO", "uuid()", nil, nil, nil, constants.DEFAULT_GENERATED, nil},
+				{"name", "text", "text", "NO", "default_name", nil, nil, nil, nil, nil},
+				{"ref", "bigint", "bigint", "NO", nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "user"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
 		},
 		{
 			query: regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`),
@@ -125,24 +125,24 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "cart"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
 			rows: [][]driver.Value{
-				{"productid", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"userid", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"quantity", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
+				{"productid", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"userid", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"quantity", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "cart"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
 			rows: [][]driver.Value{
-				{"index1", "userid", 1, sql.NullString{Valid: false}, "0"},
-				{"index2", "userid", 1, "A", "1"},
-				{"index2", "productid", 2, "D", "1"},
-				{"index3", "productid", 1, "A", "0"},
-				{"index3", "userid", 2, "D", "0"},
+				{"index1", "userid", 1, sql.NullString{Valid: false}, "0", "BTREE", nil},
+				{"index2", "userid", 1, "A", "1", "BTREE", nil},
+				{"index2", "productid", 2, "D", "1", "BTREE", nil},
+				{"index3", "productid", 1, "A", "0", "BTREE", nil},
+				{"index3", "userid", 2, "D", "0", "BTREE", nil},
 			},
 		},
 		{
@@ -169,17 +169,17 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "product"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
 			rows: [][]driver.Value{
-				{"product_id", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"product_name", "text", "text", "NO", nil, nil, nil, nil, nil},
+				{"product_id", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"product_name", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "product"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
 		},
 		{
 			query: regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`),
@@ -207,36 +207,36 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
-			rows: [][]driver.Value{
-				{"id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil},
-				{"s", "set", "set", "YES", nil, nil, nil, nil, nil},
-				{"txt", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"b", "boolean", "boolean", "YES", nil, nil, nil, nil, nil},
-				{"bs", "bigint", "bigint", "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil},
-				{"bl", "blob", "blob", "YES", nil, nil, nil, nil, nil},
-				{"c", "char", "char(1)", "YES", nil, 1, nil, nil, nil},
-				{"c8", "char", "char(8)", "YES", nil, 8, nil, nil, nil},
-				{"d", "date", "date", "YES", nil, nil, nil, nil, nil},
-				{"dec", "decimal", "decimal(20,5)", "YES", nil, nil, 20, 5, nil},
-				{"f8", "double", "double", "YES", nil, nil, 53, nil, nil},
-				{"f4", "float", "float", "YES", nil, nil, 24, nil, nil},
-				{"i8", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
-				{"i4", "integer", "integer", "YES", nil, nil, 32, 0, "auto_increment"},
-				{"i2", "smallint", "smallint", "YES", nil, nil, 16, 0, nil},
-				{"si", "integer", "integer", "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil},
-				{"ts", "datetime", "datetime", "YES", nil, nil, nil, nil, nil},
-				{"tz", "timestamp", "timestamp", "YES", nil, nil, nil, nil, nil},
-				{"vc", "varchar", "varchar", "YES", nil, nil, nil, nil, nil},
-				{"vc6", "varchar", "varchar(6)", "YES", nil, 6, nil, nil, nil},
-				{"bu", "bigint", "bigint(20) unsigned", "YES", nil, nil, 20, 0, nil},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
+			rows: [][]driver.Value{
+				{"id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil},
+				{"s", "set", "set", "YES", nil, nil, nil, nil, nil, nil},
+				{"txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"b", "boolean", "boolean", "YES", nil, nil, nil, nil, nil, nil},
+				{"bs", "bigint", "bigint", "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil, nil},
+				{"bl", "blob", "blob", "YES", nil, nil, nil, nil, nil, nil},
+				{"c", "char", "char(1)", "YES", nil, 1, nil, nil, nil, nil},
+				{"c8", "char", "char(8)", "YES", nil, 8, nil, nil, nil, nil},
+				{"d", "date", "date", "YES", nil, nil, nil, nil, nil, nil},
+				{"dec", "decimal", "decimal(20,5)", "YES", nil, nil, 20, 5, nil, nil},
+				{"f8", "double", "double", "YES", nil, nil, 53, nil, nil, nil},
+				{"f4", "float", "float", "YES", nil, nil, 24, nil, nil, nil},
+				{"i8", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil},
+				{"i4", "integer", "integer", "YES", nil, nil, 32, 0, "auto_increment", nil},
+				{"i2", "smallint", "smallint", "YES", nil, nil, 16, 0, nil, nil},
+				{"si", "integer", "integer", "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil, nil},
+				{"ts", "datetime", "datetime", "YES", nil, nil, nil, nil, nil, nil},
+				{"tz", "timestamp", "timestamp", "YES", nil, nil, nil, nil, nil, nil},
+				{"vc", "varchar", "varchar", "YES", nil, nil, nil, nil, nil, nil},
+				{"vc6", "varchar", "varchar(6)", "YES", nil, 6, nil, nil, nil, nil},
+				{"bu", "bigint", "bigint(20) unsigned", "YES", nil, nil, 20, 0, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
 		},
 		{
 			query: regexp.QuoteMeta(`SELECT COUNT(*) FROM INFORMATION_SCHEMA.TABLES WHERE (TABLE_SCHEMA = 'information_schema' OR TABLE_SCHEMA = 'INFORMATION_SCHEMA') AND TABLE_NAME = 'CHECK_CONSTRAINTS';`),
@@ -263,18 +263,23 @@ func TestProcessSchemaMYSQL(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test_ref"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
 			rows: [][]driver.Value{
-				{"ref_id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil},
-				{"ref_txt", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"abc", "text", "text", "NO", nil, nil, nil, nil, nil},
+				{"ref_id", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, nil},
+				{"ref_txt", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"abc", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test_ref"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.VIEWS (.+)",
+			args:  []driver.Value{"test"},
+			cols:  []string{"TABLE_NAME", "VIEW_DEFINITION", "TABLE_NAME"},
 		},
 	}
 	db := mkMockDB(t, ms)
@@ -381,16 +386,21 @@ func TestProcessSchemaMYSQLPKOrdering(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "pk_order"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
 			rows: [][]driver.Value{
-				{"pk_1", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"pk_2", "text", "text", "NO", nil, nil, nil, nil, nil},
+				{"pk_1", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"pk_2", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
 			},
 		},
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "pk_order"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.VIEWS (.+)",
+			args:  []driver.Value{"test"},
+			cols:  []string{"TABLE_NAME", "VIEW_DEFINITION", "TABLE_NAME"},
 		},
 	}
 	db := mkMockDB(t, ms)
@@ -510,17 +520,22 @@ func TestProcessData_MultiCol(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
 			rows: [][]driver.Value{
-				{"a", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"b", "double", "double", "YES", nil, nil, 53, nil, nil},
-				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
+				{"a", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"b", "double", "double", "YES", nil, nil, 53, nil, nil, nil},
+				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil},
 			},
 		},
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.VIEWS (.+)",
+			args:  []driver.Value{"test"},
+			cols:  []string{"TABLE_NAME", "VIEW_DEFINITION", "TABLE_NAME"},
 		},
 		{
 			query: "SELECT (.+) FROM `test`.`test`",
@@ -626,17 +641,22 @@ func TestProcessSchema_Sharded(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
 			rows: [][]driver.Value{
-				{"a", "text", "text", "NO", nil, nil, nil, nil, nil},
-				{"b", "double", "double", "YES", nil, nil, 53, nil, nil},
-				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil},
+				{"a", "text", "text", "NO", nil, nil, nil, nil, nil, nil},
+				{"b", "double", "double", "YES", nil, nil, 53, nil, nil, nil},
+				{"c", "bigint", "bigint", "YES", nil, nil, 64, 0, nil, nil},
 			},
 		},
 		{
 			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
 			args:  []driver.Value{"test", "test"},
-			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
+		},
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.VIEWS (.+)",
+			args:  []driver.Value{"test"},
+			cols:  []string{"TABLE_NAME", "VIEW_DEFINITION", "TABLE_NAME"},
 		},
 		{
 			query: "SELECT (.+) FROM `test`.`test`",
@@ -779,3 +799,113 @@ func TestGetConstraints_CheckConstraintsTableAbsent(t *testing.T) {
 	_, _, _, err := isi.GetConstraints(conv, common.SchemaAndName{Schema: "your_schema", Name: "your_table"})
 	assert.Error(t, err)
 }
+
+func TestGetColumns_Generated(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
+			args:  []driver.Value{"test", "orders"},
+			cols:  []string{"column_name", "data_type", "column_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "extra", "generation_expression"},
+			rows: [][]driver.Value{
+				{"price", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, ""},
+				{"quantity", "bigint", "bigint", "NO", nil, nil, 64, 0, nil, ""},
+				{"total", "bigint", "bigint", "YES", nil, nil, 64, 0, "STORED GENERATED", "(`price` * `quantity`)"},
+				{"label", "varchar", "varchar(20)", "YES", nil, 20, nil, nil, "VIRTUAL GENERATED", "concat(_utf8mb4'#',`price`)"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+
+	colDefs, colIds, err := isi.GetColumns(conv, common.SchemaAndName{Schema: "test", Name: "orders"}, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(colIds))
+	assert.False(t, colDefs[colIds[0]].Generated.IsPresent)
+	assert.False(t, colDefs[colIds[0]].DefaultValue.IsPresent)
+	assert.True(t, colDefs[colIds[2]].Generated.IsPresent)
+	assert.Equal(t, "(`price` * `quantity`)", colDefs[colIds[2]].Generated.Value.Statement)
+	assert.NotEmpty(t, colDefs[colIds[2]].Generated.Value.ExpressionId)
+	assert.False(t, colDefs[colIds[2]].DefaultValue.IsPresent)
+	assert.True(t, colDefs[colIds[3]].Generated.IsPresent)
+	assert.Equal(t, "concat('#',`price`)", colDefs[colIds[3]].Generated.Value.Statement)
+}
+
+func TestGetIndexes_FullText(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
+			args:  []driver.Value{"test", "posts"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
+			rows: [][]driver.Value{
+				{"ft_title_body", "title", 1, sql.NullString{Valid: false}, "1", "FULLTEXT", nil},
+				{"ft_title_body", "body", 2, sql.NullString{Valid: false}, "1", "FULLTEXT", nil},
+				{"idx_title", "title", 1, "A", "1", "BTREE", nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+
+	indexes, err := isi.GetIndexes(conv, common.SchemaAndName{Schema: "test", Name: "posts"}, map[string]string{"title": "c1", "body": "c2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(indexes))
+	assert.True(t, indexes[0].FullText)
+	assert.Equal(t, []schema.Key{{ColId: "c1"}, {ColId: "c2"}}, indexes[0].Keys)
+	assert.False(t, indexes[1].FullText)
+}
+
+func TestGetIndexes_FunctionalKeyPart(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.STATISTICS (.+)",
+			args:  []driver.Value{"test", "users"},
+			cols:  []string{"INDEX_NAME", "COLUMN_NAME", "SEQ_IN_INDEX", "COLLATION", "NON_UNIQUE", "INDEX_TYPE", "EXPRESSION"},
+			rows: [][]driver.Value{
+				{"idx_email", nil, 1, "A", "1", "BTREE", "lower(`email`)"},
+				{"idx_email", "id", 2, "D", "1", "BTREE", nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+
+	indexes, err := isi.GetIndexes(conv, common.SchemaAndName{Schema: "test", Name: "users"}, map[string]string{"id": "c1", "email": "c2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(indexes))
+	assert.Equal(t, []schema.Key{{Expression: "lower(`email`)"}, {ColId: "c1", Desc: true}}, indexes[0].Keys)
+}
+
+func TestGetViews(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM INFORMATION_SCHEMA.VIEWS (.+)",
+			args:  []driver.Value{"test"},
+			cols:  []string{"TABLE_NAME", "VIEW_DEFINITION", "TABLE_NAME"},
+			rows: [][]driver.Value{
+				{"cart_products", "select `test`.`cart`.`productid` AS `productid`,`test`.`product`.`product_name` AS `product_name` from (`test`.`cart` join `test`.`product` on((`test`.`cart`.`productid` = `test`.`product`.`product_id`)))", "cart"},
+				{"cart_products", "select `test`.`cart`.`productid` AS `productid`,`test`.`product`.`product_name` AS `product_name` from (`test`.`cart` join `test`.`product` on((`test`.`cart`.`productid` = `test`.`product`.`product_id`)))", "product"},
+				{"product_names", "select `cart_products`.`product_name` AS `product_name` from `test`.`cart_products`", "cart_products"},
+				{"now", "select now() AS `now()`", nil},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{DbName: "test", Db: db}
+	conv := internal.MakeConv()
+
+	views, err := isi.GetViews(conv)
+	assert.NoError(t, err)
+	for i := range views {
+		assert.NotEmpty(t, views[i].Id)
+		views[i].Id = ""
+	}
+	assert.Equal(t, []schema.View{
+		{Name: "cart_products", Schema: "test", Query: "select `cart`.`productid` AS `productid`,`product`.`product_name` AS `product_name` from (`cart` join `product` on((`cart`.`productid` = `product`.`product_id`)))", Refs: []string{"cart", "product"}},
+		{Name: "product_names", Schema: "test", Query: "select `cart_products`.`product_name` AS `product_name` from `cart_products`", Refs: []string{"cart_products"}},
+		{Name: "now", Schema: "test", Query: "select now() AS `now()`"},
+	}, views)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
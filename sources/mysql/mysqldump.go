@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -177,6 +178,10 @@ func processStatement(conv *internal.Conv, stmt ast.StmtNode) bool {
 		if conv.SchemaMode() {
 			processCreateIndex(conv, s)
 		}
+	case *ast.CreateViewStmt:
+		if conv.SchemaMode() {
+			processCreateView(conv, s)
+		}
 	default:
 		conv.SkipStatement(NodeType(stmt))
 	}
@@ -197,10 +202,11 @@ func processCreateIndex(conv *internal.Conv, stmt *ast.CreateIndexStmt) {
 	if tbl, ok := internal.GetSrcTableByName(conv.SrcSchema, tableName); ok {
 		ctable := conv.SrcSchema[tbl.Id]
 		ctable.Indexes = append(ctable.Indexes, schema.Index{
-			Id:     internal.GenerateIndexesId(),
-			Name:   stmt.IndexName,
-			Unique: (stmt.KeyType == ast.IndexKeyTypeUnique),
-			Keys:   toSchemaKeys(stmt.IndexPartSpecifications, tbl.ColNameIdMap),
+			Id:       internal.GenerateIndexesId(),
+			Name:     stmt.IndexName,
+			Unique:   (stmt.KeyType == ast.IndexKeyTypeUnique),
+			Keys:     toSchemaKeys(stmt.IndexPartSpecifications, tbl.ColNameIdMap),
+			FullText: (stmt.KeyType == ast.IndexKeyTypeFullText),
 		})
 		conv.SrcSchema[tbl.Id] = ctable
 	} else {
@@ -209,6 +215,58 @@ func processCreateIndex(conv *internal.Conv, stmt *ast.CreateIndexStmt) {
 	}
 }
 
+// processCreateView records the view defined by stmt. mysqldump first
+// creates a placeholder for each view, so that views can refer to views
+// defined later in the dump, and then replaces it with the actual view.
+// Older versions of mysqldump use tables as placeholders.
+func processCreateView(conv *internal.Conv, stmt *ast.CreateViewStmt) {
+	if stmt.ViewName == nil || stmt.Select == nil {
+		logStmtError(conv, stmt, fmt.Errorf("cannot process view statement with nil view or query"))
+		return
+	}
+	viewName, err := getTableName(stmt.ViewName)
+	if err != nil {
+		logStmtError(conv, stmt, fmt.Errorf("can't get view name: %w", err))
+		return
+	}
+	if tbl, ok := internal.GetSrcTableByName(conv.SrcSchema, viewName); ok {
+		delete(conv.SrcSchema, tbl.Id)
+	}
+	refs := &tableNameCollector{}
+	stmt.Select.Accept(refs)
+	view := schema.View{
+		Name:  viewName,
+		Id:    internal.GenerateViewId(),
+		Query: expressionToString(stmt.Select),
+		Refs:  refs.names,
+	}
+	for _, v := range conv.SrcViews {
+		if v.Name == viewName {
+			view.Id = v.Id
+		}
+	}
+	conv.SrcViews[view.Id] = view
+}
+
+// tableNameCollector is an ast.Visitor that collects the names of the
+// tables and views that a statement refers to.
+type tableNameCollector struct {
+	names []string
+}
+
+func (c *tableNameCollector) Enter(n ast.Node) (ast.Node, bool) {
+	if table, ok := n.(*ast.TableName); ok {
+		if name, err := getTableName(table); err == nil && !slices.Contains(c.names, name) {
+			c.names = append(c.names, name)
+		}
+	}
+	return n, false
+}
+
+func (c *tableNameCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
 func processSetStmt(conv *internal.Conv, stmt *ast.SetStmt) {
 	if stmt.Variables != nil && len(stmt.Variables) > 0 {
 		for _, variable := range stmt.Variables {
@@ -327,6 +385,9 @@ func processConstraint(conv *internal.Conv, tableId string, constraint *ast.Cons
 	case ast.ConstraintIndex:
 		idxId := internal.GenerateIndexesId()
 		st.Indexes = append(st.Indexes, schema.Index{Name: constraint.Name, Id: idxId, Keys: toSchemaKeys(constraint.Keys, colNameToIdMap)})
+	case ast.ConstraintFulltext:
+		idxId := internal.GenerateIndexesId()
+		st.Indexes = append(st.Indexes, schema.Index{Name: constraint.Name, Id: idxId, FullText: true, Keys: toSchemaKeys(constraint.Keys, colNameToIdMap)})
 	case ast.ConstraintUniq:
 		idxId := internal.GenerateIndexesId()
 		// Convert unique column constraint in mysql to a corresponding unique index in schema
@@ -378,6 +439,13 @@ func expressionToString(expr ast.Node) string {
 // TODO: Resolve ordering issue for non-primary keys.
 func toSchemaKeys(columns []*ast.IndexPartSpecification, colNameToIdMap map[string]string) (keys []schema.Key) {
 	for _, spec := range columns {
+		// Functional key parts index an expression rather than a column.
+		if spec.Column == nil {
+			if spec.Expr != nil {
+				keys = append(keys, schema.Key{Expression: expressionToString(spec.Expr)})
+			}
+			continue
+		}
 		specColName := spec.Column.OrigColName()
 		if colId, ok := colNameToIdMap[specColName]; ok {
 			keys = append(keys, schema.Key{ColId: colId})
@@ -570,6 +638,16 @@ func updateColsByOption(conv *internal.Conv, tableName string, col *ast.ColumnDe
 			if !nullDefault {
 				column.Ignored.Default = true
 			}
+		case ast.ColumnOptionGenerated:
+			// Spanner only supports stored generated columns, so virtual
+			// generated columns are migrated as stored ones.
+			column.Generated = ddl.GeneratedColumn{
+				IsPresent: true,
+				Value: ddl.Expression{
+					ExpressionId: internal.GenerateExpressionId(),
+					Statement:    expressionToString(elem.Expr),
+				},
+			}
 		case ast.ColumnOptionUniqKey:
 			cc.isUniqueKey = true
 		case ast.ColumnOptionCheck:
@@ -813,7 +891,7 @@ func processInsertStmt(conv *internal.Conv, stmt *ast.InsertStmt) {
 		logStmtError(conv, stmt, fmt.Errorf("can't get column values"))
 		return
 	}
-	commonColIds := common.IntersectionOfTwoStringSlices(conv.SpSchema[tableId].ColIds, srcColIds)
+	commonColIds := common.GetCommonColumnIds(conv, tableId, common.IntersectionOfTwoStringSlices(conv.SpSchema[tableId].ColIds, srcColIds))
 	spSchema := conv.SpSchema[tableId]
 	colNameIdMap := internal.GetSrcColNameIdMap(conv.SrcSchema[tableId])
 	for _, row := range stmt.Lists {
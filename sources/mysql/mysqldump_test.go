@@ -28,6 +28,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/mocks"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
@@ -972,6 +973,83 @@ func TestProcessMySQLDump_AddPrimaryKeys(t *testing.T) {
 	}
 }
 
+func TestProcessMySQLDump_GeneratedColumn(t *testing.T) {
+	conv, rows := runProcessMySQLDump("CREATE TABLE test (a bigint PRIMARY KEY, b bigint GENERATED ALWAYS AS (a * 2) VIRTUAL);\n" +
+		"INSERT INTO test (a) VALUES (1);\n")
+	noIssues(conv, t, "Generated column")
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "test")
+	assert.Nil(t, err)
+	colId, err := internal.GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, "b")
+	assert.Nil(t, err)
+	generated := conv.SpSchema[tableId].ColDefs[colId].Generated
+	assert.True(t, generated.IsPresent)
+	assert.Equal(t, "a*2", generated.Value.Statement)
+	assert.Equal(t, []spannerData{{table: "test", cols: []string{"a"}, vals: []interface{}{int64(1)}}}, rows)
+}
+
+func TestProcessMySQLDump_FullTextIndex(t *testing.T) {
+	conv, _ := runProcessMySQLDump("CREATE TABLE posts (id bigint PRIMARY KEY, title varchar(100), body text, FULLTEXT KEY ft_title_body (title, body));\n" +
+		"CREATE FULLTEXT INDEX ft_body ON posts (body);\n")
+	noIssues(conv, t, "Full-text index")
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "posts")
+	assert.Nil(t, err)
+	spTable := conv.SpSchema[tableId]
+	assert.Empty(t, spTable.Indexes)
+	assert.Equal(t, 2, len(spTable.SearchIndexes))
+	titleTokens, err := internal.GetColIdFromSpName(spTable.ColDefs, "title_Tokens")
+	assert.Nil(t, err)
+	bodyTokens, err := internal.GetColIdFromSpName(spTable.ColDefs, "body_Tokens")
+	assert.Nil(t, err)
+	assert.Equal(t, "ft_title_body", spTable.SearchIndexes[0].Name)
+	assert.Equal(t, []ddl.IndexKey{{ColId: titleTokens, Order: 1}, {ColId: bodyTokens, Order: 2}}, spTable.SearchIndexes[0].Keys)
+	assert.Equal(t, "ft_body", spTable.SearchIndexes[1].Name)
+	assert.Equal(t, []ddl.IndexKey{{ColId: bodyTokens, Order: 1}}, spTable.SearchIndexes[1].Keys)
+	assert.Equal(t, "TOKENIZE_FULLTEXT(`body`)", spTable.ColDefs[bodyTokens].Generated.Value.Statement)
+}
+
+func TestProcessMySQLDump_FunctionalIndex(t *testing.T) {
+	conv, rows := runProcessMySQLDump("CREATE TABLE users (id bigint PRIMARY KEY, email varchar(100), INDEX idx_email ((lower(email))), INDEX idx_email_len ((length(email))));\n" +
+		"INSERT INTO users (id, email) VALUES (1, 'A@example.com');\n")
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "users")
+	assert.Nil(t, err)
+	spTable := conv.SpSchema[tableId]
+	exprColId, err := internal.GetColIdFromSpName(spTable.ColDefs, "idx_email_Expr")
+	assert.Nil(t, err)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, spTable.ColDefs[exprColId].T)
+	assert.True(t, spTable.ColDefs[exprColId].Generated.IsPresent)
+	assert.Equal(t, "LOWER(email)", spTable.ColDefs[exprColId].Generated.Value.Statement)
+	assert.Equal(t, 1, len(spTable.Indexes))
+	assert.Equal(t, "idx_email", spTable.Indexes[0].Name)
+	assert.Equal(t, []ddl.IndexKey{{ColId: exprColId, Order: 1}}, spTable.Indexes[0].Keys)
+	// The type of length(email) isn't known, so idx_email_len isn't migrated.
+	assert.Equal(t, []internal.InvalidIndex{{IssueType: internal.IndexNotMigrated, IndexName: "idx_email_len", Expression: "LENGTH(email)"}}, conv.InvalidIndexes[tableId])
+	// The generated column is computed by Spanner, so no data is written to it.
+	assert.Equal(t, []spannerData{{table: "users", cols: []string{"id", "email"}, vals: []interface{}{int64(1), "A@example.com"}}}, rows)
+}
+
+func TestProcessMySQLDump_View(t *testing.T) {
+	// mysqldump first creates a placeholder table for each view and then
+	// replaces it with the view itself.
+	conv, _ := runProcessMySQLDump("CREATE TABLE t (a bigint PRIMARY KEY, b text);\n" +
+		"/*!50001 CREATE VIEW `v` AS SELECT 1 AS `a`, 1 AS `b`*/;\n" +
+		"/*!50001 DROP VIEW IF EXISTS `v`*/;\n" +
+		"/*!50001 CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`localhost` SQL SECURITY DEFINER VIEW `v` AS select `t`.`a` AS `a`,`t`.`b` AS `b` from `t` where (`t`.`a` > 1) */;\n" +
+		"CREATE VIEW w AS SELECT a FROM v;\n")
+	assert.Equal(t, 2, len(conv.SrcViews))
+	views := map[string]schema.View{}
+	for _, v := range conv.SrcViews {
+		views[v.Name] = v
+	}
+	assert.Equal(t, "SELECT t.a AS a,t.b AS b FROM t WHERE (t.a>1)", views["v"].Query)
+	assert.Equal(t, []string{"t"}, views["v"].Refs)
+	assert.Equal(t, []string{"v"}, views["w"].Refs)
+	_, err := internal.GetTableIdFromSrcName(conv.SrcSchema, "v")
+	assert.NotNil(t, err)
+	// Views can't be verified without a Spanner instance, so none are migrated.
+	assert.Empty(t, conv.SpViews)
+	assert.Equal(t, 2, len(conv.InvalidViews))
+}
+
 func runProcessMySQLDump(s string) (*internal.Conv, []spannerData) {
 	conv := internal.MakeConv()
 	conv.SetLocation(time.UTC)
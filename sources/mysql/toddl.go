@@ -184,6 +184,12 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 				return ddl.Type{Name: ddl.Bytes, Len: srcType.Mods[0]}, nil
 			}
 			return ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, nil
+		case ddl.UUID:
+			// char(36) is commonly used to store the text of UUIDs.
+			if srcType.Name == "char" && len(srcType.Mods) > 0 && srcType.Mods[0] == 36 {
+				return ddl.Type{Name: ddl.UUID}, nil
+			}
+			fallthrough
 		default:
 			if len(srcType.Mods) > 0 {
 				return ddl.Type{Name: ddl.String, Len: srcType.Mods[0]}, nil
@@ -212,6 +218,12 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		switch spType {
 		case ddl.String:
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		case ddl.UUID:
+			// binary(16) is commonly used to store the bytes of UUIDs.
+			if srcType.Name == "binary" && len(srcType.Mods) > 0 && srcType.Mods[0] == 16 {
+				return ddl.Type{Name: ddl.UUID}, nil
+			}
+			fallthrough
 		default:
 			if len(srcType.Mods) > 0 {
 				return ddl.Type{Name: ddl.Bytes, Len: srcType.Mods[0]}, nil
@@ -249,7 +261,16 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		default:
 			return ddl.Type{Name: ddl.Timestamp}, nil
 		}
-	case "time", "year":
+	case "time":
+		// MySQL TIME values can be negative and exceed 24 hours, so they
+		// behave like intervals rather than times of day.
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Time}
+		default:
+			return ddl.Type{Name: ddl.Interval}, nil
+		}
+	case "year":
 		return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.Time}
 
 	}
@@ -209,6 +209,28 @@ func TestToSpannerTypeInternal(t *testing.T) {
 	}
 	assert.Equal(t, "BYTES", longBlobToBytesWithoutMods.Name)
 	assert.Equal(t, int64(10_485_760), longBlobToBytesWithoutMods.Len)
+
+	binaryToUUID, errCheck := toSpannerTypeInternal(schema.Type{Name: "binary", Mods: []int64{16}}, "UUID")
+	assert.Nil(t, errCheck)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, binaryToUUID)
+	charToUUID, errCheck := toSpannerTypeInternal(schema.Type{Name: "char", Mods: []int64{36}}, "UUID")
+	assert.Nil(t, errCheck)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, charToUUID)
+	// Other lengths can't hold UUIDs, so they keep their default types.
+	binary20ToUUID, _ := toSpannerTypeInternal(schema.Type{Name: "binary", Mods: []int64{20}}, "UUID")
+	assert.Equal(t, ddl.Type{Name: ddl.Bytes, Len: 20}, binary20ToUUID)
+	varcharToUUID, _ := toSpannerTypeInternal(schema.Type{Name: "varchar", Mods: []int64{36}}, "UUID")
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: 36}, varcharToUUID)
+
+	timeToInterval, errCheck := toSpannerTypeInternal(schema.Type{Name: "time"}, "")
+	assert.Nil(t, errCheck)
+	assert.Equal(t, ddl.Type{Name: ddl.Interval}, timeToInterval)
+	timeToString, errCheck := toSpannerTypeInternal(schema.Type{Name: "time"}, ddl.String)
+	assert.Equal(t, []internal.SchemaIssue{internal.Time}, errCheck)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, timeToString)
+	yearToString, errCheck := toSpannerTypeInternal(schema.Type{Name: "year"}, "")
+	assert.Equal(t, []internal.SchemaIssue{internal.Time}, errCheck)
+	assert.Equal(t, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, yearToString)
 }
 
 // This is just a very basic smoke-test for toSpannerType.
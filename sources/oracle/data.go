@@ -28,6 +28,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	xj "github.com/basgys/goxml2json"
 )
@@ -126,6 +127,8 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, T
 			return convertXmlToJson(val)
 		}
 		return val, nil
+	case ddl.Interval:
+		return convInterval(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
@@ -160,6 +163,45 @@ func convDate(val string) (civil.Date, error) {
 	return d, err
 }
 
+// convInterval maps a source database interval, in the form TO_CHAR
+// gives it (+DD HH:MI:SS.FF for INTERVAL DAY TO SECOND and +YY-MM for
+// INTERVAL YEAR TO MONTH), into the ISO 8601 form that Spanner uses for
+// INTERVAL values.
+func convInterval(val string) (string, error) {
+	neg := strings.HasPrefix(val, "-")
+	v := strings.TrimLeft(val, "+-")
+	if days, t, ok := strings.Cut(v, " "); ok {
+		d, err := strconv.ParseInt(days, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("can't convert to interval: %w", err)
+		}
+		dur, err := common.ParseIntervalTime(t)
+		if err != nil {
+			return "", err
+		}
+		if neg {
+			d, dur = -d, -dur
+		}
+		return common.FormatInterval(0, d, dur), nil
+	}
+	years, months, ok := strings.Cut(v, "-")
+	if !ok {
+		return "", fmt.Errorf("can't convert to interval: %q is not an interval", val)
+	}
+	y, err := strconv.ParseInt(years, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("can't convert to interval: %w", err)
+	}
+	m, err := strconv.ParseInt(months, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("can't convert to interval: %w", err)
+	}
+	if neg {
+		y, m = -y, -m
+	}
+	return common.FormatInterval(12*y+m, 0, 0), nil
+}
+
 func convFloat32(val string) (float32, error) {
 	f, err := strconv.ParseFloat(val, 32)
 	if err != nil {
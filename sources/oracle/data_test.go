@@ -95,6 +95,8 @@ func TestConvertData(t *testing.T) {
 		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "TIMESTAMP(6)", "2022-01-19T09:34:06.47Z", getTime("2022-01-19T09:34:06.47Z")},
 		{"json", ddl.Type{Name: ddl.JSON}, "VARCHAR2", "{\"abc\": 123}", "{\"abc\": 123}"},
 		{"bool", ddl.Type{Name: ddl.Bool}, "CHAR(1)", "T", true},
+		{"interval day to second", ddl.Type{Name: ddl.Interval}, "INTERVAL DAY(2) TO SECOND(6)", "-01 02:03:04.500000", "P-1DT-2H-3M-4.5S"},
+		{"interval year to month", ddl.Type{Name: ddl.Interval}, "INTERVAL YEAR(2) TO MONTH", "+01-02", "P1Y2M"},
 		{"arrayStr", ddl.Type{Name: ddl.String, IsArray: true}, "", "[\"CA\",\"CDSC\",\"DSCCS\"]", []spanner.NullString{{StringVal: "CA", Valid: true}, {StringVal: "CDSC", Valid: true}, {StringVal: "DSCCS", Valid: true}}},
 		{"arrayInt", ddl.Type{Name: ddl.Int64, IsArray: true}, "", "[1,2,3]", []spanner.NullInt64{{Int64: 1, Valid: true}, {Int64: 2, Valid: true}, {Int64: 3, Valid: true}}},
 		{"arrayBinaryFloat", ddl.Type{Name: ddl.Float32, IsArray: true}, "", "[1.5,0.00002,357657]", []spanner.NullFloat32{{Float32: 1.5, Valid: true}, {Float32: 0.00002, Valid: true}, {Float32: 357657, Valid: true}}},
@@ -58,9 +58,38 @@ func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string)
 		conv.Unexpected(fmt.Sprintf("Couldn't get source columns for table %s ", tbl.Name))
 		return nil, nil
 	}
+	q, args := isi.GetKeyRangeQuery(conv, tableId, internal.KeyRange{})
+	rows, err := isi.Db.Query(q, args...)
+	return rows, err
+}
+
+// GetKeyRangeQuery returns the query that reads the rows of a table whose
+// first primary key column lies in kr.
+func (isi InfoSchemaImpl) GetKeyRangeQuery(conv *internal.Conv, tableId string, kr internal.KeyRange) (string, []interface{}) {
+	tbl := conv.SrcSchema[tableId]
 	q := getSelectQuery(isi.DbName, tbl.Schema, tbl.Name, tbl.ColIds, tbl.ColDefs)
+	col := fmt.Sprintf(`"%s"`, common.GetKeyRangeColumn(tbl))
+	cond, args := common.KeyRangeCondition(col, kr, func(i int) string { return fmt.Sprintf(":%d", i) })
+	if cond != "" {
+		q += " WHERE " + cond
+	}
+	return q, args
+}
+
+// GetKeySplitPoints returns values of the first primary key column that
+// split a table into n ranges of similar size.
+func (isi InfoSchemaImpl) GetKeySplitPoints(conv *internal.Conv, tableId string, n int) ([]interface{}, error) {
+	tbl := conv.SrcSchema[tableId]
+	col := fmt.Sprintf(`"%s"`, common.GetKeyRangeColumn(tbl))
+	q := fmt.Sprintf(`SELECT k FROM (
+			SELECT k, tile, LAG(tile) OVER (ORDER BY k) AS prev_tile FROM (
+				SELECT %s AS k, NTILE(%d) OVER (ORDER BY %s) AS tile FROM "%s"."%s"))
+		WHERE tile <> prev_tile ORDER BY k`, col, n, col, tbl.Schema, tbl.Name)
 	rows, err := isi.Db.Query(q)
-	return rows, err
+	if err != nil {
+		return nil, err
+	}
+	return common.ScanKeySplitPoints(rows)
 }
 
 func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []string, colDefs map[string]schema.Column) string {
@@ -71,6 +100,8 @@ func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []
 		var s string
 		if TimestampReg.MatchString(colDefs[colId].Type.Name) {
 			s = fmt.Sprintf(`SYS_EXTRACT_UTC("%s") AS "%s"`, cn, cn)
+		} else if IntervalReg.MatchString(colDefs[colId].Type.Name) {
+			s = fmt.Sprintf(`TO_CHAR("%s") AS "%s"`, cn, cn)
 		} else if len(colDefs[colId].Type.ArrayBounds) == 1 {
 			s = fmt.Sprintf(`(SELECT JSON_ARRAYAGG(COLUMN_VALUE RETURNING VARCHAR2(4000)) 
 				FROM TABLE ("%s"."%s")) AS "%s"`, tableName, cn, cn)
@@ -104,12 +135,12 @@ func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []
 // ProcessData performs data conversion for source database.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
 	srcTableName := conv.SrcSchema[tableId].Name
-	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
+	q, args := isi.GetKeyRangeQuery(conv, tableId, additionalAttributes.KeyRange)
+	rows, err := isi.Db.Query(q, args...)
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTableName, err))
 		return err
 	}
-	rows := rowsInterface.(*sql.Rows)
 	defer rows.Close()
 	srcCols, _ := rows.Columns()
 	v, scanArgs := buildVals(len(srcCols))
@@ -70,10 +70,7 @@ func toSpannerTypeInternal(conv *internal.Conv, spType string, srcType schema.Ty
 		case ddl.String:
 			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
 		default:
-			if len(srcType.Mods) > 0 {
-				return ddl.Type{Name: ddl.String, Len: 30}, nil
-			}
-			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+			return ddl.Type{Name: ddl.Interval}, nil
 		}
 	}
 
@@ -49,8 +49,8 @@ func TestToSpannerTypeInternal(t *testing.T) {
 	if errCheck != nil {
 		t.Errorf("Error in interval to default conversion")
 	}
-	_, errCheck = toSpannerTypeInternal(conv, "", schema.Type{Name: "INTERVAL", Mods: []int64{}, ArrayBounds: []int64{}})
-	if errCheck != nil {
+	ty, errCheck := toSpannerTypeInternal(conv, "", schema.Type{Name: "INTERVAL DAY(2) TO SECOND(6)", Mods: []int64{}, ArrayBounds: []int64{}})
+	if ty.Name != ddl.Interval || errCheck != nil {
 		t.Errorf("Error in interval to default conversion")
 	}
 	_, errCheck = toSpannerTypeInternal(conv, "STRING", schema.Type{Name: "NUMBER", Mods: []int64{1, 2, 3}, ArrayBounds: []int64{1, 2, 3}})
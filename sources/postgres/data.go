@@ -28,7 +28,9 @@ import (
 	"cloud.google.com/go/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 // ProcessDataRow converts a row of data and writes it out to Spanner.
@@ -87,7 +89,7 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, vals []st
 		var x interface{}
 		var err error
 		if spColDef.T.IsArray {
-			x, err = convArray(spColDef.T, srcColDef.Type.Name, conv.Location, vals[i])
+			x, err = convArray(conv, spColDef.T, srcColDef.Type.Name, conv.Location, vals[i])
 		} else {
 			x, err = convScalar(conv, spColDef.T, srcColDef.Type.Name, conv.Location, vals[i])
 		}
@@ -103,7 +105,7 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, vals []st
 		aux.Sequence++
 		conv.SyntheticPKeys[tableId] = aux
 	}
-	return spSchema.Name, c, v, nil
+	return spSchema.QualifiedName(), c, v, nil
 }
 
 // convScalar converts a source database string value to an
@@ -137,6 +139,10 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, l
 		return convTimestamp(srcTypeName, location, val)
 	case ddl.JSON:
 		return val, nil
+	case ddl.UUID:
+		return convUUID(val)
+	case ddl.Interval:
+		return convInterval(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
@@ -207,6 +213,59 @@ func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
 	}
 }
 
+// convUUID maps a source database string value (representing a uuid)
+// into the canonical form of Spanner UUIDs.
+func convUUID(val string) (string, error) {
+	u, err := uuid.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("can't convert to uuid: %w", err)
+	}
+	return u.String(), nil
+}
+
+// convInterval maps a source database string value (representing an
+// interval) into the ISO 8601 form that Spanner uses for INTERVAL values.
+// PostgreSQL's default interval output looks like
+// "1 year 2 mons -3 days 04:05:06.5"; values that are already in ISO 8601
+// form (IntervalStyle iso_8601) are passed through.
+func convInterval(val string) (string, error) {
+	if strings.HasPrefix(val, "P") {
+		return val, nil
+	}
+	var months, days int64
+	var d time.Duration
+	fields := strings.Fields(val)
+	for i := 0; i < len(fields); i++ {
+		if strings.Contains(fields[i], ":") {
+			t, err := common.ParseIntervalTime(fields[i])
+			if err != nil {
+				return "", err
+			}
+			d += t
+			continue
+		}
+		if i+1 == len(fields) {
+			return "", fmt.Errorf("can't convert to interval: missing unit in %q", val)
+		}
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("can't convert to interval: %w", err)
+		}
+		i++
+		switch strings.TrimSuffix(fields[i], "s") {
+		case "year":
+			months += 12 * n
+		case "mon":
+			months += n
+		case "day":
+			days += n
+		default:
+			return "", fmt.Errorf("can't convert to interval: unknown unit %q", fields[i])
+		}
+	}
+	return common.FormatInterval(months, days, d), nil
+}
+
 // convTimestamp maps a source DB timestamp into a go Time (which
 // is translated to a Spanner timestamp by the go Spanner client library).
 // It handles both timestamptz and timestamp conversions.
@@ -253,8 +312,12 @@ func convTimestamp(srcTypeName string, location *time.Location, val string) (t t
 // is NULL. However, convArray does handle the case where individual
 // array elements are NULL. In other words, convArray handles "{1,
 // NULL, 2}", but it does not handle "NULL" (it returns error).
-func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location, v string) (interface{}, error) {
+func convArray(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, location *time.Location, v string) (interface{}, error) {
 	v = strings.TrimSpace(v)
+	// pgvector writes vectors as [v1,v2,...] rather than as arrays.
+	if srcTypeName == "vector" && strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") {
+		v = "{" + v[1:len(v)-1] + "}"
+	}
 	// Handle empty array. Note that we use an empty NullString array
 	// for all Spanner array types since this will be converted to the
 	// appropriate type by the Spanner client.
@@ -378,6 +441,41 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 			r = append(r, spanner.NullInt64{Int64: i, Valid: true})
 		}
 		return r, nil
+	case ddl.Numeric:
+		// Numeric arrays are written as PG.NUMERIC values for the PostgreSQL
+		// dialect, like numeric scalars (see convNumeric).
+		if conv.SpDialect == constants.DIALECT_POSTGRESQL {
+			var r []spanner.PGNumeric
+			for _, s := range a {
+				if s == "NULL" {
+					r = append(r, spanner.PGNumeric{Valid: false})
+					continue
+				}
+				s, err := processQuote(s)
+				if err != nil {
+					return []spanner.PGNumeric{}, err
+				}
+				r = append(r, spanner.PGNumeric{Numeric: s, Valid: true})
+			}
+			return r, nil
+		}
+		var r []spanner.NullNumeric
+		for _, s := range a {
+			if s == "NULL" {
+				r = append(r, spanner.NullNumeric{Valid: false})
+				continue
+			}
+			s, err := processQuote(s)
+			if err != nil {
+				return []spanner.NullNumeric{}, err
+			}
+			n := new(big.Rat)
+			if _, ok := n.SetString(s); !ok {
+				return []spanner.NullNumeric{}, fmt.Errorf("can't convert %q to big.Rat", s)
+			}
+			r = append(r, spanner.NullNumeric{Numeric: *n, Valid: true})
+		}
+		return r, nil
 	case ddl.String:
 		var r []spanner.NullString
 		for _, s := range a {
@@ -410,6 +508,42 @@ func convArray(spannerType ddl.Type, srcTypeName string, location *time.Location
 			r = append(r, spanner.NullTime{Time: t, Valid: true})
 		}
 		return r, nil
+	case ddl.UUID:
+		var r []spanner.NullString
+		for _, s := range a {
+			if s == "NULL" {
+				r = append(r, spanner.NullString{Valid: false})
+				continue
+			}
+			s, err := processQuote(s)
+			if err != nil {
+				return []spanner.NullString{}, err
+			}
+			u, err := convUUID(s)
+			if err != nil {
+				return []spanner.NullString{}, err
+			}
+			r = append(r, spanner.NullString{StringVal: u, Valid: true})
+		}
+		return r, nil
+	case ddl.Interval:
+		var r []spanner.NullString
+		for _, s := range a {
+			if s == "NULL" {
+				r = append(r, spanner.NullString{Valid: false})
+				continue
+			}
+			s, err := processQuote(s)
+			if err != nil {
+				return []spanner.NullString{}, err
+			}
+			iv, err := convInterval(s)
+			if err != nil {
+				return []spanner.NullString{}, err
+			}
+			r = append(r, spanner.NullString{StringVal: iv, Valid: true})
+		}
+		return r, nil
 	}
 	return []interface{}{}, fmt.Errorf("array type conversion not implemented for type %v", reflect.TypeOf(spannerType))
 }
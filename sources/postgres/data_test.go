@@ -16,12 +16,14 @@ package postgres
 
 import (
 	"fmt"
+	"math/big"
 	"math/bits"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -83,6 +85,10 @@ func TestConvertData(t *testing.T) {
 		{"string", ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, "", "eh", "eh"},
 		{"timestamptz", ddl.Type{Name: ddl.Timestamp}, "timestamptz", "2019-10-29 05:30:00+10", getTime(t, "2019-10-29T05:30:00+10:00")},
 		{"timestamp", ddl.Type{Name: ddl.Timestamp}, "timestamp", "2019-10-29 05:30:00", getTime(t, "2019-10-29T05:30:00Z")},
+		{"uuid", ddl.Type{Name: ddl.UUID}, "uuid", "A0EEBC99-9C0B-4EF8-BB6D-6BB9BD380A11", "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+		{"interval", ddl.Type{Name: ddl.Interval}, "interval", "1 year 2 mons -3 days 04:05:06.5", "P1Y2M-3DT4H5M6.5S"},
+		{"interval time only", ddl.Type{Name: ddl.Interval}, "interval", "-00:00:01", "PT-1S"},
+		{"interval iso 8601", ddl.Type{Name: ddl.Interval}, "interval", "P1Y2M3DT4H5M6S", "P1Y2M3DT4H5M6S"},
 
 		// Add cases for each array type, since each is a separate code path.
 		// Note: the PostgreSQL array output routine puts double quotes around
@@ -118,7 +124,20 @@ func TestConvertData(t *testing.T) {
 		{"timestamp array", ddl.Type{Name: ddl.Timestamp, IsArray: true}, "timestamptz", `{"2019-10-29 05:30:00+10",NULL}`, []spanner.NullTime{
 			spanner.NullTime{Time: getTime(t, "2019-10-29T05:30:00+10:00"), Valid: true},
 			spanner.NullTime{Valid: false}}},
+		{"uuid array", ddl.Type{Name: ddl.UUID, IsArray: true}, "uuid", "{a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11,NULL}", []spanner.NullString{
+			spanner.NullString{StringVal: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", Valid: true},
+			spanner.NullString{Valid: false}}},
+		{"interval array", ddl.Type{Name: ddl.Interval, IsArray: true}, "interval", `{"3 days",NULL}`, []spanner.NullString{
+			spanner.NullString{StringVal: "P3D", Valid: true},
+			spanner.NullString{Valid: false}}},
+		{"numeric array", ddl.Type{Name: ddl.Numeric, IsArray: true}, "numeric", "{1.7,NULL}", []spanner.NullNumeric{
+			spanner.NullNumeric{Numeric: *big.NewRat(17, 10), Valid: true},
+			spanner.NullNumeric{Valid: false}}},
 		{"empty array", ddl.Type{Name: ddl.String, Len: ddl.MaxLength, IsArray: true}, "", "{}", []spanner.NullString{}},
+		{"vector", ddl.Type{Name: ddl.Float32, IsArray: true, VectorLength: 3}, "vector", "[1.5,2,-3]", []spanner.NullFloat32{
+			spanner.NullFloat32{Float32: 1.5, Valid: true},
+			spanner.NullFloat32{Float32: 2, Valid: true},
+			spanner.NullFloat32{Float32: -3, Valid: true}}},
 	}
 	tableName := "testtable"
 	tableId := "t1"
@@ -141,6 +160,37 @@ func TestConvertData(t *testing.T) {
 		checkResults(t, at, ac, av, err, tableName, []string{col}, []interface{}{tc.e}, tc.name)
 	}
 
+	// The PostgreSQL dialect takes numeric arrays as PG.NUMERIC values.
+	pgConv := buildConv(
+		ddl.CreateTable{
+			Name:    tableName,
+			Id:      tableId,
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]ddl.ColumnDef{"c1": ddl.ColumnDef{Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Numeric, IsArray: true}}}},
+		schema.Table{Name: tableName,
+			Id:      tableId,
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]schema.Column{"c1": schema.Column{Name: "a", Id: "c1", Type: schema.Type{Name: "numeric", ArrayBounds: []int64{-1}}}}})
+	pgConv.SpDialect = constants.DIALECT_POSTGRESQL
+	at, ac, av, err := ConvertData(pgConv, tableId, []string{"c1"}, []string{"{1.7,NULL}"})
+	checkResults(t, at, ac, av, err, tableName, []string{"a"}, []interface{}{[]spanner.PGNumeric{{Numeric: "1.7", Valid: true}, {Valid: false}}}, "pg numeric array")
+
+	// Data for tables in named schemas is written to the schema-qualified table.
+	namedSchemaConv := buildConv(
+		ddl.CreateTable{
+			Name:       tableName,
+			SchemaName: "sales",
+			Id:         tableId,
+			ColIds:     []string{"c1"},
+			ColDefs:    map[string]ddl.ColumnDef{"c1": ddl.ColumnDef{Name: "a", Id: "c1", T: ddl.Type{Name: ddl.Int64}}}},
+		schema.Table{Name: "sales." + tableName,
+			Schema:  "sales",
+			Id:      tableId,
+			ColIds:  []string{"c1"},
+			ColDefs: map[string]schema.Column{"c1": schema.Column{Name: "a", Id: "c1", Type: schema.Type{Name: "bigint"}}}})
+	at, ac, av, err = ConvertData(namedSchemaConv, tableId, []string{"c1"}, []string{"7"})
+	checkResults(t, at, ac, av, err, "sales."+tableName, []string{"a"}, []interface{}{int64(7)}, "named schema")
+
 	timestampTests := []struct {
 		name  string
 		srcTy string
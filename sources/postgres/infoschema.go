@@ -29,6 +29,7 @@ import (
 	"cloud.google.com/go/civil"
 	sp "cloud.google.com/go/spanner"
 	_ "github.com/lib/pq" // we will use database/sql package instead of using this package directly
+	pg_query "github.com/pganalyze/pg_query_go/v6"
 
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
@@ -126,22 +127,56 @@ func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
 
 // GetRowsFromTable returns a sql Rows object for a table.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	q, args := isi.GetKeyRangeQuery(conv, tableId, internal.KeyRange{})
+	rows, err := isi.Db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// GetKeyRangeQuery returns the query that reads the rows of a table whose
+// first primary key column lies in kr.
+func (isi InfoSchemaImpl) GetKeyRangeQuery(conv *internal.Conv, tableId string, kr internal.KeyRange) (string, []interface{}) {
+	q := fmt.Sprintf(`SELECT * FROM %s`, quotedTableName(conv.SrcSchema[tableId]))
+	col := fmt.Sprintf(`"%s"`, common.GetKeyRangeColumn(conv.SrcSchema[tableId]))
+	cond, args := common.KeyRangeCondition(col, kr, func(i int) string { return fmt.Sprintf("$%d", i) })
+	if cond != "" {
+		q += " WHERE " + cond
+	}
+	return q + ";", args
+}
+
+// GetKeySplitPoints returns values of the first primary key column that
+// split a table into n ranges of similar size.
+func (isi InfoSchemaImpl) GetKeySplitPoints(conv *internal.Conv, tableId string, n int) ([]interface{}, error) {
+	col := fmt.Sprintf(`"%s"`, common.GetKeyRangeColumn(conv.SrcSchema[tableId]))
+	// Split points are returned as text so that they can be passed back as
+	// arguments of the key range query whatever the type of the column.
+	q := fmt.Sprintf(`SELECT k::text FROM (
+			SELECT k, tile, LAG(tile) OVER (ORDER BY k) AS prev_tile FROM (
+				SELECT %s AS k, NTILE(%d) OVER (ORDER BY %s) AS tile FROM %s) AS t) AS b
+		WHERE tile <> prev_tile ORDER BY k;`, col, n, col, quotedTableName(conv.SrcSchema[tableId]))
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	return common.ScanKeySplitPoints(rows)
+}
+
+// quotedTableName returns the quoted, schema qualified name of a table.
+func quotedTableName(tbl schema.Table) string {
 	// PostgreSQL schema and name can be arbitrary strings.
 	// Ideally we would pass schema/name as a query parameter,
 	// but PostgreSQL doesn't support this. So we quote it instead.
-	isSchemaNamePrefixed := strings.HasPrefix(conv.SrcSchema[tableId].Name, conv.SrcSchema[tableId].Schema+".")
+	isSchemaNamePrefixed := strings.HasPrefix(tbl.Name, tbl.Schema+".")
 	var tableName string
 	if isSchemaNamePrefixed {
-		tableName = strings.TrimPrefix(conv.SrcSchema[tableId].Name, conv.SrcSchema[tableId].Schema+".")
+		tableName = strings.TrimPrefix(tbl.Name, tbl.Schema+".")
 	} else {
-		tableName = conv.SrcSchema[tableId].Name
+		tableName = tbl.Name
 	}
-	q := fmt.Sprintf(`SELECT * FROM "%s"."%s";`, conv.SrcSchema[tableId].Schema, tableName)
-	rows, err := isi.Db.Query(q)
-	if err != nil {
-		return nil, err
-	}
-	return rows, err
+	return fmt.Sprintf(`"%s"."%s"`, tbl.Schema, tableName)
 }
 
 // ProcessDataRows performs data conversion for source database
@@ -165,12 +200,12 @@ func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string)
 // *interface{} parameters to row.Scan.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, colIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
 	srcTableName := conv.SrcSchema[tableId].Name
-	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
+	q, args := isi.GetKeyRangeQuery(conv, tableId, additionalAttributes.KeyRange)
+	rows, err := isi.Db.Query(q, args...)
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTableName, err))
 		return err
 	}
-	rows := rowsInterface.(*sql.Rows)
 	defer rows.Close()
 	srcCols, _ := rows.Columns()
 	v, iv := buildVals(len(srcCols))
@@ -191,7 +226,7 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 			conv.CollectBadRow(srcTableName, srcCols, valsToStrings(v))
 			continue
 		}
-		conv.WriteRow(srcTableName, conv.SpSchema[tableId].Name, cvtCols, cvtVals)
+		conv.WriteRow(srcTableName, conv.SpSchema[tableId].QualifiedName(), cvtCols, cvtVals)
 	}
 	return nil
 }
@@ -284,9 +319,57 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	return tables, nil
 }
 
+// GetViews returns the views of user schemas. view_definition is only
+// readable by the owner of the view, and the views of other users are
+// skipped.
+func (isi InfoSchemaImpl) GetViews(conv *internal.Conv) ([]schema.View, error) {
+	q := `SELECT v.table_schema, v.table_name, v.view_definition, u.table_schema, u.table_name
+		FROM information_schema.views AS v
+		LEFT JOIN information_schema.view_table_usage AS u
+			ON u.view_schema = v.table_schema
+			AND u.view_name = v.table_name
+		WHERE v.table_schema NOT IN ('information_schema', 'pg_catalog')
+		ORDER BY v.table_schema, v.table_name, u.table_schema, u.table_name`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var viewSchema, viewName string
+	var definition, refSchema, refTable sql.NullString
+	var views []schema.View
+	for rows.Next() {
+		err := rows.Scan(&viewSchema, &viewName, &definition, &refSchema, &refTable)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		if !definition.Valid {
+			continue
+		}
+		name := isi.GetTableName(viewSchema, viewName)
+		if len(views) == 0 || views[len(views)-1].Name != name {
+			// Definitions end with a semicolon.
+			query := strings.TrimRight(strings.TrimSpace(definition.String), ";")
+			views = append(views, schema.View{Name: name, Schema: viewSchema, Id: internal.GenerateViewId(), Query: query})
+		}
+		if refTable.Valid {
+			view := &views[len(views)-1]
+			view.Refs = append(view.Refs, isi.GetTableName(refSchema.String, refTable.String))
+		}
+	}
+	return views, nil
+}
+
 // GetColumns returns a list of Column objects and names
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
-	q := `SELECT c.column_name, c.data_type, e.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale
+	// The number of dimensions of pgvector vectors is the type modifier of
+	// the column, which information_schema doesn't report.
+	q := `SELECT c.column_name, c.data_type, e.data_type, c.is_nullable, c.column_default, c.character_maximum_length, c.numeric_precision, c.numeric_scale, c.generation_expression,
+                CASE WHEN c.udt_name = 'vector' THEN
+                  (SELECT a.atttypmod FROM pg_attribute a
+                    WHERE a.attrelid = format('%I.%I', c.table_schema, c.table_name)::regclass AND a.attname = c.column_name)
+                END AS vector_dimensions
               FROM information_schema.COLUMNS c LEFT JOIN information_schema.element_types e
                  ON ((c.table_catalog, c.table_schema, c.table_name, 'TABLE', c.dtd_identifier)
                      = (e.object_catalog, e.object_schema, e.object_name, e.object_type, e.collection_type_identifier))
@@ -300,10 +383,10 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	colDefs := make(map[string]schema.Column)
 	var colIds []string
 	var colName, dataType, isNullable string
-	var colDefault, elementDataType sql.NullString
-	var charMaxLen, numericPrecision, numericScale sql.NullInt64
+	var colDefault, elementDataType, colGenerated sql.NullString
+	var charMaxLen, numericPrecision, numericScale, vectorDimensions sql.NullInt64
 	for cols.Next() {
-		err := cols.Scan(&colName, &dataType, &elementDataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale)
+		err := cols.Scan(&colName, &dataType, &elementDataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &colGenerated, &vectorDimensions)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
@@ -324,12 +407,13 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		ignored.Default = colDefault.Valid && !isSerialColumn
 		colId := internal.GenerateColumnId()
 		c := schema.Column{
-			Id:      colId,
-			Name:    colName,
-			Type:    toType(dataType, elementDataType, charMaxLen, numericPrecision, numericScale),
-			NotNull: common.ToNotNull(conv, isNullable),
-			Ignored: ignored,
-			AutoGen: toAutoGen(isSerialColumn),
+			Id:        colId,
+			Name:      colName,
+			Type:      toType(dataType, elementDataType, charMaxLen, numericPrecision, numericScale, vectorDimensions),
+			NotNull:   common.ToNotNull(conv, isNullable),
+			Ignored:   ignored,
+			AutoGen:   toAutoGen(isSerialColumn),
+			Generated: toGenerated(colGenerated),
 		}
 		colDefs[colId] = c
 		colIds = append(colIds, colId)
@@ -480,7 +564,8 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 			a.attname AS column_name,
 			1 + Array_position(i.indkey, a.attnum) AS column_position,
 			i.indisunique AS is_unique,
-			CASE o.OPTION & 1 WHEN 1 THEN 'DESC' ELSE 'ASC' END AS order
+			CASE o.OPTION & 1 WHEN 1 THEN 'DESC' ELSE 'ASC' END AS order,
+			pg_get_indexdef(i.indexrelid) AS index_def
 		FROM pg_index AS i
 		JOIN pg_class AS trel
 		ON trel.oid = i.indrelid
@@ -491,7 +576,7 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		CROSS JOIN LATERAL UNNEST (i.indkey) WITH ordinality AS c (colnum, ordinality)
 		LEFT JOIN LATERAL UNNEST (i.indoption) WITH ordinality AS o (OPTION, ordinality)
 		ON c.ordinality = o.ordinality
-		JOIN pg_attribute AS a
+		LEFT JOIN pg_attribute AS a
 		ON trel.oid = a.attrelid
 			AND a.attnum = c.colnum
 		WHERE tnsp.nspname= $1
@@ -509,36 +594,85 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		return nil, err
 	}
 	defer rows.Close()
-	var name, column, sequence, isUnique, collation string
+	var name, isUnique, collation, indexDef string
+	// Key parts that are expressions have no column.
+	var column, sequence sql.NullString
 	indexMap := make(map[string]schema.Index)
+	// skipped are the indexes that can't be migrated.
+	skipped := make(map[string]bool)
+	keysFromDef := make(map[string]bool)
 	var indexNames []string
 	var indexes []schema.Index
 	for rows.Next() {
-		if err := rows.Scan(&name, &column, &sequence, &isUnique, &collation); err != nil {
+		if err := rows.Scan(&name, &column, &sequence, &isUnique, &collation, &indexDef); err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
 		}
 		if _, found := indexMap[name]; !found {
 			indexNames = append(indexNames, name)
-			indexMap[name] = schema.Index{
+			index := schema.Index{
 				Id:     internal.GenerateIndexesId(),
 				Name:   name,
 				Unique: (isUnique == "true")}
+			if n := parseIndexDef(indexDef); n != nil {
+				if cols, ok := fullTextColumns(n); ok {
+					index.FullText = true
+					for _, col := range cols {
+						index.Keys = append(index.Keys, schema.Key{ColId: colNameIdMap[col]})
+					}
+				}
+				if distanceType, ok := vectorIndexDistanceType(conv, n); ok {
+					if distanceType == "" {
+						skipped[name] = true
+					}
+					index.VectorDistanceType = distanceType
+				}
+				// The columns of expressions are NULL, so the keys of
+				// indexes with expressions come from their definition.
+				if !index.FullText && hasExpressionKeys(n) {
+					index.Keys = toIndexKeys(conv, name, n.IndexParams, colNameIdMap)
+					keysFromDef[name] = true
+				}
+				index.Predicate = indexPredicate(conv, n)
+			}
+			indexMap[name] = index
+		}
+		if skipped[name] {
+			continue
 		}
 		index := indexMap[name]
+		if index.FullText || keysFromDef[name] || !column.Valid {
+			continue
+		}
 		index.Keys = append(index.Keys, schema.Key{
-			ColId: colNameIdMap[column],
+			ColId: colNameIdMap[column.String],
 			Desc:  (collation == "DESC")})
 		indexMap[name] = index
 	}
 	for _, k := range indexNames {
-		indexes = append(indexes, indexMap[k])
+		if !skipped[k] {
+			indexes = append(indexes, indexMap[k])
+		}
 	}
 	return indexes, nil
 }
 
-func toType(dataType string, elementDataType sql.NullString, charLen sql.NullInt64, numericPrecision, numericScale sql.NullInt64) schema.Type {
+// parseIndexDef parses the definition of an index, as returned by
+// pg_get_indexdef, and returns nil if it can't be parsed.
+func parseIndexDef(def string) *pg_query.IndexStmt {
+	tree, err := pg_query.Parse(def)
+	if err != nil || len(tree.Stmts) == 0 {
+		return nil
+	}
+	return tree.Stmts[0].Stmt.GetIndexStmt()
+}
+
+func toType(dataType string, elementDataType sql.NullString, charLen sql.NullInt64, numericPrecision, numericScale, vectorDimensions sql.NullInt64) schema.Type {
 	switch {
+	case vectorDimensions.Valid && vectorDimensions.Int64 > 0:
+		return schema.Type{Name: "vector", Mods: []int64{vectorDimensions.Int64}}
+	case vectorDimensions.Valid:
+		return schema.Type{Name: "vector"}
 	case dataType == "ARRAY" && elementDataType.Valid:
 		return schema.Type{Name: elementDataType.String, ArrayBounds: []int64{-1}}
 		// TODO: handle error cases.
@@ -563,12 +697,29 @@ func toAutoGen(isSerial bool) ddl.AutoGenCol {
 	return autoGen;
 }
 
+// toGenerated returns the generation expression of a column, which
+// information_schema reports only for generated columns.
+func toGenerated(generationExpression sql.NullString) ddl.GeneratedColumn {
+	if !generationExpression.Valid || generationExpression.String == "" {
+		return ddl.GeneratedColumn{}
+	}
+	return ddl.GeneratedColumn{
+		IsPresent: true,
+		Value: ddl.Expression{
+			ExpressionId: internal.GenerateExpressionId(),
+			Statement:    generationExpression.String,
+		},
+	}
+}
+
 func cvtSQLArray(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef, val interface{}) (interface{}, error) {
-	a, ok := val.([]byte)
-	if !ok {
-		return nil, fmt.Errorf("can't convert array values to []byte")
+	switch a := val.(type) {
+	case []byte:
+		return convArray(conv, spCd.T, srcCd.Type.Name, conv.Location, string(a))
+	case string:
+		return convArray(conv, spCd.T, srcCd.Type.Name, conv.Location, a)
 	}
-	return convArray(spCd.T, srcCd.Type.Name, conv.Location, string(a))
+	return nil, fmt.Errorf("can't convert array values to []byte")
 }
 
 // cvtSQLScalar converts a values returned from a SQL query to a
@@ -689,6 +840,20 @@ func cvtSQLScalar(conv *internal.Conv, srcCd schema.Column, spCd ddl.ColumnDef,
 		case []uint8:
 			return string(v), nil
 		}
+	case ddl.UUID:
+		switch v := val.(type) {
+		case []byte:
+			return convUUID(string(v))
+		case string:
+			return convUUID(v)
+		}
+	case ddl.Interval:
+		switch v := val.(type) {
+		case []byte:
+			return convInterval(string(v))
+		case string:
+			return convInterval(v)
+		}
 	}
 	return nil, fmt.Errorf("can't convert value of type %s to Spanner type %s", reflect.TypeOf(val), reflect.TypeOf(spCd.T))
 }
@@ -19,6 +19,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"math/big"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -85,17 +86,17 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "user"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "vector_dimensions"},
 			rows: [][]driver.Value{
-				{"user_id", "text", nil, "NO", nil, nil, nil, nil},
-				{"name", "text", nil, "NO", nil, nil, nil, nil},
-				{"ref", "bigint", nil, "YES", nil, nil, nil, nil}},
+				{"user_id", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"name", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"ref", "bigint", nil, "YES", nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "user"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
 		},
 
 		{
@@ -122,22 +123,22 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "cart"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "vector_dimensions"},
 			rows: [][]driver.Value{
-				{"productid", "text", nil, "NO", nil, nil, nil, nil},
-				{"userid", "text", nil, "NO", nil, nil, nil, nil},
-				{"quantity", "bigint", nil, "YES", nil, nil, 64, 0}},
+				{"productid", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"userid", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"quantity", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "cart"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
-			rows: [][]driver.Value{{"index1", "userid", 1, "false", "ASC"},
-				{"index2", "userid", 1, "true", "ASC"},
-				{"index2", "productid", 2, "true", "DESC"},
-				{"index3", "productid", 1, "true", "DESC"},
-				{"index3", "userid", 2, "true", "ASC"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
+			rows: [][]driver.Value{{"index1", "userid", 1, "false", "ASC", "CREATE INDEX index1 ON public.cart USING btree (userid)"},
+				{"index2", "userid", 1, "true", "ASC", "CREATE UNIQUE INDEX index2 ON public.cart USING btree (userid, productid DESC)"},
+				{"index2", "productid", 2, "true", "DESC", "CREATE UNIQUE INDEX index2 ON public.cart USING btree (userid, productid DESC)"},
+				{"index3", "productid", 1, "true", "DESC", "CREATE UNIQUE INDEX index3 ON public.cart USING btree (productid DESC, userid)"},
+				{"index3", "userid", 2, "true", "ASC", "CREATE UNIQUE INDEX index3 ON public.cart USING btree (productid DESC, userid)"},
 			},
 		},
 		{
@@ -160,16 +161,16 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "product"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "vector_dimensions"},
 			rows: [][]driver.Value{
-				{"product_id", "text", nil, "NO", nil, nil, nil, nil},
-				{"product_name", "text", nil, "NO", nil, nil, nil, nil}},
+				{"product_id", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"product_name", "text", nil, "NO", nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "product"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
 		},
 
 		{
@@ -194,35 +195,35 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "vector_dimensions"},
 			rows: [][]driver.Value{
-				{"id", "bigint", nil, "NO", "nextval('public.test_id_seq'::regclass)", nil, 64, 0},
-				{"aint", "ARRAY", "integer", "YES", nil, nil, nil, nil},
-				{"atext", "ARRAY", "text", "YES", nil, nil, nil, nil},
-				{"b", "boolean", nil, "YES", nil, nil, nil, nil},
-				{"bs", "bigint", nil, "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0},
-				{"by", "bytea", nil, "YES", nil, nil, nil, nil},
-				{"c", "character", nil, "YES", nil, 1, nil, nil},
-				{"c_8", "character", nil, "YES", nil, 8, nil, nil},
-				{"d", "date", nil, "YES", nil, nil, nil, nil},
-				{"f8", "double precision", nil, "YES", nil, nil, 53, nil},
-				{"f4", "real", nil, "YES", nil, nil, 24, nil},
-				{"i8", "bigint", nil, "YES", nil, nil, 64, 0},
-				{"i4", "integer", nil, "YES", nil, nil, 32, 0},
-				{"i2", "smallint", nil, "YES", nil, nil, 16, 0},
-				{"num", "numeric", nil, "YES", nil, nil, nil, nil},
-				{"s", "integer", nil, "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0},
-				{"ts", "timestamp without time zone", nil, "YES", nil, nil, nil, nil},
-				{"tz", "timestamp with time zone", nil, "YES", nil, nil, nil, nil},
-				{"txt", "text", nil, "NO", nil, nil, nil, nil},
-				{"vc", "character varying", nil, "YES", nil, nil, nil, nil},
-				{"vc6", "character varying", nil, "YES", nil, 6, nil, nil}},
+				{"id", "bigint", nil, "NO", "nextval('public.test_id_seq'::regclass)", nil, 64, 0, nil, nil},
+				{"aint", "ARRAY", "integer", "YES", nil, nil, nil, nil, nil, nil},
+				{"atext", "ARRAY", "text", "YES", nil, nil, nil, nil, nil, nil},
+				{"b", "boolean", nil, "YES", nil, nil, nil, nil, nil, nil},
+				{"bs", "bigint", nil, "NO", "nextval('test11_bs_seq'::regclass)", nil, 64, 0, nil, nil},
+				{"by", "bytea", nil, "YES", nil, nil, nil, nil, nil, nil},
+				{"c", "character", nil, "YES", nil, 1, nil, nil, nil, nil},
+				{"c_8", "character", nil, "YES", nil, 8, nil, nil, nil, nil},
+				{"d", "date", nil, "YES", nil, nil, nil, nil, nil, nil},
+				{"f8", "double precision", nil, "YES", nil, nil, 53, nil, nil, nil},
+				{"f4", "real", nil, "YES", nil, nil, 24, nil, nil, nil},
+				{"i8", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil},
+				{"i4", "integer", nil, "YES", nil, nil, 32, 0, nil, nil},
+				{"i2", "smallint", nil, "YES", nil, nil, 16, 0, nil, nil},
+				{"num", "numeric", nil, "YES", nil, nil, nil, nil, nil, nil},
+				{"s", "integer", nil, "NO", "nextval('test11_s_seq'::regclass)", nil, 32, 0, nil, nil},
+				{"ts", "timestamp without time zone", nil, "YES", nil, nil, nil, nil, nil, nil},
+				{"tz", "timestamp with time zone", nil, "YES", nil, nil, nil, nil, nil, nil},
+				{"txt", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"vc", "character varying", nil, "YES", nil, nil, nil, nil, nil, nil},
+				{"vc6", "character varying", nil, "YES", nil, 6, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
 		},
 
 		{
@@ -245,17 +246,21 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test_ref"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "vector_dimensions"},
 			rows: [][]driver.Value{
-				{"ref_id", "bigint", nil, "NO", nil, nil, 64, 0},
-				{"ref_txt", "text", nil, "NO", nil, nil, nil, nil},
-				{"abc", "text", nil, "NO", nil, nil, nil, nil}},
+				{"ref_id", "bigint", nil, "NO", nil, nil, 64, 0, nil, nil},
+				{"ref_txt", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"abc", "text", nil, "NO", nil, nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "test_ref"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
+		},
+		{
+			query: "SELECT (.+) FROM information_schema.views (.+)",
+			cols:  []string{"table_schema", "table_name", "view_definition", "table_schema", "table_name"},
 		},
 	}
 	db := mkMockDB(t, ms)
@@ -421,6 +426,122 @@ func TestProcessData(t *testing.T) {
 	assert.Equal(t, int64(1), conv.Unexpecteds()) // Bad row generates an entry in unexpected.
 }
 
+// TestProcessData_KeyRanges checks that a table with a primary key is read
+// in primary key ranges when more than one read worker is configured.
+func TestProcessData_KeyRanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery(`SELECT k::text FROM (.+) NTILE\(3\) OVER \(ORDER BY "id"\) AS tile FROM "public"."t"`).
+		WillReturnRows(sqlmock.NewRows([]string{"k"}).AddRow("10").AddRow("20"))
+	mock.ExpectQuery(`SELECT [*] FROM "public"."t" WHERE "id" < \$1;`).WithArgs("10").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v"}).AddRow(1, "a"))
+	mock.ExpectQuery(`SELECT [*] FROM "public"."t" WHERE "id" >= \$1 AND "id" < \$2;`).WithArgs("10", "20").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v"}).AddRow(15, "b").AddRow(16, "c"))
+	mock.ExpectQuery(`SELECT [*] FROM "public"."t" WHERE "id" >= \$1;`).WithArgs("20").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v"}).AddRow(25, "d"))
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "t",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "v", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}}},
+		schema.Table{
+			Name:   "t",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "int8"}},
+				"c2": {Name: "v", Id: "c2", Type: schema.Type{Name: "text"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c1"}}})
+	conv.SetDataMode()
+	conv.Audit.ReadWorkers = 3
+	var rows []spannerData
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, internal.AdditionalDataAttributes{})
+
+	assert.ElementsMatch(t,
+		[]spannerData{
+			{table: "t", cols: []string{"id", "v"}, vals: []interface{}{int64(1), "a"}},
+			{table: "t", cols: []string{"id", "v"}, vals: []interface{}{int64(15), "b"}},
+			{table: "t", cols: []string{"id", "v"}, vals: []interface{}{int64(16), "c"}},
+			{table: "t", cols: []string{"id", "v"}, vals: []interface{}{int64(25), "d"}},
+		},
+		rows)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestProcessData_Checkpoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.Nil(t, err)
+	// Rows below the watermark were written by the migration being resumed,
+	// so only the remaining key ranges are read.
+	mock.ExpectQuery(`SELECT [*] FROM "public"."t" WHERE "id" >= \$1 AND "id" < \$2;`).WithArgs("10", "20").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v"}).AddRow(15, "b"))
+	mock.ExpectQuery(`SELECT [*] FROM "public"."t" WHERE "id" >= \$1;`).WithArgs("20").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "v"}).AddRow(25, "d"))
+	conv := buildConv(
+		ddl.CreateTable{
+			Name:   "t",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "v", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}}},
+		schema.Table{
+			Name:   "t",
+			Id:     "t1",
+			Schema: "public",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]schema.Column{
+				"c1": {Name: "id", Id: "c1", Type: schema.Type{Name: "int8"}},
+				"c2": {Name: "v", Id: "c2", Type: schema.Type{Name: "text"}},
+			},
+			PrimaryKeys: []schema.Key{{ColId: "c1"}}})
+	conv.SetDataMode()
+	conv.Stats.Rows["t"] = 3
+	path := filepath.Join(t.TempDir(), "test.checkpoint.json")
+	assert.Nil(t, internal.NewCheckpoint(path).Update("t", internal.TableCheckpoint{Watermark: "10", SplitPoints: []interface{}{"20"}}))
+	conv.Audit.Checkpoint, err = internal.LoadCheckpoint(path)
+	assert.Nil(t, err)
+	var rows []spannerData
+	conv.SetDataSink(
+		func(table string, cols []string, vals []interface{}) {
+			rows = append(rows, spannerData{table: table, cols: cols, vals: vals})
+		})
+	commonInfoSchema := common.InfoSchemaImpl{}
+	commonInfoSchema.ProcessData(conv, InfoSchemaImpl{db, "migration-project-id", profiles.SourceProfile{}, profiles.TargetProfile{}, newFalsePtr()}, internal.AdditionalDataAttributes{})
+
+	assert.Equal(t,
+		[]spannerData{
+			{table: "t", cols: []string{"id", "v"}, vals: []interface{}{int64(15), "b"}},
+			{table: "t", cols: []string{"id", "v"}, vals: []interface{}{int64(25), "d"}},
+		},
+		rows)
+	assert.Nil(t, mock.ExpectationsWereMet())
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+	assert.Equal(t, int64(2), conv.Stats.GoodRows["t"])
+	assert.Equal(t, int64(1), conv.Stats.ResumedRows["t"])
+	assert.Equal(t, []string{"t"}, conv.Audit.Checkpoint.ResumedTables())
+	checkpoint, err := internal.LoadCheckpoint(path)
+	assert.Nil(t, err)
+	tc, _ := checkpoint.Get("t")
+	assert.True(t, tc.Completed)
+}
+
 func TestConvertSqlRow_SingleCol(t *testing.T) {
 	tDate, _ := time.Parse("2006-01-02", "2019-10-29")
 	tc := []struct {
@@ -467,6 +588,10 @@ func TestConvertSqlRow_SingleCol(t *testing.T) {
 			in: getTime(t, "2019-10-29T05:30:00Z"), e: getTime(t, "2019-10-29T05:30:00Z")},
 		{name: "timestamp string", srcType: schema.Type{Name: "timestamptz"}, spType: ddl.Type{Name: ddl.Timestamp},
 			in: "2019-10-29 05:30:00", e: getTime(t, "2019-10-29T05:30:00Z")},
+		{name: "uuid", srcType: schema.Type{Name: "uuid"}, spType: ddl.Type{Name: ddl.UUID},
+			in: []byte("a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"), e: "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"},
+		{name: "interval", srcType: schema.Type{Name: "interval"}, spType: ddl.Type{Name: ddl.Interval},
+			in: "14 mon 3 day 04:05:06.000000", e: "P1Y2M3DT4H5M6S"},
 
 		// ConvertSqlRow uses convArray for conversion of array types.
 		// Since convArray is extensively tested in data_test.go, we
@@ -540,17 +665,21 @@ func TestConvertSqlRow_MultiCol(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "generation_expression", "vector_dimensions"},
 			rows: [][]driver.Value{
-				{"a", "text", nil, "NO", nil, nil, nil, nil},
-				{"b", "double precision", nil, "YES", nil, nil, 53, nil},
-				{"c", "bigint", nil, "YES", nil, nil, 64, 0}},
+				{"a", "text", nil, "NO", nil, nil, nil, nil, nil, nil},
+				{"b", "double precision", nil, "YES", nil, nil, 53, nil, nil, nil},
+				{"c", "bigint", nil, "YES", nil, nil, 64, 0, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
 			query: "SELECT (.+) FROM pg_index (.+)",
 			args:  []driver.Value{"public", "test"},
-			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
+		},
+		{
+			query: "SELECT (.+) FROM information_schema.views (.+)",
+			cols:  []string{"table_schema", "table_name", "view_definition", "table_schema", "table_name"},
 		},
 		{
 			query: `SELECT [*] FROM "public"."test"`, // query is a regexp!
@@ -639,3 +768,127 @@ func newFalsePtr() *bool {
 	temp := false
 	return &temp
 }
+
+func TestToGenerated(t *testing.T) {
+	assert.Equal(t, ddl.GeneratedColumn{}, toGenerated(sql.NullString{}))
+	assert.Equal(t, ddl.GeneratedColumn{}, toGenerated(sql.NullString{String: "", Valid: true}))
+	generated := toGenerated(sql.NullString{String: "(price * quantity)", Valid: true})
+	assert.True(t, generated.IsPresent)
+	assert.Equal(t, "(price * quantity)", generated.Value.Statement)
+	assert.NotEmpty(t, generated.Value.ExpressionId)
+}
+
+func TestGetIndexes_FullText(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM pg_index (.+)",
+			args:  []driver.Value{"public", "posts"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
+			rows: [][]driver.Value{
+				{"ft_posts", nil, nil, "false", "ASC", "CREATE INDEX ft_posts ON public.posts USING gin (to_tsvector('english'::regconfig, ((COALESCE(title, ''::text) || ' '::text) || body)))"},
+				{"idx_title", "title", 1, "false", "ASC", "CREATE INDEX idx_title ON public.posts USING btree (title)"},
+				{"idx_lower_title", nil, nil, "false", "ASC", "CREATE INDEX idx_lower_title ON public.posts USING btree (lower(title))"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+
+	indexes, err := isi.GetIndexes(conv, common.SchemaAndName{Schema: "public", Name: "posts"}, map[string]string{"title": "c1", "body": "c2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(indexes))
+	assert.True(t, indexes[0].FullText)
+	assert.Equal(t, []schema.Key{{ColId: "c1"}, {ColId: "c2"}}, indexes[0].Keys)
+	assert.False(t, indexes[1].FullText)
+	assert.Equal(t, []schema.Key{{ColId: "c1"}}, indexes[1].Keys)
+	assert.False(t, indexes[2].FullText)
+	assert.Equal(t, []schema.Key{{Expression: "lower(title)"}}, indexes[2].Keys)
+}
+
+func TestGetIndexes_Vector(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM pg_index (.+)",
+			args:  []driver.Value{"public", "items"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
+			rows: [][]driver.Value{
+				{"idx_embedding", "embedding", 1, "false", "ASC", "CREATE INDEX idx_embedding ON public.items USING hnsw (embedding vector_ip_ops)"},
+				{"idx_embedding_l1", "embedding", 1, "false", "ASC", "CREATE INDEX idx_embedding_l1 ON public.items USING hnsw (embedding vector_l1_ops)"},
+				{"idx_embedding_l2", "embedding", 1, "false", "ASC", "CREATE INDEX idx_embedding_l2 ON public.items USING ivfflat (embedding) WITH (lists='100')"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+
+	indexes, err := isi.GetIndexes(conv, common.SchemaAndName{Schema: "public", Name: "items"}, map[string]string{"embedding": "c1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(indexes))
+	assert.Equal(t, "idx_embedding", indexes[0].Name)
+	assert.Equal(t, ddl.DotProductDistance, indexes[0].VectorDistanceType)
+	assert.Equal(t, []schema.Key{{ColId: "c1"}}, indexes[0].Keys)
+	assert.Equal(t, "idx_embedding_l2", indexes[1].Name)
+	assert.Equal(t, ddl.EuclideanDistance, indexes[1].VectorDistanceType)
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+}
+
+func TestGetIndexes_ExpressionAndPredicate(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM pg_index (.+)",
+			args:  []driver.Value{"public", "users"},
+			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "index_def"},
+			rows: [][]driver.Value{
+				{"idx_email", nil, nil, "false", "ASC", "CREATE INDEX idx_email ON public.users USING btree (lower((email)::text), id DESC) WHERE (email IS NOT NULL)"},
+				{"idx_email", "id", 2, "false", "DESC", "CREATE INDEX idx_email ON public.users USING btree (lower((email)::text), id DESC) WHERE (email IS NOT NULL)"},
+				{"idx_active", "email", 1, "false", "ASC", "CREATE INDEX idx_active ON public.users USING btree (email) WHERE active"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+
+	indexes, err := isi.GetIndexes(conv, common.SchemaAndName{Schema: "public", Name: "users"}, map[string]string{"id": "c1", "email": "c2", "active": "c3"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(indexes))
+	assert.Equal(t, []schema.Key{{Expression: "lower(email)"}, {ColId: "c1", Desc: true}}, indexes[0].Keys)
+	assert.Equal(t, "email IS NOT NULL", indexes[0].Predicate)
+	assert.Equal(t, []schema.Key{{ColId: "c2"}}, indexes[1].Keys)
+	assert.Equal(t, "active", indexes[1].Predicate)
+}
+
+func TestGetViews(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM information_schema.views (.+)",
+			cols:  []string{"table_schema", "table_name", "view_definition", "table_schema", "table_name"},
+			rows: [][]driver.Value{
+				{"public", "big_orders", " SELECT orders.id,\n    orders.total\n   FROM orders\n  WHERE orders.total > 100;", "public", "orders"},
+				{"public", "private", nil, "public", "orders"},
+				{"sales", "order_items", " SELECT o.id,\n    i.sku\n   FROM orders o\n     JOIN sales.items i ON o.id = i.order_id;", "public", "orders"},
+				{"sales", "order_items", " SELECT o.id,\n    i.sku\n   FROM orders o\n     JOIN sales.items i ON o.id = i.order_id;", "sales", "items"},
+				{"sales", "top_orders", " SELECT big_orders.id\n   FROM big_orders\n  ORDER BY big_orders.total DESC\n LIMIT 10;", "public", "big_orders"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isSchemaUnique := false
+	isi := InfoSchemaImpl{Db: db, IsSchemaUnique: &isSchemaUnique}
+	conv := internal.MakeConv()
+
+	views, err := isi.GetViews(conv)
+	assert.NoError(t, err)
+	for i := range views {
+		assert.NotEmpty(t, views[i].Id)
+		views[i].Id = ""
+	}
+	assert.Equal(t, []schema.View{
+		{Name: "big_orders", Schema: "public", Query: "SELECT orders.id,\n    orders.total\n   FROM orders\n  WHERE orders.total > 100", Refs: []string{"orders"}},
+		{Name: "sales.order_items", Schema: "sales", Query: "SELECT o.id,\n    i.sku\n   FROM orders o\n     JOIN sales.items i ON o.id = i.order_id", Refs: []string{"orders", "sales.items"}},
+		{Name: "sales.top_orders", Schema: "sales", Query: "SELECT big_orders.id\n   FROM big_orders\n  ORDER BY big_orders.total DESC\n LIMIT 10", Refs: []string{"big_orders"}},
+	}, views)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
@@ -249,6 +249,10 @@ func processStatements(conv *internal.Conv, rawStmts []*pg_query.RawStmt) *copyO
 			if conv.SchemaMode() {
 				processAlterSeqStmt(conv, n.AlterSeqStmt)
 			}
+		case *pg_query.Node_ViewStmt:
+			if conv.SchemaMode() {
+				processViewStmt(conv, n.ViewStmt)
+			}
 		default:
 			conv.SkipStatement(printNodeType(n))
 		}
@@ -268,12 +272,28 @@ func processIndexStmt(conv *internal.Conv, n *pg_query.IndexStmt) {
 	}
 	if tbl, ok := internal.GetSrcTableByName(conv.SrcSchema, tableName); ok {
 		ctable := conv.SrcSchema[tbl.Id]
-		ctable.Indexes = append(ctable.Indexes, schema.Index{
+		index := schema.Index{
 			Id:     internal.GenerateIndexesId(),
 			Name:   n.Idxname,
 			Unique: n.Unique,
-			Keys:   toIndexKeys(conv, n.Idxname, n.IndexParams, ctable.ColNameIdMap),
-		})
+		}
+		if cols, ok := fullTextColumns(n); ok {
+			index.FullText = true
+			for _, col := range cols {
+				index.Keys = append(index.Keys, schema.Key{ColId: ctable.ColNameIdMap[col]})
+			}
+		} else {
+			index.Keys = toIndexKeys(conv, n.Idxname, n.IndexParams, ctable.ColNameIdMap)
+		}
+		index.Predicate = indexPredicate(conv, n)
+		if distanceType, ok := vectorIndexDistanceType(conv, n); ok {
+			if distanceType == "" {
+				conv.SchemaStatement(printNodeType(n))
+				return
+			}
+			index.VectorDistanceType = distanceType
+		}
+		ctable.Indexes = append(ctable.Indexes, index)
 		conv.SrcSchema[tbl.Id] = ctable
 	} else {
 		conv.Unexpected(fmt.Sprintf("Table %s not found while processing index statement", tableName))
@@ -559,6 +579,88 @@ func processAlterSeqStmt(conv *internal.Conv, n *pg_query.AlterSeqStmt) {
 	checkSerialForOwningColumns(conv, seq)
 }
 
+func processViewStmt(conv *internal.Conv, n *pg_query.ViewStmt) {
+	if n.View == nil || n.Query == nil {
+		logStmtError(conv, n, fmt.Errorf("cannot process view statement with nil view or query"))
+		return
+	}
+	name, err := getTableName(conv, n.View)
+	if err != nil {
+		logStmtError(conv, n, fmt.Errorf("can't get view name: %w", err))
+		return
+	}
+	refs := relationRefs(conv, n.Query, nil)
+	query, err := pg_query.Deparse(&pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{Stmt: n.Query}}})
+	if err != nil {
+		logStmtError(conv, n, fmt.Errorf("can't deparse query of view %s: %w", name, err))
+		return
+	}
+	view := schema.View{
+		Name:  name,
+		Id:    internal.GenerateViewId(),
+		Query: query,
+		Refs:  refs,
+	}
+	for _, v := range conv.SrcViews {
+		if v.Name == name {
+			view.Id = v.Id
+		}
+	}
+	conv.SrcViews[view.Id] = view
+}
+
+// relationRefs returns the names of the tables and views that the query n
+// refers to. pg_dump qualifies every relation with its schema, so "public"
+// is dropped from the relations of n as they are visited, matching how
+// getTableName builds table names. Names in ctes are common table
+// expressions rather than relations and are skipped.
+func relationRefs(conv *internal.Conv, n *pg_query.Node, ctes []string) []string {
+	var refs []string
+	add := func(names []string) {
+		for _, name := range names {
+			if !slices.Contains(refs, name) {
+				refs = append(refs, name)
+			}
+		}
+	}
+	switch e := n.GetNode().(type) {
+	case *pg_query.Node_SelectStmt:
+		if e.SelectStmt.WithClause != nil {
+			for _, cte := range e.SelectStmt.WithClause.Ctes {
+				if c := cte.GetCommonTableExpr(); c != nil {
+					add(relationRefs(conv, c.Ctequery, ctes))
+					ctes = append(ctes, c.Ctename)
+				}
+			}
+		}
+		for _, from := range e.SelectStmt.FromClause {
+			add(relationRefs(conv, from, ctes))
+		}
+		if e.SelectStmt.Larg != nil {
+			add(relationRefs(conv, &pg_query.Node{Node: &pg_query.Node_SelectStmt{SelectStmt: e.SelectStmt.Larg}}, ctes))
+		}
+		if e.SelectStmt.Rarg != nil {
+			add(relationRefs(conv, &pg_query.Node{Node: &pg_query.Node_SelectStmt{SelectStmt: e.SelectStmt.Rarg}}, ctes))
+		}
+	case *pg_query.Node_JoinExpr:
+		add(relationRefs(conv, e.JoinExpr.Larg, ctes))
+		add(relationRefs(conv, e.JoinExpr.Rarg, ctes))
+	case *pg_query.Node_RangeSubselect:
+		add(relationRefs(conv, e.RangeSubselect.Subquery, ctes))
+	case *pg_query.Node_RangeVar:
+		if e.RangeVar.Schemaname == "public" {
+			e.RangeVar.Schemaname = ""
+		}
+		if e.RangeVar.Schemaname == "" && slices.Contains(ctes, e.RangeVar.Relname) {
+			break
+		}
+		if name, err := getTableName(conv, e.RangeVar); err == nil {
+			add([]string{name})
+		}
+	}
+	return refs
+}
+
 func getSeqName(n *pg_query.RangeVar) string {
 	var parts []string
 	if n.Schemaname != "" {
@@ -744,6 +846,8 @@ type constraint struct {
 	onUpdate   string
 	/* Fields used for DEFAULT constraint: */
 	sequenceName string // only when value is generated from sequence using nextval()
+	/* Fields used for GENERATED constraint: */
+	expr string
 }
 
 // extractConstraints traverses a list of nodes (expecting them to be
@@ -755,7 +859,7 @@ func extractConstraints(conv *internal.Conv, stmtType, table string, l []*pg_que
 			c := d.Constraint
 			var cols, referCols []string
 			var referTable, onDelete, onUpdate string
-			var conName, sequenceName string
+			var conName, sequenceName, expr string
 			switch c.Contype {
 			case pg_query.ConstrType_CONSTR_FOREIGN:
 				t, err := getTableName(conv, c.Pktable)
@@ -824,6 +928,14 @@ func extractConstraints(conv *internal.Conv, stmtType, table string, l []*pg_que
 
 			case pg_query.ConstrType_CONSTR_DEFAULT:
 				sequenceName = getSeqNameFromDefaultExpression(c.RawExpr)
+			case pg_query.ConstrType_CONSTR_GENERATED:
+				e, err := deparseExpression(c.RawExpr)
+				if err != nil {
+					conv.Unexpected(fmt.Sprintf("Processing %v statement: error processing generated column expression: %s", printNodeType(d), err.Error()))
+					conv.ErrorInStatement(printNodeType(d))
+					continue
+				}
+				expr = e
 			default:
 				if c.Conname != "" {
 					conName = c.Conname
@@ -838,7 +950,7 @@ func extractConstraints(conv *internal.Conv, stmtType, table string, l []*pg_que
 					cols = append(cols, k)
 				}
 			}
-			cs = append(cs, constraint{ct: c.Contype, cols: cols, name: conName, referCols: referCols, referTable: referTable, onDelete: onDelete, onUpdate: onUpdate, sequenceName: sequenceName})
+			cs = append(cs, constraint{ct: c.Contype, cols: cols, name: conName, referCols: referCols, referTable: referTable, onDelete: onDelete, onUpdate: onUpdate, sequenceName: sequenceName, expr: expr})
 		default:
 			conv.Unexpected(fmt.Sprintf("Processing %v statement: found %s node while processing constraints\n", stmtType, printNodeType(d)))
 		}
@@ -901,6 +1013,10 @@ func updateSchema(conv *internal.Conv, tableId string, cs []constraint, stmtType
 				updateCols(c.ct, c.cols, ct.ColDefs, colNameIdMap)
 			}
 			conv.SrcSchema[tableId] = ct
+		case pg_query.ConstrType_CONSTR_GENERATED:
+			ct := conv.SrcSchema[tableId]
+			updateColsGenerated(c.expr, c.cols, ct.ColDefs, colNameIdMap)
+			conv.SrcSchema[tableId] = ct
 		default:
 			ct := conv.SrcSchema[tableId]
 			updateCols(c.ct, c.cols, ct.ColDefs, colNameIdMap)
@@ -940,6 +1056,36 @@ func updateColsAutoGen(sequenceName string, colNames []string, colDef map[string
 	}
 }
 
+// updateColsGenerated sets the generation expression of the specified columns.
+func updateColsGenerated(expr string, colNames []string, colDef map[string]schema.Column, colNameIdMap map[string]string) {
+	for _, cn := range colNames {
+		cid := colNameIdMap[cn]
+		cd := colDef[cid]
+		cd.Generated = ddl.GeneratedColumn{
+			IsPresent: true,
+			Value: ddl.Expression{
+				ExpressionId: internal.GenerateExpressionId(),
+				Statement:    expr,
+			},
+		}
+		colDef[cid] = cd
+	}
+}
+
+// deparseExpression converts an expression node back to SQL text. pg_query
+// can only deparse statements, so the expression is deparsed as the target
+// of a SELECT statement.
+func deparseExpression(expr *pg_query.Node) (string, error) {
+	tree := &pg_query.ParseResult{Stmts: []*pg_query.RawStmt{{Stmt: &pg_query.Node{Node: &pg_query.Node_SelectStmt{SelectStmt: &pg_query.SelectStmt{
+		TargetList: []*pg_query.Node{{Node: &pg_query.Node_ResTarget{ResTarget: &pg_query.ResTarget{Val: expr}}}},
+	}}}}}}
+	s, err := pg_query.Deparse(tree)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(s, "SELECT "), nil
+}
+
 // toSchemaKeys converts a string list of PostgreSQL primary keys to
 // schema primary keys.
 func toSchemaKeys(conv *internal.Conv, tableId string, colNames []string, colNameIdMap map[string]string) (l []schema.Key) {
@@ -956,20 +1102,168 @@ func toIndexKeys(conv *internal.Conv, idxName string, s []*pg_query.Node, colNam
 	for _, k := range s {
 		switch e := k.GetNode().(type) {
 		case *pg_query.Node_IndexElem:
-			if e.IndexElem.Name == "" {
-				conv.Unexpected(fmt.Sprintf("Failed to process index %s: empty index column name", idxName))
-				continue
-			}
 			desc := false
 			if e.IndexElem.Ordering == pg_query.SortByDir_SORTBY_DESC {
 				desc = true
 			}
+			if e.IndexElem.Name == "" {
+				if e.IndexElem.Expr == nil {
+					conv.Unexpected(fmt.Sprintf("Failed to process index %s: empty index column name", idxName))
+					continue
+				}
+				expr, err := deparseExpression(withoutTextCasts(e.IndexElem.Expr))
+				if err != nil {
+					conv.Unexpected(fmt.Sprintf("Failed to process index %s: can't deparse index expression: %v", idxName, err))
+					continue
+				}
+				l = append(l, schema.Key{Expression: expr, Desc: desc})
+				continue
+			}
 			l = append(l, schema.Key{ColId: colNameIdMap[e.IndexElem.Name], Desc: desc})
 		}
 	}
 	return
 }
 
+// hasExpressionKeys returns whether n creates an index with key parts that
+// are expressions rather than columns.
+func hasExpressionKeys(n *pg_query.IndexStmt) bool {
+	for _, k := range n.IndexParams {
+		if e := k.GetIndexElem(); e != nil && e.Name == "" && e.Expr != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// indexPredicate returns the predicate of a partial index, and an empty
+// string for other indexes.
+func indexPredicate(conv *internal.Conv, n *pg_query.IndexStmt) string {
+	if n.WhereClause == nil {
+		return ""
+	}
+	predicate, err := deparseExpression(withoutTextCasts(n.WhereClause))
+	if err != nil {
+		conv.Unexpected(fmt.Sprintf("Failed to process index %s: can't deparse index predicate: %v", n.Idxname, err))
+		return ""
+	}
+	return predicate
+}
+
+// withoutTextCasts removes the casts of n to text types, such as the cast in
+// lower((email)::text) that pg_get_indexdef prints for varchar columns.
+// They are implicit in Spanner.
+func withoutTextCasts(n *pg_query.Node) *pg_query.Node {
+	switch e := n.GetNode().(type) {
+	case *pg_query.Node_TypeCast:
+		if names := e.TypeCast.GetTypeName().GetNames(); len(names) > 0 {
+			if name, err := getString(names[len(names)-1]); err == nil && (name == "text" || name == "varchar" || name == "bpchar") {
+				return withoutTextCasts(e.TypeCast.Arg)
+			}
+		}
+		e.TypeCast.Arg = withoutTextCasts(e.TypeCast.Arg)
+	case *pg_query.Node_AExpr:
+		e.AExpr.Lexpr = withoutTextCasts(e.AExpr.Lexpr)
+		e.AExpr.Rexpr = withoutTextCasts(e.AExpr.Rexpr)
+	case *pg_query.Node_FuncCall:
+		for i, arg := range e.FuncCall.Args {
+			e.FuncCall.Args[i] = withoutTextCasts(arg)
+		}
+	case *pg_query.Node_NullTest:
+		e.NullTest.Arg = withoutTextCasts(e.NullTest.Arg)
+	case *pg_query.Node_BoolExpr:
+		for i, arg := range e.BoolExpr.Args {
+			e.BoolExpr.Args[i] = withoutTextCasts(arg)
+		}
+	}
+	return n
+}
+
+// fullTextColumns returns the columns of a full-text index, which is a GIN
+// index on to_tsvector, and whether n creates such an index. For example,
+// the columns of an index on to_tsvector('english', title || ' ' || body)
+// are title and body.
+func fullTextColumns(n *pg_query.IndexStmt) ([]string, bool) {
+	if !strings.EqualFold(n.AccessMethod, "gin") {
+		return nil, false
+	}
+	var cols []string
+	for _, k := range n.IndexParams {
+		f := k.GetIndexElem().GetExpr().GetFuncCall()
+		if f == nil || len(f.Funcname) == 0 {
+			return nil, false
+		}
+		if name, _ := getString(f.Funcname[len(f.Funcname)-1]); name != "to_tsvector" {
+			return nil, false
+		}
+		for _, arg := range f.Args {
+			for _, col := range columnRefs(arg) {
+				if !slices.Contains(cols, col) {
+					cols = append(cols, col)
+				}
+			}
+		}
+	}
+	return cols, len(cols) > 0
+}
+
+// vectorDistanceTypes maps the operator classes of pgvector indexes to the
+// distance types of Spanner vector indexes.
+var vectorDistanceTypes = map[string]string{
+	"vector_l2_ops":     ddl.EuclideanDistance,
+	"vector_cosine_ops": ddl.CosineDistance,
+	"vector_ip_ops":     ddl.DotProductDistance,
+}
+
+// vectorIndexDistanceType returns the Spanner distance type of a pgvector
+// HNSW or IVFFlat index, and whether n creates such an index. The distance
+// type is empty if Spanner has no distance type for the operator class of
+// the index, which can't be migrated.
+func vectorIndexDistanceType(conv *internal.Conv, n *pg_query.IndexStmt) (string, bool) {
+	if !strings.EqualFold(n.AccessMethod, "hnsw") && !strings.EqualFold(n.AccessMethod, "ivfflat") {
+		return "", false
+	}
+	// vector_l2_ops is the default operator class of vectors.
+	opclass := "vector_l2_ops"
+	if len(n.IndexParams) == 1 {
+		if names := n.IndexParams[0].GetIndexElem().GetOpclass(); len(names) > 0 {
+			opclass, _ = getString(names[len(names)-1])
+		}
+	}
+	distanceType, ok := vectorDistanceTypes[opclass]
+	if !ok || len(n.IndexParams) != 1 {
+		conv.Unexpected(fmt.Sprintf("Vector index %s uses operator class %s, which Spanner vector indexes don't support", n.Idxname, opclass))
+		return "", true
+	}
+	return distanceType, true
+}
+
+// columnRefs returns the names of the columns that the expression n uses.
+func columnRefs(n *pg_query.Node) []string {
+	var cols []string
+	switch e := n.GetNode().(type) {
+	case *pg_query.Node_ColumnRef:
+		if len(e.ColumnRef.Fields) > 0 {
+			if name, err := getString(e.ColumnRef.Fields[len(e.ColumnRef.Fields)-1]); err == nil {
+				cols = append(cols, name)
+			}
+		}
+	case *pg_query.Node_TypeCast:
+		cols = columnRefs(e.TypeCast.Arg)
+	case *pg_query.Node_AExpr:
+		cols = append(columnRefs(e.AExpr.Lexpr), columnRefs(e.AExpr.Rexpr)...)
+	case *pg_query.Node_FuncCall:
+		for _, arg := range e.FuncCall.Args {
+			cols = append(cols, columnRefs(arg)...)
+		}
+	case *pg_query.Node_CoalesceExpr:
+		for _, arg := range e.CoalesceExpr.Args {
+			cols = append(cols, columnRefs(arg)...)
+		}
+	}
+	return cols
+}
+
 // toForeignKeys converts a string list of PostgreSQL foreign keys to schema
 // foreign keys.
 func toForeignKeys(fk constraint) (fkey schema.ForeignKey) {
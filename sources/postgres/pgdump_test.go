@@ -30,6 +30,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/mocks"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	pg_query "github.com/pganalyze/pg_query_go/v6"
@@ -1772,6 +1773,107 @@ func TestProcessPgDump_WithUnparsableContent(t *testing.T) {
 	}
 }
 
+func TestProcessPgDump_GeneratedColumn(t *testing.T) {
+	conv, rows := runProcessPgDump("CREATE TABLE test (a bigint PRIMARY KEY, b bigint GENERATED ALWAYS AS (a * 2) STORED);\n" +
+		"COPY public.test (a) FROM stdin;\n" +
+		"1\n" +
+		"\\.\n")
+	noIssues(conv, t, "Generated column")
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "test")
+	assert.Nil(t, err)
+	colId, err := internal.GetColIdFromSpName(conv.SpSchema[tableId].ColDefs, "b")
+	assert.Nil(t, err)
+	generated := conv.SpSchema[tableId].ColDefs[colId].Generated
+	assert.True(t, generated.IsPresent)
+	assert.Equal(t, "a * 2", generated.Value.Statement)
+	assert.NotEmpty(t, generated.Value.ExpressionId)
+	assert.Equal(t, []spannerData{{table: "test", cols: []string{"a"}, vals: []interface{}{int64(1)}}}, rows)
+}
+
+func TestProcessPgDump_FullTextIndex(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE posts (id bigint PRIMARY KEY, title text, body text);\n" +
+		"CREATE INDEX ft_posts ON public.posts USING gin (to_tsvector('english'::regconfig, title || ' ' || body));\n" +
+		"CREATE INDEX idx_title ON public.posts USING btree (title);\n")
+	noIssues(conv, t, "Full-text index")
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "posts")
+	assert.Nil(t, err)
+	spTable := conv.SpSchema[tableId]
+	assert.Equal(t, 1, len(spTable.Indexes))
+	assert.Equal(t, "idx_title", spTable.Indexes[0].Name)
+	titleTokens, err := internal.GetColIdFromSpName(spTable.ColDefs, "title_Tokens")
+	assert.Nil(t, err)
+	bodyTokens, err := internal.GetColIdFromSpName(spTable.ColDefs, "body_Tokens")
+	assert.Nil(t, err)
+	assert.Equal(t, []ddl.CreateSearchIndex{{Name: "ft_posts", TableId: tableId, Id: spTable.SearchIndexes[0].Id, Keys: []ddl.IndexKey{{ColId: titleTokens, Order: 1}, {ColId: bodyTokens, Order: 2}}}}, spTable.SearchIndexes)
+}
+
+func TestProcessPgDump_VectorIndex(t *testing.T) {
+	conv, rows := runProcessPgDump("CREATE TABLE items (id bigint PRIMARY KEY, embedding vector(3));\n" +
+		"CREATE INDEX idx_embedding ON public.items USING hnsw (embedding vector_cosine_ops);\n" +
+		"CREATE INDEX idx_embedding_l1 ON public.items USING hnsw (embedding vector_l1_ops);\n" +
+		"COPY public.items (id, embedding) FROM stdin;\n" +
+		"1\t[1,2,3]\n" +
+		"\\.\n")
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "items")
+	assert.Nil(t, err)
+	spTable := conv.SpSchema[tableId]
+	colId, err := internal.GetColIdFromSpName(spTable.ColDefs, "embedding")
+	assert.Nil(t, err)
+	assert.Equal(t, ddl.Type{Name: ddl.Float32, IsArray: true, VectorLength: 3}, spTable.ColDefs[colId].T)
+	assert.Equal(t, 0, len(spTable.Indexes))
+	assert.Equal(t, []ddl.CreateVectorIndex{{Name: "idx_embedding", TableId: tableId, Id: spTable.VectorIndexes[0].Id, Keys: []ddl.IndexKey{{ColId: colId, Order: 1}}, DistanceType: ddl.CosineDistance}}, spTable.VectorIndexes)
+	// Spanner vector indexes have no distance type for vector_l1_ops.
+	assert.Equal(t, int64(1), conv.Unexpecteds())
+	assert.Equal(t, []spannerData{{table: "items", cols: []string{"id", "embedding"}, vals: []interface{}{int64(1), []spanner.NullFloat32{
+		{Float32: 1, Valid: true}, {Float32: 2, Valid: true}, {Float32: 3, Valid: true}}}}}, rows)
+}
+
+func TestProcessPgDump_PartialAndExpressionIndexes(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE users (id bigint PRIMARY KEY, email text, active boolean);\n" +
+		"CREATE INDEX idx_email ON public.users USING btree (email) WHERE (email IS NOT NULL);\n" +
+		"CREATE INDEX idx_active_email ON public.users USING btree (email) WHERE active;\n" +
+		"CREATE UNIQUE INDEX idx_active_id ON public.users USING btree (id) WHERE active;\n" +
+		"CREATE INDEX idx_lower_email ON public.users USING btree (lower(email));\n")
+	tableId, err := internal.GetTableIdFromSpName(conv.SpSchema, "users")
+	assert.Nil(t, err)
+	spTable := conv.SpSchema[tableId]
+	emailColId, err := internal.GetColIdFromSpName(spTable.ColDefs, "email")
+	assert.Nil(t, err)
+	exprColId, err := internal.GetColIdFromSpName(spTable.ColDefs, "idx_lower_email_Expr")
+	assert.Nil(t, err)
+	assert.Equal(t, "lower(email)", spTable.ColDefs[exprColId].Generated.Value.Statement)
+	assert.Equal(t, 3, len(spTable.Indexes))
+	assert.Equal(t, "idx_email", spTable.Indexes[0].Name)
+	assert.True(t, spTable.Indexes[0].NullFiltered)
+	assert.Equal(t, "idx_active_email", spTable.Indexes[1].Name)
+	assert.False(t, spTable.Indexes[1].NullFiltered)
+	assert.Equal(t, []ddl.IndexKey{{ColId: emailColId, Order: 1}}, spTable.Indexes[1].Keys)
+	assert.Equal(t, "idx_lower_email", spTable.Indexes[2].Name)
+	assert.Equal(t, []ddl.IndexKey{{ColId: exprColId, Order: 1}}, spTable.Indexes[2].Keys)
+	assert.Equal(t, []internal.InvalidIndex{
+		{IssueType: internal.IndexPredicateDropped, IndexName: "idx_active_email", Expression: "active"},
+		{IssueType: internal.IndexNotMigrated, IndexName: "idx_active_id", Expression: "active"},
+	}, conv.InvalidIndexes[tableId])
+}
+
+func TestProcessPgDump_View(t *testing.T) {
+	conv, _ := runProcessPgDump("CREATE TABLE public.t (a bigint PRIMARY KEY, b text);\n" +
+		"CREATE TABLE public.u (a bigint PRIMARY KEY, c text);\n" +
+		"CREATE VIEW public.v AS\n SELECT t.a, u.c\n   FROM (public.t\n     JOIN public.u ON ((t.a = u.a)))\n  WHERE (t.a > 1);\n" +
+		"CREATE VIEW public.w AS\n WITH x AS (SELECT v.a FROM public.v) SELECT x.a FROM x;\n")
+	assert.Equal(t, 2, len(conv.SrcViews))
+	views := map[string]schema.View{}
+	for _, v := range conv.SrcViews {
+		views[v.Name] = v
+	}
+	assert.Equal(t, "SELECT t.a, u.c FROM t JOIN u ON t.a = u.a WHERE t.a > 1", views["v"].Query)
+	assert.Equal(t, []string{"t", "u"}, views["v"].Refs)
+	assert.Equal(t, []string{"v"}, views["w"].Refs)
+	// Views can't be verified without a Spanner instance, so none are migrated.
+	assert.Empty(t, conv.SpViews)
+	assert.Equal(t, 2, len(conv.InvalidViews))
+}
+
 func runProcessPgDump(s string) (*internal.Conv, []spannerData) {
 	conv := internal.MakeConv()
 	conv.SetLocation(time.UTC)
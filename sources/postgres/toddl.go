@@ -188,6 +188,33 @@ func toSpannerTypeInternal(srcType schema.Type, spType string) (ddl.Type, []inte
 		default:
 			return ddl.Type{Name: ddl.JSON}, nil
 		}
+	case "vector":
+		// pgvector vectors have a fixed number of dimensions, which is the
+		// length that Spanner vector indexes require.
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			ty := ddl.Type{Name: ddl.Float32, IsArray: true}
+			if len(srcType.Mods) > 0 {
+				ty.VectorLength = srcType.Mods[0]
+			}
+			return ty, nil
+		}
+	case "uuid":
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			return ddl.Type{Name: ddl.UUID}, nil
+		}
+	case "interval":
+		switch spType {
+		case ddl.String:
+			return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, nil
+		default:
+			return ddl.Type{Name: ddl.Interval}, nil
+		}
 	case "varchar", "character varying":
 		switch spType {
 		case ddl.Bytes:
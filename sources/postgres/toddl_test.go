@@ -115,6 +115,22 @@ func TestToSpannerTypeInternal(t *testing.T) {
 	if errCheck != nil {
 		t.Errorf("Error in json to string conversion")
 	}
+	ty, errCheck := toSpannerTypeInternal(schema.Type{Name: "uuid"}, "")
+	if ty.Name != ddl.UUID || errCheck != nil {
+		t.Errorf("Error in uuid to default conversion")
+	}
+	ty, errCheck = toSpannerTypeInternal(schema.Type{Name: "uuid"}, "STRING")
+	if ty.Name != ddl.String || errCheck != nil {
+		t.Errorf("Error in uuid to string conversion")
+	}
+	ty, errCheck = toSpannerTypeInternal(schema.Type{Name: "interval"}, "")
+	if ty.Name != ddl.Interval || errCheck != nil {
+		t.Errorf("Error in interval to default conversion")
+	}
+	ty, errCheck = toSpannerTypeInternal(schema.Type{Name: "interval"}, "STRING")
+	if ty.Name != ddl.String || errCheck != nil {
+		t.Errorf("Error in interval to string conversion")
+	}
 	_, errCheck = toSpannerTypeInternal(schema.Type{Name: "varchar", Mods: []int64{}, ArrayBounds: []int64{1, 2, 3}}, "BYTES")
 	if errCheck != nil {
 		t.Errorf("Error in varchar to bytes conversion")
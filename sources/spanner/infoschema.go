@@ -65,7 +65,7 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 
 // GetRowCount returns the row count of the table.
 func (isi InfoSchemaImpl) GetRowCount(table common.SchemaAndName) (int64, error) {
-	q := "SELECT count(*) FROM " + table.Name + ";"
+	q := "SELECT count(*) FROM " + isi.GetTableName(table.Schema, table.Name) + ";"
 	stmt := spanner.Statement{
 		SQL: q,
 	}
@@ -110,13 +110,14 @@ func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
 	return fmt.Sprintf("%s.%s", schema, tableName)
 }
 
-// GetTables return list of tables in the selected database.
+// GetTables return list of tables in the selected database, including the
+// tables in named schemas.
 func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	q := `SELECT table_schema, table_name FROM information_schema.tables 
-	WHERE table_type = 'BASE TABLE' AND table_schema = ''`
+	WHERE table_type = 'BASE TABLE' AND table_schema NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS')`
 	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
 		q = `SELECT table_schema, table_name FROM information_schema.tables 
-	WHERE table_type = 'BASE TABLE' AND table_schema = 'public'`
+	WHERE table_type = 'BASE TABLE' AND table_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog')`
 	}
 	stmt := spanner.Statement{SQL: q}
 
@@ -148,6 +149,8 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 }
 
 func (sp *InfoSchemaImpl) PopulateSpannerSchema(ctx context.Context, conv *internal.Conv, commonInfoSchema common.InfoSchemaInterface) error {
+	// Named schemas of the source database are Spanner named schemas already.
+	conv.NamedSchemas = true
 	processSchema := common.ProcessSchemaImpl{}
 	expressionVerificationAccessor, _ := expressions_api.NewExpressionVerificationAccessorImpl(ctx, conv.SpProjectId, conv.SpInstanceId)
 	ddlVerifier, err := expressions_api.NewDDLVerifierImpl(ctx, conv.SpProjectId, conv.SpInstanceId)
@@ -171,7 +174,7 @@ func (sp *InfoSchemaImpl) PopulateSpannerSchema(ctx context.Context, conv *inter
 	}
 	// Assign parents if any.
 	for tableName, parentTable := range parentTables {
-		tableId, _ := internal.GetTableIdFromSpName(conv.SpSchema, tableName)
+		tableId, _ := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, tableName)
 		spTable := conv.SpSchema[tableId]
 		spTable.ParentTable.Id = parentTable.Id
 		spTable.ParentTable.OnDelete = parentTable.OnDelete
@@ -185,18 +188,19 @@ func (sp *InfoSchemaImpl) PopulateSpannerSchema(ctx context.Context, conv *inter
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
 	q := `SELECT column_name, spanner_type, is_nullable 
 			FROM information_schema.columns
-			WHERE table_schema = '' AND table_name = @p1
+			WHERE table_schema = @p2 AND table_name = @p1
 			ORDER BY ordinal_position;`
 	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
 		q = `SELECT column_name, spanner_type, is_nullable 
 			FROM information_schema.columns
-			WHERE table_schema = 'public' AND table_name = $1
+			WHERE table_schema = $2 AND table_name = $1
 			ORDER BY ordinal_position;`
 	}
 	stmt := spanner.Statement{
 		SQL: q,
 		Params: map[string]interface{}{
 			"p1": table.Name,
+			"p2": table.Schema,
 		},
 	}
 	var iter spannerclient.RowIterator
@@ -253,18 +257,19 @@ func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.Schem
               FROM information_schema.table_constraints AS t
                 INNER JOIN information_schema.KEY_COLUMN_USAGE AS k
                   ON t.constraint_name = k.constraint_name AND t.constraint_schema = k.constraint_schema
-              WHERE k.table_schema = '' AND k.table_name = @p1 ORDER BY k.ordinal_position;`
+              WHERE k.table_schema = @p2 AND k.table_name = @p1 ORDER BY k.ordinal_position;`
 	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
 		q = `SELECT k.column_name, t.constraint_type
 		FROM information_schema.table_constraints AS t
 		  INNER JOIN information_schema.KEY_COLUMN_USAGE AS k
 			ON t.constraint_name = k.constraint_name AND t.constraint_schema = k.constraint_schema
-		WHERE k.table_schema = 'public' AND k.table_name = $1 ORDER BY k.ordinal_position;`
+		WHERE k.table_schema = $2 AND k.table_name = $1 ORDER BY k.ordinal_position;`
 	}
 	stmt := spanner.Statement{
 		SQL: q,
 		Params: map[string]interface{}{
 			"p1": table.Name,
+			"p2": table.Schema,
 		},
 	}
 
@@ -307,24 +312,25 @@ func (isi InfoSchemaImpl) GetConstraints(conv *internal.Conv, table common.Schem
 
 // GetForeignKeys returns a list of all the foreign key constraints.
 func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.SchemaAndName) (foreignKeys []schema.ForeignKey, err error) {
-	q := `SELECT  k.constraint_name, k.column_name, c.table_name, c.column_name 
+	q := `SELECT  k.constraint_name, k.column_name, c.table_schema, c.table_name, c.column_name 
 			FROM information_schema.key_column_usage AS k 
 			JOIN information_schema.constraint_column_usage AS c ON k.constraint_name = c.constraint_name
 			JOIN information_schema.table_constraints AS t ON k.constraint_name = t.constraint_name 
-			WHERE t.constraint_type='FOREIGN KEY' AND t.table_schema = '' AND t.table_name = @p1
+			WHERE t.constraint_type='FOREIGN KEY' AND t.table_schema = @p2 AND t.table_name = @p1
 			ORDER BY k.constraint_name, k.ordinal_position;`
 	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
-		q = `SELECT  k.constraint_name, k.column_name, c.table_name, c.column_name 
+		q = `SELECT  k.constraint_name, k.column_name, c.table_schema, c.table_name, c.column_name 
 				FROM information_schema.key_column_usage AS k 
 				JOIN information_schema.constraint_column_usage AS c ON k.constraint_name = c.constraint_name
 				JOIN information_schema.table_constraints AS t ON k.constraint_name = t.constraint_name 
-				WHERE t.constraint_type='FOREIGN KEY' AND t.table_schema = 'public' AND t.table_name = $1
+				WHERE t.constraint_type='FOREIGN KEY' AND t.table_schema = $2 AND t.table_name = $1
 				ORDER BY k.constraint_name, k.ordinal_position;`
 	}
 	stmt := spanner.Statement{
 		SQL: q,
 		Params: map[string]interface{}{
 			"p1": table.Name,
+			"p2": table.Schema,
 		},
 	}
 	var iter spannerclient.RowIterator
@@ -335,7 +341,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 	}
 	defer iter.Stop()
 
-	var col, refCol, fKeyName, refTable string
+	var col, refCol, fKeyName, refSchema, refTable string
 	fKeys := make(map[string]common.FkConstraint)
 	var keyNames []string
 	for {
@@ -346,7 +352,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 		if err != nil {
 			return nil, fmt.Errorf("couldn't get row while fetching foreign keys: %w", err)
 		}
-		err = row.Columns(&fKeyName, &col, &refTable, &refCol)
+		err = row.Columns(&fKeyName, &col, &refSchema, &refTable, &refCol)
 		if err != nil {
 			return nil, err
 		}
@@ -357,7 +363,7 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 			fKeys[fKeyName] = fk
 			continue
 		}
-		fKeys[fKeyName] = common.FkConstraint{Name: fKeyName, Table: isi.GetTableName(table.Schema, refTable), Refcols: []string{refCol}, Cols: []string{col}}
+		fKeys[fKeyName] = common.FkConstraint{Name: fKeyName, Table: isi.GetTableName(refSchema, refTable), Refcols: []string{refCol}, Cols: []string{col}}
 		keyNames = append(keyNames, fKeyName)
 	}
 	sort.Strings(keyNames)
@@ -384,22 +390,167 @@ func (isi InfoSchemaImpl) GetForeignKeys(conv *internal.Conv, table common.Schem
 
 // GetIndexes returns a list of Indexes per table.
 func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return isi.getIndexes(conv, table, colNameIdMap, "INDEX")
+}
+
+// GetSearchIndexes returns the search indexes of a table. Their keys are
+// the TOKENLIST columns that they index.
+func (isi InfoSchemaImpl) GetSearchIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	indexes, err := isi.getIndexes(conv, table, colNameIdMap, "SEARCH")
+	for i := range indexes {
+		indexes[i].FullText = true
+	}
+	return indexes, err
+}
+
+// GetVectorIndexes returns the vector indexes of a table. Their key is the
+// column of vectors that they index. The options of vector indexes, such as
+// their distance type, aren't read.
+func (isi InfoSchemaImpl) GetVectorIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string) ([]schema.Index, error) {
+	return isi.getIndexes(conv, table, colNameIdMap, "VECTOR")
+}
+
+// IndexAttributes are the attributes of a Spanner index that schema.Index
+// doesn't hold.
+type IndexAttributes struct {
+	NullFiltered bool
+	// InterleaveIn is the name of the table that the index is interleaved
+	// in, as returned by GetTableName, or empty if it isn't interleaved.
+	InterleaveIn string
+}
+
+// GetIndexAttributes returns the attributes of the indexes of a table
+// returned by GetIndexes, keyed by index name.
+func (isi InfoSchemaImpl) GetIndexAttributes(table common.SchemaAndName) (map[string]IndexAttributes, error) {
+	q := `SELECT INDEX_NAME, IS_NULL_FILTERED, PARENT_TABLE_NAME FROM information_schema.indexes
+			WHERE TABLE_SCHEMA = @p2 AND TABLE_NAME = @p1 AND INDEX_TYPE = 'INDEX';`
+	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+		q = `SELECT index_name, is_null_filtered, parent_table_name FROM information_schema.indexes
+			WHERE table_schema = $2 AND table_name = $1 AND index_type = 'INDEX';`
+	}
+	stmt := spanner.Statement{
+		SQL: q,
+		Params: map[string]interface{}{
+			"p1": table.Name,
+			"p2": table.Schema,
+		},
+	}
+	iter := isi.query(stmt)
+	defer iter.Stop()
+	attributes := make(map[string]IndexAttributes)
+	var name string
+	var nullFiltered bool
+	var pgNullFiltered string
+	var parent spanner.NullString
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get index attributes for table %s: %w", table.Name, err)
+		}
+		if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+			err = row.Columns(&name, &pgNullFiltered, &parent)
+			nullFiltered = pgNullFiltered == "YES"
+		} else {
+			err = row.Columns(&name, &nullFiltered, &parent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		a := IndexAttributes{NullFiltered: nullFiltered}
+		if parent.StringVal != "" {
+			// Indexes are interleaved in a table of their own schema.
+			a.InterleaveIn = isi.GetTableName(table.Schema, parent.StringVal)
+		}
+		attributes[name] = a
+	}
+	return attributes, nil
+}
+
+// GetRowDeletionPolicies returns the expressions of the row deletion
+// policies of tables, keyed by the table name returned by GetTableName.
+func (isi InfoSchemaImpl) GetRowDeletionPolicies() (map[string]string, error) {
+	q := `SELECT table_schema, table_name, row_deletion_policy_expression FROM information_schema.tables
+			WHERE table_type = 'BASE TABLE' AND row_deletion_policy_expression IS NOT NULL`
+	stmt := spanner.Statement{SQL: q}
+	iter := isi.query(stmt)
+	defer iter.Stop()
+	policies := make(map[string]string)
+	var tableSchema, tableName, expr string
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get row deletion policies: %w", err)
+		}
+		if err = row.Columns(&tableSchema, &tableName, &expr); err != nil {
+			return nil, err
+		}
+		policies[isi.GetTableName(tableSchema, tableName)] = expr
+	}
+	return policies, nil
+}
+
+// GetSpannerViews returns the views of the database, keyed by the view
+// name returned by GetTableName.
+func (isi InfoSchemaImpl) GetSpannerViews() (map[string]ddl.CreateView, error) {
+	q := `SELECT table_schema, table_name, view_definition, security_type FROM information_schema.views
+			WHERE table_schema NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS')`
+	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+		q = `SELECT table_schema, table_name, view_definition, security_type FROM information_schema.views
+			WHERE table_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog')`
+	}
+	stmt := spanner.Statement{SQL: q}
+	iter := isi.query(stmt)
+	defer iter.Stop()
+	views := make(map[string]ddl.CreateView)
+	var viewSchema, viewName, query string
+	var securityType spanner.NullString
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get views: %w", err)
+		}
+		if err = row.Columns(&viewSchema, &viewName, &query, &securityType); err != nil {
+			return nil, err
+		}
+		name := isi.GetTableName(viewSchema, viewName)
+		v := ddl.CreateView{Name: viewName, Query: query, SqlSecurity: strings.ToUpper(securityType.StringVal)}
+		if name != viewName {
+			v.SchemaName = viewSchema
+		}
+		views[name] = v
+	}
+	return views, nil
+}
+
+// getIndexes returns the indexes of a table of the given INDEX_TYPE.
+func (isi InfoSchemaImpl) getIndexes(conv *internal.Conv, table common.SchemaAndName, colNameIdMap map[string]string, indexType string) ([]schema.Index, error) {
 	q := `SELECT distinct c.INDEX_NAME,c.COLUMN_NAME,c.ORDINAL_POSITION,c.COLUMN_ORDERING,i.IS_UNIQUE
 			FROM information_schema.index_columns AS c
 			JOIN information_schema.indexes AS i
-			ON c.INDEX_NAME=i.INDEX_NAME
-			WHERE c.table_schema = '' AND i.INDEX_TYPE='INDEX' AND c.TABLE_NAME = @p1 ORDER BY c.INDEX_NAME, c.ORDINAL_POSITION;`
+			ON c.INDEX_NAME=i.INDEX_NAME AND c.TABLE_SCHEMA=i.TABLE_SCHEMA
+			WHERE c.table_schema = @p2 AND i.INDEX_TYPE=@p3 AND c.TABLE_NAME = @p1 ORDER BY c.INDEX_NAME, c.ORDINAL_POSITION;`
 	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
 		q = `SELECT distinct c.INDEX_NAME,c.COLUMN_NAME,c.ORDINAL_POSITION,c.COLUMN_ORDERING,i.IS_UNIQUE
 		FROM information_schema.index_columns AS c
 		JOIN information_schema.indexes AS i
-		ON c.INDEX_NAME=i.INDEX_NAME
-		WHERE c.table_schema = 'public' AND i.INDEX_TYPE='INDEX' AND c.TABLE_NAME = $1 ORDER BY c.INDEX_NAME, c.ORDINAL_POSITION;`
+		ON c.INDEX_NAME=i.INDEX_NAME AND c.TABLE_SCHEMA=i.TABLE_SCHEMA
+		WHERE c.table_schema = $2 AND i.INDEX_TYPE=$3 AND c.TABLE_NAME = $1 ORDER BY c.INDEX_NAME, c.ORDINAL_POSITION;`
 	}
 	stmt := spanner.Statement{
 		SQL: q,
 		Params: map[string]interface{}{
 			"p1": table.Name,
+			"p2": table.Schema,
+			"p3": indexType,
 		},
 	}
 	var iter spannerclient.RowIterator
@@ -409,10 +560,11 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		iter = isi.Client.Single().Query(isi.Ctx, stmt)
 	}
 	defer iter.Stop()
-	var name, column, ordering string
+	var name, column string
+	var ordering spanner.NullString
 	var isUnique bool
 	var isPgUnique string
-	var sequence int64
+	var sequence spanner.NullInt64
 	indexMap := make(map[string]schema.Index)
 	var indexNames []string
 	var indexes []schema.Index
@@ -440,7 +592,9 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 			}
 		}
 
-		isUnique = isPgUnique == "YES"
+		if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+			isUnique = isPgUnique == "YES"
+		}
 		if _, found := indexMap[name]; !found {
 			indexNames = append(indexNames, name)
 			indexMap[name] = schema.Index{
@@ -450,9 +604,14 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 		}
 
 		index := indexMap[name]
-		index.Keys = append(index.Keys, schema.Key{
-			ColId: colNameIdMap[column],
-			Desc:  (ordering == "DESC")})
+		// Columns in the STORING clause of an index have no position.
+		if !sequence.Valid {
+			index.StoredColumnIds = append(index.StoredColumnIds, colNameIdMap[column])
+		} else {
+			index.Keys = append(index.Keys, schema.Key{
+				ColId: colNameIdMap[column],
+				Desc:  (ordering.StringVal == "DESC")})
+		}
 		indexMap[name] = index
 	}
 	for _, k := range indexNames {
@@ -461,12 +620,124 @@ func (isi InfoSchemaImpl) GetIndexes(conv *internal.Conv, table common.SchemaAnd
 	return indexes, nil
 }
 
+// GetCheckConstraints returns the check constraints of a table. The check
+// constraints that Spanner adds for NOT NULL columns are left out.
+func (isi InfoSchemaImpl) GetCheckConstraints(table common.SchemaAndName) ([]schema.CheckConstraint, error) {
+	q := `SELECT tc.constraint_name, cc.check_clause
+			FROM information_schema.table_constraints AS tc
+			JOIN information_schema.check_constraints AS cc
+			ON tc.constraint_name = cc.constraint_name AND tc.constraint_schema = cc.constraint_schema
+			WHERE tc.constraint_type = 'CHECK' AND tc.table_schema = @p2 AND tc.table_name = @p1
+			ORDER BY tc.constraint_name;`
+	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+		q = `SELECT tc.constraint_name, cc.check_clause
+			FROM information_schema.table_constraints AS tc
+			JOIN information_schema.check_constraints AS cc
+			ON tc.constraint_name = cc.constraint_name AND tc.constraint_schema = cc.constraint_schema
+			WHERE tc.constraint_type = 'CHECK' AND tc.table_schema = $2 AND tc.table_name = $1
+			ORDER BY tc.constraint_name;`
+	}
+	stmt := spanner.Statement{
+		SQL: q,
+		Params: map[string]interface{}{
+			"p1": table.Name,
+			"p2": table.Schema,
+		},
+	}
+	iter := isi.query(stmt)
+	defer iter.Stop()
+	var checks []schema.CheckConstraint
+	var name, clause string
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get check constraints for table %s: %w", table.Name, err)
+		}
+		if err = row.Columns(&name, &clause); err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(name, "CK_IS_NOT_NULL_") {
+			continue
+		}
+		checks = append(checks, schema.CheckConstraint{Name: name, Expr: clause})
+	}
+	return checks, nil
+}
+
+// GetSequences returns the sequences of the database, keyed by their name
+// qualified by their named schema. The options of sequences are only read
+// for the GoogleSQL dialect.
+func (isi InfoSchemaImpl) GetSequences() (map[string]ddl.Sequence, error) {
+	q := `SELECT s.schema, s.name, o.option_name, o.option_value
+			FROM information_schema.sequences AS s
+			LEFT JOIN information_schema.sequence_options AS o
+			ON s.schema = o.schema AND s.name = o.name
+			WHERE s.schema NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS')
+			ORDER BY s.schema, s.name;`
+	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+		q = `SELECT sequence_schema, sequence_name FROM information_schema.sequences
+			WHERE sequence_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog')
+			ORDER BY sequence_schema, sequence_name;`
+	}
+	iter := isi.query(spanner.Statement{SQL: q})
+	defer iter.Stop()
+	sequences := make(map[string]ddl.Sequence)
+	var schemaName, name string
+	var option, value spanner.NullString
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get sequences: %w", err)
+		}
+		if isi.SpDialect == constants.DIALECT_POSTGRESQL {
+			err = row.Columns(&schemaName, &name)
+		} else {
+			err = row.Columns(&schemaName, &name, &option, &value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if schemaName == "public" {
+			schemaName = ""
+		}
+		key := ddl.QualifiedName(schemaName, name)
+		seq := sequences[key]
+		seq.Name = name
+		seq.SchemaName = schemaName
+		switch option.StringVal {
+		case "sequence_kind":
+			seq.SequenceKind = strings.ToUpper(strings.ReplaceAll(value.StringVal, "_", " "))
+		case "skip_range_min":
+			seq.SkipRangeMin = value.StringVal
+		case "skip_range_max":
+			seq.SkipRangeMax = value.StringVal
+		case "start_with_counter":
+			seq.StartWithCounter = value.StringVal
+		}
+		sequences[key] = seq
+	}
+	return sequences, nil
+}
+
+func (isi InfoSchemaImpl) query(stmt spanner.Statement) spannerclient.RowIterator {
+	if isi.SpannerClient != nil {
+		return isi.SpannerClient.Single().Query(isi.Ctx, stmt)
+	}
+	return isi.Client.Single().Query(isi.Ctx, stmt)
+}
+
 func (isi InfoSchemaImpl) GetInterleaveTables(spSchema ddl.Schema) (map[string]ddl.InterleavedParent, error) {
-	q := `SELECT table_name, parent_table_name, on_delete_action, interleave_type FROM information_schema.tables 
-	WHERE interleave_type <> '' AND table_type = 'BASE TABLE' AND table_schema = ''`
+	q := `SELECT table_schema, table_name, parent_table_name, on_delete_action, interleave_type FROM information_schema.tables 
+	WHERE interleave_type <> '' AND table_type = 'BASE TABLE' AND table_schema NOT IN ('INFORMATION_SCHEMA', 'SPANNER_SYS')`
 	if isi.SpDialect == constants.DIALECT_POSTGRESQL {
-		q = `SELECT table_name, parent_table_name, on_delete_action, interleave_type FROM information_schema.tables 
-		WHERE interleave_type <> '' AND table_type = 'BASE TABLE' AND table_schema = 'public'`
+		q = `SELECT table_schema, table_name, parent_table_name, on_delete_action, interleave_type FROM information_schema.tables 
+		WHERE interleave_type <> '' AND table_type = 'BASE TABLE' AND table_schema NOT IN ('information_schema', 'spanner_sys', 'pg_catalog')`
 	}
 	stmt := spanner.Statement{SQL: q}
 
@@ -479,7 +750,7 @@ func (isi InfoSchemaImpl) GetInterleaveTables(spSchema ddl.Schema) (map[string]d
 
 	defer iter.Stop()
 
-	var tableName, parentTableName, onDelete, interleaveType string
+	var tableSchema, tableName, parentTableName, onDelete, interleaveType string
 	parentTables := map[string]ddl.InterleavedParent{}
 	for {
 		row, err := iter.Next()
@@ -489,12 +760,13 @@ func (isi InfoSchemaImpl) GetInterleaveTables(spSchema ddl.Schema) (map[string]d
 		if err != nil {
 			return nil, fmt.Errorf("couldn't read row while fetching interleaved tables: %w", err)
 		}
-		err = row.Columns(&tableName, &parentTableName, &onDelete, &interleaveType)
+		err = row.Columns(&tableSchema, &tableName, &parentTableName, &onDelete, &interleaveType)
 		if err != nil {
 			return nil, err
 		}
-		parentTableId, _ := internal.GetTableIdFromSpName(spSchema, parentTableName)
-		parentTables[tableName] = ddl.InterleavedParent{Id: parentTableId, OnDelete: onDelete, InterleaveType: interleaveType}
+		// Interleaved tables are in the named schema of their parent.
+		parentTableId, _ := internal.GetTableIdFromSpQualifiedName(spSchema, isi.GetTableName(tableSchema, parentTableName))
+		parentTables[isi.GetTableName(tableSchema, tableName)] = ddl.InterleavedParent{Id: parentTableId, OnDelete: onDelete, InterleaveType: interleaveType}
 	}
 	return parentTables, nil
 }
@@ -15,11 +15,18 @@
 package spanner
 
 import (
+	"context"
 	"testing"
 
+	"cloud.google.com/go/spanner"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
 )
 
 func TestToType(t *testing.T) {
@@ -53,3 +60,127 @@ func TestToType(t *testing.T) {
 		assert.Equal(t, tc.expColumnType, ty, tc.name)
 	}
 }
+
+// queryResult returns an InfoSchemaImpl whose queries all return the given rows.
+func queryResult(dialect string, cols []string, rows ...[]interface{}) InfoSchemaImpl {
+	return InfoSchemaImpl{
+		Ctx:       context.Background(),
+		SpDialect: dialect,
+		SpannerClient: spannerclient.SpannerClientMock{
+			SingleMock: func() spannerclient.ReadOnlyTransaction {
+				return spannerclient.ReadOnlyTransactionMock{
+					QueryMock: func(ctx context.Context, stmt spanner.Statement) spannerclient.RowIterator {
+						i := 0
+						return &spannerclient.RowIteratorMock{
+							NextMock: func() (*spanner.Row, error) {
+								if i == len(rows) {
+									return nil, iterator.Done
+								}
+								i++
+								return spanner.NewRow(cols, rows[i-1])
+							},
+							StopMock: func() {},
+						}
+					},
+				}
+			},
+		},
+	}
+}
+
+func TestGetIndexes(t *testing.T) {
+	cols := []string{"INDEX_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "COLUMN_ORDERING", "IS_UNIQUE"}
+	isi := queryResult(constants.DIALECT_GOOGLESQL, cols,
+		[]interface{}{"idx", "note", spanner.NullInt64{}, spanner.NullString{}, true},
+		[]interface{}{"idx", "a", spanner.NullInt64{Int64: 1, Valid: true}, spanner.NullString{StringVal: "ASC", Valid: true}, true},
+		[]interface{}{"idx", "b", spanner.NullInt64{Int64: 2, Valid: true}, spanner.NullString{StringVal: "DESC", Valid: true}, true},
+	)
+	indexes, err := isi.GetIndexes(internal.MakeConv(), common.SchemaAndName{Name: "t"}, map[string]string{"a": "c1", "b": "c2", "note": "c3"})
+	assert.Nil(t, err)
+	assert.Len(t, indexes, 1)
+	assert.Equal(t, "idx", indexes[0].Name)
+	assert.True(t, indexes[0].Unique)
+	assert.Equal(t, []schema.Key{{ColId: "c1"}, {ColId: "c2", Desc: true}}, indexes[0].Keys)
+	assert.Equal(t, []string{"c3"}, indexes[0].StoredColumnIds)
+}
+
+func TestGetCheckConstraints(t *testing.T) {
+	isi := queryResult(constants.DIALECT_GOOGLESQL, []string{"constraint_name", "check_clause"},
+		[]interface{}{"CK_IS_NOT_NULL_t_id", "id IS NOT NULL"},
+		[]interface{}{"positive", "(id > 0)"},
+	)
+	checks, err := isi.GetCheckConstraints(common.SchemaAndName{Name: "t"})
+	assert.Nil(t, err)
+	assert.Equal(t, []schema.CheckConstraint{{Name: "positive", Expr: "(id > 0)"}}, checks)
+}
+
+func TestGetSequences(t *testing.T) {
+	isi := queryResult(constants.DIALECT_GOOGLESQL, []string{"schema", "name", "option_name", "option_value"},
+		[]interface{}{"", "s1", spanner.NullString{StringVal: "sequence_kind", Valid: true}, spanner.NullString{StringVal: "bit_reversed_positive", Valid: true}},
+		[]interface{}{"", "s1", spanner.NullString{StringVal: "skip_range_min", Valid: true}, spanner.NullString{StringVal: "1", Valid: true}},
+		[]interface{}{"", "s1", spanner.NullString{StringVal: "skip_range_max", Valid: true}, spanner.NullString{StringVal: "1000", Valid: true}},
+		[]interface{}{"", "s2", spanner.NullString{}, spanner.NullString{}},
+		[]interface{}{"sales", "s1", spanner.NullString{}, spanner.NullString{}},
+	)
+	sequences, err := isi.GetSequences()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]ddl.Sequence{
+		"s1":       {Name: "s1", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "1000"},
+		"s2":       {Name: "s2"},
+		"sales.s1": {Name: "s1", SchemaName: "sales"},
+	}, sequences)
+
+	isi = queryResult(constants.DIALECT_POSTGRESQL, []string{"sequence_schema", "sequence_name"},
+		[]interface{}{"public", "s1"},
+		[]interface{}{"sales", "s1"},
+	)
+	sequences, err = isi.GetSequences()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]ddl.Sequence{"s1": {Name: "s1"}, "sales.s1": {Name: "s1", SchemaName: "sales"}}, sequences)
+}
+
+func TestGetIndexAttributes(t *testing.T) {
+	isi := queryResult(constants.DIALECT_GOOGLESQL, []string{"INDEX_NAME", "IS_NULL_FILTERED", "PARENT_TABLE_NAME"},
+		[]interface{}{"idx", true, spanner.NullString{StringVal: "singers", Valid: true}},
+		[]interface{}{"idx2", false, spanner.NullString{}},
+	)
+	attributes, err := isi.GetIndexAttributes(common.SchemaAndName{Schema: "music", Name: "albums"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]IndexAttributes{
+		"idx":  {NullFiltered: true, InterleaveIn: "music.singers"},
+		"idx2": {},
+	}, attributes)
+
+	isi = queryResult(constants.DIALECT_POSTGRESQL, []string{"index_name", "is_null_filtered", "parent_table_name"},
+		[]interface{}{"idx", "YES", spanner.NullString{StringVal: "singers", Valid: true}},
+	)
+	attributes, err = isi.GetIndexAttributes(common.SchemaAndName{Schema: "public", Name: "albums"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]IndexAttributes{"idx": {NullFiltered: true, InterleaveIn: "singers"}}, attributes)
+}
+
+func TestGetRowDeletionPolicies(t *testing.T) {
+	isi := queryResult(constants.DIALECT_GOOGLESQL, []string{"table_schema", "table_name", "row_deletion_policy_expression"},
+		[]interface{}{"", "events", "OLDER_THAN(created, INTERVAL 30 DAY)"},
+		[]interface{}{"sales", "orders", "OLDER_THAN(created, INTERVAL 7 DAY)"},
+	)
+	policies, err := isi.GetRowDeletionPolicies()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"events":       "OLDER_THAN(created, INTERVAL 30 DAY)",
+		"sales.orders": "OLDER_THAN(created, INTERVAL 7 DAY)",
+	}, policies)
+}
+
+func TestGetSpannerViews(t *testing.T) {
+	isi := queryResult(constants.DIALECT_GOOGLESQL, []string{"table_schema", "table_name", "view_definition", "security_type"},
+		[]interface{}{"", "v1", "SELECT id FROM t", spanner.NullString{StringVal: "INVOKER", Valid: true}},
+		[]interface{}{"sales", "v2", "SELECT id FROM sales.orders", spanner.NullString{StringVal: "DEFINER", Valid: true}},
+	)
+	views, err := isi.GetSpannerViews()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]ddl.CreateView{
+		"v1":       {Name: "v1", Query: "SELECT id FROM t", SqlSecurity: "INVOKER"},
+		"sales.v2": {Name: "v2", SchemaName: "sales", Query: "SELECT id FROM sales.orders", SqlSecurity: "DEFINER"},
+	}, views)
+}
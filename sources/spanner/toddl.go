@@ -71,6 +71,10 @@ func toSpannerTypeInternal(conv *internal.Conv, srcType schema.Type) (ddl.Type,
 		return ddl.Type{Name: ddl.String, Len: srcType.Mods[0]}, nil
 	case "TIMESTAMP", "timestamp with time zone":
 		return ddl.Type{Name: ddl.Timestamp}, nil
+	case "UUID", "uuid":
+		return ddl.Type{Name: ddl.UUID}, nil
+	case "INTERVAL", "interval":
+		return ddl.Type{Name: ddl.Interval}, nil
 	}
 	return ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, []internal.SchemaIssue{internal.NoGoodType}
 }
@@ -44,6 +44,8 @@ func TestToSpannerType(t *testing.T) {
 		{"numeric", false, schema.Type{Name: "NUMERIC"}, ddl.Type{Name: ddl.Numeric}},
 		{"string", false, schema.Type{Name: "STRING", Mods: []int64{100}}, ddl.Type{Name: ddl.String, Len: 100}},
 		{"timestamp", false, schema.Type{Name: "TIMESTAMP"}, ddl.Type{Name: ddl.Timestamp}},
+		{"uuid", false, schema.Type{Name: "UUID"}, ddl.Type{Name: ddl.UUID}},
+		{"interval", false, schema.Type{Name: "INTERVAL"}, ddl.Type{Name: ddl.Interval}},
 		// PG target.
 		{"pg_numeric", true, schema.Type{Name: "numeric"}, ddl.Type{Name: ddl.Numeric}},
 		{"pg_json", true, schema.Type{Name: "jsonb"}, ddl.Type{Name: ddl.JSON}},
@@ -58,6 +60,8 @@ func TestToSpannerType(t *testing.T) {
 		{"pg_string", true, schema.Type{Name: "character varying", Mods: []int64{}}, ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
 		{"pg_string_with_szie", true, schema.Type{Name: "character varying", Mods: []int64{100}}, ddl.Type{Name: ddl.String, Len: 100}},
 		{"pg_timestamp", true, schema.Type{Name: "timestamp with time zone"}, ddl.Type{Name: ddl.Timestamp}},
+		{"pg_uuid", true, schema.Type{Name: "uuid"}, ddl.Type{Name: ddl.UUID}},
+		{"pg_interval", true, schema.Type{Name: "interval"}, ddl.Type{Name: ddl.Interval}},
 	}
 	for _, tc := range toDDLTests {
 		conv.SpDialect = constants.DIALECT_GOOGLESQL
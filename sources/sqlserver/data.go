@@ -28,6 +28,7 @@ import (
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/google/uuid"
 )
 
 // ProcessDataRow converts a row of data and writes it out to Spanner.
@@ -86,7 +87,7 @@ func ConvertData(conv *internal.Conv, tableId string, colIds []string, srcSchema
 		aux.Sequence++
 		conv.SyntheticPKeys[tableId] = aux
 	}
-	return spSchema.Name, c, v, nil
+	return spSchema.QualifiedName(), c, v, nil
 }
 
 // convScalar converts a source database string value to an
@@ -117,6 +118,8 @@ func convScalar(conv *internal.Conv, spannerType ddl.Type, srcTypeName string, t
 		return val, nil
 	case ddl.Timestamp:
 		return convTimestamp(srcTypeName, val)
+	case ddl.UUID:
+		return convUUID(val)
 	default:
 		return val, fmt.Errorf("data conversion not implemented for type %v", spannerType.Name)
 	}
@@ -183,6 +186,16 @@ func convNumeric(conv *internal.Conv, val string) (interface{}, error) {
 	}
 }
 
+// convUUID maps a source database string value (representing a
+// uniqueidentifier) into the canonical form of Spanner UUIDs.
+func convUUID(val string) (string, error) {
+	u, err := uuid.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("can't convert to uuid: %w", err)
+	}
+	return u.String(), nil
+}
+
 // convTimestamp maps a source DB datetime types to Spanner timestamp
 func convTimestamp(srcTypeName string, val string) (t time.Time, err error) {
 	// the query returns the datetime in ISO8601
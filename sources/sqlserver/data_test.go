@@ -96,6 +96,7 @@ func TestConvertData(t *testing.T) {
 		{"datetimeoffset", ddl.Type{Name: ddl.Timestamp}, "datetimeoffset", "2021-12-15T07:39:52.9433333+01:20", getTimeWithTimezone(t, "2021-12-15T07:39:52.9433333+01:20")},
 		{"decimal", ddl.Type{Name: ddl.Numeric}, "decimal", "234.90909090909", big.NewRat(23490909090909, 100000000000)},
 		{"numeric", ddl.Type{Name: ddl.Numeric}, "numeric", numStr, numVal},
+		{"uniqueidentifier", ddl.Type{Name: ddl.UUID}, "uniqueidentifier", "6F9619FF-8B86-D011-B42D-00C04FC964FF", "6f9619ff-8b86-d011-b42d-00c04fc964ff"},
 	}
 	tableName := "testtable"
 	tableId := "t1"
@@ -18,11 +18,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	sp "cloud.google.com/go/spanner"
 
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
@@ -43,6 +45,21 @@ const (
 	dateType           string = "date"
 )
 
+// bracketIdentifierRegexp matches identifiers quoted with brackets.
+var bracketIdentifierRegexp = regexp.MustCompile(`\[([^\]]+)\]`)
+
+// viewDefinitionRegexp matches the CREATE VIEW statement that defines a view,
+// capturing its query.
+var viewDefinitionRegexp = regexp.MustCompile(`(?is)^\s*CREATE\s+(?:OR\s+ALTER\s+)?VIEW\s+.+?\s+AS\s+(.+?)[\s;]*$`)
+
+// quotedIdentifierRegexp matches identifiers quoted with brackets, in
+// which "]" is written "]]".
+var quotedIdentifierRegexp = regexp.MustCompile(`\[((?:[^\]]|\]\])+)\]`)
+
+// dboQualifierRegexp matches the dbo schema qualifying table names, quoted
+// or not, which is dropped along with the schema (see GetTableName).
+var dboQualifierRegexp = regexp.MustCompile("(?i)(?:\\bdbo|`dbo`|\"dbo\")\\.")
+
 type InfoSchemaImpl struct {
 	DbName string
 	Db     *sql.DB
@@ -86,12 +103,12 @@ func (isi InfoSchemaImpl) GetTableName(schema string, tableName string) string {
 // *interface{} parameters to row.Scan.
 func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSchema schema.Table, commonColIds []string, spSchema ddl.CreateTable, additionalAttributes internal.AdditionalDataAttributes) error {
 	srcTableName := conv.SrcSchema[tableId].Name
-	rowsInterface, err := isi.GetRowsFromTable(conv, tableId)
+	q, args := isi.GetKeyRangeQuery(conv, tableId, additionalAttributes.KeyRange)
+	rows, err := isi.Db.Query(q, args...)
 	if err != nil {
 		conv.Unexpected(fmt.Sprintf("Couldn't get data for table %s : err = %s", srcTableName, err))
 		return err
 	}
-	rows := rowsInterface.(*sql.Rows)
 	defer rows.Close()
 	srcCols, _ := rows.Columns()
 	v, scanArgs := buildVals(len(srcCols))
@@ -120,16 +137,45 @@ func (isi InfoSchemaImpl) ProcessData(conv *internal.Conv, tableId string, srcSc
 
 // GetRowsFromTable returns a sql Rows object for a table.
 func (isi InfoSchemaImpl) GetRowsFromTable(conv *internal.Conv, tableId string) (interface{}, error) {
+	q, args := isi.GetKeyRangeQuery(conv, tableId, internal.KeyRange{})
+	rows, err := isi.Db.Query(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, err
+}
+
+// GetKeyRangeQuery returns the query that reads the rows of a table whose
+// first primary key column lies in kr.
+func (isi InfoSchemaImpl) GetKeyRangeQuery(conv *internal.Conv, tableId string, kr internal.KeyRange) (string, []interface{}) {
 	tbl := conv.SrcSchema[tableId]
 	//To get only the table name by removing the schema name prefix
 	tblName := strings.Replace(tbl.Name, tbl.Schema+".", "", 1)
 
 	q := getSelectQuery(isi.DbName, tbl.Schema, tblName, tbl.ColIds, tbl.ColDefs)
+	col := fmt.Sprintf("[%s]", common.GetKeyRangeColumn(tbl))
+	cond, args := common.KeyRangeCondition(col, kr, func(i int) string { return fmt.Sprintf("@p%d", i) })
+	if cond != "" {
+		q += " WHERE " + cond
+	}
+	return q, args
+}
+
+// GetKeySplitPoints returns values of the first primary key column that
+// split a table into n ranges of similar size.
+func (isi InfoSchemaImpl) GetKeySplitPoints(conv *internal.Conv, tableId string, n int) ([]interface{}, error) {
+	tbl := conv.SrcSchema[tableId]
+	tblName := strings.Replace(tbl.Name, tbl.Schema+".", "", 1)
+	col := fmt.Sprintf("[%s]", common.GetKeyRangeColumn(tbl))
+	q := fmt.Sprintf(`SELECT k FROM (
+			SELECT k, tile, LAG(tile) OVER (ORDER BY k) AS prev_tile FROM (
+				SELECT %s AS k, NTILE(%d) OVER (ORDER BY %s) AS tile FROM [%s].[%s].[%s]) AS t) AS b
+		WHERE tile <> prev_tile ORDER BY k`, col, n, col, isi.DbName, tbl.Schema, tblName)
 	rows, err := isi.Db.Query(q)
 	if err != nil {
 		return nil, err
 	}
-	return rows, err
+	return common.ScanKeySplitPoints(rows)
 }
 
 func getSelectQuery(srcDb string, schemaName string, tableName string, colIds []string, colDefs map[string]schema.Column) string {
@@ -216,20 +262,89 @@ func (isi InfoSchemaImpl) GetTables() ([]common.SchemaAndName, error) {
 	return tables, nil
 }
 
+// GetViews returns the views in the selected database. Views are defined by
+// CREATE VIEW statements, and only their queries are kept.
+func (isi InfoSchemaImpl) GetViews(conv *internal.Conv) ([]schema.View, error) {
+	q := `
+	SELECT
+		SCH.name AS view_schema,
+		V.name AS view_name,
+		M.definition,
+		U.TABLE_SCHEMA,
+		U.TABLE_NAME
+	FROM sys.views AS V
+	INNER JOIN sys.schemas AS SCH
+		ON SCH.schema_id = V.schema_id
+	LEFT JOIN sys.sql_modules AS M
+		ON M.object_id = V.object_id
+	LEFT JOIN INFORMATION_SCHEMA.VIEW_TABLE_USAGE AS U
+		ON U.VIEW_SCHEMA = SCH.name
+		AND U.VIEW_NAME = V.name
+	WHERE V.is_ms_shipped = 0
+	ORDER BY SCH.name, V.name, U.TABLE_SCHEMA, U.TABLE_NAME
+	`
+	rows, err := isi.Db.Query(q)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get views: %w", err)
+	}
+	defer rows.Close()
+	var viewSchema, viewName string
+	var definition, refSchema, refTable sql.NullString
+	var views []schema.View
+	for rows.Next() {
+		err := rows.Scan(&viewSchema, &viewName, &definition, &refSchema, &refTable)
+		if err != nil {
+			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
+			continue
+		}
+		name := isi.GetTableName(viewSchema, viewName)
+		if len(views) == 0 || views[len(views)-1].Name != name {
+			// The definitions of encrypted views can't be read, and are left
+			// empty.
+			var query string
+			if m := viewDefinitionRegexp.FindStringSubmatch(definition.String); m != nil {
+				query = dboQualifierRegexp.ReplaceAllString(quoteIdentifiers(m[1], conv.SpDialect), "")
+			}
+			views = append(views, schema.View{Name: name, Schema: viewSchema, Id: internal.GenerateViewId(), Query: query})
+		}
+		if refTable.Valid {
+			view := &views[len(views)-1]
+			view.Refs = append(view.Refs, isi.GetTableName(refSchema.String, refTable.String))
+		}
+	}
+	return views, nil
+}
+
+// quoteIdentifiers quotes the identifiers that query quotes with brackets the
+// way Spanner does, which keeps identifiers that need quoting, such as those
+// with spaces or that are keywords, valid.
+func quoteIdentifiers(query, spDialect string) string {
+	return quotedIdentifierRegexp.ReplaceAllStringFunc(query, func(s string) string {
+		name := strings.ReplaceAll(s[1:len(s)-1], "]]", "]")
+		if spDialect == constants.DIALECT_POSTGRESQL {
+			return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+		}
+		return "`" + strings.ReplaceAll(name, "`", "\\`") + "`"
+	})
+}
+
 // GetColumns returns a list of Column objects and names
 func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAndName, constraints map[string][]string, primaryKeys []string) (map[string]schema.Column, []string, error) {
 	q := `
 		SELECT 
-			column_name, 
-			data_type, 
-			is_nullable, 
-			column_default, 
-			character_maximum_length, 
-			numeric_precision, 
-			numeric_scale
-		FROM information_schema.COLUMNS 
-		WHERE table_schema = @p1 and table_name = @p2 
-		ORDER BY ordinal_position;
+			c.column_name, 
+			c.data_type, 
+			c.is_nullable, 
+			c.column_default, 
+			c.character_maximum_length, 
+			c.numeric_precision, 
+			c.numeric_scale,
+			cc.definition
+		FROM information_schema.COLUMNS c
+		LEFT JOIN sys.computed_columns cc
+			ON cc.object_id = OBJECT_ID(QUOTENAME(c.table_schema) + '.' + QUOTENAME(c.table_name)) AND cc.name = c.column_name
+		WHERE c.table_schema = @p1 and c.table_name = @p2 
+		ORDER BY c.ordinal_position;
 	`
 	cols, err := isi.Db.Query(q, table.Schema, table.Name)
 	if err != nil {
@@ -240,11 +355,11 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	var colIds []string
 	var colName, dataType string
 	var isNullable string
-	var colDefault sql.NullString
+	var colDefault, computedDefinition sql.NullString
 	// elementDataType
 	var charMaxLen, numericPrecision, numericScale sql.NullInt64
 	for cols.Next() {
-		err := cols.Scan(&colName, &dataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale)
+		err := cols.Scan(&colName, &dataType, &isNullable, &colDefault, &charMaxLen, &numericPrecision, &numericScale, &computedDefinition)
 		if err != nil {
 			conv.Unexpected(fmt.Sprintf("Can't scan: %v", err))
 			continue
@@ -264,11 +379,12 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 		ignored.Default = colDefault.Valid
 		colId := internal.GenerateColumnId()
 		c := schema.Column{
-			Id:      colId,
-			Name:    colName,
-			Type:    toType(dataType, charMaxLen, numericPrecision, numericScale),
-			NotNull: strings.ToUpper(isNullable) == "NO",
-			Ignored: ignored,
+			Id:        colId,
+			Name:      colName,
+			Type:      toType(dataType, charMaxLen, numericPrecision, numericScale),
+			NotNull:   strings.ToUpper(isNullable) == "NO",
+			Ignored:   ignored,
+			Generated: toGenerated(computedDefinition),
 		}
 		colDefs[colId] = c
 		colIds = append(colIds, colId)
@@ -276,6 +392,22 @@ func (isi InfoSchemaImpl) GetColumns(conv *internal.Conv, table common.SchemaAnd
 	return colDefs, colIds, nil
 }
 
+// toGenerated returns the expression of a computed column. SQL Server
+// quotes identifiers in computed column definitions with brackets, which
+// Spanner doesn't support, so the brackets are removed.
+func toGenerated(definition sql.NullString) ddl.GeneratedColumn {
+	if !definition.Valid || definition.String == "" {
+		return ddl.GeneratedColumn{}
+	}
+	return ddl.GeneratedColumn{
+		IsPresent: true,
+		Value: ddl.Expression{
+			ExpressionId: internal.GenerateExpressionId(),
+			Statement:    bracketIdentifierRegexp.ReplaceAllString(definition.String, "$1"),
+		},
+	}
+}
+
 // GetConstraints returns a list of primary keys and by-column map of
 // other constraints.  Note: we need to preserve ordinal order of
 // columns in primary key constraints.
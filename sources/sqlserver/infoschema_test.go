@@ -21,10 +21,12 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/expressions_api"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/mocks"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
@@ -75,11 +77,11 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"dbo", "user"},
-			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "definition"},
 			rows: [][]driver.Value{
-				{"user_id", "text", "NO", nil, nil, nil, nil},
-				{"name", "text", "NO", nil, nil, nil, nil},
-				{"ref", "bigint", "YES", nil, nil, nil, nil}},
+				{"user_id", "text", "NO", nil, nil, nil, nil, nil},
+				{"name", "text", "NO", nil, nil, nil, nil, nil},
+				{"ref", "bigint", "YES", nil, nil, nil, nil, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -103,45 +105,45 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"dbo", "test"},
-			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "definition"},
 			rows: [][]driver.Value{
-				{"Id", "int", "NO", nil, nil, 10, 0},
-				{"BigInt", "bigint", "YES", nil, nil, 19, 0},
-				{"Binary", "binary", "YES", nil, 50, nil, nil},
-				{"Bit", "bit", "YES", nil, nil, nil, nil},
-				{"Char", "char", "YES", nil, 10, nil, nil},
-				{"Date", "date", "YES", nil, nil, nil, nil},
-				{"DateTime", "datetime", "YES", nil, nil, nil, nil},
-				{"DateTime2", "datetime2", "YES", nil, nil, nil, nil},
-				{"DateTimeOffset", "datetimeoffset", "YES", nil, nil, nil, nil},
-				{"Decimal", "decimal", "YES", nil, nil, 18, 9},
-				{"Float", "float", "YES", nil, nil, 53, nil},
-				{"Geography", "geography", "YES", nil, -1, nil, nil},
-				{"Geometry", "geometry", "YES", nil, -1, nil, nil},
-				{"HierarchyId", "hierarchyid", "YES", nil, 892, nil, nil},
-				{"Image", "image", "YES", nil, 2147483647, nil, nil},
-				{"Int", "int", "YES", nil, nil, 10, 0},
-				{"Money", "money", "YES", nil, nil, 19, 4},
-				{"NChar", "nchar", "YES", nil, 10, nil, nil},
-				{"NText", "ntext", "YES", nil, 1073741823, nil, nil},
-				{"Numeric", "numeric", "YES", nil, nil, 18, 17},
-				{"NVarChar", "nvarchar", "YES", nil, 50, nil, nil},
-				{"NVarCharMax", "nvarchar", "YES", nil, -1, nil, nil},
-				{"Real", "real", "YES", nil, nil, 24, nil},
-				{"SmallDateTime", "smalldatetime", "YES", nil, nil, nil, nil},
-				{"SmallInt", "smallint", "YES", nil, nil, 5, 0},
-				{"SmallMoney", "smallmoney", "YES", nil, nil, 10, 4},
-				{"SQLVariant", "sql_variant", "YES", nil, 0, nil, nil},
-				{"Text", "text", "YES", nil, 2147483647, nil, nil},
-				{"Time", "time", "YES", nil, nil, nil, nil},
-				{"TimeStamp", "timestamp", "YES", nil, nil, nil, nil},
-				{"TinyInt", "tinyint", "YES", nil, nil, 3, 0},
-				{"UniqueIdentifier", "uniqueidentifier", "YES", nil, nil, nil, nil},
-				{"VarBinary", "varbinary", "YES", nil, 50, nil, nil},
-				{"VarBinaryMax", "varbinary", "YES", nil, -1, nil, nil},
-				{"VarChar", "varchar", "YES", nil, 50, nil, nil},
-				{"VarCharMax", "varchar", "YES", nil, -1, nil, nil},
-				{"Xml", "xml", "YES", nil, -1, nil, nil},
+				{"Id", "int", "NO", nil, nil, 10, 0, nil},
+				{"BigInt", "bigint", "YES", nil, nil, 19, 0, nil},
+				{"Binary", "binary", "YES", nil, 50, nil, nil, nil},
+				{"Bit", "bit", "YES", nil, nil, nil, nil, nil},
+				{"Char", "char", "YES", nil, 10, nil, nil, nil},
+				{"Date", "date", "YES", nil, nil, nil, nil, nil},
+				{"DateTime", "datetime", "YES", nil, nil, nil, nil, nil},
+				{"DateTime2", "datetime2", "YES", nil, nil, nil, nil, nil},
+				{"DateTimeOffset", "datetimeoffset", "YES", nil, nil, nil, nil, nil},
+				{"Decimal", "decimal", "YES", nil, nil, 18, 9, nil},
+				{"Float", "float", "YES", nil, nil, 53, nil, nil},
+				{"Geography", "geography", "YES", nil, -1, nil, nil, nil},
+				{"Geometry", "geometry", "YES", nil, -1, nil, nil, nil},
+				{"HierarchyId", "hierarchyid", "YES", nil, 892, nil, nil, nil},
+				{"Image", "image", "YES", nil, 2147483647, nil, nil, nil},
+				{"Int", "int", "YES", nil, nil, 10, 0, nil},
+				{"Money", "money", "YES", nil, nil, 19, 4, nil},
+				{"NChar", "nchar", "YES", nil, 10, nil, nil, nil},
+				{"NText", "ntext", "YES", nil, 1073741823, nil, nil, nil},
+				{"Numeric", "numeric", "YES", nil, nil, 18, 17, nil},
+				{"NVarChar", "nvarchar", "YES", nil, 50, nil, nil, nil},
+				{"NVarCharMax", "nvarchar", "YES", nil, -1, nil, nil, nil},
+				{"Real", "real", "YES", nil, nil, 24, nil, nil},
+				{"SmallDateTime", "smalldatetime", "YES", nil, nil, nil, nil, nil},
+				{"SmallInt", "smallint", "YES", nil, nil, 5, 0, nil},
+				{"SmallMoney", "smallmoney", "YES", nil, nil, 10, 4, nil},
+				{"SQLVariant", "sql_variant", "YES", nil, 0, nil, nil, nil},
+				{"Text", "text", "YES", nil, 2147483647, nil, nil, nil},
+				{"Time", "time", "YES", nil, nil, nil, nil, nil},
+				{"TimeStamp", "timestamp", "YES", nil, nil, nil, nil, nil},
+				{"TinyInt", "tinyint", "YES", nil, nil, 3, 0, nil},
+				{"UniqueIdentifier", "uniqueidentifier", "YES", nil, nil, nil, nil, nil},
+				{"VarBinary", "varbinary", "YES", nil, 50, nil, nil, nil},
+				{"VarBinaryMax", "varbinary", "YES", nil, -1, nil, nil, nil},
+				{"VarChar", "varchar", "YES", nil, 50, nil, nil, nil},
+				{"VarCharMax", "varchar", "YES", nil, -1, nil, nil, nil},
+				{"Xml", "xml", "YES", nil, -1, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
@@ -171,11 +173,11 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"dbo", "cart"},
-			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "definition"},
 			rows: [][]driver.Value{
-				{"productid", "text", "NO", nil, nil, nil, nil},
-				{"userid", "text", "NO", nil, nil, nil, nil},
-				{"quantity", "bigint", "YES", nil, nil, 64, 0}},
+				{"productid", "text", "NO", nil, nil, nil, nil, nil},
+				{"userid", "text", "NO", nil, nil, nil, nil, nil},
+				{"quantity", "bigint", "YES", nil, nil, 64, 0, nil}},
 		},
 		// db call to fetch index happens after fetching of column
 		{
@@ -206,10 +208,10 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"production", "product"},
-			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "definition"},
 			rows: [][]driver.Value{
-				{"product_id", "text", "NO", nil, nil, nil, nil},
-				{"product_name", "text", "NO", nil, nil, nil, nil},
+				{"product_id", "text", "NO", nil, nil, nil, nil, nil},
+				{"product_name", "text", "NO", nil, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
@@ -236,11 +238,11 @@ func TestProcessSchema(t *testing.T) {
 		{
 			query: "SELECT (.+) FROM information_schema.COLUMNS (.+)",
 			args:  []driver.Value{"dbo", "test_ref"},
-			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale"},
+			cols:  []string{"column_name", "data_type", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "numeric_scale", "definition"},
 			rows: [][]driver.Value{
-				{"ref_id", "bigint", "NO", nil, nil, 64, 0},
-				{"ref_txt", "text", "NO", nil, nil, nil, nil},
-				{"abc", "text", "NO", nil, nil, nil, nil},
+				{"ref_id", "bigint", "NO", nil, nil, 64, 0, nil},
+				{"ref_txt", "text", "NO", nil, nil, nil, nil, nil},
+				{"abc", "text", "NO", nil, nil, nil, nil, nil},
 			},
 		},
 		// db call to fetch index happens after fetching of column
@@ -249,6 +251,10 @@ func TestProcessSchema(t *testing.T) {
 			args:  []driver.Value{"test_ref", "dbo"},
 			cols:  []string{"index_name", "column_name", "column_position", "is_unique", "order", "is_included_column"},
 		},
+		{
+			query: "SELECT (.+) FROM sys.views (.+)",
+			cols:  []string{"view_schema", "view_name", "definition", "TABLE_SCHEMA", "TABLE_NAME"},
+		},
 	}
 	db := mkMockDB(t, ms)
 	conv := internal.MakeConv()
@@ -317,7 +323,7 @@ func TestProcessSchema(t *testing.T) {
 				"Time":             {Name: "Time", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: false},
 				"TimeStamp":        {Name: "TimeStamp", T: ddl.Type{Name: ddl.Int64}, NotNull: false},
 				"TinyInt":          {Name: "TinyInt", T: ddl.Type{Name: ddl.Int64}, NotNull: false},
-				"UniqueIdentifier": {Name: "UniqueIdentifier", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}, NotNull: false},
+				"UniqueIdentifier": {Name: "UniqueIdentifier", T: ddl.Type{Name: ddl.UUID}, NotNull: false},
 				"VarBinary":        {Name: "VarBinary", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, NotNull: false},
 				"VarBinaryMax":     {Name: "VarBinaryMax", T: ddl.Type{Name: ddl.Bytes, Len: ddl.MaxLength}, NotNull: false},
 				"VarChar":          {Name: "VarChar", T: ddl.Type{Name: ddl.String, Len: 50}, NotNull: false},
@@ -389,6 +395,14 @@ func mkMockDB(t *testing.T, ms []mockSpec) *sql.DB {
 	return db
 }
 
+func TestToGenerated(t *testing.T) {
+	assert.Equal(t, ddl.GeneratedColumn{}, toGenerated(sql.NullString{}))
+	generated := toGenerated(sql.NullString{String: "([Price]*[Quantity])", Valid: true})
+	assert.True(t, generated.IsPresent)
+	assert.Equal(t, "(Price*Quantity)", generated.Value.Statement)
+	assert.NotEmpty(t, generated.Value.ExpressionId)
+}
+
 // stripSchemaComments returns a schema with all comments removed.
 // We mostly ignore schema comments in testing since schema comments
 // are often changed and are not a core part of conversion functionality.
@@ -403,3 +417,41 @@ func stripSchemaComments(spSchema map[string]ddl.CreateTable) map[string]ddl.Cre
 	}
 	return spSchema
 }
+
+func TestGetViews(t *testing.T) {
+	ms := []mockSpec{
+		{
+			query: "SELECT (.+) FROM sys.views (.+)",
+			cols:  []string{"view_schema", "view_name", "definition", "TABLE_SCHEMA", "TABLE_NAME"},
+			rows: [][]driver.Value{
+				{"dbo", "big_orders", "CREATE VIEW [dbo].[big_orders]\r\nAS\r\nSELECT [id], [total] FROM [dbo].[orders] WHERE [total] > 100\r\n", "dbo", "orders"},
+				{"dbo", "secret", nil, nil, nil},
+				{"sales", "order_items", "CREATE OR ALTER VIEW sales.order_items (id, sku) WITH SCHEMABINDING AS\nSELECT o.id, i.sku FROM dbo.orders AS o JOIN sales.items AS i ON o.id = i.order_id;", "dbo", "orders"},
+				{"sales", "order_items", "CREATE OR ALTER VIEW sales.order_items (id, sku) WITH SCHEMABINDING AS\nSELECT o.id, i.sku FROM dbo.orders AS o JOIN sales.items AS i ON o.id = i.order_id;", "sales", "items"},
+			},
+		},
+	}
+	db := mkMockDB(t, ms)
+	isi := InfoSchemaImpl{Db: db}
+	conv := internal.MakeConv()
+
+	views, err := isi.GetViews(conv)
+	assert.NoError(t, err)
+	for i := range views {
+		assert.NotEmpty(t, views[i].Id)
+		views[i].Id = ""
+	}
+	assert.Equal(t, []schema.View{
+		{Name: "big_orders", Schema: "dbo", Query: "SELECT `id`, `total` FROM `orders` WHERE `total` > 100", Refs: []string{"orders"}},
+		{Name: "secret", Schema: "dbo"},
+		{Name: "sales.order_items", Schema: "sales", Query: "SELECT o.id, i.sku FROM orders AS o JOIN sales.items AS i ON o.id = i.order_id", Refs: []string{"orders", "sales.items"}},
+	}, views)
+	assert.Equal(t, int64(0), conv.Unexpecteds())
+}
+
+func TestQuoteIdentifiers(t *testing.T) {
+	query := "SELECT [order id], [a]]b] FROM [order details]"
+	assert.Equal(t, "SELECT `order id`, `a]b` FROM `order details`", quoteIdentifiers(query, constants.DIALECT_GOOGLESQL))
+	assert.Equal(t, `SELECT "order id", "a]b" FROM "order details"`, quoteIdentifiers(query, constants.DIALECT_POSTGRESQL))
+	assert.Equal(t, "SELECT `a\\`b`", quoteIdentifiers("SELECT [a`b]", constants.DIALECT_GOOGLESQL))
+}
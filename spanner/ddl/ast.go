@@ -53,6 +53,14 @@ const (
 	Numeric string = "NUMERIC"
 	// Json represent JSON type.
 	JSON string = "JSON"
+	// TokenList represents the TOKENLIST type of the columns that search
+	// indexes are built on.
+	TokenList string = "TOKENLIST"
+	// UUID represents the UUID type, which has the same name in both dialects.
+	UUID string = "UUID"
+	// Interval represents the INTERVAL type, which has the same name in both
+	// dialects.
+	Interval string = "INTERVAL"
 	// MaxLength is a sentinel for Type's Len field, representing the MAX value.
 	MaxLength = math.MaxInt64
 	// StringMaxLength represents maximum allowed STRING length.
@@ -77,6 +85,8 @@ const (
 	PGTimestamptz string = "TIMESTAMPTZ"
 	// Jsonb represents the PG.JSONB type
 	PGJSONB string = "JSONB"
+	// PGTokenList represents the SPANNER.TOKENLIST type, which is TOKENLIST in PG.
+	PGTokenList string = "SPANNER.TOKENLIST"
 	// PGMaxLength represents sentinel for Type's Len field in PG.
 	PGMaxLength = 2621440
 )
@@ -89,6 +99,7 @@ var STANDARD_TYPE_TO_PGSQL_TYPEMAP = map[string]string{
 	String:    PGVarchar,
 	Timestamp: PGTimestamptz,
 	JSON:      PGJSONB,
+	TokenList: PGTokenList,
 }
 
 var PGSQL_TO_STANDARD_TYPE_TYPEMAP = map[string]string{
@@ -99,6 +110,7 @@ var PGSQL_TO_STANDARD_TYPE_TYPEMAP = map[string]string{
 	PGVarchar:     String,
 	PGTimestamptz: Timestamp,
 	PGJSONB:       JSON,
+	PGTokenList:   TokenList,
 }
 
 // PGDialect keyword list
@@ -115,7 +127,7 @@ var PGSQL_RESERVED_KEYWORD_LIST = []string{"ALL", "ANALYSE", "ANALYZE", "AND", "
 // Type represents the type of a column.
 //
 //	type:
-//	   { BOOL | INT64 | FLOAT32 | FLOAT64 | STRING( length ) | BYTES( length ) | DATE | TIMESTAMP | NUMERIC }
+//	   { BOOL | INT64 | FLOAT32 | FLOAT64 | STRING( length ) | BYTES( length ) | DATE | TIMESTAMP | NUMERIC | UUID | INTERVAL }
 type Type struct {
 	Name string
 	// Len encodes the following Spanner DDL definition:
@@ -125,6 +137,10 @@ type Type struct {
 	// IsArray represents if Type is an array_type or not
 	// When false, column has type T; when true, it is an array of type T.
 	IsArray bool
+	// VectorLength is the number of elements of every array of an array
+	// type, which vector indexes require. It is 0 if arrays can have any
+	// number of elements.
+	VectorLength int64
 }
 
 // PrintColumnDefType unparses the type encoded in a ColumnDef.
@@ -141,6 +157,9 @@ func (ty Type) PrintColumnDefType() string {
 	}
 	if ty.IsArray {
 		str = "ARRAY<" + str + ">"
+		if ty.VectorLength > 0 {
+			str += fmt.Sprintf("(vector_length=>%d)", ty.VectorLength)
+		}
 	}
 	return str
 }
@@ -155,15 +174,9 @@ func GetPGType(spType Type) string {
 
 func (ty Type) PGPrintColumnDefType() string {
 	str := GetPGType(ty)
-	// PG doesn't support array types, and we don't expect to receive a type
-	// with IsArray set to true. In the unlikely event, set to string type.
-	if ty.IsArray {
-		str = PGVarchar
-		ty.Len = PGMaxLength
-	}
 	// PG doesn't support variable length Bytea and thus doesn't support
 	// setting length (or max length) for the Bytes.
-	if ty.Name == String || ty.IsArray {
+	if ty.Name == String {
 		str += "("
 		if ty.Len == MaxLength || ty.Len == PGMaxLength {
 			str += fmt.Sprintf("%v", PGMaxLength)
@@ -172,13 +185,19 @@ func (ty Type) PGPrintColumnDefType() string {
 		}
 		str += ")"
 	}
+	if ty.IsArray {
+		str += "[]"
+		if ty.VectorLength > 0 {
+			str += fmt.Sprintf(" VECTOR LENGTH %d", ty.VectorLength)
+		}
+	}
 	return str
 }
 
 // ColumnDef encodes the following DDL definition:
 //
 //	column_def:
-//	  column_name type [NOT NULL] [options_def]
+//	  column_name type [NOT NULL] [AS ( expression ) [STORED]] [HIDDEN] [options_def]
 type ColumnDef struct {
 	Name         string
 	T            Type
@@ -187,7 +206,11 @@ type ColumnDef struct {
 	Id           string
 	AutoGen      AutoGenCol
 	DefaultValue DefaultValue
-	Opts         map[string]string
+	Generated    GeneratedColumn
+	// Hidden columns aren't returned by SELECT *. Spanner requires the
+	// TOKENLIST columns of search indexes to be hidden.
+	Hidden bool
+	Opts   map[string]string
 }
 
 // Config controls how AST nodes are printed (aka unparsed).
@@ -196,6 +219,7 @@ type Config struct {
 	ProtectIds  bool // If true, table and col names are quoted using backticks (avoids reserved-word issue).
 	Tables      bool // If true, print tables
 	ForeignKeys bool // If true, print foreign key constraints.
+	SkipIndexes bool // If true, don't print secondary indexes with tables.
 	SpDialect   string
 	Source      string // SourceDB information for determining case-sensitivity handling for PGSQL
 }
@@ -218,6 +242,40 @@ func isSourceCaseSensitive(source string) bool {
 	}
 }
 
+// QuoteIdentifier quotes the table or column name s the way the statements
+// printed with c do, for use in queries against the converted schema.
+func (c Config) QuoteIdentifier(s string) string {
+	return c.quote(s)
+}
+
+// quoteQualified quotes name, qualifying it by the named schema schemaName
+// unless the object is in the default schema.
+func (c Config) quoteQualified(schemaName, name string) string {
+	if schemaName == "" {
+		return c.quote(name)
+	}
+	return c.quote(schemaName) + "." + c.quote(name)
+}
+
+// QuoteQualifiedName quotes a name returned by QualifiedName. Spanner names
+// can't contain ".", so the part before the first "." is the named schema.
+func (c Config) QuoteQualifiedName(name string) string {
+	if schemaName, n, ok := strings.Cut(name, "."); ok {
+		return c.quoteQualified(schemaName, n)
+	}
+	return c.quote(name)
+}
+
+// QualifiedName returns name qualified by the named schema schemaName, the
+// way tables and sequences in named schemas are referred to in mutations
+// and queries. Objects in the default schema are referred to by name only.
+func QualifiedName(schemaName, name string) string {
+	if schemaName == "" {
+		return name
+	}
+	return schemaName + "." + name
+}
+
 func (c Config) quote(s string) string {
 	if c.ProtectIds {
 		if c.SpDialect == constants.DIALECT_POSTGRESQL {
@@ -243,15 +301,30 @@ func (cd ColumnDef) PrintColumnDef(c Config) (string, string) {
 		if cd.NotNull {
 			s += " NOT NULL "
 		}
-		s += cd.DefaultValue.PGPrintDefaultValue(cd.T)
-		s += cd.AutoGen.PGPrintAutoGenCol(c)
+		if cd.Generated.IsPresent {
+			// Generated columns can't have defaults.
+			s += cd.Generated.PrintGeneratedColumn(c)
+		} else {
+			s += cd.DefaultValue.PGPrintDefaultValue(cd.T)
+			s += cd.AutoGen.PGPrintAutoGenCol(c)
+		}
+		if cd.Hidden {
+			s += " HIDDEN"
+		}
 	} else {
 		s = fmt.Sprintf("%s %s", c.quote(cd.Name), cd.T.PrintColumnDefType())
 		if cd.NotNull {
 			s += " NOT NULL "
 		}
-		s += cd.DefaultValue.PrintDefaultValue(cd.T)
-		s += cd.AutoGen.PrintAutoGenCol(c)
+		if cd.Generated.IsPresent {
+			s += cd.Generated.PrintGeneratedColumn(c)
+		} else {
+			s += cd.DefaultValue.PrintDefaultValue(cd.T)
+			s += cd.AutoGen.PrintAutoGenCol(c)
+		}
+		if cd.Hidden {
+			s += " HIDDEN"
+		}
 	}
 	var  opts []string
 	if cd.Opts != nil {
@@ -306,6 +379,8 @@ type Foreignkey struct {
 	Id             string
 	OnDelete       string
 	OnUpdate       string
+	// ReferSchemaName is the named schema of the referenced table, if any.
+	ReferSchemaName string `json:",omitempty"`
 }
 
 // InterleavedParent encodes the following DDL definition:
@@ -328,28 +403,60 @@ func (k Foreignkey) PrintForeignKey(c Config) string {
 	if k.Name != "" {
 		s = fmt.Sprintf("CONSTRAINT %s ", c.quote(k.Name))
 	}
-	s = s + fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", strings.Join(cols, ", "), c.quote(k.ReferTableId), strings.Join(referCols, ", "))
+	s = s + fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", strings.Join(cols, ", "), c.quoteQualified(k.ReferSchemaName, k.ReferTableId), strings.Join(referCols, ", "))
 	if k.OnDelete != "" {
 		s = s + fmt.Sprintf(" ON DELETE %s", k.OnDelete)
 	}
 	return s
 }
 
+// RowDeletionPolicy encodes the following DDL definition:
+//
+//	ROW DELETION POLICY ( OLDER_THAN ( column_name, INTERVAL num_days DAY ) )
+//
+// which is written TTL INTERVAL 'num_days days' ON column_name in PostgreSQL.
+type RowDeletionPolicy struct {
+	ColId string
+	Days  int64
+}
+
+// PrintRowDeletionPolicy unparses the row deletion policy of table ct.
+func (rdp RowDeletionPolicy) PrintRowDeletionPolicy(ct CreateTable, c Config) string {
+	col := c.quote(ct.ColDefs[rdp.ColId].Name)
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Sprintf("TTL INTERVAL '%d days' ON %s", rdp.Days, col)
+	}
+	return fmt.Sprintf("ROW DELETION POLICY (OLDER_THAN(%s, INTERVAL %d DAY))", col, rdp.Days)
+}
+
 // CreateTable encodes the following DDL definition:
 //
-//	create_table: CREATE TABLE table_name ([column_def, ...] ) primary_key [, cluster]
+//	create_table: CREATE TABLE [schema_name.]table_name ([column_def, ...] ) primary_key [, cluster] [, row_deletion_policy]
 type CreateTable struct {
-	Name             string
+	Name string
+	// SchemaName is the named schema the table is in. Tables in the default
+	// schema have no schema name.
+	SchemaName       string   `json:",omitempty"`
 	ColIds           []string // Provides names and order of columns
 	ShardIdColumn    string
 	ColDefs          map[string]ColumnDef // Provides definition of columns (a map for simpler/faster lookup during type processing)
 	PrimaryKeys      []IndexKey
 	ForeignKeys      []Foreignkey
 	Indexes          []CreateIndex
+	SearchIndexes    []CreateSearchIndex
+	VectorIndexes    []CreateVectorIndex
 	ParentTable      InterleavedParent // if not empty, this table will be interleaved
 	CheckConstraints []CheckConstraint
-	Comment          string
-	Id               string
+	// RowDeletionPolicy, if set, deletes rows once they are older than
+	// its number of days.
+	RowDeletionPolicy *RowDeletionPolicy `json:",omitempty"`
+	Comment           string
+	Id                string
+}
+
+// QualifiedName returns the name of table ct qualified by its named schema.
+func (ct CreateTable) QualifiedName() string {
+	return QualifiedName(ct.SchemaName, ct.Name)
 }
 
 // PrintCreateTable unparses a CREATE TABLE statement.
@@ -390,24 +497,24 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 
 	var interleave string
 	if ct.ParentTable.Id != "" {
-		parent := spSchema[ct.ParentTable.Id].Name
+		parent := config.quoteQualified(spSchema[ct.ParentTable.Id].SchemaName, spSchema[ct.ParentTable.Id].Name)
 		if config.SpDialect == constants.DIALECT_POSTGRESQL {
 			// PG spanner only supports PRIMARY KEY() inside the CREATE TABLE()
 			// and thus INTERLEAVE follows immediately after closing brace.
 			// ON DELETE option is not supported by INTERLEAVE IN and is dropped.
 			if ct.ParentTable.InterleaveType == "IN" {
-				interleave = " INTERLEAVE IN " + config.quote(parent)
+				interleave = " INTERLEAVE IN " + parent
 			} else {
-				interleave = " INTERLEAVE IN PARENT " + config.quote(parent)
+				interleave = " INTERLEAVE IN PARENT " + parent
 				if ct.ParentTable.OnDelete != "" {
 					interleave = interleave + " ON DELETE " + ct.ParentTable.OnDelete
 				}
 			}
 		} else {
 			if ct.ParentTable.InterleaveType == "IN" {
-				interleave = ",\nINTERLEAVE IN " + config.quote(parent)
+				interleave = ",\nINTERLEAVE IN " + parent
 			} else {
-				interleave = ",\nINTERLEAVE IN PARENT " + config.quote(parent)
+				interleave = ",\nINTERLEAVE IN PARENT " + parent
 				if ct.ParentTable.OnDelete != "" {
 					interleave = interleave + " ON DELETE " + ct.ParentTable.OnDelete
 				}
@@ -415,6 +522,14 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 		}
 	}
 
+	if ct.RowDeletionPolicy != nil {
+		if config.SpDialect == constants.DIALECT_POSTGRESQL {
+			interleave += " " + ct.RowDeletionPolicy.PrintRowDeletionPolicy(ct, config)
+		} else {
+			interleave += ",\n" + ct.RowDeletionPolicy.PrintRowDeletionPolicy(ct, config)
+		}
+	}
+
 	var checkString string
 	if len(ct.CheckConstraints) > 0 {
 		checkString = FormatCheckConstraints(ct.CheckConstraints, config.SpDialect)
@@ -423,17 +538,17 @@ func (ct CreateTable) PrintCreateTable(spSchema Schema, config Config) string {
 	}
 
 	if len(keys) == 0 {
-		return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s) %s", tableComment, config.quote(ct.Name), cols, checkString, interleave)
+		return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s) %s", tableComment, config.quoteQualified(ct.SchemaName, ct.Name), cols, checkString, interleave)
 	}
 	if config.SpDialect == constants.DIALECT_POSTGRESQL {
-		return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s\tPRIMARY KEY (%s)\n)%s", tableComment, config.quote(ct.Name), cols, checkString, strings.Join(keys, ", "), interleave)
+		return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s\tPRIMARY KEY (%s)\n)%s", tableComment, config.quoteQualified(ct.SchemaName, ct.Name), cols, checkString, strings.Join(keys, ", "), interleave)
 	}
-	return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s) PRIMARY KEY (%s)%s", tableComment, config.quote(ct.Name), cols, checkString, strings.Join(keys, ", "), interleave)
+	return fmt.Sprintf("%sCREATE TABLE %s (\n%s%s) PRIMARY KEY (%s)%s", tableComment, config.quoteQualified(ct.SchemaName, ct.Name), cols, checkString, strings.Join(keys, ", "), interleave)
 }
 
 // CreateIndex encodes the following DDL definition:
 //
-//	create index: CREATE [UNIQUE] [NULL_FILTERED] INDEX index_name ON table_name ( key_part [, ...] ) [ storing_clause ] [ , interleave_clause ]
+//	create index: CREATE [UNIQUE] [NULL_FILTERED] INDEX [schema_name.]index_name ON [schema_name.]table_name ( key_part [, ...] ) [ storing_clause ] [ , interleave_clause ]
 type CreateIndex struct {
 	Name            string
 	TableId         string `json:"TableId"`
@@ -441,8 +556,14 @@ type CreateIndex struct {
 	Keys            []IndexKey
 	Id              string
 	StoredColumnIds []string
-	// We have no requirements for null-filtered option and
-	// interleaving clauses yet, so we omit them for now.
+	// NullFiltered indexes don't index rows in which any key column is NULL.
+	NullFiltered bool `json:",omitempty"`
+	// InterleaveIn is the id of the ancestor table that the index is
+	// interleaved in, or empty if the index isn't interleaved.
+	InterleaveIn string `json:",omitempty"`
+	// SchemaName is the named schema of the index, which is always the
+	// schema of its table.
+	SchemaName string `json:",omitempty"`
 }
 
 type AutoGenCol struct {
@@ -470,6 +591,32 @@ type Expression struct {
 	Statement    string
 }
 
+// GeneratedColumn represents the expression of a generated column,
+// which Spanner computes and, unless the column is virtual, stores:
+//
+//	AS ( expression ) [STORED]
+type GeneratedColumn struct {
+	IsPresent bool
+	Value     Expression
+	// Virtual columns are computed when they are read. Spanner only
+	// supports them for TOKENLIST columns.
+	Virtual bool
+}
+
+// PrintGeneratedColumn unparses the generation clause of a column.
+func (gc GeneratedColumn) PrintGeneratedColumn(c Config) string {
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		if gc.Virtual {
+			return fmt.Sprintf(" GENERATED ALWAYS AS (%s) VIRTUAL", gc.Value.Statement)
+		}
+		return fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", gc.Value.Statement)
+	}
+	if gc.Virtual {
+		return fmt.Sprintf(" AS (%s)", gc.Value.Statement)
+	}
+	return fmt.Sprintf(" AS (%s) STORED", gc.Value.Statement)
+}
+
 func (dv DefaultValue) PrintDefaultValue(ty Type) string {
 	if !dv.IsPresent {
 		return ""
@@ -542,8 +689,9 @@ func (agc AutoGenCol) PrintIdentityCol(c Config) string {
 	return fmt.Sprintf(" GENERATED BY DEFAULT AS IDENTITY (%s)", strings.Join(options, " "))
 }
 
-// PrintCreateIndex unparses a CREATE INDEX statement.
-func (ci CreateIndex) PrintCreateIndex(ct CreateTable, c Config) string {
+// PrintCreateIndex unparses a CREATE INDEX statement. The name of the table
+// that the index is interleaved in is looked up in spSchema.
+func (ci CreateIndex) PrintCreateIndex(ct CreateTable, spSchema Schema, c Config) string {
 	var keys []string
 
 	orderedKeys := []IndexKey{}
@@ -573,7 +721,129 @@ func (ci CreateIndex) PrintCreateIndex(ct CreateTable, c Config) string {
 		}
 		storingClause = fmt.Sprintf(" %s (%s)", stored, strings.Join(storedColumns, ", "))
 	}
-	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)%s", unique, c.quote(ci.Name), c.quote(ct.Name), strings.Join(keys, ", "), storingClause)
+	var interleave string
+	if ci.InterleaveIn != "" {
+		interleave = " INTERLEAVE IN " + c.quoteQualified(spSchema[ci.InterleaveIn].SchemaName, spSchema[ci.InterleaveIn].Name)
+	}
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		// PostgreSQL has no NULL_FILTERED indexes, instead the index filters
+		// out NULL keys with a WHERE clause.
+		var where string
+		if ci.NullFiltered {
+			var conds []string
+			for _, k := range orderedKeys {
+				conds = append(conds, c.quote(ct.ColDefs[k.ColId].Name)+" IS NOT NULL")
+			}
+			where = " WHERE " + strings.Join(conds, " AND ")
+		}
+		// PostgreSQL indexes are created in the schema of their table, so
+		// their names can't be qualified.
+		return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)%s%s%s", unique, c.quote(ci.Name), c.quoteQualified(ct.SchemaName, ct.Name), strings.Join(keys, ", "), storingClause, interleave, where)
+	}
+	var nullFiltered string
+	if ci.NullFiltered {
+		nullFiltered = "NULL_FILTERED "
+	}
+	if interleave != "" {
+		interleave = "," + interleave
+	}
+	return fmt.Sprintf("CREATE %s%sINDEX %s ON %s (%s)%s%s", unique, nullFiltered, c.quoteQualified(ci.SchemaName, ci.Name), c.quoteQualified(ct.SchemaName, ct.Name), strings.Join(keys, ", "), storingClause, interleave)
+}
+
+// CreateSearchIndex encodes the following DDL definition:
+//
+//	create search index: CREATE SEARCH INDEX index_name ON table_name ( tokenlist_column [, ...] )
+type CreateSearchIndex struct {
+	Name    string
+	TableId string `json:"TableId"`
+	// Keys are the TOKENLIST columns that are indexed.
+	Keys []IndexKey
+	Id   string
+}
+
+// PrintCreateSearchIndex unparses a CREATE SEARCH INDEX statement.
+func (si CreateSearchIndex) PrintCreateSearchIndex(ct CreateTable, c Config) string {
+	var keys []string
+	for _, k := range si.Keys {
+		keys = append(keys, c.quote(ct.ColDefs[k.ColId].Name))
+	}
+	return fmt.Sprintf("CREATE SEARCH INDEX %s ON %s (%s)", c.quoteQualified(ct.SchemaName, si.Name), c.quoteQualified(ct.SchemaName, ct.Name), strings.Join(keys, ", "))
+}
+
+// Distance types of vector indexes.
+const (
+	CosineDistance     = "COSINE"
+	EuclideanDistance  = "EUCLIDEAN"
+	DotProductDistance = "DOT_PRODUCT"
+)
+
+// CreateVectorIndex encodes the following DDL definition:
+//
+//	create vector index: CREATE VECTOR INDEX index_name ON table_name ( column_name ) [ WHERE column_name IS NOT NULL ] OPTIONS ( distance_type = type )
+type CreateVectorIndex struct {
+	Name    string
+	TableId string `json:"TableId"`
+	// Keys is the ARRAY<FLOAT32> or ARRAY<FLOAT64> column that is indexed.
+	Keys         []IndexKey
+	DistanceType string
+	Id           string
+}
+
+// PrintCreateVectorIndex unparses a CREATE VECTOR INDEX statement, which is
+// an index using ScaNN in PostgreSQL. Vector indexes can't index NULL
+// vectors, so they are filtered out unless the column is NOT NULL.
+func (vi CreateVectorIndex) PrintCreateVectorIndex(ct CreateTable, c Config) string {
+	var col string
+	var notNull bool
+	if len(vi.Keys) > 0 {
+		col = c.quote(ct.ColDefs[vi.Keys[0].ColId].Name)
+		notNull = ct.ColDefs[vi.Keys[0].ColId].NotNull
+	}
+	var where string
+	if !notNull {
+		where = fmt.Sprintf(" WHERE %s IS NOT NULL", col)
+	}
+	if c.SpDialect == constants.DIALECT_POSTGRESQL {
+		return fmt.Sprintf("CREATE INDEX %s ON %s USING ScaNN (%s spanner.%s)%s", c.quote(vi.Name), c.quoteQualified(ct.SchemaName, ct.Name), col, strings.ToLower(vi.DistanceType), where)
+	}
+	return fmt.Sprintf("CREATE VECTOR INDEX %s ON %s (%s)%s OPTIONS (distance_type = '%s')", c.quoteQualified(ct.SchemaName, vi.Name), c.quoteQualified(ct.SchemaName, ct.Name), col, where, vi.DistanceType)
+}
+
+// SQL security types of views.
+const (
+	SqlSecurityInvoker = "INVOKER"
+	SqlSecurityDefiner = "DEFINER"
+)
+
+// CreateView encodes the following DDL definition:
+//
+//	create view: CREATE VIEW view_name SQL SECURITY { INVOKER | DEFINER } AS query
+type CreateView struct {
+	Name string
+	// SchemaName is the named schema the view is in, if any.
+	SchemaName string `json:",omitempty"`
+	// SqlSecurity is the SQL security of the view. Views without one are
+	// INVOKER views.
+	SqlSecurity string `json:",omitempty"`
+	Query       string
+	// RefIds are the ids of the tables and views that the query refers to.
+	RefIds []string `json:",omitempty"`
+	Id     string
+}
+
+// QualifiedName returns the name of view v qualified by its named schema.
+func (v CreateView) QualifiedName() string {
+	return QualifiedName(v.SchemaName, v.Name)
+}
+
+// PrintCreateView unparses a CREATE VIEW statement. The statement is the
+// same in both dialects.
+func (v CreateView) PrintCreateView(c Config) string {
+	sqlSecurity := v.SqlSecurity
+	if sqlSecurity == "" {
+		sqlSecurity = SqlSecurityInvoker
+	}
+	return fmt.Sprintf("CREATE VIEW %s SQL SECURITY %s AS %s", c.quoteQualified(v.SchemaName, v.Name), sqlSecurity, v.Query)
 }
 
 // Checks if the colId is part of the primary of a table
@@ -599,7 +869,8 @@ func (k Foreignkey) PrintForeignKeyAlterTable(spannerSchema Schema, c Config, ta
 	if k.Name != "" {
 		s = fmt.Sprintf("CONSTRAINT %s ", c.quote(k.Name))
 	}
-	s = fmt.Sprintf("ALTER TABLE %s ADD %sFOREIGN KEY (%s) REFERENCES %s (%s)", c.quote(spannerSchema[tableId].Name), s, strings.Join(cols, ", "), c.quote(spannerSchema[k.ReferTableId].Name), strings.Join(referCols, ", "))
+	table, referTable := spannerSchema[tableId], spannerSchema[k.ReferTableId]
+	s = fmt.Sprintf("ALTER TABLE %s ADD %sFOREIGN KEY (%s) REFERENCES %s (%s)", c.quoteQualified(table.SchemaName, table.Name), s, strings.Join(cols, ", "), c.quoteQualified(referTable.SchemaName, referTable.Name), strings.Join(referCols, ", "))
 	if k.OnDelete != "" {
 		s = s + fmt.Sprintf(" ON DELETE %s", k.OnDelete)
 	}
@@ -648,8 +919,8 @@ func GetSortedTableIdsBySpName(s Schema) []string {
 	var tableNames, sortedTableNames, sortedTableIds []string
 	tableNameIdMap := map[string]string{}
 	for _, t := range s {
-		tableNames = append(tableNames, t.Name)
-		tableNameIdMap[t.Name] = t.Id
+		tableNames = append(tableNames, t.QualifiedName())
+		tableNameIdMap[t.QualifiedName()] = t.Id
 	}
 	logger.Log.Debug(fmt.Sprintf("getting sorted table ids by table name: %s", tableNames))
 	sort.Strings(tableNames)
@@ -667,7 +938,7 @@ func GetSortedTableIdsBySpName(s Schema) []string {
 		// Add table t if either:
 		// a) t is not interleaved in another table, or
 		// b) t is interleaved in another table and that table has already been added to the list.
-		if table.ParentTable.Id == "" || tableAdded[s[table.ParentTable.Id].Name] || !parentTableExists {
+		if table.ParentTable.Id == "" || tableAdded[s[table.ParentTable.Id].QualifiedName()] || !parentTableExists {
 			sortedTableNames = append(sortedTableNames, tableName)
 			tableAdded[tableName] = true
 		} else {
@@ -702,6 +973,14 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence, db
 		}
 	}
 
+	// Named schemas are created along with their tables, and not again when
+	// only foreign keys are printed.
+	if c.Tables {
+		for _, schemaName := range getSchemaNames(tableSchema, sequenceSchema) {
+			ddl = append(ddl, "CREATE SCHEMA "+c.quote(schemaName))
+		}
+	}
+
 	for _, seq := range sequenceSchema {
 		if c.SpDialect == constants.DIALECT_POSTGRESQL {
 			ddl = append(ddl, seq.PGPrintSequence(c))
@@ -714,10 +993,7 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence, db
 
 	if c.Tables {
 		for _, tableId := range tableIds {
-			ddl = append(ddl, tableSchema[tableId].PrintCreateTable(tableSchema, c))
-			for _, index := range tableSchema[tableId].Indexes {
-				ddl = append(ddl, index.PrintCreateIndex(tableSchema[tableId], c))
-			}
+			ddl = append(ddl, GetTableDDL(c, tableSchema, tableId)...)
 		}
 	}
 	// Append foreign key constraints to DDL.
@@ -737,6 +1013,111 @@ func GetDDL(c Config, tableSchema Schema, sequenceSchema map[string]Sequence, db
 	return ddl
 }
 
+// GetTableDDL returns the statements that create table tableId of
+// tableSchema and, unless c.SkipIndexes is set, its secondary indexes.
+// Foreign keys are created separately (see GetDDL).
+func GetTableDDL(c Config, tableSchema Schema, tableId string) []string {
+	ct := tableSchema[tableId]
+	ddl := []string{ct.PrintCreateTable(tableSchema, c)}
+	if c.SkipIndexes {
+		return ddl
+	}
+	for _, index := range ct.Indexes {
+		ddl = append(ddl, index.PrintCreateIndex(ct, tableSchema, c))
+	}
+	for _, index := range ct.SearchIndexes {
+		ddl = append(ddl, index.PrintCreateSearchIndex(ct, c))
+	}
+	for _, index := range ct.VectorIndexes {
+		ddl = append(ddl, index.PrintCreateVectorIndex(ct, c))
+	}
+	return ddl
+}
+
+// GetIndexDDL returns the statements that create the secondary indexes of
+// the tables in tableSchema. It is used to create indexes separately from
+// their tables (see Config.SkipIndexes).
+func GetIndexDDL(c Config, tableSchema Schema) []string {
+	var ddl []string
+	for _, tableId := range GetSortedTableIdsBySpName(tableSchema) {
+		for _, index := range tableSchema[tableId].Indexes {
+			ddl = append(ddl, index.PrintCreateIndex(tableSchema[tableId], tableSchema, c))
+		}
+		for _, index := range tableSchema[tableId].SearchIndexes {
+			ddl = append(ddl, index.PrintCreateSearchIndex(tableSchema[tableId], c))
+		}
+		for _, index := range tableSchema[tableId].VectorIndexes {
+			ddl = append(ddl, index.PrintCreateVectorIndex(tableSchema[tableId], c))
+		}
+	}
+	return ddl
+}
+
+// GetViewDDL returns the statements that create views, in the order of
+// GetSortedViewIds. The tables they refer to must be created first (see
+// GetDDL).
+func GetViewDDL(c Config, views map[string]CreateView) []string {
+	var ddl []string
+	for _, id := range GetSortedViewIds(views) {
+		ddl = append(ddl, views[id].PrintCreateView(c))
+	}
+	return ddl
+}
+
+// GetSortedViewIds returns the ids of views in alphabetical order of their
+// names with one exception: views appear after the views they refer to.
+func GetSortedViewIds(views map[string]CreateView) []string {
+	var ids []string
+	for id := range views {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return views[ids[i]].QualifiedName() < views[ids[j]].QualifiedName()
+	})
+	var sorted []string
+	added := make(map[string]bool)
+	var add func(id string)
+	add = func(id string) {
+		if added[id] {
+			return
+		}
+		// Mark the view before visiting its references so that a cycle,
+		// which Spanner would reject anyway, can't recurse forever.
+		added[id] = true
+		for _, refId := range views[id].RefIds {
+			if _, ok := views[refId]; ok {
+				add(refId)
+			}
+		}
+		sorted = append(sorted, id)
+	}
+	for _, id := range ids {
+		add(id)
+	}
+	return sorted
+}
+
+// getSchemaNames returns the sorted names of the named schemas used by the
+// tables and sequences, which must be created before them.
+func getSchemaNames(tableSchema Schema, sequenceSchema map[string]Sequence) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, t := range tableSchema {
+		add(t.SchemaName)
+	}
+	for _, seq := range sequenceSchema {
+		add(seq.SchemaName)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // CheckInterleaved checks if schema contains interleaved tables.
 func (s Schema) CheckInterleaved() bool {
 	for _, table := range s {
@@ -758,8 +1139,10 @@ func maxStringLength(s []string) int {
 }
 
 type Sequence struct {
-	Id               string
-	Name             string
+	Id   string
+	Name string
+	// SchemaName is the named schema the sequence is in, if any.
+	SchemaName       string `json:",omitempty"`
 	SequenceKind     string
 	SkipRangeMin     string
 	SkipRangeMax     string
@@ -785,7 +1168,7 @@ func (seq Sequence) PrintSequence(c Config) string {
 		options = append(options, fmt.Sprintf("start_with_counter = %s", seq.StartWithCounter))
 	}
 
-	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s", c.quote(seq.Name))
+	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s", c.quoteQualified(seq.SchemaName, seq.Name))
 	if len(options) > 0 {
 		seqDDL += " OPTIONS (" + strings.Join(options, ", ") + ") "
 	}
@@ -807,7 +1190,7 @@ func (seq Sequence) PGPrintSequence(c Config) string {
 		options = append(options, fmt.Sprintf("START COUNTER WITH %s", seq.StartWithCounter))
 	}
 
-	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s", c.quote(seq.Name))
+	seqDDL := fmt.Sprintf("CREATE SEQUENCE %s", c.quoteQualified(seq.SchemaName, seq.Name))
 	if len(options) > 0 {
 		seqDDL += strings.Join(options, " ")
 	}
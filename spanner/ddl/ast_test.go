@@ -42,6 +42,8 @@ func TestPrintScalarType(t *testing.T) {
 		{Type{Name: Bytes, Len: int64(42)}, "BYTES(42)"},
 		{Type{Name: Date}, "DATE"},
 		{Type{Name: Timestamp}, "TIMESTAMP"},
+		{Type{Name: UUID}, "UUID"},
+		{Type{Name: Interval}, "INTERVAL"},
 	}
 	for _, tc := range tests {
 		assert.Equal(t, tc.expected, tc.in.PrintColumnDefType())
@@ -62,6 +64,8 @@ func TestPrintScalarTypePG(t *testing.T) {
 		{Type{Name: Bytes, Len: MaxLength}, "BYTEA"},
 		{Type{Name: Bytes, Len: int64(42)}, "BYTEA"},
 		{Type{Name: Timestamp}, "TIMESTAMPTZ"},
+		{Type{Name: UUID}, "UUID"},
+		{Type{Name: Interval}, "INTERVAL"},
 	}
 	for _, tc := range tests {
 		assert.Equal(t, tc.expected, tc.in.PGPrintColumnDefType())
@@ -79,6 +83,7 @@ func TestPrintColumnDef(t *testing.T) {
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}, NotNull: true}, expected: "col1 INT64 NOT NULL "},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}, NotNull: true}, expected: "col1 ARRAY<INT64> NOT NULL "},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}}, protectIds: true, expected: "`col1` INT64"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: Float32, IsArray: true, VectorLength: 3}}, expected: "col1 ARRAY<FLOAT32>(vector_length=>3)"},
 		{
 			in: ColumnDef{
 				Name: "col1",
@@ -90,6 +95,24 @@ func TestPrintColumnDef(t *testing.T) {
 			},
 			expected: "col1 INT64 DEFAULT ((`col2` + 1))",
 		},
+		{
+			in: ColumnDef{
+				Name:      "col1",
+				T:         Type{Name: Int64},
+				NotNull:   true,
+				Generated: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "col2 + 1"}},
+			},
+			expected: "col1 INT64 NOT NULL  AS (col2 + 1) STORED",
+		},
+		{
+			in: ColumnDef{
+				Name:      "col1_Tokens",
+				T:         Type{Name: TokenList},
+				Generated: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "TOKENIZE_FULLTEXT(col1)"}, Virtual: true},
+				Hidden:    true,
+			},
+			expected: "col1_Tokens TOKENLIST AS (TOKENIZE_FULLTEXT(col1)) HIDDEN",
+		},
 		{
 			in: ColumnDef{
 				Name: "col1",
@@ -112,9 +135,11 @@ func TestPrintColumnDefPG(t *testing.T) {
 		expected   string
 	}{
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}}, expected: "col1 INT8"},
-		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}}, expected: "col1 VARCHAR(2621440)"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}}, expected: "col1 INT8[]"},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}, NotNull: true}, expected: "col1 INT8 NOT NULL "},
-		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}, NotNull: true}, expected: "col1 VARCHAR(2621440) NOT NULL "},
+		{in: ColumnDef{Name: "col1", T: Type{Name: Int64, IsArray: true}, NotNull: true}, expected: "col1 INT8[] NOT NULL "},
+		{in: ColumnDef{Name: "col1", T: Type{Name: String, Len: 42, IsArray: true}}, expected: "col1 VARCHAR(42)[]"},
+		{in: ColumnDef{Name: "col1", T: Type{Name: Float32, IsArray: true, VectorLength: 3}}, expected: "col1 FLOAT4[] VECTOR LENGTH 3"},
 		{in: ColumnDef{Name: "col1", T: Type{Name: Int64}}, protectIds: true, expected: "col1 INT8"},
 		{
 			in: ColumnDef{
@@ -127,6 +152,23 @@ func TestPrintColumnDefPG(t *testing.T) {
 			},
 			expected: "col1 INT8 DEFAULT ((`col2` + 1))",
 		},
+		{
+			in: ColumnDef{
+				Name:      "col1",
+				T:         Type{Name: Int64},
+				Generated: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "col2 + 1"}},
+			},
+			expected: "col1 INT8 GENERATED ALWAYS AS (col2 + 1) STORED",
+		},
+		{
+			in: ColumnDef{
+				Name:      "col1_tokens",
+				T:         Type{Name: TokenList},
+				Generated: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "spanner.tokenize_fulltext(col1)"}, Virtual: true},
+				Hidden:    true,
+			},
+			expected: "col1_tokens SPANNER.TOKENLIST GENERATED ALWAYS AS (spanner.tokenize_fulltext(col1)) VIRTUAL HIDDEN",
+		},
 	}
 	for _, tc := range tests {
 		s, _ := tc.in.PrintColumnDef(Config{ProtectIds: tc.protectIds, SpDialect: constants.DIALECT_POSTGRESQL})
@@ -507,14 +549,52 @@ func TestPrintCreateTablePG(t *testing.T) {
 	}
 }
 
+func TestPrintCreateTableRowDeletionPolicy(t *testing.T) {
+	s := Schema{
+		"t1": CreateTable{
+			Name:        "table1",
+			ColIds:      []string{"col1"},
+			ColDefs:     map[string]ColumnDef{"col1": {Name: "col1", T: Type{Name: Int64}, NotNull: true}},
+			PrimaryKeys: []IndexKey{{ColId: "col1"}},
+			Id:          "t1",
+		},
+		"t2": CreateTable{
+			Name:   "table2",
+			ColIds: []string{"col1", "col2"},
+			ColDefs: map[string]ColumnDef{
+				"col1": {Name: "col1", T: Type{Name: Int64}, NotNull: true},
+				"col2": {Name: "expire_at", T: Type{Name: Timestamp}},
+			},
+			PrimaryKeys:       []IndexKey{{ColId: "col1"}},
+			ParentTable:       InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+			RowDeletionPolicy: &RowDeletionPolicy{ColId: "col2", Days: 30},
+			Id:                "t2",
+		},
+	}
+	assert.Equal(t, "CREATE TABLE table2 (\n"+
+		"	col1 INT64 NOT NULL ,\n"+
+		"	expire_at TIMESTAMP,\n"+
+		") PRIMARY KEY (col1),\n"+
+		"INTERLEAVE IN PARENT table1 ON DELETE CASCADE,\n"+
+		"ROW DELETION POLICY (OLDER_THAN(expire_at, INTERVAL 30 DAY))",
+		s["t2"].PrintCreateTable(s, Config{SpDialect: constants.DIALECT_GOOGLESQL}))
+	assert.Equal(t, "CREATE TABLE table2 (\n"+
+		"	col1 INT8 NOT NULL ,\n"+
+		"	expire_at TIMESTAMPTZ,\n"+
+		"	PRIMARY KEY (col1)\n"+
+		") INTERLEAVE IN PARENT table1 ON DELETE CASCADE TTL INTERVAL '30 days' ON expire_at",
+		s["t2"].PrintCreateTable(s, Config{SpDialect: constants.DIALECT_POSTGRESQL}))
+}
+
 func TestPrintCreateIndex(t *testing.T) {
 	ct := CreateTable{
 		Name:   "mytable",
 		Id:     "t1",
-		ColIds: []string{"c1", "c2"},
+		ColIds: []string{"c1", "c2", "c3"},
 		ColDefs: map[string]ColumnDef{
 			"c1": {Name: "col1", Id: "c1"},
 			"c2": {Name: "col2", Id: "c2"},
+			"c3": {Name: "col3", Id: "c3"},
 		},
 	}
 	ci := []CreateIndex{
@@ -525,6 +605,9 @@ func TestPrintCreateIndex(t *testing.T) {
 			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
 			"i1",
 			nil,
+			/*NullFiltered =*/ false,
+			"",
+			/*SchemaName =*/ "",
 		},
 		{
 			"myindex2",
@@ -533,8 +616,23 @@ func TestPrintCreateIndex(t *testing.T) {
 			[]IndexKey{{ColId: "c1", Desc: true}, {ColId: "c2"}},
 			"i2",
 			nil,
+			/*NullFiltered =*/ false,
+			"",
+			/*SchemaName =*/ "",
+		},
+		{
+			"myindex3",
+			"t1",
+			/*Unique =*/ false,
+			[]IndexKey{{ColId: "c1", Order: 1}, {ColId: "c2", Order: 2}},
+			"i3",
+			[]string{"c3"},
+			/*NullFiltered =*/ true,
+			"t2",
+			/*SchemaName =*/ "",
 		},
 	}
+	s := Schema{"t1": ct, "t2": {Name: "parent", Id: "t2"}}
 	tests := []struct {
 		name       string
 		protectIds bool
@@ -547,12 +645,152 @@ func TestPrintCreateIndex(t *testing.T) {
 		{"unique key", true, "", ci[1], "CREATE UNIQUE INDEX `myindex2` ON `mytable` (`col1` DESC, `col2`)"},
 		{"quote non unique PG", true, constants.DIALECT_POSTGRESQL, ci[0], "CREATE INDEX myindex ON mytable (col1 DESC, col2)"},
 		{"unique key PG", true, constants.DIALECT_POSTGRESQL, ci[1], "CREATE UNIQUE INDEX myindex2 ON mytable (col1 DESC, col2)"},
+		{"null filtered interleaved", true, "", ci[2], "CREATE NULL_FILTERED INDEX `myindex3` ON `mytable` (`col1`, `col2`) STORING (`col3`), INTERLEAVE IN `parent`"},
+		{"null filtered interleaved PG", true, constants.DIALECT_POSTGRESQL, ci[2], "CREATE INDEX myindex3 ON mytable (col1, col2) INCLUDE (col3) INTERLEAVE IN parent WHERE col1 IS NOT NULL AND col2 IS NOT NULL"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, tc.index.PrintCreateIndex(ct, s, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}))
+	}
+}
+
+func TestPrintCreateSearchIndex(t *testing.T) {
+	ct := CreateTable{
+		Name:   "mytable",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "col1", Id: "c1"},
+			"c2": {Name: "col1_Tokens", Id: "c2"},
+			"c3": {Name: "col2_Tokens", Id: "c3"},
+		},
+	}
+	si := CreateSearchIndex{Name: "mysearchindex", TableId: "t1", Keys: []IndexKey{{ColId: "c2", Order: 1}, {ColId: "c3", Order: 2}}, Id: "i1"}
+	tests := []struct {
+		name       string
+		protectIds bool
+		spDialect  string
+		expected   string
+	}{
+		{"no quote", false, "", "CREATE SEARCH INDEX mysearchindex ON mytable (col1_Tokens, col2_Tokens)"},
+		{"quote", true, "", "CREATE SEARCH INDEX `mysearchindex` ON `mytable` (`col1_Tokens`, `col2_Tokens`)"},
+		{"quote PG", true, constants.DIALECT_POSTGRESQL, "CREATE SEARCH INDEX mysearchindex ON mytable (col1_Tokens, col2_Tokens)"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, si.PrintCreateSearchIndex(ct, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}), tc.name)
+	}
+}
+
+func TestPrintCreateVectorIndex(t *testing.T) {
+	ct := CreateTable{
+		Name:   "mytable",
+		Id:     "t1",
+		ColIds: []string{"c1", "c2", "c3"},
+		ColDefs: map[string]ColumnDef{
+			"c1": {Name: "col1", Id: "c1"},
+			"c2": {Name: "embedding", Id: "c2", T: Type{Name: Float32, IsArray: true, VectorLength: 3}},
+			"c3": {Name: "embedding2", Id: "c3", T: Type{Name: Float32, IsArray: true, VectorLength: 3}, NotNull: true},
+		},
+	}
+	tests := []struct {
+		name       string
+		index      CreateVectorIndex
+		protectIds bool
+		spDialect  string
+		expected   string
+	}{
+		{
+			"nullable column",
+			CreateVectorIndex{Name: "myvectorindex", TableId: "t1", Keys: []IndexKey{{ColId: "c2", Order: 1}}, DistanceType: CosineDistance, Id: "i1"},
+			false,
+			"",
+			"CREATE VECTOR INDEX myvectorindex ON mytable (embedding) WHERE embedding IS NOT NULL OPTIONS (distance_type = 'COSINE')",
+		},
+		{
+			"not null column",
+			CreateVectorIndex{Name: "myvectorindex", TableId: "t1", Keys: []IndexKey{{ColId: "c3", Order: 1}}, DistanceType: EuclideanDistance, Id: "i1"},
+			true,
+			"",
+			"CREATE VECTOR INDEX `myvectorindex` ON `mytable` (`embedding2`) OPTIONS (distance_type = 'EUCLIDEAN')",
+		},
+		{
+			"PG nullable column",
+			CreateVectorIndex{Name: "myvectorindex", TableId: "t1", Keys: []IndexKey{{ColId: "c2", Order: 1}}, DistanceType: DotProductDistance, Id: "i1"},
+			true,
+			constants.DIALECT_POSTGRESQL,
+			"CREATE INDEX myvectorindex ON mytable USING ScaNN (embedding spanner.dot_product) WHERE embedding IS NOT NULL",
+		},
+		{
+			"PG not null column",
+			CreateVectorIndex{Name: "myvectorindex", TableId: "t1", Keys: []IndexKey{{ColId: "c3", Order: 1}}, DistanceType: CosineDistance, Id: "i1"},
+			false,
+			constants.DIALECT_POSTGRESQL,
+			"CREATE INDEX myvectorindex ON mytable USING ScaNN (embedding2 spanner.cosine)",
+		},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, tc.index.PrintCreateVectorIndex(ct, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}), tc.name)
+	}
+}
+
+func TestPrintCreateView(t *testing.T) {
+	tests := []struct {
+		name       string
+		view       CreateView
+		protectIds bool
+		spDialect  string
+		expected   string
+	}{
+		{
+			"default sql security",
+			CreateView{Name: "myview", Query: "SELECT a FROM t", Id: "vw1"},
+			false,
+			"",
+			"CREATE VIEW myview SQL SECURITY INVOKER AS SELECT a FROM t",
+		},
+		{
+			"definer",
+			CreateView{Name: "myview", SqlSecurity: SqlSecurityDefiner, Query: "SELECT a FROM t", Id: "vw1"},
+			true,
+			"",
+			"CREATE VIEW `myview` SQL SECURITY DEFINER AS SELECT a FROM t",
+		},
+		{
+			"named schema",
+			CreateView{Name: "myview", SchemaName: "sales", SqlSecurity: SqlSecurityInvoker, Query: "SELECT a FROM sales.t", Id: "vw1"},
+			true,
+			"",
+			"CREATE VIEW `sales`.`myview` SQL SECURITY INVOKER AS SELECT a FROM sales.t",
+		},
+		{
+			"PG",
+			CreateView{Name: "myview", SqlSecurity: SqlSecurityInvoker, Query: "SELECT a FROM t", Id: "vw1"},
+			true,
+			constants.DIALECT_POSTGRESQL,
+			"CREATE VIEW myview SQL SECURITY INVOKER AS SELECT a FROM t",
+		},
 	}
 	for _, tc := range tests {
-		assert.Equal(t, tc.expected, tc.index.PrintCreateIndex(ct, Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}))
+		assert.Equal(t, tc.expected, tc.view.PrintCreateView(Config{ProtectIds: tc.protectIds, SpDialect: tc.spDialect}), tc.name)
 	}
 }
 
+func TestGetViewDDL(t *testing.T) {
+	views := map[string]CreateView{
+		"vw1": {Name: "a_totals", Query: "SELECT s, t FROM c_sums", RefIds: []string{"vw3"}, Id: "vw1"},
+		"vw2": {Name: "b_orders", Query: "SELECT a FROM orders", RefIds: []string{"t1"}, Id: "vw2"},
+		"vw3": {Name: "c_sums", Query: "SELECT s, t FROM d_items", RefIds: []string{"t2", "vw4"}, Id: "vw3"},
+		"vw4": {Name: "d_items", Query: "SELECT s, t FROM items", RefIds: []string{"t2"}, Id: "vw4"},
+	}
+	e := []string{
+		"CREATE VIEW `d_items` SQL SECURITY INVOKER AS SELECT s, t FROM items",
+		"CREATE VIEW `c_sums` SQL SECURITY INVOKER AS SELECT s, t FROM d_items",
+		"CREATE VIEW `a_totals` SQL SECURITY INVOKER AS SELECT s, t FROM c_sums",
+		"CREATE VIEW `b_orders` SQL SECURITY INVOKER AS SELECT a FROM orders",
+	}
+	assert.Equal(t, e, GetViewDDL(Config{ProtectIds: true}, views))
+	assert.Nil(t, GetViewDDL(Config{}, nil))
+}
+
 func TestPrintForeignKey(t *testing.T) {
 	fk := []Foreignkey{
 		{
@@ -563,6 +801,7 @@ func TestPrintForeignKey(t *testing.T) {
 			"1",
 			constants.FK_NO_ACTION,
 			constants.FK_NO_ACTION,
+			"",
 		},
 		{
 			"",
@@ -572,6 +811,7 @@ func TestPrintForeignKey(t *testing.T) {
 			"1",
 			constants.FK_CASCADE,
 			constants.FK_NO_ACTION,
+			"",
 		},
 		{
 			"fk_test",
@@ -581,6 +821,17 @@ func TestPrintForeignKey(t *testing.T) {
 			"1",
 			"",
 			"",
+			"",
+		},
+		{
+			"fk_test",
+			[]string{"c1"},
+			"ref_table",
+			[]string{"ref_c1"},
+			"1",
+			"",
+			"",
+			"hr",
 		},
 	}
 	tests := []struct {
@@ -595,6 +846,7 @@ func TestPrintForeignKey(t *testing.T) {
 		{"no constraint name", false, "", "FOREIGN KEY (c1) REFERENCES ref_table (ref_c1) ON DELETE CASCADE", fk[1]},
 		{"quote PG", true, constants.DIALECT_POSTGRESQL, "CONSTRAINT fk_test FOREIGN KEY (c1, c2) REFERENCES ref_table (ref_c1, ref_c2) ON DELETE NO ACTION", fk[0]},
 		{"foreign key constraints not supported i.e. dont print ON DELETE", false, "", "CONSTRAINT fk_test FOREIGN KEY (c1, c2) REFERENCES ref_table (ref_c1, ref_c2)", fk[2]},
+		{"named schema", true, "", "CONSTRAINT `fk_test` FOREIGN KEY (`c1`) REFERENCES `hr`.`ref_table` (`ref_c1`)", fk[3]},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -623,6 +875,7 @@ func TestPrintForeignKeyAlterTable(t *testing.T) {
 					"f1",
 					constants.FK_CASCADE,
 					constants.FK_NO_ACTION,
+					"",
 				},
 				{
 					"",
@@ -632,6 +885,7 @@ func TestPrintForeignKeyAlterTable(t *testing.T) {
 					"f2",
 					constants.FK_NO_ACTION,
 					constants.FK_NO_ACTION,
+					"",
 				},
 				{
 					"fk_test2",
@@ -641,6 +895,7 @@ func TestPrintForeignKeyAlterTable(t *testing.T) {
 					"f1",
 					"",
 					"",
+					"",
 				},
 			},
 		},
@@ -1165,6 +1420,11 @@ func TestGetDDL(t *testing.T) {
 	}
 	assert.ElementsMatch(t, e3, tablesAndFks)
 
+	tablesWithoutIndexes := GetDDL(Config{Tables: true, SkipIndexes: true}, s, make(map[string]Sequence), DatabaseOptions{})
+	assert.ElementsMatch(t, []string{e[0], e[2], e[4], e[5]}, tablesWithoutIndexes)
+	indexesOnly := GetIndexDDL(Config{}, s)
+	assert.Equal(t, []string{e[1], e[3]}, indexesOnly)
+
 	sequences := make(map[string]Sequence)
 	sequences["s1"] = Sequence{
 		Id:               "s1",
@@ -1338,6 +1598,110 @@ func TestGetPGDDL(t *testing.T) {
 	assert.ElementsMatch(t, e5, dbOptionsOnly)
 }
 
+func TestGetDDLNamedSchemas(t *testing.T) {
+	s := Schema{
+		"t1": CreateTable{
+			Name:       "orders",
+			SchemaName: "sales",
+			Id:         "t1",
+			ColIds:     []string{"c1", "c2"},
+			ColDefs: map[string]ColumnDef{
+				"c1": {Name: "a", Id: "c1", T: Type{Name: Int64}},
+				"c2": {Name: "b", Id: "c2", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c1"}},
+			ForeignKeys: []Foreignkey{{Name: "fk1", ColIds: []string{"c2"}, ReferTableId: "t2", ReferColumnIds: []string{"c3"}}},
+			Indexes:     []CreateIndex{{Name: "index1", SchemaName: "sales", TableId: "t1", Keys: []IndexKey{{ColId: "c2"}}}},
+		},
+		"t2": CreateTable{
+			Name:       "orders",
+			SchemaName: "hr",
+			Id:         "t2",
+			ColIds:     []string{"c3"},
+			ColDefs: map[string]ColumnDef{
+				"c3": {Name: "a", Id: "c3", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c3"}},
+		},
+		"t3": CreateTable{
+			Name:       "items",
+			SchemaName: "sales",
+			Id:         "t3",
+			ColIds:     []string{"c4", "c5"},
+			ColDefs: map[string]ColumnDef{
+				"c4": {Name: "a", Id: "c4", T: Type{Name: Int64}},
+				"c5": {Name: "b", Id: "c5", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c4"}, {ColId: "c5"}},
+			ParentTable: InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+		},
+		"t4": CreateTable{
+			Name:   "customers",
+			Id:     "t4",
+			ColIds: []string{"c6"},
+			ColDefs: map[string]ColumnDef{
+				"c6": {Name: "a", Id: "c6", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c6"}},
+		},
+	}
+	seqs := map[string]Sequence{
+		"s1": {Id: "s1", Name: "order_seq", SchemaName: "sales", SequenceKind: "BIT REVERSED POSITIVE"},
+	}
+	actual := GetDDL(Config{Tables: true, ForeignKeys: true, ProtectIds: true}, s, seqs, DatabaseOptions{})
+	e := []string{
+		"CREATE SCHEMA `hr`",
+		"CREATE SCHEMA `sales`",
+		"CREATE SEQUENCE `sales`.`order_seq` OPTIONS (sequence_kind='bit_reversed_positive') ",
+		"CREATE TABLE `customers` (\n" +
+			"	`a` INT64,\n" +
+			") PRIMARY KEY (`a`)",
+		"CREATE TABLE `hr`.`orders` (\n" +
+			"	`a` INT64,\n" +
+			") PRIMARY KEY (`a`)",
+		"CREATE TABLE `sales`.`orders` (\n" +
+			"	`a` INT64,\n" +
+			"	`b` INT64,\n" +
+			") PRIMARY KEY (`a`)",
+		"CREATE INDEX `sales`.`index1` ON `sales`.`orders` (`b`)",
+		"CREATE TABLE `sales`.`items` (\n" +
+			"	`a` INT64,\n" +
+			"	`b` INT64,\n" +
+			") PRIMARY KEY (`a`, `b`),\n" +
+			"INTERLEAVE IN PARENT `sales`.`orders` ON DELETE CASCADE",
+		"ALTER TABLE `sales`.`orders` ADD CONSTRAINT `fk1` FOREIGN KEY (`b`) REFERENCES `hr`.`orders` (`a`)",
+	}
+	assert.Equal(t, e, actual)
+
+	actual = GetDDL(Config{Tables: true, ForeignKeys: true, SpDialect: constants.DIALECT_POSTGRESQL}, s, seqs, DatabaseOptions{})
+	e = []string{
+		"CREATE SCHEMA hr",
+		"CREATE SCHEMA sales",
+		"CREATE SEQUENCE sales.order_seq BIT_REVERSED_POSITIVE",
+		"CREATE TABLE customers (\n" +
+			"	a INT8,\n" +
+			"	PRIMARY KEY (a)\n" +
+			")",
+		"CREATE TABLE hr.orders (\n" +
+			"	a INT8,\n" +
+			"	PRIMARY KEY (a)\n" +
+			")",
+		"CREATE TABLE sales.orders (\n" +
+			"	a INT8,\n" +
+			"	b INT8,\n" +
+			"	PRIMARY KEY (a)\n" +
+			")",
+		"CREATE INDEX index1 ON sales.orders (b)",
+		"CREATE TABLE sales.items (\n" +
+			"	a INT8,\n" +
+			"	b INT8,\n" +
+			"	PRIMARY KEY (a, b)\n" +
+			") INTERLEAVE IN PARENT sales.orders ON DELETE CASCADE",
+		"ALTER TABLE sales.orders ADD CONSTRAINT fk1 FOREIGN KEY (b) REFERENCES hr.orders (a)",
+	}
+	assert.Equal(t, e, actual)
+}
+
 func TestGetSortedTableIdsBySpName(t *testing.T) {
 	testCases := []struct {
 		description string
@@ -0,0 +1,1391 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+)
+
+// ParsedSchema is a Spanner schema parsed by ParseDDL.
+type ParsedSchema struct {
+	Tables    Schema
+	Sequences map[string]Sequence
+	Views     map[string]CreateView
+	// Skipped are the statements that don't define tables, columns,
+	// indexes, foreign keys, check constraints, sequences or views, such as
+	// ALTER DATABASE or CREATE CHANGE STREAM, which are ignored.
+	Skipped []string
+}
+
+// ParseDDL parses Spanner DDL statements, separated by semicolons, of the
+// given dialect: CREATE TABLE, CREATE INDEX, CREATE SEQUENCE, CREATE VIEW,
+// and ALTER TABLE statements that add columns, foreign keys or check
+// constraints. Tables, sequences and views may be qualified by a named
+// schema; CREATE SCHEMA statements are accepted since GetDDL creates the
+// named schemas of the tables and sequences. Other statements are returned
+// as skipped. newId generates the ids of the parsed objects from the
+// prefixes that internal.Conv uses ("t" for tables, "c" for columns and so
+// on), so that the schema can be used in a Conv.
+func ParseDDL(text, dialect string, newId func(prefix string) string) (ParsedSchema, error) {
+	toks, err := tokenize(text, dialect == constants.DIALECT_POSTGRESQL)
+	if err != nil {
+		return ParsedSchema{}, err
+	}
+	p := &parser{
+		src:       text,
+		pg:        dialect == constants.DIALECT_POSTGRESQL,
+		newId:     newId,
+		schema:    ParsedSchema{Tables: NewSchema(), Sequences: make(map[string]Sequence), Views: make(map[string]CreateView)},
+		tableIds:  make(map[string]string),
+		sequences: make(map[string]string),
+		viewIds:   make(map[string]string),
+		viewRefs:  make(map[string][]string),
+	}
+	for len(toks) > 0 {
+		n := 0
+		for n < len(toks) && !toks[n].is(";") {
+			n++
+		}
+		if n > 0 {
+			p.toks, p.i = toks[:n], 0
+			if err := p.statement(); err != nil {
+				return ParsedSchema{}, fmt.Errorf("can't parse statement %q: %w", p.text(0, n), err)
+			}
+		}
+		if n < len(toks) {
+			n++
+		}
+		toks = toks[n:]
+	}
+	if err := p.resolveForeignKeys(); err != nil {
+		return ParsedSchema{}, err
+	}
+	if err := p.resolveSequences(); err != nil {
+		return ParsedSchema{}, err
+	}
+	p.resolveViews()
+	return p.schema, nil
+}
+
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokQuotedIdent
+	tokString
+	tokNumber
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	// val is the text of the token, without the quotes of quoted
+	// identifiers.
+	val      string
+	pos, end int
+}
+
+// is returns whether the token is the punctuation s, or the unquoted
+// keyword s.
+func (t token) is(s string) bool {
+	return (t.kind == tokPunct || t.kind == tokIdent) && strings.EqualFold(t.val, s)
+}
+
+// tokenize splits DDL text into tokens, dropping white space and comments.
+// Double quotes quote identifiers in PostgreSQL and strings in GoogleSQL.
+func tokenize(s string, pg bool) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(s[i:], "--") || (!pg && c == '#'):
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			i += end + 4
+		case c == '`' || c == '"' && pg:
+			end, err := quotedEnd(s, i, c)
+			if err != nil {
+				return nil, err
+			}
+			val := strings.ReplaceAll(s[i+1:end-1], string([]byte{c, c}), string(c))
+			toks = append(toks, token{tokQuotedIdent, val, i, end})
+			i = end
+		case c == '\'' || c == '"':
+			end, err := quotedEnd(s, i, c)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{tokString, s[i:end], i, end})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(s) && (s[j] == '_' || s[j] == '$' || unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j]))) {
+				j++
+			}
+			// String prefixes, e.g. b'abc' or E'abc'.
+			if j < len(s) && (s[j] == '\'' || s[j] == '"' && !pg) && j-i <= 2 {
+				end, err := quotedEnd(s, j, s[j])
+				if err != nil {
+					return nil, err
+				}
+				toks = append(toks, token{tokString, s[i:end], i, end})
+				i = end
+				continue
+			}
+			toks = append(toks, token{tokIdent, s[i:j], i, j})
+			i = j
+		case unicode.IsDigit(rune(c)):
+			j := i
+			for j < len(s) && (unicode.IsDigit(rune(s[j])) || s[j] == '.' || s[j] == 'e' || s[j] == 'E' || s[j] == 'x' || s[j] == 'X' ||
+				(s[j] == '-' || s[j] == '+') && (s[j-1] == 'e' || s[j-1] == 'E')) {
+				j++
+			}
+			toks = append(toks, token{tokNumber, s[i:j], i, j})
+			i = j
+		default:
+			toks = append(toks, token{tokPunct, s[i : i+1], i, i + 1})
+			i++
+		}
+	}
+	return toks, nil
+}
+
+// quotedEnd returns the offset after the closing quote of the quoted
+// string or identifier that starts at offset i.
+func quotedEnd(s string, i int, quote byte) (int, error) {
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			if j+1 < len(s) && s[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated quote at offset %d", i)
+}
+
+type parser struct {
+	src   string
+	pg    bool
+	newId func(prefix string) string
+	toks  []token
+	i     int
+
+	schema ParsedSchema
+	// tableIds maps table names to table ids, and sequences maps sequence
+	// names to sequence ids. Names are lower case for GoogleSQL, whose
+	// names are case insensitive.
+	tableIds  map[string]string
+	sequences map[string]string
+	// viewIds maps view names to view ids, and viewRefs maps view ids to
+	// the names in their queries, which may refer to tables and views.
+	viewIds  map[string]string
+	viewRefs map[string][]string
+	// tableOrder are the ids of the tables in the order they are defined.
+	tableOrder []string
+	// foreignKeys are the foreign keys whose referenced tables and
+	// columns are looked up once every table is parsed.
+	foreignKeys []pendingForeignKey
+}
+
+type pendingForeignKey struct {
+	tableId    string
+	fk         Foreignkey
+	referTable string
+	referCols  []string
+}
+
+func (p *parser) text(from, to int) string {
+	return p.src[p.toks[from].pos:p.toks[to-1].end]
+}
+
+func (p *parser) done() bool {
+	return p.i >= len(p.toks)
+}
+
+func (p *parser) peek(words ...string) bool {
+	for j, w := range words {
+		if p.i+j >= len(p.toks) || !p.toks[p.i+j].is(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// accept consumes the keywords or punctuation words if the next tokens
+// are words.
+func (p *parser) accept(words ...string) bool {
+	if !p.peek(words...) {
+		return false
+	}
+	p.i += len(words)
+	return true
+}
+
+func (p *parser) expect(words ...string) error {
+	if !p.accept(words...) {
+		return p.errorf("expected %s", strings.Join(words, " "))
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	if p.done() {
+		return fmt.Errorf(format+" at end of statement", args...)
+	}
+	return fmt.Errorf(format+" at %q", append(args, p.toks[p.i].val)...)
+}
+
+// name parses a possibly qualified identifier. Unquoted PostgreSQL
+// identifiers are folded to lower case.
+func (p *parser) name() (string, error) {
+	var parts []string
+	for {
+		if p.done() || p.toks[p.i].kind != tokIdent && p.toks[p.i].kind != tokQuotedIdent {
+			return "", p.errorf("expected a name")
+		}
+		t := p.toks[p.i]
+		p.i++
+		if p.pg && t.kind == tokIdent {
+			parts = append(parts, strings.ToLower(t.val))
+		} else {
+			parts = append(parts, t.val)
+		}
+		if !p.accept(".") {
+			return strings.Join(parts, "."), nil
+		}
+	}
+}
+
+func (p *parser) names() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		n, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, n)
+		if p.accept(")") {
+			return names, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parenthesized parses a parenthesized expression, and returns its text
+// without the parentheses.
+func (p *parser) parenthesized() (string, error) {
+	if !p.peek("(") {
+		return "", p.errorf("expected (")
+	}
+	start := p.i
+	depth := 0
+	for ; !p.done(); p.i++ {
+		switch {
+		case p.toks[p.i].is("("):
+			depth++
+		case p.toks[p.i].is(")"):
+			depth--
+		}
+		if depth == 0 {
+			p.i++
+			if p.i-start == 2 {
+				return "", nil
+			}
+			return p.text(start+1, p.i-1), nil
+		}
+	}
+	return "", p.errorf("expected )")
+}
+
+// expression parses an expression that isn't parenthesized, which ends
+// before a comma or closing parenthesis outside of parentheses, or before
+// one of the keywords in stop.
+func (p *parser) expression(stop ...string) (string, error) {
+	start := p.i
+	depth := 0
+loop:
+	for ; !p.done(); p.i++ {
+		t := p.toks[p.i]
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			if depth == 0 {
+				break loop
+			}
+			depth--
+		case depth == 0 && t.is(","):
+			break loop
+		case depth == 0:
+			for _, s := range stop {
+				if t.is(s) {
+					break loop
+				}
+			}
+		}
+	}
+	if p.i == start {
+		return "", p.errorf("expected an expression")
+	}
+	return p.text(start, p.i), nil
+}
+
+func (p *parser) tableKey(name string) string {
+	if p.pg {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// splitName splits a possibly qualified name into its named schema and
+// name. PostgreSQL's public schema is the default schema, so it is dropped.
+func (p *parser) splitName(name string) (string, string) {
+	schemaName, n, ok := strings.Cut(name, ".")
+	if !ok || p.pg && schemaName == "public" {
+		return "", strings.TrimPrefix(name, "public.")
+	}
+	return schemaName, n
+}
+
+// qualifiedKey returns the key of tableIds or sequences for a possibly
+// qualified name.
+func (p *parser) qualifiedKey(name string) string {
+	return p.tableKey(QualifiedName(p.splitName(name)))
+}
+
+func (p *parser) table(name string) (CreateTable, error) {
+	id, ok := p.tableIds[p.qualifiedKey(name)]
+	if !ok {
+		return CreateTable{}, fmt.Errorf("table %s isn't defined", name)
+	}
+	return p.schema.Tables[id], nil
+}
+
+// isAncestor returns whether the table with id is an ancestor of ct in the
+// interleaving hierarchy.
+func (p *parser) isAncestor(id string, ct CreateTable) bool {
+	for ct.ParentTable.Id != "" {
+		if ct.ParentTable.Id == id {
+			return true
+		}
+		ct = p.schema.Tables[ct.ParentTable.Id]
+	}
+	return false
+}
+
+func (p *parser) columnId(ct CreateTable, name string) (string, error) {
+	for _, id := range ct.ColIds {
+		if ct.ColDefs[id].Name == name || !p.pg && strings.EqualFold(ct.ColDefs[id].Name, name) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("table %s has no column %s", ct.Name, name)
+}
+
+func (p *parser) statement() error {
+	switch {
+	case p.accept("CREATE", "TABLE"):
+		return p.createTable()
+	case p.peek("CREATE", "UNIQUE"), p.peek("CREATE", "NULL_FILTERED"), p.peek("CREATE", "INDEX"):
+		p.i++
+		return p.createIndex()
+	case p.accept("CREATE", "SEARCH", "INDEX"):
+		return p.createSearchIndex()
+	case !p.pg && p.accept("CREATE", "VECTOR", "INDEX"):
+		return p.createVectorIndex()
+	case p.accept("CREATE", "SEQUENCE"):
+		return p.createSequence()
+	case p.accept("CREATE", "VIEW"), p.accept("CREATE", "OR", "REPLACE", "VIEW"):
+		return p.createView()
+	case p.accept("CREATE", "SCHEMA"):
+		// Named schemas are created for the tables and sequences in them.
+		return nil
+	case p.accept("ALTER", "TABLE"):
+		return p.alterTable()
+	}
+	p.schema.Skipped = append(p.schema.Skipped, p.text(0, len(p.toks)))
+	return nil
+}
+
+func (p *parser) createTable() error {
+	p.accept("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := p.tableIds[p.qualifiedKey(name)]; ok {
+		return fmt.Errorf("table %s is defined twice", name)
+	}
+	ct := CreateTable{Id: p.newId("t"), ColDefs: make(map[string]ColumnDef)}
+	ct.SchemaName, ct.Name = p.splitName(name)
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	var pkCols []string
+	var pkDesc []bool
+	for !p.accept(")") {
+		switch {
+		case p.peek("CONSTRAINT") || p.peek("FOREIGN") || p.peek("CHECK") || p.peek("PRIMARY"):
+			cols, err := p.tableConstraint(&ct)
+			if err != nil {
+				return err
+			}
+			if cols != nil {
+				pkCols, pkDesc = cols, make([]bool, len(cols))
+			}
+		default:
+			cd, pk, err := p.columnDef()
+			if err != nil {
+				return err
+			}
+			ct.ColIds = append(ct.ColIds, cd.Id)
+			ct.ColDefs[cd.Id] = cd
+			if pk {
+				pkCols, pkDesc = []string{cd.Name}, []bool{false}
+			}
+		}
+		if !p.accept(",") && !p.peek(")") {
+			return p.errorf("expected , or )")
+		}
+	}
+	if !p.pg {
+		if err := p.expect("PRIMARY", "KEY", "("); err != nil {
+			return err
+		}
+		pkCols, pkDesc = nil, nil
+		for !p.accept(")") {
+			col, err := p.name()
+			if err != nil {
+				return err
+			}
+			desc := p.accept("DESC")
+			if !desc {
+				p.accept("ASC")
+			}
+			pkCols, pkDesc = append(pkCols, col), append(pkDesc, desc)
+			p.accept(",")
+		}
+	}
+	for i, col := range pkCols {
+		id, err := p.columnId(ct, col)
+		if err != nil {
+			return err
+		}
+		ct.PrimaryKeys = append(ct.PrimaryKeys, IndexKey{ColId: id, Desc: pkDesc[i], Order: i + 1})
+	}
+	for !p.done() {
+		if !p.pg {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+		if !p.pg && p.accept("ROW", "DELETION", "POLICY") || p.pg && p.accept("TTL") {
+			rdp, err := p.rowDeletionPolicy(ct)
+			if err != nil {
+				return err
+			}
+			ct.RowDeletionPolicy = &rdp
+			continue
+		}
+		if !p.accept("INTERLEAVE", "IN") {
+			return p.errorf("unsupported table option")
+		}
+		parent := InterleavedParent{InterleaveType: "IN"}
+		if p.accept("PARENT") {
+			parent.InterleaveType = "IN PARENT"
+		}
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		pt, err := p.table(name)
+		if err != nil {
+			return err
+		}
+		parent.Id = pt.Id
+		if p.accept("ON", "DELETE") {
+			if parent.OnDelete, err = p.onDeleteAction(); err != nil {
+				return err
+			}
+		}
+		ct.ParentTable = parent
+	}
+	p.tableIds[p.tableKey(ct.QualifiedName())] = ct.Id
+	p.tableOrder = append(p.tableOrder, ct.Id)
+	p.schema.Tables[ct.Id] = ct
+	return nil
+}
+
+// rowDeletionPolicy parses the rest of a row deletion policy, which is
+// ( OLDER_THAN ( column_name, INTERVAL num_days DAY ) ) in GoogleSQL and
+// INTERVAL 'num_days days' ON column_name in PostgreSQL.
+func (p *parser) rowDeletionPolicy(ct CreateTable) (RowDeletionPolicy, error) {
+	var col, days string
+	var err error
+	if p.pg {
+		if err := p.expect("INTERVAL"); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+		if p.done() || p.toks[p.i].kind != tokString {
+			return RowDeletionPolicy{}, p.errorf("expected an interval")
+		}
+		interval := strings.Fields(strings.Trim(p.toks[p.i].val, "'"))
+		if len(interval) != 2 || !strings.EqualFold(strings.TrimSuffix(interval[1], "s"), "day") {
+			return RowDeletionPolicy{}, p.errorf("expected an interval in days")
+		}
+		days = interval[0]
+		p.i++
+		if err := p.expect("ON"); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+		if col, err = p.name(); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+	} else {
+		if err := p.expect("(", "OLDER_THAN", "("); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+		if col, err = p.name(); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+		if err := p.expect(",", "INTERVAL"); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+		if days, err = p.number(); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+		if err := p.expect("DAY", ")", ")"); err != nil {
+			return RowDeletionPolicy{}, err
+		}
+	}
+	n, err := strconv.ParseInt(days, 10, 64)
+	if err != nil || n < 0 {
+		return RowDeletionPolicy{}, fmt.Errorf("invalid number of days %s in row deletion policy of table %s", days, ct.Name)
+	}
+	colId, err := p.columnId(ct, col)
+	if err != nil {
+		return RowDeletionPolicy{}, err
+	}
+	if t := ct.ColDefs[colId].T; t.Name != Timestamp || t.IsArray {
+		return RowDeletionPolicy{}, fmt.Errorf("row deletion policy of table %s uses column %s, which isn't a timestamp", ct.Name, col)
+	}
+	return RowDeletionPolicy{ColId: colId, Days: n}, nil
+}
+
+// tableConstraint parses a foreign key or check constraint of a table, or
+// the primary key of a PostgreSQL table, whose columns it returns.
+func (p *parser) tableConstraint(ct *CreateTable) ([]string, error) {
+	var name string
+	if p.accept("CONSTRAINT") {
+		var err error
+		if name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case p.pg && p.accept("PRIMARY", "KEY"):
+		return p.names()
+	case p.accept("FOREIGN", "KEY"):
+		return nil, p.foreignKey(ct.Id, name)
+	case p.accept("CHECK"):
+		expr, err := p.parenthesized()
+		if err != nil {
+			return nil, err
+		}
+		ct.CheckConstraints = append(ct.CheckConstraints, CheckConstraint{Id: p.newId("cc"), Name: name, Expr: "(" + expr + ")", ExprId: p.newId("e")})
+		return nil, nil
+	}
+	return nil, p.errorf("unsupported constraint")
+}
+
+func (p *parser) foreignKey(tableId, name string) error {
+	cols, err := p.names()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("REFERENCES"); err != nil {
+		return err
+	}
+	referTable, err := p.name()
+	if err != nil {
+		return err
+	}
+	referCols, err := p.names()
+	if err != nil {
+		return err
+	}
+	if len(cols) != len(referCols) {
+		return fmt.Errorf("foreign key %s has %d columns but references %d", name, len(cols), len(referCols))
+	}
+	fk := Foreignkey{Name: name, Id: p.newId("f"), ColIds: cols}
+	if p.accept("ON", "DELETE") {
+		if fk.OnDelete, err = p.onDeleteAction(); err != nil {
+			return err
+		}
+	}
+	p.foreignKeys = append(p.foreignKeys, pendingForeignKey{tableId: tableId, fk: fk, referTable: referTable, referCols: referCols})
+	return nil
+}
+
+func (p *parser) onDeleteAction() (string, error) {
+	switch {
+	case p.accept("CASCADE"):
+		return constants.FK_CASCADE, nil
+	case p.accept("NO", "ACTION"):
+		return constants.FK_NO_ACTION, nil
+	}
+	return "", p.errorf("unsupported ON DELETE action")
+}
+
+// resolveForeignKeys looks up the columns of the foreign keys, once every
+// table they may reference is parsed.
+func (p *parser) resolveForeignKeys() error {
+	for _, pending := range p.foreignKeys {
+		ct := p.schema.Tables[pending.tableId]
+		refer, err := p.table(pending.referTable)
+		if err != nil {
+			return fmt.Errorf("can't parse foreign key %s of table %s: %w", pending.fk.Name, ct.Name, err)
+		}
+		fk := pending.fk
+		fk.ReferTableId = refer.Id
+		for i := range fk.ColIds {
+			if fk.ColIds[i], err = p.columnId(ct, fk.ColIds[i]); err != nil {
+				return fmt.Errorf("can't parse foreign key %s: %w", fk.Name, err)
+			}
+			id, err := p.columnId(refer, pending.referCols[i])
+			if err != nil {
+				return fmt.Errorf("can't parse foreign key %s: %w", fk.Name, err)
+			}
+			fk.ReferColumnIds = append(fk.ReferColumnIds, id)
+		}
+		ct.ForeignKeys = append(ct.ForeignKeys, fk)
+		p.schema.Tables[ct.Id] = ct
+	}
+	return nil
+}
+
+// resolveSequences records the columns that use each sequence.
+func (p *parser) resolveSequences() error {
+	for _, id := range p.tableOrder {
+		ct := p.schema.Tables[id]
+		for _, colId := range ct.ColIds {
+			agc := ct.ColDefs[colId].AutoGen
+			if agc.GenerationType != constants.SEQUENCE {
+				continue
+			}
+			seqId, ok := p.sequences[p.qualifiedKey(agc.Name)]
+			if !ok {
+				return fmt.Errorf("column %s of table %s uses sequence %s, which isn't defined", ct.ColDefs[colId].Name, ct.Name, agc.Name)
+			}
+			seq := p.schema.Sequences[seqId]
+			seq.ColumnsUsingSeq[ct.Id] = append(seq.ColumnsUsingSeq[ct.Id], colId)
+		}
+	}
+	return nil
+}
+
+// columnDef parses a column definition, and returns whether it declares
+// the column to be the primary key, which PostgreSQL allows.
+func (p *parser) columnDef() (ColumnDef, bool, error) {
+	name, err := p.name()
+	if err != nil {
+		return ColumnDef{}, false, err
+	}
+	cd := ColumnDef{Name: name, Id: p.newId("c")}
+	if p.pg {
+		cd.T, err = p.pgType()
+	} else {
+		cd.T, err = p.googleSQLType()
+	}
+	if err != nil {
+		return ColumnDef{}, false, err
+	}
+	pk := false
+	for !p.done() && !p.peek(",") && !p.peek(")") {
+		switch {
+		case p.accept("NOT", "NULL"):
+			cd.NotNull = true
+		case p.accept("NULL"):
+		case p.pg && p.accept("PRIMARY", "KEY"):
+			pk = true
+			cd.NotNull = true
+		case p.accept("DEFAULT"):
+			var expr string
+			if p.pg && !p.peek("(") {
+				expr, err = p.expression("NOT", "PRIMARY", "GENERATED")
+			} else {
+				expr, err = p.parenthesized()
+			}
+			if err != nil {
+				return ColumnDef{}, false, err
+			}
+			p.defaultValue(&cd, expr)
+		case p.accept("GENERATED", "BY", "DEFAULT", "AS", "IDENTITY"):
+			if cd.AutoGen, err = p.identity(); err != nil {
+				return ColumnDef{}, false, err
+			}
+		case p.accept("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return ColumnDef{}, false, err
+			}
+			cd.Opts = opts
+		case !p.pg && p.accept("AS"), p.pg && p.accept("GENERATED", "ALWAYS", "AS"):
+			expr, err := p.parenthesized()
+			if err != nil {
+				return ColumnDef{}, false, err
+			}
+			// Spanner only supports virtual generated columns of type
+			// TOKENLIST, which GoogleSQL makes virtual by default.
+			virtual := cd.T.Name == TokenList && (p.accept("VIRTUAL") || !p.pg && !p.peek("STORED"))
+			if !virtual && !p.accept("STORED") {
+				return ColumnDef{}, false, p.errorf("generated columns that aren't stored aren't supported")
+			}
+			cd.Generated = GeneratedColumn{IsPresent: true, Value: Expression{ExpressionId: p.newId("e"), Statement: expr}, Virtual: virtual}
+		case p.accept("HIDDEN"):
+			cd.Hidden = true
+		default:
+			return ColumnDef{}, false, p.errorf("unsupported column option")
+		}
+	}
+	return cd, pk, nil
+}
+
+// defaultValue sets the default value of a column. Defaults that generate
+// UUIDs or use sequences are represented by AutoGen.
+func (p *parser) defaultValue(cd *ColumnDef, expr string) {
+	norm := strings.ToLower(strings.Join(strings.Fields(expr), ""))
+	switch {
+	case norm == "generate_uuid()" || norm == "spanner.generate_uuid()":
+		cd.AutoGen = AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}
+		return
+	case strings.HasPrefix(norm, "get_next_sequence_value(sequence") && strings.HasSuffix(norm, ")"):
+		args := strings.Fields(expr[strings.Index(expr, "(")+1 : strings.LastIndex(expr, ")")])
+		cd.AutoGen = AutoGenCol{Name: strings.ReplaceAll(args[len(args)-1], "`", ""), GenerationType: constants.SEQUENCE}
+		return
+	case strings.HasPrefix(norm, "nextval('") && strings.HasSuffix(norm, "')"):
+		seq := expr[strings.Index(expr, "'")+1 : strings.LastIndex(expr, "'")]
+		cd.AutoGen = AutoGenCol{Name: seq, GenerationType: constants.SEQUENCE}
+		return
+	}
+	// PrintDefaultValue casts the defaults of some types, so the cast is
+	// removed to print the same default again.
+	typeName := cd.T.Name
+	if p.pg {
+		typeName = GetPGType(cd.T)
+	}
+	if strings.HasPrefix(norm, "cast(") && strings.HasSuffix(norm, "as"+strings.ToLower(typeName)+")") {
+		inner := strings.TrimSpace(expr[strings.Index(expr, "(")+1 : strings.LastIndex(expr, ")")])
+		if i := strings.LastIndex(strings.ToUpper(inner), " AS "); i >= 0 {
+			expr = strings.TrimSpace(inner[:i])
+		}
+	}
+	cd.DefaultValue = DefaultValue{IsPresent: true, Value: Expression{ExpressionId: p.newId("e"), Statement: expr}}
+}
+
+// identity parses the options of an identity column.
+func (p *parser) identity() (AutoGenCol, error) {
+	agc := AutoGenCol{Name: constants.IDENTITY, GenerationType: constants.IDENTITY}
+	if !p.accept("(") {
+		return agc, nil
+	}
+	for !p.accept(")") {
+		switch {
+		case p.accept("BIT_REVERSED_POSITIVE"):
+		case p.accept("SKIP", "RANGE"):
+			min, err := p.number()
+			if err != nil {
+				return agc, err
+			}
+			p.accept(",")
+			max, err := p.number()
+			if err != nil {
+				return agc, err
+			}
+			agc.IdentityOptions.SkipRangeMin, agc.IdentityOptions.SkipRangeMax = min, max
+		case p.accept("START", "COUNTER"):
+			p.accept("WITH")
+			n, err := p.number()
+			if err != nil {
+				return agc, err
+			}
+			agc.IdentityOptions.StartCounterWith = n
+		default:
+			return agc, p.errorf("unsupported identity option")
+		}
+	}
+	return agc, nil
+}
+
+func (p *parser) number() (string, error) {
+	sign := ""
+	if p.accept("-") {
+		sign = "-"
+	}
+	if p.done() || p.toks[p.i].kind != tokNumber {
+		return "", p.errorf("expected a number")
+	}
+	p.i++
+	return sign + p.toks[p.i-1].val, nil
+}
+
+// options parses OPTIONS (name = value, ...).
+func (p *parser) options() (map[string]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	opts := make(map[string]string)
+	for !p.accept(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("="); err != nil {
+			return nil, err
+		}
+		value, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		opts[strings.ToLower(name)] = strings.Trim(value, `'"`)
+		p.accept(",")
+	}
+	return opts, nil
+}
+
+func (p *parser) length() (int64, error) {
+	if err := p.expect("("); err != nil {
+		return 0, err
+	}
+	var n int64 = MaxLength
+	if !p.accept("MAX") {
+		s, err := p.number()
+		if err != nil {
+			return 0, err
+		}
+		if n, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid length %s", s)
+		}
+	}
+	return n, p.expect(")")
+}
+
+func (p *parser) googleSQLType() (Type, error) {
+	if p.accept("ARRAY") {
+		if err := p.expect("<"); err != nil {
+			return Type{}, err
+		}
+		t, err := p.googleSQLType()
+		if err != nil {
+			return Type{}, err
+		}
+		if t.IsArray {
+			return Type{}, fmt.Errorf("arrays of arrays aren't supported")
+		}
+		t.IsArray = true
+		if err := p.expect(">"); err != nil {
+			return Type{}, err
+		}
+		if p.accept("(", "VECTOR_LENGTH", "=", ">") {
+			n, err := p.number()
+			if err != nil {
+				return Type{}, err
+			}
+			if t.VectorLength, err = strconv.ParseInt(n, 10, 64); err != nil {
+				return Type{}, fmt.Errorf("invalid vector length %s", n)
+			}
+			return t, p.expect(")")
+		}
+		return t, nil
+	}
+	if p.done() || p.toks[p.i].kind != tokIdent {
+		return Type{}, p.errorf("expected a type")
+	}
+	t := Type{Name: strings.ToUpper(p.toks[p.i].val)}
+	switch t.Name {
+	case Bool, Int64, Float32, Float64, Numeric, Date, Timestamp, JSON, TokenList, UUID, Interval:
+		p.i++
+	case String, Bytes:
+		p.i++
+		var err error
+		if t.Len, err = p.length(); err != nil {
+			return Type{}, err
+		}
+	default:
+		return Type{}, p.errorf("unsupported type")
+	}
+	return t, nil
+}
+
+// pgTypes maps the names of PostgreSQL-dialect types, and their aliases,
+// to Spanner types.
+var pgTypes = map[string]string{
+	"bigint":                   Int64,
+	"int8":                     Int64,
+	"boolean":                  Bool,
+	"bool":                     Bool,
+	"double precision":         Float64,
+	"float8":                   Float64,
+	"real":                     Float32,
+	"float4":                   Float32,
+	"numeric":                  Numeric,
+	"decimal":                  Numeric,
+	"varchar":                  String,
+	"character varying":        String,
+	"text":                     String,
+	"bytea":                    Bytes,
+	"date":                     Date,
+	"timestamptz":              Timestamp,
+	"timestamp with time zone": Timestamp,
+	"jsonb":                    JSON,
+	"uuid":                     UUID,
+	"interval":                 Interval,
+}
+
+func (p *parser) pgType() (Type, error) {
+	if p.accept("SPANNER", ".", "TOKENLIST") {
+		return Type{Name: TokenList}, nil
+	}
+	// Find the longest sequence of words that names a type, since some
+	// names, such as double precision, have more than one word.
+	var words []string
+	name, n := "", 0
+	for j := p.i; j < len(p.toks) && p.toks[j].kind == tokIdent && len(words) < 4; j++ {
+		words = append(words, strings.ToLower(p.toks[j].val))
+		if t, ok := pgTypes[strings.Join(words, " ")]; ok {
+			name, n = t, len(words)
+		}
+	}
+	if n == 0 {
+		return Type{}, p.errorf("unsupported type")
+	}
+	p.i += n
+	t := Type{Name: name}
+	switch name {
+	case String:
+		t.Len = PGMaxLength
+		if p.peek("(") {
+			var err error
+			if t.Len, err = p.length(); err != nil {
+				return Type{}, err
+			}
+		}
+	case Bytes:
+		t.Len = MaxLength
+	}
+	if p.accept("[", "]") {
+		t.IsArray = true
+		if p.accept("VECTOR", "LENGTH") {
+			n, err := p.number()
+			if err != nil {
+				return Type{}, err
+			}
+			if t.VectorLength, err = strconv.ParseInt(n, 10, 64); err != nil {
+				return Type{}, fmt.Errorf("invalid vector length %s", n)
+			}
+		}
+	}
+	return t, nil
+}
+
+func (p *parser) createIndex() error {
+	unique := p.accept("UNIQUE")
+	nullFiltered := p.accept("NULL_FILTERED")
+	if err := p.expect("INDEX"); err != nil {
+		return err
+	}
+	p.accept("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("ON"); err != nil {
+		return err
+	}
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	ct, err := p.table(tableName)
+	if err != nil {
+		return err
+	}
+	// Indexes are in the named schema of their table.
+	_, name = p.splitName(name)
+	idx := CreateIndex{Name: name, SchemaName: ct.SchemaName, TableId: ct.Id, Unique: unique, NullFiltered: nullFiltered, Id: p.newId("i")}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for !p.accept(")") {
+		col, err := p.name()
+		if err != nil {
+			return err
+		}
+		id, err := p.columnId(ct, col)
+		if err != nil {
+			return err
+		}
+		desc := p.accept("DESC")
+		if !desc {
+			p.accept("ASC")
+		}
+		idx.Keys = append(idx.Keys, IndexKey{ColId: id, Desc: desc, Order: len(idx.Keys) + 1})
+		p.accept(",")
+	}
+	if p.accept("STORING") || p.accept("INCLUDE") {
+		cols, err := p.names()
+		if err != nil {
+			return err
+		}
+		for _, col := range cols {
+			id, err := p.columnId(ct, col)
+			if err != nil {
+				return err
+			}
+			idx.StoredColumnIds = append(idx.StoredColumnIds, id)
+		}
+	}
+	if p.accept(",", "INTERLEAVE", "IN") || p.accept("INTERLEAVE", "IN") {
+		parentName, err := p.name()
+		if err != nil {
+			return err
+		}
+		pt, err := p.table(parentName)
+		if err != nil {
+			return err
+		}
+		if !p.isAncestor(pt.Id, ct) {
+			return fmt.Errorf("index %s can't be interleaved in %s, which isn't an ancestor of table %s", name, parentName, ct.Name)
+		}
+		idx.InterleaveIn = pt.Id
+	}
+	// PostgreSQL filters out NULL keys with a WHERE clause that checks
+	// each key column.
+	if p.accept("WHERE") {
+		notNull := make(map[string]bool)
+		for {
+			col, err := p.name()
+			if err != nil {
+				return err
+			}
+			id, err := p.columnId(ct, col)
+			if err != nil {
+				return err
+			}
+			if err := p.expect("IS", "NOT", "NULL"); err != nil {
+				return err
+			}
+			notNull[id] = true
+			if !p.accept("AND") {
+				break
+			}
+		}
+		for _, k := range idx.Keys {
+			if !notNull[k.ColId] {
+				return fmt.Errorf("index %s filters out NULL values of some key columns but not others", name)
+			}
+		}
+		idx.NullFiltered = true
+	}
+	if !p.done() {
+		return p.errorf("unsupported index option")
+	}
+	ct.Indexes = append(ct.Indexes, idx)
+	p.schema.Tables[ct.Id] = ct
+	return nil
+}
+
+func (p *parser) createSearchIndex() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("ON"); err != nil {
+		return err
+	}
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	ct, err := p.table(tableName)
+	if err != nil {
+		return err
+	}
+	cols, err := p.names()
+	if err != nil {
+		return err
+	}
+	_, name = p.splitName(name)
+	idx := CreateSearchIndex{Name: name, TableId: ct.Id, Id: p.newId("i")}
+	for _, col := range cols {
+		id, err := p.columnId(ct, col)
+		if err != nil {
+			return err
+		}
+		idx.Keys = append(idx.Keys, IndexKey{ColId: id, Order: len(idx.Keys) + 1})
+	}
+	if !p.done() {
+		return p.errorf("unsupported search index option")
+	}
+	ct.SearchIndexes = append(ct.SearchIndexes, idx)
+	p.schema.Tables[ct.Id] = ct
+	return nil
+}
+
+func (p *parser) createVectorIndex() error {
+	p.accept("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("ON"); err != nil {
+		return err
+	}
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	ct, err := p.table(tableName)
+	if err != nil {
+		return err
+	}
+	cols, err := p.names()
+	if err != nil {
+		return err
+	}
+	if len(cols) != 1 {
+		return fmt.Errorf("vector index %s must index one column", name)
+	}
+	id, err := p.columnId(ct, cols[0])
+	if err != nil {
+		return err
+	}
+	_, name = p.splitName(name)
+	idx := CreateVectorIndex{Name: name, TableId: ct.Id, Keys: []IndexKey{{ColId: id, Order: 1}}, Id: p.newId("i")}
+	// The index filters out NULL vectors, which PrintCreateVectorIndex
+	// prints for columns that can be NULL.
+	if p.accept("WHERE") {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if err := p.expect("IS", "NOT", "NULL"); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("OPTIONS"); err != nil {
+		return err
+	}
+	opts, err := p.options()
+	if err != nil {
+		return err
+	}
+	for k, v := range opts {
+		switch k {
+		case "distance_type":
+			idx.DistanceType = strings.ToUpper(v)
+		default:
+			return fmt.Errorf("unsupported vector index option %s", k)
+		}
+	}
+	if !p.done() {
+		return p.errorf("unsupported vector index option")
+	}
+	ct.VectorIndexes = append(ct.VectorIndexes, idx)
+	p.schema.Tables[ct.Id] = ct
+	return nil
+}
+
+func (p *parser) createSequence() error {
+	p.accept("IF", "NOT", "EXISTS")
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	seq := Sequence{Id: p.newId("s"), ColumnsUsingSeq: make(map[string][]string)}
+	seq.SchemaName, seq.Name = p.splitName(name)
+	for !p.done() {
+		switch {
+		case !p.pg && p.accept("OPTIONS"):
+			opts, err := p.options()
+			if err != nil {
+				return err
+			}
+			for k, v := range opts {
+				switch k {
+				case "sequence_kind":
+					seq.SequenceKind = strings.ToUpper(strings.ReplaceAll(v, "_", " "))
+				case "skip_range_min":
+					seq.SkipRangeMin = v
+				case "skip_range_max":
+					seq.SkipRangeMax = v
+				case "start_with_counter":
+					seq.StartWithCounter = v
+				default:
+					return fmt.Errorf("unsupported sequence option %s", k)
+				}
+			}
+		case p.accept("BIT_REVERSED_POSITIVE"):
+			seq.SequenceKind = "BIT REVERSED POSITIVE"
+		case p.accept("SKIP", "RANGE"):
+			if seq.SkipRangeMin, err = p.number(); err != nil {
+				return err
+			}
+			p.accept(",")
+			if seq.SkipRangeMax, err = p.number(); err != nil {
+				return err
+			}
+		case p.accept("START", "COUNTER"):
+			p.accept("WITH")
+			if seq.StartWithCounter, err = p.number(); err != nil {
+				return err
+			}
+		default:
+			return p.errorf("unsupported sequence option")
+		}
+	}
+	if _, ok := p.sequences[p.qualifiedKey(name)]; ok {
+		return fmt.Errorf("sequence %s is defined twice", name)
+	}
+	p.sequences[p.qualifiedKey(name)] = seq.Id
+	p.schema.Sequences[seq.Id] = seq
+	return nil
+}
+
+// createView parses the name, SQL security and query of a view. The query
+// is kept as it is written.
+func (p *parser) createView() error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	v := CreateView{Id: p.newId("vw")}
+	v.SchemaName, v.Name = p.splitName(name)
+	if p.accept("SQL", "SECURITY") {
+		switch {
+		case p.accept(SqlSecurityInvoker):
+			v.SqlSecurity = SqlSecurityInvoker
+		case p.accept(SqlSecurityDefiner):
+			v.SqlSecurity = SqlSecurityDefiner
+		default:
+			return p.errorf("expected %s or %s", SqlSecurityInvoker, SqlSecurityDefiner)
+		}
+	}
+	if err := p.expect("AS"); err != nil {
+		return err
+	}
+	if p.done() {
+		return p.errorf("expected a query")
+	}
+	v.Query = p.text(p.i, len(p.toks))
+	var refs []string
+	for !p.done() {
+		if k := p.toks[p.i].kind; k != tokIdent && k != tokQuotedIdent {
+			p.i++
+			continue
+		}
+		// Names that are followed by "." and something other than a name,
+		// such as t.*, aren't names of tables or views.
+		if ref, err := p.name(); err == nil {
+			refs = append(refs, ref)
+		}
+	}
+	if _, ok := p.viewIds[p.qualifiedKey(name)]; ok {
+		return fmt.Errorf("view %s is defined twice", name)
+	}
+	p.viewIds[p.qualifiedKey(name)] = v.Id
+	p.viewRefs[v.Id] = refs
+	p.schema.Views[v.Id] = v
+	return nil
+}
+
+// resolveViews records the tables and views that each view refers to, once
+// every table and view is parsed. Names in the queries that aren't tables
+// or views, such as column names, are ignored.
+func (p *parser) resolveViews() {
+	for id, refs := range p.viewRefs {
+		v := p.schema.Views[id]
+		for _, ref := range refs {
+			key := p.qualifiedKey(ref)
+			refId, ok := p.tableIds[key]
+			if !ok {
+				refId, ok = p.viewIds[key]
+			}
+			if ok && refId != id && !slices.Contains(v.RefIds, refId) {
+				v.RefIds = append(v.RefIds, refId)
+			}
+		}
+		p.schema.Views[id] = v
+	}
+}
+
+func (p *parser) alterTable() error {
+	start := p.i - 2
+	tableName, err := p.name()
+	if err != nil {
+		return err
+	}
+	if !p.accept("ADD") {
+		p.schema.Skipped = append(p.schema.Skipped, p.text(start, len(p.toks)))
+		return nil
+	}
+	ct, err := p.table(tableName)
+	if err != nil {
+		return err
+	}
+	if p.peek("CONSTRAINT") || p.peek("FOREIGN") || p.peek("CHECK") {
+		if _, err := p.tableConstraint(&ct); err != nil {
+			return err
+		}
+	} else {
+		p.accept("COLUMN")
+		p.accept("IF", "NOT", "EXISTS")
+		cd, _, err := p.columnDef()
+		if err != nil {
+			return err
+		}
+		ct.ColIds = append(ct.ColIds, cd.Id)
+		ct.ColDefs[cd.Id] = cd
+	}
+	if !p.done() {
+		return p.errorf("unexpected")
+	}
+	p.schema.Tables[ct.Id] = ct
+	return nil
+}
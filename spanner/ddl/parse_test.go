@@ -0,0 +1,370 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+// idGenerator returns ids like "t1", "c2", ... numbered in the order they
+// are generated.
+func idGenerator() func(prefix string) string {
+	n := 0
+	return func(prefix string) string {
+		n++
+		return fmt.Sprintf("%s%d", prefix, n)
+	}
+}
+
+func TestParseDDL(t *testing.T) {
+	text := `
+-- Singers and their albums.
+CREATE SEQUENCE seq OPTIONS (sequence_kind = 'bit_reversed_positive', skip_range_min = 1, skip_range_max = 10);
+CREATE TABLE Singers (
+	SingerId INT64 NOT NULL DEFAULT (GET_NEXT_SEQUENCE_VALUE(SEQUENCE seq)),
+	` + "`Name`" + ` STRING(MAX),
+	Tags ARRAY<STRING(10)>,
+	Rating FLOAT32 DEFAULT (CAST(1.5 AS FLOAT32)),
+	CONSTRAINT positive CHECK (SingerId > 0),
+) PRIMARY KEY (SingerId);
+/* Albums are interleaved. */
+CREATE TABLE IF NOT EXISTS Albums (
+	SingerId INT64 NOT NULL,
+	AlbumId STRING(36) NOT NULL DEFAULT (GENERATE_UUID()),
+	Released DATE OPTIONS (cassandra_type = 'date'),
+) PRIMARY KEY (SingerId, AlbumId DESC),
+  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;
+CREATE UNIQUE INDEX AlbumsByDate ON Albums (Released DESC) STORING (SingerId);
+ALTER TABLE Albums ADD CONSTRAINT fk_singer FOREIGN KEY (SingerId) REFERENCES singers (singerid);
+ALTER DATABASE db SET OPTIONS (default_leader = 'us-east1');
+`
+	got, err := ParseDDL(text, constants.DIALECT_GOOGLESQL, idGenerator())
+	assert.Nil(t, err)
+	assert.Equal(t, ParsedSchema{
+		Tables: Schema{
+			"t2": {
+				Name:   "Singers",
+				Id:     "t2",
+				ColIds: []string{"c3", "c4", "c5", "c6"},
+				ColDefs: map[string]ColumnDef{
+					"c3": {Name: "SingerId", Id: "c3", T: Type{Name: Int64}, NotNull: true, AutoGen: AutoGenCol{Name: "seq", GenerationType: constants.SEQUENCE}},
+					"c4": {Name: "Name", Id: "c4", T: Type{Name: String, Len: MaxLength}},
+					"c5": {Name: "Tags", Id: "c5", T: Type{Name: String, Len: 10, IsArray: true}},
+					"c6": {Name: "Rating", Id: "c6", T: Type{Name: Float32}, DefaultValue: DefaultValue{IsPresent: true, Value: Expression{ExpressionId: "e7", Statement: "1.5"}}},
+				},
+				PrimaryKeys:      []IndexKey{{ColId: "c3", Order: 1}},
+				CheckConstraints: []CheckConstraint{{Id: "cc8", Name: "positive", Expr: "(SingerId > 0)", ExprId: "e9"}},
+			},
+			"t10": {
+				Name:   "Albums",
+				Id:     "t10",
+				ColIds: []string{"c11", "c12", "c13"},
+				ColDefs: map[string]ColumnDef{
+					"c11": {Name: "SingerId", Id: "c11", T: Type{Name: Int64}, NotNull: true},
+					"c12": {Name: "AlbumId", Id: "c12", T: Type{Name: String, Len: 36}, NotNull: true, AutoGen: AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}},
+					"c13": {Name: "Released", Id: "c13", T: Type{Name: Date}, Opts: map[string]string{"cassandra_type": "date"}},
+				},
+				PrimaryKeys: []IndexKey{{ColId: "c11", Order: 1}, {ColId: "c12", Desc: true, Order: 2}},
+				ParentTable: InterleavedParent{Id: "t2", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+				Indexes:     []CreateIndex{{Name: "AlbumsByDate", TableId: "t10", Unique: true, Id: "i14", Keys: []IndexKey{{ColId: "c13", Desc: true, Order: 1}}, StoredColumnIds: []string{"c11"}}},
+				ForeignKeys: []Foreignkey{{Name: "fk_singer", Id: "f15", ColIds: []string{"c11"}, ReferTableId: "t2", ReferColumnIds: []string{"c3"}}},
+			},
+		},
+		Sequences: map[string]Sequence{
+			"s1": {Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "10", ColumnsUsingSeq: map[string][]string{"t2": {"c3"}}},
+		},
+		Views:   map[string]CreateView{},
+		Skipped: []string{"ALTER DATABASE db SET OPTIONS (default_leader = 'us-east1')"},
+	}, got)
+}
+
+func TestParseDDLPG(t *testing.T) {
+	text := `
+CREATE SEQUENCE seq BIT_REVERSED_POSITIVE SKIP RANGE 1 10 START COUNTER WITH 5;
+CREATE TABLE "Singers" (
+	id bigint PRIMARY KEY DEFAULT nextval('seq'),
+	name character varying(100) NOT NULL,
+	score double precision DEFAULT 0,
+	born timestamp with time zone,
+	tags text[],
+	data jsonb
+);
+CREATE TABLE albums (
+	singer_id int8 NOT NULL,
+	album_id bigint GENERATED BY DEFAULT AS IDENTITY (BIT_REVERSED_POSITIVE SKIP RANGE 1 5),
+	cover bytea,
+	PRIMARY KEY (singer_id, album_id),
+	CONSTRAINT fk_singer FOREIGN KEY (singer_id) REFERENCES "Singers" (id)
+) INTERLEAVE IN "Singers";
+CREATE INDEX by_name ON "Singers" (name) INCLUDE (score);
+CREATE CHANGE STREAM everything FOR ALL;
+`
+	got, err := ParseDDL(text, constants.DIALECT_POSTGRESQL, idGenerator())
+	assert.Nil(t, err)
+	assert.Equal(t, Sequence{Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "10", StartWithCounter: "5", ColumnsUsingSeq: map[string][]string{"t2": {"c3"}}}, got.Sequences["s1"])
+
+	singers := got.Tables["t2"]
+	assert.Equal(t, "Singers", singers.Name)
+	assert.Equal(t, []IndexKey{{ColId: "c3", Order: 1}}, singers.PrimaryKeys)
+	assert.Equal(t, ColumnDef{Name: "id", Id: "c3", T: Type{Name: Int64}, NotNull: true, AutoGen: AutoGenCol{Name: "seq", GenerationType: constants.SEQUENCE}}, singers.ColDefs["c3"])
+	assert.Equal(t, ColumnDef{Name: "name", Id: "c4", T: Type{Name: String, Len: 100}, NotNull: true}, singers.ColDefs["c4"])
+	assert.Equal(t, ColumnDef{Name: "score", Id: "c5", T: Type{Name: Float64}, DefaultValue: DefaultValue{IsPresent: true, Value: Expression{ExpressionId: "e6", Statement: "0"}}}, singers.ColDefs["c5"])
+	assert.Equal(t, Type{Name: Timestamp}, singers.ColDefs["c7"].T)
+	assert.Equal(t, Type{Name: String, Len: PGMaxLength, IsArray: true}, singers.ColDefs["c8"].T)
+	assert.Equal(t, Type{Name: JSON}, singers.ColDefs["c9"].T)
+	assert.Equal(t, []CreateIndex{{Name: "by_name", TableId: "t2", Id: "i15", Keys: []IndexKey{{ColId: "c4", Order: 1}}, StoredColumnIds: []string{"c5"}}}, singers.Indexes)
+
+	albums := got.Tables["t10"]
+	assert.Equal(t, []IndexKey{{ColId: "c11", Order: 1}, {ColId: "c12", Order: 2}}, albums.PrimaryKeys)
+	assert.Equal(t, AutoGenCol{Name: constants.IDENTITY, GenerationType: constants.IDENTITY, IdentityOptions: IdentityOptions{SkipRangeMin: "1", SkipRangeMax: "5"}}, albums.ColDefs["c12"].AutoGen)
+	assert.Equal(t, Type{Name: Bytes, Len: MaxLength}, albums.ColDefs["c13"].T)
+	assert.Equal(t, InterleavedParent{Id: "t2", InterleaveType: "IN"}, albums.ParentTable)
+	assert.Equal(t, []Foreignkey{{Name: "fk_singer", Id: "f14", ColIds: []string{"c11"}, ReferTableId: "t2", ReferColumnIds: []string{"c3"}}}, albums.ForeignKeys)
+
+	assert.Equal(t, []string{"CREATE CHANGE STREAM everything FOR ALL"}, got.Skipped)
+}
+
+// TestParseDDLRoundTrip checks that parsing the DDL printed for a schema
+// gives a schema that prints the same DDL.
+func TestParseDDLRoundTrip(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:   "singers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3", "c4", "c5", "c13", "c14", "c15", "c16"},
+			ColDefs: map[string]ColumnDef{
+				"c1":  {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true, AutoGen: AutoGenCol{Name: "seq", GenerationType: constants.SEQUENCE}},
+				"c2":  {Name: "name", Id: "c2", T: Type{Name: String, Len: 100}, NotNull: true},
+				"c3":  {Name: "active", Id: "c3", T: Type{Name: Bool}, DefaultValue: DefaultValue{IsPresent: true, Value: Expression{Statement: "true"}}},
+				"c4":  {Name: "price", Id: "c4", T: Type{Name: Numeric}, DefaultValue: DefaultValue{IsPresent: true, Value: Expression{Statement: "1.5"}}},
+				"c5":  {Name: "uuid", Id: "c5", T: Type{Name: String, Len: 36}, AutoGen: AutoGenCol{Name: constants.UUID, GenerationType: "Pre-defined"}},
+				"c13": {Name: "external_id", Id: "c13", T: Type{Name: UUID}},
+				"c14": {Name: "tour_length", Id: "c14", T: Type{Name: Interval}},
+				"c15": {Name: "tags", Id: "c15", T: Type{Name: String, Len: MaxLength, IsArray: true}},
+				"c16": {Name: "embedding", Id: "c16", T: Type{Name: Float32, IsArray: true, VectorLength: 3}},
+			},
+			PrimaryKeys:      []IndexKey{{ColId: "c1", Order: 1}},
+			CheckConstraints: []CheckConstraint{{Name: "positive", Expr: "(id > 0)"}},
+			Indexes:          []CreateIndex{{Name: "by_name", TableId: "t1", Unique: true, Keys: []IndexKey{{ColId: "c2", Desc: true, Order: 1}}, StoredColumnIds: []string{"c3"}}},
+		},
+		"t2": {
+			Name:   "albums",
+			Id:     "t2",
+			ColIds: []string{"c6", "c7", "c8", "c9", "c10", "c11", "c12"},
+			ColDefs: map[string]ColumnDef{
+				"c6":  {Name: "singer_id", Id: "c6", T: Type{Name: Int64}, NotNull: true},
+				"c7":  {Name: "album_id", Id: "c7", T: Type{Name: Int64}, NotNull: true, AutoGen: AutoGenCol{Name: constants.IDENTITY, GenerationType: constants.IDENTITY, IdentityOptions: IdentityOptions{SkipRangeMin: "1", SkipRangeMax: "10", StartCounterWith: "3"}}},
+				"c8":  {Name: "cover", Id: "c8", T: Type{Name: Bytes, Len: MaxLength}},
+				"c9":  {Name: "next_id", Id: "c9", T: Type{Name: Int64}, Generated: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "album_id + 1"}}},
+				"c10": {Name: "title", Id: "c10", T: Type{Name: String, Len: MaxLength}},
+				"c11": {Name: "title_tokens", Id: "c11", T: Type{Name: TokenList}, Generated: GeneratedColumn{IsPresent: true, Value: Expression{Statement: "TOKENIZE_FULLTEXT(title)"}, Virtual: true}, Hidden: true},
+				"c12": {Name: "released_at", Id: "c12", T: Type{Name: Timestamp}},
+			},
+			Indexes:           []CreateIndex{{Name: "albums_by_next_id", TableId: "t2", Keys: []IndexKey{{ColId: "c6", Order: 1}, {ColId: "c9", Order: 2}}, NullFiltered: true, InterleaveIn: "t1"}},
+			SearchIndexes:     []CreateSearchIndex{{Name: "albums_by_title", TableId: "t2", Keys: []IndexKey{{ColId: "c11", Order: 1}}}},
+			PrimaryKeys:       []IndexKey{{ColId: "c6", Order: 1}, {ColId: "c7", Order: 2}},
+			ParentTable:       InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+			ForeignKeys:       []Foreignkey{{Name: "fk_singer", ColIds: []string{"c6"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}, OnDelete: constants.FK_NO_ACTION}},
+			RowDeletionPolicy: &RowDeletionPolicy{ColId: "c12", Days: 30},
+		},
+	}
+	sequences := map[string]Sequence{
+		"s1": {Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "10"},
+	}
+	for _, dialect := range []string{constants.DIALECT_GOOGLESQL, constants.DIALECT_POSTGRESQL} {
+		for _, protectIds := range []bool{false, true} {
+			c := Config{ProtectIds: protectIds, Tables: true, ForeignKeys: true, SpDialect: dialect}
+			want := GetDDL(c, s, sequences, DatabaseOptions{})
+			parsed, err := ParseDDL(strings.Join(want, ";\n"), dialect, idGenerator())
+			assert.Nil(t, err, dialect)
+			assert.Equal(t, want, GetDDL(c, parsed.Tables, parsed.Sequences, DatabaseOptions{}), dialect)
+		}
+	}
+}
+
+// TestParseDDLViews checks that views printed by GetViewDDL are parsed
+// along with the tables they refer to.
+func TestParseDDLViews(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:        "singers",
+			Id:          "t1",
+			ColIds:      []string{"c1", "c2"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true}, "c2": {Name: "name", Id: "c2", T: Type{Name: String, Len: 100}}},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+		},
+	}
+	views := map[string]CreateView{
+		"v1": {Id: "v1", Name: "singer_names", SqlSecurity: SqlSecurityInvoker, Query: "SELECT s.name FROM singers AS s WHERE s.id > 0", RefIds: []string{"t1"}},
+		"v2": {Id: "v2", Name: "all_names", SqlSecurity: SqlSecurityInvoker, Query: "SELECT n.* FROM singer_names AS n", RefIds: []string{"v1"}},
+		"v3": {Id: "v3", Name: "names_as_owner", SqlSecurity: SqlSecurityDefiner, Query: "SELECT name FROM singers", RefIds: []string{"t1"}},
+	}
+	for _, dialect := range []string{constants.DIALECT_GOOGLESQL, constants.DIALECT_POSTGRESQL} {
+		for _, protectIds := range []bool{false, true} {
+			c := Config{ProtectIds: protectIds, Tables: true, ForeignKeys: true, SpDialect: dialect}
+			want := append(GetDDL(c, s, nil, DatabaseOptions{}), GetViewDDL(c, views)...)
+			parsed, err := ParseDDL(strings.Join(want, ";\n"), dialect, idGenerator())
+			assert.Nil(t, err, dialect)
+			assert.Empty(t, parsed.Skipped, dialect)
+			assert.Equal(t, want, append(GetDDL(c, parsed.Tables, parsed.Sequences, DatabaseOptions{}), GetViewDDL(c, parsed.Views)...), dialect)
+			refs := make(map[string][]string)
+			for _, v := range parsed.Views {
+				for _, id := range v.RefIds {
+					if ct, ok := parsed.Tables[id]; ok {
+						refs[v.Name] = append(refs[v.Name], ct.Name)
+					} else {
+						refs[v.Name] = append(refs[v.Name], parsed.Views[id].Name)
+					}
+				}
+			}
+			assert.Equal(t, map[string][]string{"singer_names": {"singers"}, "all_names": {"singer_names"}, "names_as_owner": {"singers"}}, refs, dialect)
+		}
+	}
+	parsed, err := ParseDDL("CREATE TABLE t (a INT64) PRIMARY KEY (a); CREATE OR REPLACE VIEW v AS SELECT a FROM t", constants.DIALECT_GOOGLESQL, idGenerator())
+	assert.Nil(t, err)
+	assert.Equal(t, CreateView{Id: "vw3", Name: "v", Query: "SELECT a FROM t", RefIds: []string{"t1"}}, parsed.Views["vw3"])
+	_, err = ParseDDL("CREATE VIEW v SQL SECURITY OWNER AS SELECT 1", constants.DIALECT_GOOGLESQL, idGenerator())
+	assert.ErrorContains(t, err, "expected INVOKER or DEFINER")
+}
+
+func TestParseDDLNamedSchemas(t *testing.T) {
+	s := Schema{
+		"t1": {
+			Name:        "customers",
+			Id:          "t1",
+			ColIds:      []string{"c1"},
+			ColDefs:     map[string]ColumnDef{"c1": {Name: "id", Id: "c1", T: Type{Name: Int64}, NotNull: true}},
+			PrimaryKeys: []IndexKey{{ColId: "c1", Order: 1}},
+		},
+		"t2": {
+			Name:       "orders",
+			SchemaName: "sales",
+			Id:         "t2",
+			ColIds:     []string{"c2", "c3"},
+			ColDefs: map[string]ColumnDef{
+				"c2": {Name: "id", Id: "c2", T: Type{Name: Int64}, NotNull: true, AutoGen: AutoGenCol{Name: "sales.order_seq", GenerationType: constants.SEQUENCE}},
+				"c3": {Name: "customer_id", Id: "c3", T: Type{Name: Int64}},
+			},
+			PrimaryKeys: []IndexKey{{ColId: "c2", Order: 1}},
+			Indexes:     []CreateIndex{{Name: "orders_by_customer", SchemaName: "sales", TableId: "t2", Keys: []IndexKey{{ColId: "c3", Order: 1}}}},
+			ForeignKeys: []Foreignkey{{Name: "fk_customer", ColIds: []string{"c3"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}, OnDelete: constants.FK_NO_ACTION}},
+		},
+		"t3": {
+			Name:        "items",
+			SchemaName:  "sales",
+			Id:          "t3",
+			ColIds:      []string{"c4", "c5"},
+			ColDefs:     map[string]ColumnDef{"c4": {Name: "order_id", Id: "c4", T: Type{Name: Int64}, NotNull: true}, "c5": {Name: "item_id", Id: "c5", T: Type{Name: Int64}, NotNull: true}},
+			PrimaryKeys: []IndexKey{{ColId: "c4", Order: 1}, {ColId: "c5", Order: 2}},
+			ParentTable: InterleavedParent{Id: "t2", OnDelete: constants.FK_CASCADE, InterleaveType: "IN PARENT"},
+		},
+	}
+	sequences := map[string]Sequence{
+		"s1": {Id: "s1", Name: "order_seq", SchemaName: "sales", SequenceKind: "BIT REVERSED POSITIVE"},
+	}
+	for _, dialect := range []string{constants.DIALECT_GOOGLESQL, constants.DIALECT_POSTGRESQL} {
+		for _, protectIds := range []bool{false, true} {
+			c := Config{ProtectIds: protectIds, Tables: true, ForeignKeys: true, SpDialect: dialect}
+			want := GetDDL(c, s, sequences, DatabaseOptions{})
+			parsed, err := ParseDDL(strings.Join(want, ";\n"), dialect, idGenerator())
+			assert.Nil(t, err, dialect)
+			assert.Empty(t, parsed.Skipped, dialect)
+			assert.Equal(t, want, GetDDL(c, parsed.Tables, parsed.Sequences, DatabaseOptions{}), dialect)
+		}
+	}
+	// PostgreSQL's public schema is the default schema.
+	parsed, err := ParseDDL("CREATE TABLE public.t (a bigint PRIMARY KEY); CREATE INDEX i ON t (a)", constants.DIALECT_POSTGRESQL, idGenerator())
+	assert.Nil(t, err)
+	assert.Equal(t, "t", parsed.Tables["t1"].QualifiedName())
+}
+
+func TestParseDDLVectorIndex(t *testing.T) {
+	text := `
+CREATE TABLE Items (
+	Id INT64 NOT NULL,
+	Embedding ARRAY<FLOAT32>(vector_length=>3),
+) PRIMARY KEY (Id);
+CREATE VECTOR INDEX ItemsByEmbedding ON Items (Embedding) WHERE Embedding IS NOT NULL OPTIONS (distance_type = 'COSINE');
+`
+	got, err := ParseDDL(text, constants.DIALECT_GOOGLESQL, idGenerator())
+	assert.Nil(t, err)
+	assert.Equal(t, Schema{
+		"t1": {
+			Name:   "Items",
+			Id:     "t1",
+			ColIds: []string{"c2", "c3"},
+			ColDefs: map[string]ColumnDef{
+				"c2": {Name: "Id", Id: "c2", T: Type{Name: Int64}, NotNull: true},
+				"c3": {Name: "Embedding", Id: "c3", T: Type{Name: Float32, IsArray: true, VectorLength: 3}},
+			},
+			PrimaryKeys:   []IndexKey{{ColId: "c2", Order: 1}},
+			VectorIndexes: []CreateVectorIndex{{Name: "ItemsByEmbedding", TableId: "t1", Keys: []IndexKey{{ColId: "c3", Order: 1}}, DistanceType: CosineDistance, Id: "i4"}},
+		},
+	}, got.Tables)
+}
+
+func TestParseDDLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect string
+		text    string
+		err     string
+	}{
+		{"unknown type", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a GEOGRAPHY) PRIMARY KEY (a)", "unsupported type"},
+		{"unknown pg type", constants.DIALECT_POSTGRESQL, "CREATE TABLE t (a point PRIMARY KEY)", "unsupported type"},
+		{"missing primary key", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64)", "expected PRIMARY KEY ("},
+		{"unknown key column", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64) PRIMARY KEY (b)", "table t has no column b"},
+		{"unknown parent", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64) PRIMARY KEY (a), INTERLEAVE IN PARENT p", "table p isn't defined"},
+		{"duplicate table", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64) PRIMARY KEY (a); CREATE TABLE T (a INT64) PRIMARY KEY (a)", "table T is defined twice"},
+		{"virtual generated column", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64, b INT64 AS (a + 1)) PRIMARY KEY (a)", "generated columns that aren't stored aren't supported"},
+		{"virtual generated pg column", constants.DIALECT_POSTGRESQL, "CREATE TABLE t (a bigint PRIMARY KEY, b bigint GENERATED ALWAYS AS (a + 1) VIRTUAL)", "generated columns that aren't stored aren't supported"},
+		{"unknown search index column", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64) PRIMARY KEY (a); CREATE SEARCH INDEX i ON t (b)", "table t has no column b"},
+		{"row deletion policy on non-timestamp column", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64) PRIMARY KEY (a), ROW DELETION POLICY (OLDER_THAN(a, INTERVAL 1 DAY))", "uses column a, which isn't a timestamp"},
+		{"pg ttl in hours", constants.DIALECT_POSTGRESQL, "CREATE TABLE t (a bigint PRIMARY KEY, b timestamptz) TTL INTERVAL '12 hours' ON b", "expected an interval in days"},
+		{"unsupported table option", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64) PRIMARY KEY (a), OPTIONS (locality_group = 'ssd')", "unsupported table option"},
+		{"multi-column vector index", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64, b ARRAY<FLOAT32>(vector_length=>3)) PRIMARY KEY (a); CREATE VECTOR INDEX i ON t (a, b) OPTIONS (distance_type = 'COSINE')", "must index one column"},
+		{"index interleaved in non-ancestor", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a INT64) PRIMARY KEY (a); CREATE TABLE u (a INT64) PRIMARY KEY (a); CREATE INDEX i ON t (a), INTERLEAVE IN u", "isn't an ancestor of table t"},
+		{"partially null filtered pg index", constants.DIALECT_POSTGRESQL, "CREATE TABLE t (a bigint PRIMARY KEY, b bigint, c bigint); CREATE INDEX i ON t (b, c) WHERE b IS NOT NULL", "filters out NULL values of some key columns but not others"},
+		{"unknown foreign key table", constants.DIALECT_POSTGRESQL, "CREATE TABLE t (a bigint PRIMARY KEY, FOREIGN KEY (a) REFERENCES u (a))", "table u isn't defined"},
+		{"unknown sequence", constants.DIALECT_POSTGRESQL, "CREATE TABLE t (a bigint PRIMARY KEY DEFAULT nextval('s'))", "uses sequence s, which isn't defined"},
+		{"unterminated string", constants.DIALECT_GOOGLESQL, "CREATE TABLE t (a STRING(MAX) DEFAULT ('x)) PRIMARY KEY (a)", "unterminated quote"},
+	}
+	for _, tc := range tests {
+		_, err := ParseDDL(tc.text, tc.dialect, idGenerator())
+		if assert.NotNil(t, err, tc.name) {
+			assert.Contains(t, err.Error(), tc.err, tc.name)
+		}
+	}
+}
+
+func TestParseDDLRowDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		dialect string
+		text    string
+	}{
+		{constants.DIALECT_GOOGLESQL, "CREATE TABLE Events (Id INT64, CreatedAt TIMESTAMP) PRIMARY KEY (Id), ROW DELETION POLICY (OLDER_THAN(CreatedAt, INTERVAL 7 DAY))"},
+		{constants.DIALECT_POSTGRESQL, "CREATE TABLE events (id bigint PRIMARY KEY, created_at timestamptz) TTL INTERVAL '7 days' ON created_at"},
+	}
+	for _, tc := range tests {
+		got, err := ParseDDL(tc.text, tc.dialect, idGenerator())
+		assert.Nil(t, err, tc.dialect)
+		assert.Equal(t, &RowDeletionPolicy{ColId: "c3", Days: 7}, got.Tables["t1"].RowDeletionPolicy, tc.dialect)
+	}
+}
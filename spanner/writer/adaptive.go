@@ -0,0 +1,116 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"google.golang.org/grpc/codes"
+)
+
+// Defaults for the bounds of adaptive writes.
+const (
+	defaultMinBatchMutations = 1000
+	defaultTargetLatency     = 5 * time.Second
+)
+
+// batchLimits returns the current limits on the mutation count, byte size
+// and row count of a batch.
+func (bw *BatchWriter) batchLimits() (count, bytes, rows int64) {
+	count = atomic.LoadInt64(&bw.async.batchCount)
+	// Scale the byte limit with the mutation count, so that batches of
+	// large rows shrink too.
+	bytes = byteThreshold * count / countThreshold
+	rows = math.MaxInt64
+	if bw.rowsPerSecond > 0 {
+		rows = bw.rowsPerSecond
+	}
+	return count, bytes, rows
+}
+
+// adapt adjusts the number of in-progress writes and the size of batches
+// after a write that took latency and returned err. It implements additive
+// increase and multiplicative decrease: both limits are halved when the
+// database is overloaded, and the number of in-progress writes grows by one
+// (and the size of batches by a quarter) once that many writes in a row
+// have succeeded quickly.
+// Note: adapt must be thread-safe because it is run inside a go routine.
+func (bw *BatchWriter) adapt(latency time.Duration, err error) {
+	if !bw.adaptive {
+		return
+	}
+	overloaded := latency > bw.targetLatency || isOverloadError(err)
+	if err != nil && !overloaded {
+		// Errors caused by bad data say nothing about the load.
+		return
+	}
+	bw.async.lock.Lock()
+	defer bw.async.lock.Unlock()
+	writeLimit := atomic.LoadInt64(&bw.async.writeLimit)
+	batchCount := atomic.LoadInt64(&bw.async.batchCount)
+	if overloaded {
+		// All writes in progress when the database became overloaded report
+		// it, so back off at most once per target latency.
+		if time.Since(bw.async.lastBackoff) < bw.targetLatency {
+			return
+		}
+		bw.async.lastBackoff = time.Now()
+		bw.async.goodWrites = 0
+		writeLimit = max(bw.minWriteLimit, writeLimit/2)
+		batchCount = max(bw.minBatchCount, batchCount/2)
+		logger.Log.Debug(fmt.Sprintf("Database is overloaded (latency %v, error %v): reducing in-progress writes to %d and batches to %d mutations\n", latency, err, writeLimit, batchCount))
+	} else {
+		bw.async.goodWrites++
+		if bw.async.goodWrites < writeLimit {
+			return
+		}
+		bw.async.goodWrites = 0
+		writeLimit = min(bw.writeLimit, writeLimit+1)
+		batchCount = min(int64(countThreshold), batchCount+batchCount/4)
+	}
+	atomic.StoreInt64(&bw.async.writeLimit, writeLimit)
+	atomic.StoreInt64(&bw.async.batchCount, batchCount)
+}
+
+// isOverloadError returns whether err indicates that the database is too
+// busy to take more writes, rather than a problem with the data written.
+func isOverloadError(err error) bool {
+	switch sp.ErrCode(err) {
+	case codes.Aborted, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Unavailable:
+		return true
+	}
+	return false
+}
+
+// throttle waits until rows rows can be written without exceeding
+// bw.rowsPerSecond. Callers must hold bw.bufLock.
+func (bw *BatchWriter) throttle(rows int) {
+	if bw.rowsPerSecond <= 0 {
+		return
+	}
+	now := time.Now()
+	if bw.nextWrite.Before(now) {
+		// Don't let time spent idle be used for a burst of writes.
+		bw.nextWrite = now
+	}
+	wait := bw.nextWrite.Sub(now)
+	bw.nextWrite = bw.nextWrite.Add(time.Duration(rows) * time.Second / time.Duration(bw.rowsPerSecond))
+	time.Sleep(wait)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdapt(t *testing.T) {
+	bw := NewBatchWriter(BatchWriterConfig{
+		WriteLimit:        8,
+		Adaptive:          true,
+		MinWriteLimit:     2,
+		MinBatchMutations: 20000,
+		TargetLatency:     time.Second,
+	})
+	limits := func() (int64, int64) { return bw.async.writeLimit, bw.async.batchCount }
+	w, c := limits()
+	assert.Equal(t, int64(8), w)
+	assert.Equal(t, int64(countThreshold), c)
+
+	// Slow writes and overload errors halve both limits, at most once per
+	// target latency.
+	bw.adapt(2*time.Second, nil)
+	w, c = limits()
+	assert.Equal(t, int64(4), w)
+	assert.Equal(t, int64(countThreshold/2), c)
+	bw.adapt(time.Millisecond, status.Error(codes.ResourceExhausted, "overloaded"))
+	w, _ = limits()
+	assert.Equal(t, int64(4), w)
+	bw.async.lastBackoff = time.Now().Add(-time.Minute)
+	bw.adapt(time.Millisecond, status.Error(codes.ResourceExhausted, "overloaded"))
+	bw.async.lastBackoff = time.Now().Add(-time.Minute)
+	bw.adapt(time.Millisecond, status.Error(codes.Aborted, "aborted"))
+	w, c = limits()
+	assert.Equal(t, int64(2), w)
+	assert.Equal(t, int64(20000), c)
+
+	// Errors caused by bad data don't change the limits.
+	bw.adapt(time.Millisecond, status.Error(codes.InvalidArgument, "bad data"))
+	w, _ = limits()
+	assert.Equal(t, int64(2), w)
+
+	// Fast writes increase the limits gradually, up to WriteLimit.
+	bw.adapt(time.Millisecond, nil)
+	w, _ = limits()
+	assert.Equal(t, int64(2), w)
+	bw.adapt(time.Millisecond, nil)
+	w, c = limits()
+	assert.Equal(t, int64(3), w)
+	assert.Equal(t, int64(25000), c)
+	for i := 0; i < 100; i++ {
+		bw.adapt(time.Millisecond, nil)
+	}
+	w, c = limits()
+	assert.Equal(t, int64(8), w)
+	assert.Equal(t, int64(countThreshold), c)
+}
+
+func TestAdaptDisabled(t *testing.T) {
+	bw := NewBatchWriter(BatchWriterConfig{WriteLimit: 8})
+	bw.adapt(time.Hour, status.Error(codes.Unavailable, "unavailable"))
+	assert.Equal(t, int64(8), bw.async.writeLimit)
+	assert.Equal(t, int64(countThreshold), bw.async.batchCount)
+}
+
+func TestIsOverloadError(t *testing.T) {
+	assert.True(t, isOverloadError(status.Error(codes.DeadlineExceeded, "")))
+	assert.True(t, isOverloadError(status.Error(codes.Unavailable, "")))
+	assert.False(t, isOverloadError(status.Error(codes.AlreadyExists, "")))
+	assert.False(t, isOverloadError(errors.New("bad data")))
+	assert.False(t, isOverloadError(nil))
+}
+
+func TestRowsPerSecond(t *testing.T) {
+	var lock sync.Mutex
+	var batches []int
+	bw := NewBatchWriter(BatchWriterConfig{
+		BytesLimit:    100 << 20,
+		WriteLimit:    40,
+		RetryLimit:    10,
+		RowsPerSecond: 50,
+		Write: func(m []*sp.Mutation) error {
+			lock.Lock()
+			defer lock.Unlock()
+			batches = append(batches, len(m))
+			return nil
+		},
+	})
+	start := time.Now()
+	for i := 0; i < 150; i++ {
+		bw.AddRow("t", []string{"id"}, []interface{}{int64(i)})
+	}
+	bw.Flush()
+	// Batches hold at most a second's worth of rows, and the third batch
+	// can't start until the first two seconds' worth have been written.
+	assert.Equal(t, []int{50, 50, 50}, batches)
+	assert.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+}
@@ -19,9 +19,11 @@ package writer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,6 +31,8 @@ import (
 
 	sp "cloud.google.com/go/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"google.golang.org/api/iterator"
 )
 
 // Parameters used to control building batches to write to Spanner.
@@ -41,29 +45,88 @@ const (
 	byteThreshold  = 20 * 1 << 20 // Spanner per-operation limit is 100MB.
 )
 
+// WriteMode specifies how BatchWriter writes rows that may already exist
+// in the database.
+type WriteMode string
+
+const (
+	// WriteModeInsert fails rows that already exist with error 'AlreadyExists'.
+	WriteModeInsert WriteMode = "insert"
+	// WriteModeInsertOrUpdate updates the written columns of rows that
+	// already exist, leaving their other columns unchanged.
+	WriteModeInsertOrUpdate WriteMode = "insert-or-update"
+	// WriteModeReplace deletes rows that already exist and inserts them
+	// again, so that columns that aren't written become NULL.
+	WriteModeReplace WriteMode = "replace"
+)
+
+// ParseWriteMode returns the WriteMode named by s. An empty string selects
+// WriteModeInsert.
+func ParseWriteMode(s string) (WriteMode, error) {
+	switch m := WriteMode(s); m {
+	case "":
+		return WriteModeInsert, nil
+	case WriteModeInsert, WriteModeInsertOrUpdate, WriteModeReplace:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid write mode %q: must be one of %s, %s or %s", s, WriteModeInsert, WriteModeInsertOrUpdate, WriteModeReplace)
+}
+
+// mutation returns the mutation that writes a row using write mode m.
+func (m WriteMode) mutation(table string, cols []string, vals []interface{}) *sp.Mutation {
+	switch m {
+	case WriteModeInsertOrUpdate:
+		return sp.InsertOrUpdate(table, cols, vals)
+	case WriteModeReplace:
+		return sp.Replace(table, cols, vals)
+	}
+	return sp.Insert(table, cols, vals)
+}
+
 // BatchWriter accumulates rows of data (via AddRow) and assembles them
-// into batches that it asynchronously writes to Spanner.  Rows are
-// written to Spanner using insert semantics i.e. if a row already exists
-// in the database, the row will fail with error 'AlreadyExists'.  If
+// into batches that it asynchronously writes to Spanner.  By default, rows
+// are written to Spanner using insert semantics i.e. if a row already exists
+// in the database, the row will fail with error 'AlreadyExists'.  Other
+// semantics can be chosen with BatchWriterConfig.WriteMode.  If
 // Spanner returns an error for a batch, BatchWriter splits the batch
 // into smaller chunks to retry, as it attempts to isolate which row(s)
-// in a batch is bad.  BatchWriter respects Spanner's limits on byte size
+// in a batch is bad.  Alternatively, batches can be written as mutation
+// groups that Spanner applies independently (see BatchWriterConfig.BatchWrite),
+// in which case errors are reported per group and batches aren't split.  BatchWriter respects Spanner's limits on byte size
 // and mutation count and has configurable limits on the number of
-// in-progress writes, amount of data buffered and retry behavior.
-// BatchWriter is not threadsafe: only one call to AddRow or Flush should
-// be active at any time.  See ExampleBatchWriter (batchwriter_test.go)
-// for sample usage code.
+// in-progress writes, amount of data buffered, retry behavior and the
+// rate at which rows are written.  Optionally, BatchWriter adapts the
+// size of batches and the number of in-progress writes to the load the
+// database can take (see BatchWriterConfig.Adaptive).
+// AddRow and Flush may be called concurrently: Flush waits for the writes
+// of the rows added before it was called.  See ExampleBatchWriter
+// (batchwriter_test.go) for sample usage code.
 type BatchWriter struct {
-	rows       []*row                     // Buffered rows.
-	rBytes     int64                      // Estimate of bytes for buffered rows.
-	rCount     int64                      // Mutation count for buffered rows.
-	write      func([]*sp.Mutation) error // Typically a closure that calls client.Apply, but structured this way for testing.
-	wg         sync.WaitGroup             // Tracks in-progress writes.
-	writeLimit int64                      // Limit on number of in-progress writes.
-	bytesLimit int64                      // Limit on bytes buffered. AddRow blocks if rBytes exceeded this value.
-	retryLimit int64                      // Limit on retries.
-	verbose    bool                       // If true, print out messages about each write batch.
-	async      asyncState
+	bufLock     sync.Mutex                 // Protects rows, rBytes, rCount, wg and nextWrite.
+	rows        []*row                     // Buffered rows.
+	rBytes      int64                      // Estimate of bytes for buffered rows.
+	rCount      int64                      // Mutation count for buffered rows.
+	write       func([]*sp.Mutation) error // Typically a closure that calls client.Apply, but structured this way for testing.
+	wg          *sync.WaitGroup            // Tracks in-progress writes started since the last Flush, and the writes before it.
+	writeLimit  int64                      // Limit on number of in-progress writes.
+	bytesLimit  int64                      // Limit on bytes buffered. AddRow blocks if rBytes exceeded this value.
+	retryLimit  int64                      // Limit on retries.
+	verbose     bool                       // If true, print out messages about each write batch.
+	writeMode   WriteMode                  // How rows are written.
+	keyColumns  func(table string) []string
+	countRows   func(table string, keyCols []string, keys []sp.Key) (int64, error)
+	deadLetter  io.Writer                             // If set, every dropped row is written to deadLetter.
+	batchWrite  func(groups [][]*sp.Mutation) []error // If set, batches are written as mutation groups with batchWrite instead of write.
+	parentTable func(table string) string
+	columnType  func(table, col string) ddl.Type
+	// Parameters of adaptive writes; see BatchWriterConfig.
+	adaptive      bool
+	minWriteLimit int64
+	minBatchCount int64
+	targetLatency time.Duration
+	rowsPerSecond int64     // Limit on rows written per second. 0 means no limit.
+	nextWrite     time.Time // Earliest start of the next write when rowsPerSecond is set.
+	async         asyncState
 }
 
 type row struct {
@@ -87,6 +150,13 @@ type asyncState struct {
 	sampleBadRows      []*row           // A sample of rows that generated errors; protected by lock.
 	sampleBadRowsBytes int64            // Estimate of bytes for sampleBadRows; protected by lock.
 	droppedRows        map[string]int64 // Count of dropped rows, broken down by table.
+	updatedRows        map[string]int64 // Count of written rows that replaced existing rows, broken down by table; protected by lock.
+	deadLetterErr      error            // First error writing to the dead-letter file; protected by lock.
+	deferred           []deferredGroup  // Mutation groups to write again once their parent rows are written; protected by lock.
+	writeLimit         int64            // Current limit on number of in-progress writes; access using atomic.
+	batchCount         int64            // Current limit on mutation count of a batch; access using atomic.
+	goodWrites         int64            // Writes since writeLimit was last changed; protected by lock.
+	lastBackoff        time.Time        // Last time writeLimit and batchCount were reduced; protected by lock.
 }
 
 // BatchWriterConfig specifies parameters for configuring BatchWriter.
@@ -96,21 +166,97 @@ type BatchWriterConfig struct {
 	RetryLimit int64                      // Limit on retries.
 	Write      func([]*sp.Mutation) error // Function to call to write to Spanner (typically a closure that calls client.Apply).
 	Verbose    bool                       // If true, print out messages about each write batch.
+	WriteMode  WriteMode                  // How rows are written. Defaults to WriteModeInsert.
+	// KeyColumns and CountExisting are used to count the rows that update
+	// existing rows when WriteMode isn't WriteModeInsert. KeyColumns returns
+	// the primary key columns of a table, and CountExisting returns how many
+	// of the given keys of a table exist in the database (typically by
+	// reading them from Spanner). CountExisting is called for every batch
+	// before it is written, so it adds a read to each write. Updated rows
+	// aren't counted if either is nil.
+	KeyColumns    func(table string) []string
+	CountExisting func(table string, keyCols []string, keys []sp.Key) (int64, error)
+	// DeadLetter, if set, receives every dropped row as a line of JSON
+	// (see DeadLetterRow), so that the rows can be fixed and written to
+	// Spanner again with ReadDeadLetters.
+	DeadLetter io.Writer
+	// ColumnType, if set, returns the Spanner type of a column of a table,
+	// or the zero Type if it isn't known. It tells UUID and INTERVAL
+	// values, which are written as strings, apart from STRING values in
+	// the dead-letter file.
+	ColumnType func(table, col string) ddl.Type
+	// BatchWrite, if set, is called instead of Write to write each batch as
+	// mutation groups that Spanner applies independently and non-atomically
+	// (typically a closure that calls client.BatchWrite). It returns the
+	// error of each group, nil if the group was applied. A row is grouped
+	// with the rows of the same batch that it is interleaved in, or that are
+	// interleaved in it (see ParentTable and KeyColumns); other rows form a
+	// group of their own. Groups that fail are dropped, except that groups
+	// failing because the database is overloaded are retried, and groups
+	// failing because their parent rows don't exist yet are written again
+	// by Flush.
+	BatchWrite func(groups [][]*sp.Mutation) []error
+	// ParentTable returns the table that a table is interleaved in, or ""
+	// if it isn't interleaved.
+	ParentTable func(table string) string
+	// Adaptive, if set, lets BatchWriter adjust the number of in-progress
+	// writes between MinWriteLimit and WriteLimit, and the mutation count
+	// of batches between MinBatchMutations and Spanner's limit, based on
+	// the commit latency and errors of its writes. Both are reduced when a
+	// write takes longer than TargetLatency or fails because the database
+	// is overloaded, and are increased gradually otherwise.
+	Adaptive          bool
+	MinWriteLimit     int64         // Defaults to 1.
+	MinBatchMutations int64         // Defaults to defaultMinBatchMutations.
+	TargetLatency     time.Duration // Defaults to defaultTargetLatency.
+	// RowsPerSecond, if positive, caps the rate at which rows are written,
+	// so that a migration can share a database with other traffic. Batches
+	// then hold at most RowsPerSecond rows.
+	RowsPerSecond int64
 }
 
 // NewBatchWriter returns a new BatchWriter with parameters defined by config.
 func NewBatchWriter(config BatchWriterConfig) *BatchWriter {
-	return &BatchWriter{
-		write:      config.Write,
-		writeLimit: config.WriteLimit,
-		bytesLimit: config.BytesLimit,
-		retryLimit: config.RetryLimit,
-		verbose:    config.Verbose,
+	bw := &BatchWriter{
+		write:       config.Write,
+		writeLimit:  config.WriteLimit,
+		bytesLimit:  config.BytesLimit,
+		retryLimit:  config.RetryLimit,
+		verbose:     config.Verbose,
+		writeMode:   config.WriteMode,
+		keyColumns:  config.KeyColumns,
+		countRows:   config.CountExisting,
+		deadLetter:  config.DeadLetter,
+		batchWrite:  config.BatchWrite,
+		parentTable: config.ParentTable,
+		columnType:  config.ColumnType,
+		wg:          &sync.WaitGroup{},
 		async: asyncState{
 			errors:      make(map[string]int64),
 			droppedRows: make(map[string]int64),
+			updatedRows: make(map[string]int64),
+			writeLimit:  config.WriteLimit,
+			batchCount:  countThreshold,
 		},
+		adaptive:      config.Adaptive,
+		minWriteLimit: config.MinWriteLimit,
+		minBatchCount: config.MinBatchMutations,
+		targetLatency: config.TargetLatency,
+		rowsPerSecond: config.RowsPerSecond,
 	}
+	if bw.minWriteLimit <= 0 {
+		bw.minWriteLimit = 1
+	}
+	if bw.minWriteLimit > bw.writeLimit && bw.writeLimit > 0 {
+		bw.minWriteLimit = bw.writeLimit
+	}
+	if bw.minBatchCount <= 0 || bw.minBatchCount > countThreshold {
+		bw.minBatchCount = defaultMinBatchMutations
+	}
+	if bw.targetLatency <= 0 {
+		bw.targetLatency = defaultTargetLatency
+	}
+	return bw
 }
 
 // AddRow appends a new row of data to bw's buffer of rows. Depending on the
@@ -118,6 +264,8 @@ func NewBatchWriter(config BatchWriterConfig) *BatchWriter {
 // or it may block (waiting for some of the writes already in progress to
 // complete) and then initiate writes.
 func (bw *BatchWriter) AddRow(table string, cols []string, vals []interface{}) {
+	bw.bufLock.Lock()
+	defer bw.bufLock.Unlock()
 	r := &row{table, cols, vals}
 	bw.rows = append(bw.rows, r)
 	bw.rBytes += byteSize(r)
@@ -126,10 +274,13 @@ func (bw *BatchWriter) AddRow(table string, cols []string, vals []interface{}) {
 }
 
 // Flush initiates writes to Spanner of all buffered rows of data, and waits
-// for them to complete.
+// for them to complete. Mutation groups that failed because their parent
+// rows weren't written yet are then written again. Rows added while Flush
+// waits are written, but not waited for.
 func (bw *BatchWriter) Flush() {
+	bw.bufLock.Lock()
 	for len(bw.rows) > 0 {
-		if atomic.LoadInt64(&bw.async.writes) < bw.writeLimit {
+		if atomic.LoadInt64(&bw.async.writes) < atomic.LoadInt64(&bw.async.writeLimit) {
 			m, count, bytes := bw.getBatch()
 			if bw.verbose {
 				fmt.Printf("Starting write of %d rows to Spanner (%d bytes, %d mutations) [%d in progress]\n",
@@ -143,7 +294,24 @@ func (bw *BatchWriter) Flush() {
 			time.Sleep(10 * time.Millisecond)
 		}
 	}
-	bw.wg.Wait()
+	// Writes started from now on are tracked by a new WaitGroup, which
+	// also waits for this one, so that this Flush doesn't wait for them
+	// and a later Flush waits for all writes started before it.
+	wg := bw.wg
+	if wg == nil {
+		// The zero BatchWriter has never written anything.
+		bw.bufLock.Unlock()
+		return
+	}
+	bw.wg = &sync.WaitGroup{}
+	bw.wg.Add(1)
+	go func(next *sync.WaitGroup) {
+		wg.Wait()
+		next.Done()
+	}(bw.wg)
+	bw.bufLock.Unlock()
+	wg.Wait()
+	bw.writeDeferred()
 }
 
 // DroppedRowsByTable returns a map of tables to counts of dropped rows.
@@ -160,6 +328,21 @@ func (bw *BatchWriter) DroppedRowsByTable() map[string]int64 {
 	return m
 }
 
+// UpdatedRowsByTable returns a map of tables to counts of written rows
+// that updated or replaced an existing row. Rows are only counted when
+// BatchWriter is configured with a WriteMode other than WriteModeInsert
+// and a way to count existing rows. When batches are written as mutation
+// groups, existing rows of groups that failed are counted too.
+func (bw *BatchWriter) UpdatedRowsByTable() map[string]int64 {
+	bw.async.lock.Lock()
+	defer bw.async.lock.Unlock()
+	m := make(map[string]int64)
+	for t, n := range bw.async.updatedRows {
+		m[t] = n
+	}
+	return m
+}
+
 // SampleBadRows returns a string-formatted list of sample rows that
 // generated errors. Returns at most n rows.
 // Note that we split up batches to isolate errors. Each row returned
@@ -196,8 +379,10 @@ func (bw *BatchWriter) getBadRowsForTest() []*row {
 }
 
 // getBatch returns a slice of data from the front of bw.rows.  The slice
-// returned is the largest one not exceeding countThreshold and byteThreshold.
+// returned is the largest one not exceeding the limits returned by
+// batchLimits.
 func (bw *BatchWriter) getBatch() (rows []*row, count int64, bytes int64) {
+	countLimit, bytesLimit, rowLimit := bw.batchLimits()
 	for i := range bw.rows {
 		c := count + int64(len(bw.rows[i].cols))
 		b := bytes + byteSize(bw.rows[i])
@@ -205,7 +390,7 @@ func (bw *BatchWriter) getBatch() (rows []*row, count int64, bytes int64) {
 		// we have at least one row. If a single row puts us over the
 		// thresholds, there's not much we can do: we just try sending it to Spanner
 		// (it might succeed, since our thresholds are conservative).
-		if (c >= countThreshold || b >= byteThreshold) && len(rows) >= 1 {
+		if (c >= countLimit || b >= bytesLimit || int64(len(rows)) >= rowLimit) && len(rows) >= 1 {
 			bw.rCount -= count
 			bw.rBytes -= bytes
 			bw.rows = bw.rows[i:]
@@ -246,18 +431,54 @@ func (bw *BatchWriter) errorStats(rows []*row, err error, retry bool) {
 	}
 	for _, x := range rows {
 		bw.async.droppedRows[x.table]++
+		bw.writeDeadLetter(x, err)
 	}
 	return
 }
 
+// writeDeadLetter writes dropped row r, which failed with error err, to
+// bw.deadLetter. Callers must hold bw.async.lock.
+func (bw *BatchWriter) writeDeadLetter(r *row, err error) {
+	if bw.deadLetter == nil || bw.async.deadLetterErr != nil {
+		return
+	}
+	b, jsonErr := json.Marshal(newDeadLetterRow(r, err, bw.columnType))
+	if jsonErr == nil {
+		_, jsonErr = bw.deadLetter.Write(append(b, '\n'))
+	}
+	if jsonErr != nil {
+		bw.async.deadLetterErr = jsonErr
+		logger.Log.Warn(fmt.Sprintf("Can't write dropped rows to the dead-letter file, no more dropped rows will be written to it: %v", jsonErr))
+	}
+}
+
+// DeadLetterError returns the error that stopped dropped rows from being
+// written to the dead-letter file, or nil if all of them were written.
+func (bw *BatchWriter) DeadLetterError() error {
+	bw.async.lock.Lock()
+	defer bw.async.lock.Unlock()
+	return bw.async.deadLetterErr
+}
+
+// doWriteAndHandleErrors writes rows in a single transaction. A batch that
+// fails is split and written again. parentExisting holds the existing rows
+// counted for the batch that rows were split from, or is nil.
 // Note: doWriteAndHandleErrors must be thread-safe because it is run
 // inside a go routine.
-func (bw *BatchWriter) doWriteAndHandleErrors(rows []*row) {
+func (bw *BatchWriter) doWriteAndHandleErrors(rows []*row, parentExisting map[string]int64) {
+	if bw.batchWrite != nil {
+		bw.doBatchWriteAndHandleErrors(rows, true)
+		return
+	}
 	var m []*sp.Mutation
 	for _, x := range rows {
-		m = append(m, sp.Insert(x.table, x.cols, x.vals))
+		m = append(m, bw.writeMode.mutation(x.table, x.cols, x.vals))
 	}
-	if err := bw.write(m); err != nil {
+	existing := bw.countExisting(rows, parentExisting)
+	start := time.Now()
+	err := bw.write(m)
+	bw.adapt(time.Since(start), err)
+	if err != nil {
 		hitRetryLimit := atomic.LoadInt64(&bw.async.retries) >= bw.retryLimit
 		retry := len(rows) > 1 && !hitRetryLimit
 		bw.errorStats(rows, err, retry)
@@ -284,24 +505,87 @@ func (bw *BatchWriter) doWriteAndHandleErrors(rows []*row) {
 		}
 		for i := 0; i < len(rows); i += k {
 			atomic.AddInt64(&bw.async.retries, 1)
-			bw.doWriteAndHandleErrors(rows[i:min(i+k, len(rows))])
+			bw.doWriteAndHandleErrors(rows[i:min(i+k, len(rows))], existing)
+		}
+		return
+	}
+	if len(existing) > 0 {
+		bw.async.lock.Lock()
+		defer bw.async.lock.Unlock()
+		for t, n := range existing {
+			bw.async.updatedRows[t] += n
 		}
 	}
 }
 
+// countExisting returns the number of rows that already exist in the
+// database, broken down by table. It returns nil if rows are written with
+// insert semantics, since rows that exist then fail to be written. If rows
+// were split from a batch whose existing rows were counted in
+// parentExisting, the tables that had no existing rows in that batch
+// aren't read again.
+// Note: countExisting must be thread-safe because it is run inside a go
+// routine.
+func (bw *BatchWriter) countExisting(rows []*row, parentExisting map[string]int64) map[string]int64 {
+	if bw.writeMode == "" || bw.writeMode == WriteModeInsert || bw.keyColumns == nil || bw.countRows == nil {
+		return nil
+	}
+	var tables []string
+	keyCols := make(map[string][]string)
+	keys := make(map[string][]sp.Key)
+	for _, r := range rows {
+		if _, ok := keyCols[r.table]; !ok {
+			tables = append(tables, r.table)
+			keyCols[r.table] = bw.keyColumns(r.table)
+		}
+		var k sp.Key
+		for _, kc := range keyCols[r.table] {
+			// Null values are not written, so a missing key column is NULL.
+			var v interface{}
+			for i, c := range r.cols {
+				if c == kc {
+					v = r.vals[i]
+					break
+				}
+			}
+			k = append(k, v)
+		}
+		keys[r.table] = append(keys[r.table], k)
+	}
+	existing := make(map[string]int64)
+	for _, t := range tables {
+		if len(keyCols[t]) == 0 {
+			continue
+		}
+		if n, ok := parentExisting[t]; ok && n == 0 {
+			existing[t] = 0
+			continue
+		}
+		n, err := bw.countRows(t, keyCols[t], keys[t])
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("Can't count existing rows of table %s, counting them as new rows: %v", t, err))
+			continue
+		}
+		existing[t] = n
+	}
+	return existing
+}
+
 // Note: backgroundWrite must be thread-safe because it is run as
 // a go routine.
-func (bw *BatchWriter) backgroundWrite(rows []*row) {
-	defer bw.wg.Done()
+func (bw *BatchWriter) backgroundWrite(wg *sync.WaitGroup, rows []*row) {
+	defer wg.Done()
 	defer atomic.AddInt64(&bw.async.writes, -1)
-	bw.doWriteAndHandleErrors(rows)
+	bw.doWriteAndHandleErrors(rows, nil)
 }
 
-// startWrite initiates an asynchronous write of rows to Spanner.
+// startWrite initiates an asynchronous write of rows to Spanner. Callers
+// must hold bw.bufLock.
 func (bw *BatchWriter) startWrite(rows []*row) {
+	bw.throttle(len(rows))
 	bw.wg.Add(1)
 	atomic.AddInt64(&bw.async.writes, 1)
-	go bw.backgroundWrite(rows)
+	go bw.backgroundWrite(bw.wg, rows)
 }
 
 // writeData initiates writes to Spanner until either:
@@ -309,8 +593,12 @@ func (bw *BatchWriter) startWrite(rows []*row) {
 // b) we've hit writeLimit and we're under bytesLimit.
 // It will block and re-try till either (a) or (b) holds.
 func (bw *BatchWriter) writeData() {
-	for bw.rCount > countThreshold || bw.rBytes > byteThreshold {
-		if atomic.LoadInt64(&bw.async.writes) < bw.writeLimit {
+	for {
+		countLimit, bytesLimit, rowLimit := bw.batchLimits()
+		if bw.rCount <= countLimit && bw.rBytes <= bytesLimit && int64(len(bw.rows)) <= rowLimit {
+			return
+		}
+		if atomic.LoadInt64(&bw.async.writes) < atomic.LoadInt64(&bw.async.writeLimit) {
 			m, count, bytes := bw.getBatch()
 			if bw.verbose {
 				fmt.Printf("Starting write of %d rows to Spanner (%d bytes, %d mutations) [%d in progress]\n",
@@ -363,6 +651,29 @@ func GetBatchWriterWithConfig(ctx context.Context, spannerClient spannerclient.S
 		atomic.AddInt64(&rows, int64(len(m)))
 		return nil
 	}
+	config.WriteMode = WriteMode(conv.Audit.WriteMode)
+	config.DeadLetter = conv.Audit.DeadLetter
+	config.ColumnType = ColumnTypes(conv)
+	config.Adaptive = conv.Audit.AdaptiveWrites
+	config.RowsPerSecond = conv.Audit.MaxRowsPerSecond
+	if conv.Audit.BatchWrite {
+		config.BatchWrite = func(groups [][]*sp.Mutation) []error {
+			errs := MutationGroupErrors(spannerClient.BatchWrite(ctx, MutationGroups(groups)), len(groups))
+			for i, err := range errs {
+				if err == nil {
+					atomic.AddInt64(&rows, int64(len(groups[i])))
+				}
+			}
+			return errs
+		}
+		config.ParentTable = InterleaveParents(conv)
+	}
+	config.KeyColumns = PrimaryKeyColumns(conv)
+	if conv.Audit.CountUpdatedRows {
+		config.CountExisting = func(table string, keyCols []string, keys []sp.Key) (int64, error) {
+			return CountRows(spannerClient.Single().Read(ctx, table, sp.KeySetFromKeys(keys...), keyCols))
+		}
+	}
 	batchWriter := NewBatchWriter(config)
 	conv.SetDataMode()
 	conv.SetDataSink(
@@ -374,3 +685,52 @@ func GetBatchWriterWithConfig(ctx context.Context, spannerClient spannerclient.S
 	}
 	return batchWriter
 }
+
+// PrimaryKeyColumns returns a function that looks up the primary key
+// columns of a Spanner table in conv, for use as BatchWriterConfig.KeyColumns.
+func PrimaryKeyColumns(conv *internal.Conv) func(table string) []string {
+	return func(table string) []string {
+		tableId, err := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, table)
+		if err != nil {
+			return nil
+		}
+		var cols []string
+		for _, k := range conv.SpSchema[tableId].PrimaryKeys {
+			cols = append(cols, conv.SpSchema[tableId].ColDefs[k.ColId].Name)
+		}
+		return cols
+	}
+}
+
+// ColumnTypes returns a function that looks up the type of a column of a
+// Spanner table in conv, for use as BatchWriterConfig.ColumnType.
+func ColumnTypes(conv *internal.Conv) func(table, col string) ddl.Type {
+	return func(table, col string) ddl.Type {
+		tableId, err := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, table)
+		if err != nil {
+			return ddl.Type{}
+		}
+		for _, cd := range conv.SpSchema[tableId].ColDefs {
+			if cd.Name == col {
+				return cd.T
+			}
+		}
+		return ddl.Type{}
+	}
+}
+
+// CountRows returns the number of rows returned by iter.
+func CountRows(iter spannerclient.RowIterator) (int64, error) {
+	defer iter.Stop()
+	n := int64(0)
+	for {
+		_, err := iter.Next()
+		if err == iterator.Done {
+			return n, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		n++
+	}
+}
@@ -15,6 +15,7 @@
 package writer
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -29,6 +30,7 @@ import (
 
 	sp "cloud.google.com/go/spanner"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 )
@@ -102,6 +104,58 @@ func TestFlush(t *testing.T) {
 	}
 }
 
+// TestConcurrentFlush checks that Flush, called while other go routines
+// add rows, waits for the writes of the rows added before it.
+func TestConcurrentFlush(t *testing.T) {
+	var lock sync.Mutex
+	written := make(map[string]bool)
+	bw := NewBatchWriter(BatchWriterConfig{
+		BytesLimit: 100 << 20,
+		WriteLimit: 10,
+		RetryLimit: 1000,
+		Write: func(m []*sp.Mutation) error {
+			time.Sleep(time.Millisecond) // Mimic a Spanner write.
+			lock.Lock()
+			defer lock.Unlock()
+			for _, x := range m {
+				written[fmt.Sprintf("%v", *x)] = true
+			}
+			return nil
+		},
+	})
+	isWritten := func(id string) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		for s := range written {
+			if strings.Contains(s, "<"+id+">") {
+				return true
+			}
+		}
+		return false
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for f := 0; f < 5; f++ {
+				var ids []string
+				for r := 0; r < 20; r++ {
+					id := fmt.Sprintf("%d-%d-%d", g, f, r)
+					ids = append(ids, id)
+					bw.AddRow("t", []string{"id"}, []interface{}{"<" + id + ">"})
+				}
+				bw.Flush()
+				for _, id := range ids {
+					assert.True(t, isWritten(id), id)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 4*5*20, len(written))
+}
+
 func TestDroppedRowsByTable(t *testing.T) {
 	bw := NewBatchWriter(BatchWriterConfig{})
 	bw.async.lock.Lock()
@@ -114,6 +168,155 @@ func TestDroppedRowsByTable(t *testing.T) {
 	assert.Equal(t, int64(42), m["test2"])
 }
 
+func TestParseWriteMode(t *testing.T) {
+	for _, s := range []string{"", "insert"} {
+		m, err := ParseWriteMode(s)
+		assert.Nil(t, err)
+		assert.Equal(t, WriteModeInsert, m)
+	}
+	m, err := ParseWriteMode("insert-or-update")
+	assert.Nil(t, err)
+	assert.Equal(t, WriteModeInsertOrUpdate, m)
+	m, err = ParseWriteMode("replace")
+	assert.Nil(t, err)
+	assert.Equal(t, WriteModeReplace, m)
+	_, err = ParseWriteMode("upsert")
+	assert.NotNil(t, err)
+}
+
+func TestWriteModeUpdatedRows(t *testing.T) {
+	tests := []struct {
+		mode     WriteMode
+		mutation func(string, []string, []interface{}) *sp.Mutation
+		updated  map[string]int64
+	}{
+		{WriteModeInsert, sp.Insert, map[string]int64{}},
+		{WriteModeInsertOrUpdate, sp.InsertOrUpdate, map[string]int64{"t1": 1, "t2": 0}},
+		{WriteModeReplace, sp.Replace, map[string]int64{"t1": 1, "t2": 0}},
+	}
+	rows := []*row{
+		{"t1", []string{"id", "v"}, []interface{}{int64(1), "a"}},
+		{"t1", []string{"id", "v"}, []interface{}{int64(2), "b"}},
+		{"t2", []string{"id"}, []interface{}{int64(1)}},
+	}
+	for _, tc := range tests {
+		var written []*sp.Mutation
+		bw := NewBatchWriter(BatchWriterConfig{
+			BytesLimit: 100 << 20,
+			WriteLimit: 1,
+			RetryLimit: 10,
+			WriteMode:  tc.mode,
+			Write: func(m []*sp.Mutation) error {
+				written = append(written, m...)
+				return nil
+			},
+			KeyColumns: func(table string) []string { return []string{"id"} },
+			CountExisting: func(table string, keyCols []string, keys []sp.Key) (int64, error) {
+				assert.Equal(t, []string{"id"}, keyCols)
+				// Only row 1 of t1 exists.
+				var n int64
+				for _, k := range keys {
+					if table == "t1" && k[0] == int64(1) {
+						n++
+					}
+				}
+				return n, nil
+			},
+		})
+		for _, r := range rows {
+			bw.AddRow(r.table, r.cols, r.vals)
+		}
+		bw.Flush()
+		var expected []*sp.Mutation
+		for _, r := range rows {
+			expected = append(expected, tc.mutation(r.table, r.cols, r.vals))
+		}
+		equalMutations(t, expected, written, string(tc.mode))
+		assert.Equal(t, tc.updated, bw.UpdatedRowsByTable(), string(tc.mode))
+	}
+}
+
+func TestCountExistingOnSplit(t *testing.T) {
+	// Row 1 of t1 exists and row 3 of t1 is bad, so the batch is split and
+	// written again. t2 had no existing rows in the batch, so it isn't read
+	// again for the split batches.
+	reads := make(map[string]int)
+	bw := NewBatchWriter(BatchWriterConfig{
+		BytesLimit: 100 << 20,
+		WriteLimit: 1,
+		RetryLimit: 10,
+		WriteMode:  WriteModeInsertOrUpdate,
+		Write: func(m []*sp.Mutation) error {
+			for _, x := range m {
+				if strings.Contains(fmt.Sprintf("%v", x), "bad") {
+					return errors.New("bad data")
+				}
+			}
+			return nil
+		},
+		KeyColumns: func(table string) []string { return []string{"id"} },
+		CountExisting: func(table string, keyCols []string, keys []sp.Key) (int64, error) {
+			reads[table]++
+			var n int64
+			for _, k := range keys {
+				if table == "t1" && k[0] == int64(1) {
+					n++
+				}
+			}
+			return n, nil
+		},
+	})
+	bw.AddRow("t1", []string{"id", "v"}, []interface{}{int64(1), "a"})
+	bw.AddRow("t1", []string{"id", "v"}, []interface{}{int64(3), "bad"})
+	bw.AddRow("t2", []string{"id"}, []interface{}{int64(1)})
+	bw.Flush()
+	assert.Equal(t, map[string]int64{"t1": 1, "t2": 0}, bw.UpdatedRowsByTable())
+	assert.Equal(t, map[string]int{"t1": 3, "t2": 1}, reads)
+}
+
+func TestDeadLetter(t *testing.T) {
+	var buf bytes.Buffer
+	bw := NewBatchWriter(BatchWriterConfig{
+		BytesLimit: 100 << 20,
+		WriteLimit: 1,
+		RetryLimit: 10,
+		Write: func(m []*sp.Mutation) error {
+			for _, x := range m {
+				if strings.Contains(fmt.Sprintf("%v", x), "bad") {
+					return errors.New("bad data")
+				}
+			}
+			return nil
+		},
+		DeadLetter: &buf,
+	})
+	bw.AddRow("t", []string{"id", "v"}, []interface{}{int64(1), "good"})
+	bw.AddRow("t", []string{"id", "v"}, []interface{}{int64(2), "bad"})
+	bw.Flush()
+	assert.Equal(t, map[string]int64{"t": 1}, bw.DroppedRowsByTable())
+	assert.Nil(t, bw.DeadLetterError())
+	assert.Equal(t, `{"table":"t","columns":["id","v"],"types":["INT64","STRING"],"values":[2,"bad"],"error":"bad data"}`+"\n", buf.String())
+}
+
+func TestColumnTypes(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "orders", Id: "t1", ColDefs: map[string]ddl.ColumnDef{
+			"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.UUID}},
+			"c2": {Name: "waits", Id: "c2", T: ddl.Type{Name: ddl.Interval, IsArray: true}},
+		}},
+		"t2": {Name: "items", SchemaName: "sales", Id: "t2", ColDefs: map[string]ddl.ColumnDef{
+			"c3": {Name: "id", Id: "c3", T: ddl.Type{Name: ddl.Int64}},
+		}},
+	}
+	columnType := ColumnTypes(conv)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, columnType("orders", "id"))
+	assert.Equal(t, ddl.Type{Name: ddl.Interval, IsArray: true}, columnType("orders", "waits"))
+	assert.Equal(t, ddl.Type{Name: ddl.Int64}, columnType("sales.items", "id"))
+	assert.Equal(t, ddl.Type{}, columnType("orders", "unknown"))
+	assert.Equal(t, ddl.Type{}, columnType("unknown", "id"))
+}
+
 func TestSampleBadRows(t *testing.T) {
 	bw := NewBatchWriter(BatchWriterConfig{})
 	bw.async.lock.Lock()
@@ -250,6 +453,11 @@ func Test_getBatchWriterWithConfig(t *testing.T) {
 	if bw == nil {
 		t.Errorf("getBatchWriterWithConfig() returned nil")
 	}
+	// Existing rows are only read when updated rows are counted.
+	assert.Nil(t, bw.countRows)
+	conv.Audit.CountUpdatedRows = true
+	bw = GetBatchWriterWithConfig(context.Background(), spannerClient, conv)
+	assert.NotNil(t, bw.countRows)
 }
 
 func getSpannerClientMock() spannerclient.SpannerClientMock {
@@ -0,0 +1,454 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// DeadLetterRow is a row that BatchWriter dropped, as written to a
+// dead-letter file. Each line of a dead-letter file is a DeadLetterRow
+// encoded as JSON.
+//
+// Values are encoded as JSON according to the Spanner type recorded for
+// them in Types, so that rows can be edited and written to Spanner again
+// with the same Go types that the data conversion produced:
+//   - BOOL, INT64, FLOAT32 and FLOAT64 values are JSON booleans and
+//     numbers. NaN and infinite floats are the strings "NaN", "Infinity"
+//     and "-Infinity".
+//   - STRING, NUMERIC and PG_NUMERIC values are JSON strings.
+//   - UUID and INTERVAL values are JSON strings, as written by the data
+//     conversion: UUIDs in their canonical form and intervals in ISO 8601
+//     form. They are only told apart from STRING values by the type of
+//     their column (see BatchWriterConfig.ColumnType).
+//   - BYTES values are base64-encoded strings.
+//   - DATE values are strings of the form YYYY-MM-DD and TIMESTAMP values
+//     are RFC 3339 strings.
+//   - ARRAY<T> values are JSON arrays of values of type T, e.g. FLOAT32
+//     vectors are ARRAY<FLOAT32> and PostgreSQL numeric arrays are
+//     ARRAY<PG_NUMERIC>.
+//
+// NULL values, including NULL array elements, are JSON nulls.
+type DeadLetterRow struct {
+	Table   string        `json:"table"`
+	Columns []string      `json:"columns"`
+	Types   []string      `json:"types"`
+	Values  []interface{} `json:"values"`
+	Error   string        `json:"error"`
+}
+
+// newDeadLetterRow returns the dead-letter record of row r, which failed to
+// be written with error err. columnType, if set, returns the types of the
+// columns of r.
+func newDeadLetterRow(r *row, err error, columnType func(table, col string) ddl.Type) DeadLetterRow {
+	d := DeadLetterRow{
+		Table:   r.table,
+		Columns: r.cols,
+		Types:   make([]string, len(r.vals)),
+		Values:  make([]interface{}, len(r.vals)),
+		Error:   err.Error(),
+	}
+	for i, v := range r.vals {
+		d.Types[i], d.Values[i] = encodeDeadLetterValue(v)
+		if columnType != nil && i < len(r.cols) {
+			d.Types[i] = stringColumnType(d.Types[i], columnType(r.table, r.cols[i]))
+		}
+	}
+	return d
+}
+
+// stringColumnType returns the type recorded for a value encoded with type
+// t in a column of type colType. UUID and INTERVAL values are passed to
+// BatchWriter as strings, so they are recorded with the type of their
+// column instead of STRING.
+func stringColumnType(t string, colType ddl.Type) string {
+	if colType.Name != ddl.UUID && colType.Name != ddl.Interval {
+		return t
+	}
+	switch {
+	case t == "STRING" && !colType.IsArray:
+		return colType.Name
+	case t == "ARRAY<STRING>" && colType.IsArray:
+		return "ARRAY<" + colType.Name + ">"
+	}
+	return t
+}
+
+// DeadLetterColumnTypes reads a dead-letter file written by BatchWriter and
+// returns a function, for use as BatchWriterConfig.ColumnType, that returns
+// the types of its UUID and INTERVAL columns. This keeps the types of rows
+// that are dropped again when the file is replayed.
+func DeadLetterColumnTypes(r io.Reader) (func(table, col string) ddl.Type, error) {
+	types := make(map[string]map[string]ddl.Type)
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("can't read dead-letter file: %w", err)
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			var d DeadLetterRow
+			if jsonErr := json.Unmarshal(line, &d); jsonErr != nil {
+				return nil, fmt.Errorf("line %d: can't parse row: %w", lineNum, jsonErr)
+			}
+			for i, t := range d.Types {
+				colType := ddl.Type{Name: t}
+				if strings.HasPrefix(t, "ARRAY<") && strings.HasSuffix(t, ">") {
+					colType = ddl.Type{Name: strings.TrimSuffix(strings.TrimPrefix(t, "ARRAY<"), ">"), IsArray: true}
+				}
+				if i >= len(d.Columns) || (colType.Name != ddl.UUID && colType.Name != ddl.Interval) {
+					continue
+				}
+				if types[d.Table] == nil {
+					types[d.Table] = make(map[string]ddl.Type)
+				}
+				types[d.Table][d.Columns[i]] = colType
+			}
+		}
+		if err == io.EOF {
+			return func(table, col string) ddl.Type { return types[table][col] }, nil
+		}
+	}
+}
+
+// ReadDeadLetters reads a dead-letter file written by BatchWriter and calls
+// f with the table, columns and values of each row, decoded to the Go types
+// accepted by the Spanner client. Blank lines are skipped. It stops at the
+// first row that can't be decoded.
+func ReadDeadLetters(r io.Reader, f func(table string, cols []string, vals []interface{})) error {
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("can't read dead-letter file: %w", err)
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			table, cols, vals, decodeErr := decodeDeadLetterRow(line)
+			if decodeErr != nil {
+				return fmt.Errorf("line %d: %w", lineNum, decodeErr)
+			}
+			f(table, cols, vals)
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func decodeDeadLetterRow(line []byte) (string, []string, []interface{}, error) {
+	var d DeadLetterRow
+	dec := json.NewDecoder(bytes.NewReader(line))
+	// Keep INT64 values above 2^53 exact.
+	dec.UseNumber()
+	if err := dec.Decode(&d); err != nil {
+		return "", nil, nil, fmt.Errorf("can't parse row: %w", err)
+	}
+	if d.Table == "" {
+		return "", nil, nil, fmt.Errorf("row has no table")
+	}
+	if len(d.Columns) != len(d.Values) || len(d.Columns) != len(d.Types) {
+		return "", nil, nil, fmt.Errorf("columns, types and values of row don't all have the same lengths: len(columns)=%d, len(types)=%d, len(values)=%d", len(d.Columns), len(d.Types), len(d.Values))
+	}
+	vals := make([]interface{}, len(d.Values))
+	for i, v := range d.Values {
+		x, err := decodeDeadLetterValue(d.Types[i], v)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("column %s: %w", d.Columns[i], err)
+		}
+		vals[i] = x
+	}
+	return d.Table, d.Columns, vals, nil
+}
+
+// encodeDeadLetterValue returns the Spanner type name and the JSON encoding
+// of a value passed to BatchWriter.AddRow. Values of types that the data
+// conversion never produces are recorded with an empty type, and can't be
+// replayed.
+func encodeDeadLetterValue(v interface{}) (string, interface{}) {
+	if t, x, ok := encodeDeadLetterScalar(v); ok {
+		return t, x
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		elemType, _, ok := encodeDeadLetterScalar(reflect.Zero(rv.Type().Elem()).Interface())
+		if ok && elemType != "" {
+			elems := make([]interface{}, rv.Len())
+			for i := range elems {
+				_, elems[i], _ = encodeDeadLetterScalar(rv.Index(i).Interface())
+			}
+			return "ARRAY<" + elemType + ">", elems
+		}
+	}
+	return "", fmt.Sprintf("%v", v)
+}
+
+func encodeDeadLetterScalar(v interface{}) (string, interface{}, bool) {
+	switch x := v.(type) {
+	case nil:
+		return "", nil, true
+	case bool:
+		return "BOOL", x, true
+	case sp.NullBool:
+		return "BOOL", nullable(x.Valid, x.Bool), true
+	case int64:
+		return "INT64", x, true
+	case int:
+		return "INT64", int64(x), true
+	case int32:
+		return "INT64", int64(x), true
+	case sp.NullInt64:
+		return "INT64", nullable(x.Valid, x.Int64), true
+	case float32:
+		return "FLOAT32", encodeFloat(float64(x)), true
+	case sp.NullFloat32:
+		return "FLOAT32", nullable(x.Valid, encodeFloat(float64(x.Float32))), true
+	case float64:
+		return "FLOAT64", encodeFloat(x), true
+	case sp.NullFloat64:
+		return "FLOAT64", nullable(x.Valid, encodeFloat(x.Float64)), true
+	case string:
+		return "STRING", x, true
+	case sp.NullString:
+		return "STRING", nullable(x.Valid, x.StringVal), true
+	case []byte:
+		if x == nil {
+			return "BYTES", nil, true
+		}
+		return "BYTES", base64.StdEncoding.EncodeToString(x), true
+	case civil.Date:
+		return "DATE", x.String(), true
+	case sp.NullDate:
+		return "DATE", nullable(x.Valid, x.Date.String()), true
+	case time.Time:
+		return "TIMESTAMP", x.Format(time.RFC3339Nano), true
+	case sp.NullTime:
+		return "TIMESTAMP", nullable(x.Valid, x.Time.Format(time.RFC3339Nano)), true
+	case *big.Rat:
+		if x == nil {
+			return "NUMERIC", nil, true
+		}
+		return "NUMERIC", sp.NumericString(x), true
+	case big.Rat:
+		return "NUMERIC", sp.NumericString(&x), true
+	case sp.NullNumeric:
+		return "NUMERIC", nullable(x.Valid, sp.NumericString(&x.Numeric)), true
+	case sp.PGNumeric:
+		return "PG_NUMERIC", nullable(x.Valid, x.Numeric), true
+	}
+	return "", nil, false
+}
+
+func nullable(valid bool, v interface{}) interface{} {
+	if !valid {
+		return nil
+	}
+	return v
+}
+
+// encodeFloat returns f, or its string form if JSON can't represent it.
+func encodeFloat(f float64) interface{} {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return f
+}
+
+// decodeDeadLetterValue decodes a value of a dead-letter row. Scalars are
+// decoded to plain Go types, with nil for NULL, and arrays to slices of the
+// Spanner client's Null types.
+func decodeDeadLetterValue(t string, v interface{}) (interface{}, error) {
+	if strings.HasPrefix(t, "ARRAY<") && strings.HasSuffix(t, ">") {
+		return decodeDeadLetterArray(strings.TrimSuffix(strings.TrimPrefix(t, "ARRAY<"), ">"), v)
+	}
+	if v == nil {
+		return nil, nil
+	}
+	switch t {
+	case "BOOL":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "INT64":
+		if n, ok := v.(json.Number); ok {
+			return n.Int64()
+		}
+	case "FLOAT32":
+		f, err := decodeFloat(v, 32)
+		return float32(f), err
+	case "FLOAT64":
+		return decodeFloat(v, 64)
+	case "STRING", "UUID", "INTERVAL":
+		// The Spanner client writes UUID and INTERVAL values as strings.
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "BYTES":
+		if s, ok := v.(string); ok {
+			return base64.StdEncoding.DecodeString(s)
+		}
+	case "DATE":
+		if s, ok := v.(string); ok {
+			return civil.ParseDate(s)
+		}
+	case "TIMESTAMP":
+		if s, ok := v.(string); ok {
+			return time.Parse(time.RFC3339Nano, s)
+		}
+	case "NUMERIC":
+		if s, ok := v.(string); ok {
+			r, ok := new(big.Rat).SetString(s)
+			if !ok {
+				return nil, fmt.Errorf("can't convert %q to NUMERIC", s)
+			}
+			return r, nil
+		}
+	case "PG_NUMERIC":
+		if s, ok := v.(string); ok {
+			return sp.PGNumeric{Numeric: s, Valid: true}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %q", t)
+	}
+	return nil, fmt.Errorf("can't convert %v to %s", v, t)
+}
+
+func decodeFloat(v interface{}, bitSize int) (float64, error) {
+	switch x := v.(type) {
+	case json.Number:
+		return strconv.ParseFloat(string(x), bitSize)
+	case string:
+		// NaN, Infinity and -Infinity.
+		return strconv.ParseFloat(x, bitSize)
+	}
+	return 0, fmt.Errorf("can't convert %v to a float", v)
+}
+
+// decodeDeadLetterArray decodes an array with elements of type elemType.
+// The Spanner client does not accept []interface{} for arrays, so we build
+// a slice of the specific element type.
+func decodeDeadLetterArray(elemType string, v interface{}) (interface{}, error) {
+	var elems []interface{}
+	if v != nil {
+		var ok bool
+		if elems, ok = v.([]interface{}); !ok {
+			return nil, fmt.Errorf("can't convert %v to ARRAY<%s>", v, elemType)
+		}
+	}
+	vals := make([]interface{}, len(elems))
+	for i, e := range elems {
+		x, err := decodeDeadLetterValue(elemType, e)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = x
+	}
+	switch elemType {
+	case "BOOL":
+		r := []sp.NullBool{}
+		for _, x := range vals {
+			b, ok := x.(bool)
+			r = append(r, sp.NullBool{Bool: b, Valid: ok})
+		}
+		return r, nil
+	case "INT64":
+		r := []sp.NullInt64{}
+		for _, x := range vals {
+			n, ok := x.(int64)
+			r = append(r, sp.NullInt64{Int64: n, Valid: ok})
+		}
+		return r, nil
+	case "FLOAT32":
+		r := []sp.NullFloat32{}
+		for _, x := range vals {
+			f, ok := x.(float32)
+			r = append(r, sp.NullFloat32{Float32: f, Valid: ok})
+		}
+		return r, nil
+	case "FLOAT64":
+		r := []sp.NullFloat64{}
+		for _, x := range vals {
+			f, ok := x.(float64)
+			r = append(r, sp.NullFloat64{Float64: f, Valid: ok})
+		}
+		return r, nil
+	case "STRING", "UUID", "INTERVAL":
+		r := []sp.NullString{}
+		for _, x := range vals {
+			s, ok := x.(string)
+			r = append(r, sp.NullString{StringVal: s, Valid: ok})
+		}
+		return r, nil
+	case "BYTES":
+		r := [][]byte{}
+		for _, x := range vals {
+			b, _ := x.([]byte)
+			r = append(r, b)
+		}
+		return r, nil
+	case "DATE":
+		r := []sp.NullDate{}
+		for _, x := range vals {
+			d, ok := x.(civil.Date)
+			r = append(r, sp.NullDate{Date: d, Valid: ok})
+		}
+		return r, nil
+	case "TIMESTAMP":
+		r := []sp.NullTime{}
+		for _, x := range vals {
+			t, ok := x.(time.Time)
+			r = append(r, sp.NullTime{Time: t, Valid: ok})
+		}
+		return r, nil
+	case "NUMERIC":
+		r := []sp.NullNumeric{}
+		for _, x := range vals {
+			n, ok := x.(*big.Rat)
+			if !ok {
+				r = append(r, sp.NullNumeric{})
+				continue
+			}
+			r = append(r, sp.NullNumeric{Numeric: *n, Valid: true})
+		}
+		return r, nil
+	case "PG_NUMERIC":
+		r := []sp.PGNumeric{}
+		for _, x := range vals {
+			n, _ := x.(sp.PGNumeric)
+			r = append(r, n)
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("unsupported type ARRAY<%s>", elemType)
+}
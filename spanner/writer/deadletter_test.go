@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterRoundTrip(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 10, time.UTC)
+	date := civil.Date{Year: 2024, Month: 5, Day: 6}
+	tests := []struct {
+		name     string
+		val      interface{}
+		typ      string
+		expected interface{} // Value read back, if different from val.
+		null     bool        // Whether the value read back is NULL.
+	}{
+		{name: "bool", val: true, typ: "BOOL"},
+		{name: "int64", val: int64(9007199254740993), typ: "INT64"},
+		{name: "int", val: 7, typ: "INT64", expected: int64(7)},
+		{name: "float32", val: float32(1.5), typ: "FLOAT32"},
+		{name: "float64", val: 2.25, typ: "FLOAT64"},
+		{name: "nan", val: math.NaN(), typ: "FLOAT64"},
+		{name: "infinity", val: math.Inf(-1), typ: "FLOAT64"},
+		{name: "string", val: "abc", typ: "STRING"},
+		{name: "null string", val: sp.NullString{}, typ: "STRING", null: true},
+		{name: "bytes", val: []byte{0, 1, 255}, typ: "BYTES"},
+		{name: "date", val: date, typ: "DATE"},
+		{name: "timestamp", val: ts, typ: "TIMESTAMP"},
+		{name: "numeric", val: big.NewRat(5, 4), typ: "NUMERIC"},
+		{name: "pg numeric", val: sp.PGNumeric{Numeric: "NaN", Valid: true}, typ: "PG_NUMERIC"},
+		{name: "int64 array", val: []sp.NullInt64{{Int64: 1, Valid: true}, {}}, typ: "ARRAY<INT64>"},
+		{name: "empty string array", val: []sp.NullString{}, typ: "ARRAY<STRING>"},
+		{name: "bytes array", val: [][]byte{{1}, nil}, typ: "ARRAY<BYTES>"},
+		{name: "numeric array", val: []sp.NullNumeric{{Numeric: *big.NewRat(1, 2), Valid: true}}, typ: "ARRAY<NUMERIC>"},
+		{name: "timestamp array", val: []sp.NullTime{{Time: ts, Valid: true}}, typ: "ARRAY<TIMESTAMP>"},
+		{name: "float32 vector", val: []float32{1.5, float32(math.Inf(1))}, typ: "ARRAY<FLOAT32>", expected: []sp.NullFloat32{{Float32: 1.5, Valid: true}, {Float32: float32(math.Inf(1)), Valid: true}}},
+		{name: "float32 array", val: []sp.NullFloat32{{Float32: 0.25, Valid: true}, {}}, typ: "ARRAY<FLOAT32>"},
+		{name: "pg numeric array", val: []sp.PGNumeric{{Numeric: "1.25", Valid: true}, {}}, typ: "ARRAY<PG_NUMERIC>"},
+	}
+	for _, tc := range tests {
+		var buf bytes.Buffer
+		bw := NewBatchWriter(BatchWriterConfig{DeadLetter: &buf})
+		bw.writeDeadLetter(&row{"t", []string{"c"}, []interface{}{tc.val}}, errors.New("bad row"))
+
+		var d DeadLetterRow
+		assert.Nil(t, json.Unmarshal(buf.Bytes(), &d), tc.name)
+		assert.Equal(t, "t", d.Table, tc.name)
+		assert.Equal(t, []string{"c"}, d.Columns, tc.name)
+		assert.Equal(t, []string{tc.typ}, d.Types, tc.name)
+		assert.Equal(t, "bad row", d.Error, tc.name)
+
+		var got []interface{}
+		err := ReadDeadLetters(&buf, func(table string, cols []string, vals []interface{}) {
+			assert.Equal(t, "t", table, tc.name)
+			got = vals
+		})
+		assert.Nil(t, err, tc.name)
+		expected := tc.expected
+		if expected == nil && !tc.null {
+			expected = tc.val
+		}
+		if f, ok := expected.(float64); ok && math.IsNaN(f) {
+			assert.True(t, math.IsNaN(got[0].(float64)), tc.name)
+			continue
+		}
+		assert.Equal(t, []interface{}{expected}, got, tc.name)
+	}
+}
+
+func TestDeadLetterUUIDAndInterval(t *testing.T) {
+	columnTypes := map[string]ddl.Type{
+		"id":       {Name: ddl.UUID},
+		"ids":      {Name: ddl.UUID, IsArray: true},
+		"duration": {Name: ddl.Interval},
+		"name":     {Name: ddl.String, Len: ddl.MaxLength},
+	}
+	var buf bytes.Buffer
+	bw := NewBatchWriter(BatchWriterConfig{
+		DeadLetter: &buf,
+		ColumnType: func(table, col string) ddl.Type { return columnTypes[col] },
+	})
+	cols := []string{"id", "ids", "duration", "name", "other"}
+	vals := []interface{}{
+		"0b4c8ed5-6bb0-4a7a-9d6c-3c1d5e7f8a9b",
+		[]sp.NullString{{StringVal: "5f2b0c1e-8a4d-4e6f-b1c3-d5e7f9a1b3c5", Valid: true}, {}},
+		"P1Y2M3DT4H5M6.5S",
+		"abc",
+		"def",
+	}
+	bw.writeDeadLetter(&row{"t", cols, vals}, errors.New("bad row"))
+
+	var d DeadLetterRow
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &d))
+	assert.Equal(t, []string{"UUID", "ARRAY<UUID>", "INTERVAL", "STRING", "STRING"}, d.Types)
+
+	columnType, err := DeadLetterColumnTypes(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, ddl.Type{Name: ddl.UUID}, columnType("t", "id"))
+	assert.Equal(t, ddl.Type{Name: ddl.UUID, IsArray: true}, columnType("t", "ids"))
+	assert.Equal(t, ddl.Type{Name: ddl.Interval}, columnType("t", "duration"))
+	assert.Equal(t, ddl.Type{}, columnType("t", "name"))
+	assert.Equal(t, ddl.Type{}, columnType("u", "id"))
+
+	var got []interface{}
+	err = ReadDeadLetters(&buf, func(table string, cols []string, v []interface{}) { got = v })
+	assert.Nil(t, err)
+	assert.Equal(t, vals, got)
+}
+
+func TestReadDeadLetters(t *testing.T) {
+	in := `{"table":"t","columns":["a","b"],"types":["INT64","STRING"],"values":[1,null],"error":"x"}
+
+{"table":"u","columns":["c"],"types":["ARRAY<BOOL>"],"values":[[true,null]],"error":"y"}
+`
+	var tables []string
+	var vals [][]interface{}
+	err := ReadDeadLetters(strings.NewReader(in), func(table string, cols []string, v []interface{}) {
+		tables = append(tables, table)
+		vals = append(vals, v)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"t", "u"}, tables)
+	assert.Equal(t, [][]interface{}{{int64(1), nil}, {[]sp.NullBool{{Bool: true, Valid: true}, {}}}}, vals)
+
+	for _, bad := range []string{
+		`not json`,
+		`{"columns":["a"],"types":["INT64"],"values":[1]}`,
+		`{"table":"t","columns":["a","b"],"types":["INT64"],"values":[1]}`,
+		`{"table":"t","columns":["a"],"types":["INT64"],"values":["one"]}`,
+		`{"table":"t","columns":["a"],"types":["DATE"],"values":["2024-13-01"]}`,
+		`{"table":"t","columns":["a"],"types":[""],"values":["{1 2}"]}`,
+	} {
+		err := ReadDeadLetters(strings.NewReader("\n"+bad), func(string, []string, []interface{}) {})
+		assert.ErrorContains(t, err, "line 2", bad)
+	}
+}
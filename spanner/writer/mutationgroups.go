@@ -0,0 +1,319 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	sp "cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// doBatchWriteAndHandleErrors writes rows as mutation groups using
+// bw.batchWrite. Groups that fail are dropped, except that groups that fail
+// because the database is overloaded are written again until the retry
+// limit is hit. Existing rows are only counted on the first attempt, so
+// that retried rows aren't counted twice.
+// Note: doBatchWriteAndHandleErrors must be thread-safe because it is run
+// inside a go routine.
+func (bw *BatchWriter) doBatchWriteAndHandleErrors(rows []*row, countExisting bool) {
+	groups := bw.mutationGroups(rows)
+	mgs := make([][]*sp.Mutation, len(groups))
+	for i, g := range groups {
+		for _, x := range g {
+			mgs[i] = append(mgs[i], bw.writeMode.mutation(x.table, x.cols, x.vals))
+		}
+	}
+	var existing map[string]int64
+	if countExisting {
+		existing = bw.countExisting(rows, nil)
+	}
+	start := time.Now()
+	errs := bw.batchWrite(mgs)
+	if len(errs) != len(mgs) {
+		err := fmt.Errorf("BatchWrite returned %d results for %d mutation groups", len(errs), len(mgs))
+		errs = make([]error, len(mgs))
+		for i := range errs {
+			errs[i] = err
+		}
+	}
+	if bw.verbose {
+		fmt.Printf("Wrote %d mutation groups to Spanner in %v\n", len(mgs), time.Since(start))
+	}
+	logBatchWrite(len(mgs), errs)
+	var overloadErr error
+	var retryRows []*row
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !isOverloadError(err) {
+			if bw.missingParent(groups[i], err) {
+				// The parent rows may be in a batch that is still being
+				// written, so the group is written again by Flush.
+				bw.errorStats(groups[i], err, true)
+				bw.async.lock.Lock()
+				bw.async.deferred = append(bw.async.deferred, deferredGroup{groups[i], err})
+				bw.async.lock.Unlock()
+				continue
+			}
+			bw.errorStats(groups[i], err, false)
+			continue
+		}
+		overloadErr = err
+		retry := atomic.LoadInt64(&bw.async.retries) < bw.retryLimit
+		bw.errorStats(groups[i], err, retry)
+		if retry {
+			retryRows = append(retryRows, groups[i]...)
+		}
+	}
+	bw.adapt(time.Since(start), overloadErr)
+	if len(existing) > 0 {
+		// Existing rows of groups that failed are counted too, since we
+		// only know how many rows of each table exist.
+		bw.async.lock.Lock()
+		for t, n := range existing {
+			bw.async.updatedRows[t] += n
+		}
+		bw.async.lock.Unlock()
+	}
+	if len(retryRows) > 0 {
+		atomic.AddInt64(&bw.async.retries, 1)
+		bw.doBatchWriteAndHandleErrors(retryRows, false)
+	}
+}
+
+// deferredGroup is a mutation group that failed with err because its
+// parent rows weren't written yet.
+type deferredGroup struct {
+	rows []*row
+	err  error
+}
+
+// missingParent reports whether group failed with err because the rows it
+// is interleaved in don't exist. Rows of a group are ordered parents first,
+// so this is the case if its first row is interleaved in another table.
+func (bw *BatchWriter) missingParent(group []*row, err error) bool {
+	return sp.ErrCode(err) == codes.NotFound && bw.parentTable != nil && bw.parentTable(group[0].table) != ""
+}
+
+// writeDeferred writes the mutation groups whose parent rows were missing
+// again, once the writes started before Flush are complete. Groups are
+// written until none are left, or until none of them can be written, in
+// which case they are dropped. Groups deferred meanwhile by writes that
+// are still in progress are left for a later Flush.
+func (bw *BatchWriter) writeDeferred() {
+	for {
+		bw.async.lock.Lock()
+		deferred := bw.async.deferred
+		bw.async.deferred = nil
+		bw.async.lock.Unlock()
+		if len(deferred) == 0 {
+			return
+		}
+		var rows []*row
+		retried := make(map[*row]bool)
+		for _, g := range deferred {
+			rows = append(rows, g.rows...)
+			for _, r := range g.rows {
+				retried[r] = true
+			}
+		}
+		bw.doBatchWriteAndHandleErrors(rows, false)
+		bw.async.lock.Lock()
+		var failed, others []deferredGroup
+		n := 0
+		for _, g := range bw.async.deferred {
+			if retried[g.rows[0]] {
+				failed = append(failed, g)
+				n += len(g.rows)
+			} else {
+				others = append(others, g)
+			}
+		}
+		if len(failed) == 0 {
+			bw.async.lock.Unlock()
+			return
+		}
+		if n < len(rows) {
+			bw.async.lock.Unlock()
+			continue
+		}
+		bw.async.deferred = others
+		bw.async.lock.Unlock()
+		for _, g := range failed {
+			bw.errorStats(g.rows, g.err, false)
+		}
+		return
+	}
+}
+
+// mutationGroups splits rows into mutation groups. A row of an interleaved
+// table is grouped with the rows of its ancestor tables that have the same
+// key, so that a child row is applied together with its parent. Rows of a
+// group are ordered parents first, and rows that have no ancestors or
+// descendants in rows form a group of their own.
+func (bw *BatchWriter) mutationGroups(rows []*row) [][]*row {
+	if bw.parentTable == nil || bw.keyColumns == nil {
+		groups := make([][]*row, len(rows))
+		for i, r := range rows {
+			groups[i] = []*row{r}
+		}
+		return groups
+	}
+	// Map each table to its root table (the ancestor that isn't
+	// interleaved) and its depth below it.
+	type ancestry struct {
+		root  string
+		depth int
+	}
+	tables := make(map[string]ancestry)
+	keyCols := make(map[string][]string)
+	var groups [][]*row
+	var depths [][]int
+	groupOf := make(map[string]int)
+	for _, r := range rows {
+		a, ok := tables[r.table]
+		if !ok {
+			a = ancestry{root: r.table}
+			// Guard against cycles, which a valid schema can't have.
+			for p := bw.parentTable(r.table); p != "" && a.depth < 16; p = bw.parentTable(p) {
+				a.root = p
+				a.depth++
+			}
+			tables[r.table] = a
+		}
+		if _, ok := keyCols[a.root]; !ok {
+			keyCols[a.root] = bw.keyColumns(a.root)
+		}
+		// Interleaved tables share the primary key columns of their root
+		// table, so rows with the same values for them belong together.
+		k := a.root
+		for _, kc := range keyCols[a.root] {
+			var v interface{}
+			for i, c := range r.cols {
+				if c == kc {
+					v = r.vals[i]
+					break
+				}
+			}
+			k += fmt.Sprintf("\x00%T\x00%v", v, v)
+		}
+		if len(keyCols[a.root]) == 0 {
+			// Without a key, rows can't be matched up.
+			k = fmt.Sprintf("%p", r)
+		}
+		i, ok := groupOf[k]
+		if !ok {
+			i = len(groups)
+			groupOf[k] = i
+			groups = append(groups, nil)
+			depths = append(depths, nil)
+		}
+		groups[i] = append(groups[i], r)
+		depths[i] = append(depths[i], a.depth)
+	}
+	for i := range groups {
+		sort.Stable(byDepth{groups[i], depths[i]})
+	}
+	return groups
+}
+
+// byDepth sorts the rows of a mutation group by their depth in the
+// interleaving hierarchy.
+type byDepth struct {
+	rows   []*row
+	depths []int
+}
+
+func (b byDepth) Len() int           { return len(b.rows) }
+func (b byDepth) Less(i, j int) bool { return b.depths[i] < b.depths[j] }
+func (b byDepth) Swap(i, j int) {
+	b.rows[i], b.rows[j] = b.rows[j], b.rows[i]
+	b.depths[i], b.depths[j] = b.depths[j], b.depths[i]
+}
+
+// MutationGroups converts groups of mutations to the mutation groups of
+// a BatchWrite call.
+func MutationGroups(groups [][]*sp.Mutation) []*sp.MutationGroup {
+	mgs := make([]*sp.MutationGroup, len(groups))
+	for i, g := range groups {
+		mgs[i] = &sp.MutationGroup{Mutations: g}
+	}
+	return mgs
+}
+
+// MutationGroupErrors returns the error of each of the n mutation groups
+// of a BatchWrite call, given the iterator over its responses. Groups that
+// no response reports on get the error that ended the responses, or an
+// error saying that they weren't reported on.
+func MutationGroupErrors(iter spannerclient.BatchWriteIterator, n int) []error {
+	errs := make([]error, n)
+	reported := make([]bool, n)
+	err := iter.Do(func(r *sppb.BatchWriteResponse) error {
+		groupErr := status.ErrorProto(r.GetStatus())
+		for _, i := range r.GetIndexes() {
+			if int(i) < n {
+				errs[i] = groupErr
+				reported[i] = true
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		err = fmt.Errorf("BatchWrite didn't report whether the mutation group was applied")
+	}
+	for i := range errs {
+		if !reported[i] {
+			errs[i] = err
+		}
+	}
+	return errs
+}
+
+// InterleaveParents returns a function that looks up the table that a
+// Spanner table is interleaved in, for use as BatchWriterConfig.ParentTable.
+func InterleaveParents(conv *internal.Conv) func(table string) string {
+	return func(table string) string {
+		tableId, err := internal.GetTableIdFromSpQualifiedName(conv.SpSchema, table)
+		if err != nil {
+			return ""
+		}
+		parentId := conv.SpSchema[tableId].ParentTable.Id
+		if parentId == "" {
+			return ""
+		}
+		return conv.SpSchema[parentId].QualifiedName()
+	}
+}
+
+// logBatchWrite logs the outcome of writing mutation groups.
+func logBatchWrite(groups int, errs []error) {
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	logger.Log.Debug(fmt.Sprintf("Wrote %d mutation groups to Spanner, %d failed\n", groups, failed))
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	sp "cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Tables singers > albums > songs are interleaved, and concerts isn't.
+var testParents = map[string]string{"albums": "singers", "songs": "albums"}
+
+func testKeyColumns(table string) []string {
+	switch table {
+	case "singers":
+		return []string{"singer_id"}
+	case "albums":
+		return []string{"singer_id", "album_id"}
+	case "songs":
+		return []string{"singer_id", "album_id", "song_id"}
+	}
+	return []string{"id"}
+}
+
+func groupTables(groups [][]*row) []string {
+	var l []string
+	for _, g := range groups {
+		var s []string
+		for _, r := range g {
+			s = append(s, fmt.Sprintf("%s%v", r.table, r.vals))
+		}
+		l = append(l, strings.Join(s, " "))
+	}
+	return l
+}
+
+func TestMutationGroups(t *testing.T) {
+	rows := []*row{
+		{"songs", []string{"singer_id", "album_id", "song_id"}, []interface{}{int64(1), int64(1), int64(1)}},
+		{"singers", []string{"singer_id"}, []interface{}{int64(1)}},
+		{"albums", []string{"singer_id", "album_id"}, []interface{}{int64(1), int64(1)}},
+		{"albums", []string{"singer_id", "album_id"}, []interface{}{int64(2), int64(1)}},
+		{"concerts", []string{"id"}, []interface{}{int64(1)}},
+		{"concerts", []string{"id"}, []interface{}{int64(2)}},
+	}
+	bw := NewBatchWriter(BatchWriterConfig{
+		KeyColumns:  testKeyColumns,
+		ParentTable: func(table string) string { return testParents[table] },
+	})
+	assert.Equal(t, []string{
+		"singers[1] albums[1 1] songs[1 1 1]",
+		"albums[2 1]",
+		"concerts[1]",
+		"concerts[2]",
+	}, groupTables(bw.mutationGroups(rows)))
+
+	// Without interleaving information, every row is a group of its own.
+	bw = NewBatchWriter(BatchWriterConfig{KeyColumns: testKeyColumns})
+	assert.Equal(t, 6, len(bw.mutationGroups(rows)))
+}
+
+func TestBatchWrite(t *testing.T) {
+	var lock sync.Mutex
+	var written []string
+	calls := 0
+	var deadLetter strings.Builder
+	bw := NewBatchWriter(BatchWriterConfig{
+		BytesLimit: 100 << 20,
+		WriteLimit: 1,
+		RetryLimit: 10,
+		Write: func(m []*sp.Mutation) error {
+			t.Fatal("Write shouldn't be called when BatchWrite is set")
+			return nil
+		},
+		BatchWrite: func(groups [][]*sp.Mutation) []error {
+			lock.Lock()
+			defer lock.Unlock()
+			calls++
+			errs := make([]error, len(groups))
+			for i, g := range groups {
+				s := fmt.Sprintf("%v", *g[0])
+				switch {
+				case strings.Contains(s, "bad"):
+					errs[i] = status.Error(codes.InvalidArgument, "bad data")
+				case strings.Contains(s, "busy") && calls == 1:
+					errs[i] = status.Error(codes.ResourceExhausted, "busy")
+				default:
+					written = append(written, s)
+				}
+			}
+			return errs
+		},
+		DeadLetter: &deadLetter,
+	})
+	bw.AddRow("t", []string{"id", "v"}, []interface{}{int64(1), "good"})
+	bw.AddRow("t", []string{"id", "v"}, []interface{}{int64(2), "bad"})
+	bw.AddRow("t", []string{"id", "v"}, []interface{}{int64(3), "busy"})
+	bw.Flush()
+	// The bad row is dropped without splitting the batch, and the row that
+	// failed because the database was busy is written again.
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, len(written))
+	assert.Equal(t, map[string]int64{"t": 1}, bw.DroppedRowsByTable())
+	assert.Equal(t, map[string]int64{
+		"rpc error: code = InvalidArgument desc = bad data": 1,
+		"rpc error: code = ResourceExhausted desc = busy":   1,
+	}, bw.Errors())
+	assert.Contains(t, deadLetter.String(), `"values":[2,"bad"]`)
+}
+
+func TestBatchWriteMissingParent(t *testing.T) {
+	var lock sync.Mutex
+	var written []string
+	calls := 0
+	bw := NewBatchWriter(BatchWriterConfig{
+		BytesLimit:  100 << 20,
+		WriteLimit:  1,
+		RetryLimit:  10,
+		KeyColumns:  testKeyColumns,
+		ParentTable: func(table string) string { return testParents[table] },
+		BatchWrite: func(groups [][]*sp.Mutation) []error {
+			lock.Lock()
+			defer lock.Unlock()
+			calls++
+			errs := make([]error, len(groups))
+			for i, g := range groups {
+				s := fmt.Sprintf("%v", *g[0])
+				// Singer 1 is written by another batch after the first
+				// attempt, and singer 9 is never written.
+				if strings.Contains(s, "orphan") || (strings.Contains(s, "late") && calls == 1) {
+					errs[i] = status.Error(codes.NotFound, "parent row missing")
+					continue
+				}
+				written = append(written, s)
+			}
+			return errs
+		},
+	})
+	bw.AddRow("albums", []string{"singer_id", "album_id", "title"}, []interface{}{int64(1), int64(1), "late"})
+	bw.AddRow("albums", []string{"singer_id", "album_id", "title"}, []interface{}{int64(9), int64(1), "orphan"})
+	bw.Flush()
+	// The album whose singer was written later is written again by Flush,
+	// and the album whose singer never appears is dropped.
+	assert.Equal(t, 1, len(written))
+	assert.Contains(t, written[0], "late")
+	assert.Equal(t, map[string]int64{"albums": 1}, bw.DroppedRowsByTable())
+}
+
+type testBatchWriteIterator struct {
+	responses []*sppb.BatchWriteResponse
+	err       error
+}
+
+func (it testBatchWriteIterator) Do(f func(r *sppb.BatchWriteResponse) error) error {
+	for _, r := range it.responses {
+		if err := f(r); err != nil {
+			return err
+		}
+	}
+	return it.err
+}
+
+func TestMutationGroupErrors(t *testing.T) {
+	streamErr := errors.New("stream broken")
+	errs := MutationGroupErrors(testBatchWriteIterator{
+		responses: []*sppb.BatchWriteResponse{
+			{Indexes: []int32{0, 2}, Status: &rpcstatus.Status{Code: int32(codes.OK)}},
+			{Indexes: []int32{1}, Status: &rpcstatus.Status{Code: int32(codes.NotFound), Message: "parent row missing"}},
+		},
+		err: streamErr,
+	}, 4)
+	assert.Nil(t, errs[0])
+	assert.Equal(t, codes.NotFound, sp.ErrCode(errs[1]))
+	assert.Nil(t, errs[2])
+	assert.Equal(t, streamErr, errs[3])
+
+	errs = MutationGroupErrors(testBatchWriteIterator{}, 1)
+	assert.ErrorContains(t, errs[0], "didn't report")
+}
+
+func TestInterleaveParents(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {Name: "singers", Id: "t1"},
+		"t2": {Name: "albums", Id: "t2", ParentTable: ddl.InterleavedParent{Id: "t1"}},
+		"t3": {Name: "orders", SchemaName: "sales", Id: "t3"},
+		"t4": {Name: "items", SchemaName: "sales", Id: "t4", ParentTable: ddl.InterleavedParent{Id: "t3"}},
+	}
+	parents := InterleaveParents(conv)
+	assert.Equal(t, "singers", parents("albums"))
+	assert.Equal(t, "", parents("singers"))
+	assert.Equal(t, "", parents("unknown"))
+	// Tables in named schemas are written with qualified names.
+	assert.Equal(t, "sales.orders", parents("sales.items"))
+	assert.Equal(t, "", parents("items"))
+}
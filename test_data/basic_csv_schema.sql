@@ -0,0 +1,5 @@
+-- Spanner schema of basic_csv.csv.
+CREATE TABLE basic_csv (
+  c3 INT64 NOT NULL,
+  c4 STRING(250) NOT NULL,
+) PRIMARY KEY (c3);
@@ -0,0 +1,495 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// SchemaPlan is the schema update that makes the schema of a Spanner
+// database conform to the schema of a session file.
+type SchemaPlan struct {
+	// Statements are the DDL statements of the update, in the order in
+	// which they must be applied.
+	Statements []PlannedStatement `json:"statements"`
+	// Unapplied are the differences that the statements don't resolve.
+	Unapplied []UnappliedChange `json:"unapplied"`
+}
+
+// PlannedStatement is a DDL statement of a schema update, and the
+// difference that it resolves.
+type PlannedStatement struct {
+	Statement  string           `json:"statement"`
+	Difference SchemaDifference `json:"difference"`
+}
+
+// UnappliedChange is a difference that a schema update doesn't resolve,
+// because Spanner can't change the schema in place or because the change
+// would delete data.
+type UnappliedChange struct {
+	Difference SchemaDifference `json:"difference"`
+	Reason     string           `json:"reason"`
+}
+
+// DDL returns the statements of the plan.
+func (p SchemaPlan) DDL() []string {
+	var stmts []string
+	for _, s := range p.Statements {
+		stmts = append(stmts, s.Statement)
+	}
+	return stmts
+}
+
+// Phases of a schema update. Statements are ordered by phase so that
+// objects are dropped before the objects they depend on change, and
+// created after the objects they depend on exist.
+const (
+	phaseDrop = iota
+	phaseSchemas
+	phaseSequences
+	phaseTables
+	phaseColumns
+	phaseConstraints
+	phaseForeignKeys
+	phaseViews
+	phaseDropSequences
+)
+
+// PlanSchemaUpdate returns the statements that update the schema of a
+// Spanner database, read by ReadLiveSchema, to the schema of the session
+// file. Tables and columns that aren't in the session file aren't dropped,
+// since that would delete their data, and changes that Spanner can't make
+// to an existing table, such as changing its primary key, are returned as
+// unapplied changes.
+func PlanSchemaUpdate(conv *internal.Conv, live LiveSchema) SchemaPlan {
+	p := planner{
+		conv:   conv,
+		live:   live,
+		config: ddl.Config{ProtectIds: true, SpDialect: conv.SpDialect, Source: conv.Source},
+		pg:     conv.SpDialect == constants.DIALECT_POSTGRESQL,
+		tables: make(map[string]ddl.CreateTable),
+		done:   make(map[string]bool),
+	}
+	for _, ct := range conv.SpSchema {
+		p.tables[ct.QualifiedName()] = ct
+	}
+	// The named schemas of the database are those of its objects.
+	for _, names := range [][]string{mapKeys(live.Tables), mapKeys(live.Sequences), mapKeys(live.Views)} {
+		for _, name := range names {
+			if schemaName, _, ok := strings.Cut(name, "."); ok {
+				p.done["schema "+schemaName] = true
+			}
+		}
+	}
+	// Tables are created parents first, and views after the views they
+	// refer to.
+	rank := make(map[string]int)
+	for i, id := range ddl.GetSortedTableIdsBySpName(conv.SpSchema) {
+		rank[conv.SpSchema[id].QualifiedName()] = i
+	}
+	for i, id := range ddl.GetSortedViewIds(conv.SpViews) {
+		rank[conv.SpViews[id].QualifiedName()] = i
+	}
+	for _, d := range DiffSchema(conv, live) {
+		if d.Kind == KindView {
+			p.planView(d, rank[d.Name])
+			continue
+		}
+		p.plan(d, rank[d.Table])
+	}
+	sort.SliceStable(p.stmts, func(i, j int) bool {
+		if p.stmts[i].phase != p.stmts[j].phase {
+			return p.stmts[i].phase < p.stmts[j].phase
+		}
+		return p.stmts[i].rank < p.stmts[j].rank
+	})
+	plan := SchemaPlan{Statements: []PlannedStatement{}, Unapplied: p.unapplied}
+	for _, s := range p.stmts {
+		plan.Statements = append(plan.Statements, s.PlannedStatement)
+	}
+	if plan.Unapplied == nil {
+		plan.Unapplied = []UnappliedChange{}
+	}
+	return plan
+}
+
+type planner struct {
+	conv   *internal.Conv
+	live   LiveSchema
+	config ddl.Config
+	pg     bool
+	tables map[string]ddl.CreateTable
+	// done records the columns, sequences, views and named schemas that
+	// already have a statement, since several differences of an object are
+	// resolved by one statement.
+	done      map[string]bool
+	stmts     []phasedStatement
+	unapplied []UnappliedChange
+}
+
+type phasedStatement struct {
+	PlannedStatement
+	phase, rank int
+}
+
+func (p *planner) add(d SchemaDifference, phase, rank int, stmt string) {
+	p.stmts = append(p.stmts, phasedStatement{PlannedStatement{Statement: stmt, Difference: d}, phase, rank})
+}
+
+func (p *planner) skip(d SchemaDifference, reason string) {
+	p.unapplied = append(p.unapplied, UnappliedChange{Difference: d, Reason: reason})
+}
+
+func (p *planner) plan(d SchemaDifference, rank int) {
+	ct := p.tables[d.Table]
+	missing := d.Property == "" && d.Actual == ""
+	extra := d.Property == "" && d.Expected == ""
+	switch d.Kind {
+	case KindTable:
+		if extra {
+			p.skip(d, "dropping the table would delete its data, drop it by hand if it isn't needed")
+			return
+		}
+		p.createSchema(d, ct.SchemaName)
+		// The table is created with its indexes the way the migration
+		// creates it.
+		for _, stmt := range ddl.GetTableDDL(p.config, p.conv.SpSchema, ct.Id) {
+			p.add(d, phaseTables, rank, stmt)
+		}
+		for _, fk := range ct.ForeignKeys {
+			p.add(d, phaseForeignKeys, rank, fk.PrintForeignKeyAlterTable(p.conv.SpSchema, p.config, ct.Id))
+		}
+	case KindColumn:
+		p.planColumn(d, ct, missing, extra)
+	case KindPrimaryKey:
+		p.skip(d, "Spanner can't change the primary key of an existing table")
+	case KindInterleave:
+		p.planInterleave(d, ct)
+	case KindRowDeletionPolicy:
+		p.planRowDeletionPolicy(d, ct)
+	case KindIndex:
+		if !missing {
+			p.add(d, phaseDrop, 0, "DROP INDEX "+p.quoteIndex(ct, d.Name))
+		}
+		if extra {
+			return
+		}
+		for _, idx := range ct.Indexes {
+			if idx.Name == d.Name {
+				p.add(d, phaseConstraints, 0, idx.PrintCreateIndex(ct, p.conv.SpSchema, p.config))
+			}
+		}
+	case KindSearchIndex:
+		if !missing {
+			p.add(d, phaseDrop, 0, "DROP SEARCH INDEX "+p.quoteIndex(ct, d.Name))
+		}
+		if extra {
+			return
+		}
+		for _, idx := range ct.SearchIndexes {
+			if idx.Name == d.Name {
+				p.add(d, phaseConstraints, 0, idx.PrintCreateSearchIndex(ct, p.config))
+			}
+		}
+	case KindVectorIndex:
+		if !missing {
+			// PostgreSQL vector indexes are ScaNN indexes.
+			drop := "DROP VECTOR INDEX "
+			if p.pg {
+				drop = "DROP INDEX "
+			}
+			p.add(d, phaseDrop, 0, drop+p.quoteIndex(ct, d.Name))
+		}
+		if extra {
+			return
+		}
+		for _, idx := range ct.VectorIndexes {
+			if idx.Name == d.Name {
+				p.add(d, phaseConstraints, 0, idx.PrintCreateVectorIndex(ct, p.config))
+			}
+		}
+	case KindForeignKey:
+		if !missing {
+			p.add(d, phaseDrop, 0, p.alterTable(ct, "DROP CONSTRAINT "+p.config.QuoteIdentifier(d.Name)))
+		}
+		if extra {
+			return
+		}
+		for _, fk := range ct.ForeignKeys {
+			if fk.Name == d.Name {
+				p.add(d, phaseForeignKeys, 0, fk.PrintForeignKeyAlterTable(p.conv.SpSchema, p.config, ct.Id))
+			}
+		}
+	case KindCheckConstraint:
+		if !missing {
+			p.add(d, phaseDrop, 0, p.alterTable(ct, "DROP CONSTRAINT "+p.config.QuoteIdentifier(d.Name)))
+		}
+		if extra {
+			return
+		}
+		for _, cc := range ct.CheckConstraints {
+			if cc.Name == d.Name {
+				p.add(d, phaseConstraints, 0, p.alterTable(ct, fmt.Sprintf("ADD CONSTRAINT %s CHECK %s", p.config.QuoteIdentifier(cc.Name), parenthesize(cc.Expr))))
+			}
+		}
+	case KindSequence:
+		p.planSequence(d, missing, extra)
+	}
+}
+
+func (p *planner) planColumn(d SchemaDifference, ct ddl.CreateTable, missing, extra bool) {
+	if extra {
+		p.skip(d, "dropping the column would delete its data, drop it by hand if it isn't needed")
+		return
+	}
+	var cd ddl.ColumnDef
+	for _, id := range ct.ColIds {
+		if ct.ColDefs[id].Name == d.Name {
+			cd = ct.ColDefs[id]
+		}
+	}
+	if missing {
+		if cd.NotNull && !cd.DefaultValue.IsPresent && cd.AutoGen.Name == "" && !cd.Generated.IsPresent {
+			p.skip(d, "Spanner can't add a NOT NULL column without a default value to an existing table")
+			return
+		}
+		def, _ := cd.PrintColumnDef(p.config)
+		p.add(d, phaseColumns, 0, p.alterTable(ct, "ADD COLUMN "+strings.TrimSpace(def)))
+		return
+	}
+	for _, pk := range ct.PrimaryKeys {
+		if ct.ColDefs[pk.ColId].Name == d.Name {
+			p.skip(d, fmt.Sprintf("Spanner can't change the %s of a primary key column", d.Property))
+			return
+		}
+	}
+	if cd.Generated.IsPresent {
+		p.skip(d, fmt.Sprintf("Spanner can't change the %s of a generated column", d.Property))
+		return
+	}
+	if d.Property == "type" && !alterableType(cd.T, p.liveColumnType(ct.QualifiedName(), d.Name)) {
+		p.skip(d, fmt.Sprintf("Spanner can't change the type of a column from %s to %s", d.Actual, d.Expected))
+		return
+	}
+	col := p.config.QuoteIdentifier(cd.Name)
+	if p.pg {
+		// PostgreSQL changes the type and the nullability of a column with
+		// separate statements.
+		if d.Property == "type" {
+			p.add(d, phaseColumns, 0, p.alterTable(ct, fmt.Sprintf("ALTER COLUMN %s TYPE %s", col, cd.T.PGPrintColumnDefType())))
+		} else if cd.NotNull {
+			p.add(d, phaseColumns, 0, p.alterTable(ct, fmt.Sprintf("ALTER COLUMN %s SET NOT NULL", col)))
+		} else {
+			p.add(d, phaseColumns, 0, p.alterTable(ct, fmt.Sprintf("ALTER COLUMN %s DROP NOT NULL", col)))
+		}
+		return
+	}
+	key := ct.QualifiedName() + "." + cd.Name
+	if p.done[key] {
+		return
+	}
+	p.done[key] = true
+	// GoogleSQL ALTER COLUMN replaces the whole column definition, so the
+	// default value is repeated to keep it.
+	s := fmt.Sprintf("ALTER COLUMN %s %s", col, cd.T.PrintColumnDefType())
+	if cd.NotNull {
+		s += " NOT NULL"
+	}
+	s += strings.TrimRight(cd.DefaultValue.PrintDefaultValue(cd.T)+cd.AutoGen.PrintAutoGenCol(p.config), " ")
+	p.add(d, phaseColumns, 0, p.alterTable(ct, s))
+}
+
+// liveColumnType returns the type of a column of the database.
+func (p *planner) liveColumnType(table, column string) ddl.Type {
+	for _, c := range p.live.Tables[table].Columns {
+		if c.Name == column {
+			t, _ := spanner.ToDdlImpl{}.ToSpannerType(nil, "", c.Type, false)
+			return t
+		}
+	}
+	return ddl.Type{}
+}
+
+// alterableType returns whether Spanner can change the type of a column
+// from one type to the other in place, which is only the case for STRING
+// and BYTES columns, and arrays of them.
+func alterableType(to, from ddl.Type) bool {
+	ok := func(name string) bool { return name == ddl.String || name == ddl.Bytes }
+	return ok(to.Name) && ok(from.Name) && to.IsArray == from.IsArray
+}
+
+func (p *planner) planInterleave(d SchemaDifference, ct ddl.CreateTable) {
+	expectedType, expectedParent, onDelete := parseInterleave(d.Expected)
+	_, actualParent, _ := parseInterleave(d.Actual)
+	if expectedParent == "" || expectedParent != actualParent {
+		p.skip(d, "Spanner can't change the parent of an existing table")
+		return
+	}
+	table := p.config.QuoteQualifiedName(ct.QualifiedName())
+	parent := p.config.QuoteQualifiedName(expectedParent)
+	switch {
+	case expectedType == "IN":
+		p.add(d, phaseColumns, 0, fmt.Sprintf("ALTER TABLE %s SET INTERLEAVE IN %s", table, parent))
+	case strings.HasPrefix(d.Actual, "IN PARENT "):
+		p.add(d, phaseColumns, 0, fmt.Sprintf("ALTER TABLE %s SET ON DELETE %s", table, onDelete))
+	default:
+		p.add(d, phaseColumns, 0, fmt.Sprintf("ALTER TABLE %s SET INTERLEAVE IN PARENT %s ON DELETE %s", table, parent, onDelete))
+	}
+}
+
+// parseInterleave splits an interleave clause, as written by
+// interleaveClause, into the interleave type, the parent table and the
+// ON DELETE action.
+func parseInterleave(s string) (string, string, string) {
+	if rest, ok := strings.CutPrefix(s, "IN PARENT "); ok {
+		parent, onDelete, _ := strings.Cut(rest, " ON DELETE ")
+		return "IN PARENT", parent, onDelete
+	}
+	if parent, ok := strings.CutPrefix(s, "IN "); ok {
+		return "IN", parent, ""
+	}
+	return "", "", ""
+}
+
+func (p *planner) planSequence(d SchemaDifference, missing, extra bool) {
+	seq := ddl.Sequence{}
+	for _, s := range p.conv.SpSequences {
+		if ddl.QualifiedName(s.SchemaName, s.Name) == d.Name {
+			seq = s
+		}
+	}
+	switch {
+	case missing:
+		p.createSchema(d, seq.SchemaName)
+		if p.pg {
+			p.add(d, phaseSequences, 0, seq.PGPrintSequence(p.config))
+		} else {
+			p.add(d, phaseSequences, 0, seq.PrintSequence(p.config))
+		}
+	case extra:
+		// Sequences are dropped last, after the columns that use them
+		// have changed.
+		p.add(d, phaseDropSequences, 0, "DROP SEQUENCE "+p.config.QuoteQualifiedName(d.Name))
+	case d.Property == "kind":
+		p.skip(d, "Spanner can't change the kind of a sequence")
+	case !p.done["sequence "+d.Name]:
+		// Options of PostgreSQL sequences aren't compared, so this is
+		// GoogleSQL.
+		p.done["sequence "+d.Name] = true
+		option := func(v string) string {
+			if v == "" {
+				return "NULL"
+			}
+			return v
+		}
+		// The skip range is set as a whole. The counter is only set if it
+		// changed, since setting it restarts the sequence.
+		options := []string{
+			"skip_range_min = " + option(seq.SkipRangeMin),
+			"skip_range_max = " + option(seq.SkipRangeMax),
+		}
+		if seq.StartWithCounter != "" && seq.StartWithCounter != p.live.Sequences[d.Name].StartWithCounter {
+			options = append(options, "start_with_counter = "+seq.StartWithCounter)
+		}
+		p.add(d, phaseSequences, 0, fmt.Sprintf("ALTER SEQUENCE %s SET OPTIONS (%s)", p.config.QuoteQualifiedName(d.Name), strings.Join(options, ", ")))
+	}
+}
+
+// planRowDeletionPolicy adds, replaces or drops the row deletion policy of
+// a table.
+func (p *planner) planRowDeletionPolicy(d SchemaDifference, ct ddl.CreateTable) {
+	switch {
+	case ct.RowDeletionPolicy == nil:
+		if p.pg {
+			p.add(d, phaseConstraints, 0, p.alterTable(ct, "DROP TTL"))
+		} else {
+			p.add(d, phaseConstraints, 0, p.alterTable(ct, "DROP ROW DELETION POLICY"))
+		}
+	case d.Actual == "":
+		p.add(d, phaseConstraints, 0, p.alterTable(ct, "ADD "+ct.RowDeletionPolicy.PrintRowDeletionPolicy(ct, p.config)))
+	case p.pg:
+		p.add(d, phaseConstraints, 0, p.alterTable(ct, "ALTER "+ct.RowDeletionPolicy.PrintRowDeletionPolicy(ct, p.config)))
+	default:
+		p.add(d, phaseConstraints, 0, p.alterTable(ct, "REPLACE "+ct.RowDeletionPolicy.PrintRowDeletionPolicy(ct, p.config)))
+	}
+}
+
+// planView creates, replaces or drops a view. Views are dropped before the
+// tables they refer to change, and created after.
+func (p *planner) planView(d SchemaDifference, rank int) {
+	if d.Property == "" && d.Expected == "" {
+		p.add(d, phaseDrop, 0, "DROP VIEW "+p.config.QuoteQualifiedName(d.Name))
+		return
+	}
+	if p.done["view "+d.Name] {
+		return
+	}
+	p.done["view "+d.Name] = true
+	for _, v := range p.conv.SpViews {
+		if v.QualifiedName() != d.Name {
+			continue
+		}
+		stmt := v.PrintCreateView(p.config)
+		if d.Property != "" {
+			stmt = strings.Replace(stmt, "CREATE VIEW", "CREATE OR REPLACE VIEW", 1)
+		} else {
+			p.createSchema(d, v.SchemaName)
+		}
+		p.add(d, phaseViews, rank, stmt)
+	}
+}
+
+// createSchema creates a named schema unless the database already has it.
+func (p *planner) createSchema(d SchemaDifference, schemaName string) {
+	if schemaName == "" || p.done["schema "+schemaName] {
+		return
+	}
+	p.done["schema "+schemaName] = true
+	p.add(d, phaseSchemas, 0, "CREATE SCHEMA "+p.config.QuoteIdentifier(schemaName))
+}
+
+// quoteIndex quotes the name of an index of table ct, which is in the named
+// schema of the table.
+func (p *planner) quoteIndex(ct ddl.CreateTable, name string) string {
+	return p.config.QuoteQualifiedName(ddl.QualifiedName(ct.SchemaName, name))
+}
+
+func (p *planner) alterTable(ct ddl.CreateTable, action string) string {
+	return fmt.Sprintf("ALTER TABLE %s %s", p.config.QuoteQualifiedName(ct.QualifiedName()), action)
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// parenthesize encloses an expression in parentheses, unless it already is.
+func parenthesize(expr string) string {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") && balanced(expr[1:len(expr)-1]) {
+		return expr
+	}
+	return "(" + expr + ")"
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+)
+
+func unappliedReasons(plan SchemaPlan) map[string]string {
+	reasons := make(map[string]string)
+	for _, u := range plan.Unapplied {
+		reasons[u.Difference.String()] = u.Reason
+	}
+	return reasons
+}
+
+func TestPlanSchemaUpdate(t *testing.T) {
+	conv := schemaConv()
+	plan := PlanSchemaUpdate(conv, conformingSchema())
+	assert.Empty(t, plan.Statements)
+	assert.Empty(t, plan.Unapplied)
+
+	// The database lacks the singers table, which albums is interleaved
+	// in, so it is created before albums changes.
+	live := conformingSchema()
+	delete(live.Tables, "singers")
+	albums := live.Tables["albums"]
+	albums.Columns = albums.Columns[:1]
+	albums.Interleave = ""
+	albums.ForeignKeys = nil
+	live.Tables["albums"] = albums
+	plan = PlanSchemaUpdate(conv, live)
+	assert.Equal(t, []string{
+		"CREATE TABLE `singers` (\n\t`id` INT64 NOT NULL ,\n\t`name` STRING(100),\n\tCONSTRAINT positive CHECK (id > 0)\n) PRIMARY KEY (`id`)",
+		"CREATE INDEX `by_name` ON `singers` (`name`)",
+		"ALTER TABLE `albums` ADD CONSTRAINT `fk_singer` FOREIGN KEY (`singer_id`) REFERENCES `singers` (`id`)",
+	}, plan.DDL())
+	assert.Equal(t, map[string]string{
+		`albums: column "album_id" is missing from the database`:                                       "Spanner can't add a NOT NULL column without a default value to an existing table",
+		`albums: interleave "albums" parent: expected IN PARENT singers ON DELETE CASCADE, found none`: "Spanner can't change the parent of an existing table",
+	}, unappliedReasons(plan))
+}
+
+func TestPlanSchemaUpdateChanges(t *testing.T) {
+	conv := schemaConv()
+	singers := conv.SpSchema["t1"]
+	singers.ColIds = append(singers.ColIds, "c5", "c6")
+	singers.ColDefs["c5"] = ddl.ColumnDef{Name: "bio", Id: "c5", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}}
+	singers.ColDefs["c6"] = ddl.ColumnDef{Name: "rating", Id: "c6", T: ddl.Type{Name: ddl.Float64}}
+	name := singers.ColDefs["c2"]
+	name.DefaultValue = ddl.DefaultValue{IsPresent: true, Value: ddl.Expression{Statement: "'unknown'"}}
+	singers.ColDefs["c2"] = name
+	conv.SpSchema["t1"] = singers
+
+	live := conformingSchema()
+	ls := live.Tables["singers"]
+	ls.Columns = []LiveColumn{
+		{Name: "id", Type: schema.Type{Name: "INT64"}, NotNull: true},
+		{Name: "name", Type: schema.Type{Name: "BYTES", Mods: []int64{50}}, NotNull: true},
+		{Name: "rating", Type: schema.Type{Name: "INT64"}},
+		{Name: "old", Type: schema.Type{Name: "BOOL"}},
+	}
+	ls.Indexes = []LiveIndex{
+		{Index: schema.Index{Name: "by_name", Keys: []schema.Key{{ColId: "name", Desc: true}}}},
+		{Index: schema.Index{Name: "by_old", Keys: []schema.Key{{ColId: "old"}}}},
+	}
+	ls.CheckConstraints = []schema.CheckConstraint{{Name: "positive", Expr: "id >= 0"}}
+	live.Tables["singers"] = ls
+	la := live.Tables["albums"]
+	la.Interleave = "IN PARENT singers ON DELETE NO ACTION"
+	la.ForeignKeys = append(la.ForeignKeys, schema.ForeignKey{Name: "fk_old", ColumnNames: []string{"album_id"}, ReferTableName: "singers", ReferColumnNames: []string{"id"}})
+	live.Tables["albums"] = la
+	live.Sequences["seq"] = ddl.Sequence{Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "5"}
+	live.Sequences["unused"] = ddl.Sequence{Name: "unused"}
+
+	plan := PlanSchemaUpdate(conv, live)
+	assert.Equal(t, []string{
+		"ALTER TABLE `albums` DROP CONSTRAINT `fk_old`",
+		"DROP INDEX `by_name`",
+		"DROP INDEX `by_old`",
+		"ALTER TABLE `singers` DROP CONSTRAINT `positive`",
+		"ALTER SEQUENCE `seq` SET OPTIONS (skip_range_min = 1, skip_range_max = 10)",
+		"ALTER TABLE `albums` SET ON DELETE CASCADE",
+		"ALTER TABLE `singers` ADD COLUMN `bio` STRING(MAX)",
+		"ALTER TABLE `singers` ALTER COLUMN `name` STRING(100) DEFAULT ('unknown')",
+		"CREATE INDEX `by_name` ON `singers` (`name`)",
+		"ALTER TABLE `singers` ADD CONSTRAINT `positive` CHECK (id > 0)",
+		"DROP SEQUENCE `unused`",
+	}, plan.DDL())
+	assert.Equal(t, map[string]string{
+		`singers: column "old" isn't in the session file`:              "dropping the column would delete its data, drop it by hand if it isn't needed",
+		`singers: column "rating" type: expected FLOAT64, found INT64`: "Spanner can't change the type of a column from INT64 to FLOAT64",
+	}, unappliedReasons(plan))
+}
+
+func TestPlanSchemaUpdatePostgreSQL(t *testing.T) {
+	conv := schemaConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	conv.SpSequences = nil
+	live := conformingSchema()
+	live.Sequences = nil
+	ls := live.Tables["singers"]
+	ls.Columns = []LiveColumn{
+		{Name: "id", Type: schema.Type{Name: "bigint"}, NotNull: true},
+		{Name: "name", Type: schema.Type{Name: "character varying", Mods: []int64{10}}, NotNull: true},
+	}
+	live.Tables["singers"] = ls
+	la := live.Tables["albums"]
+	la.Interleave = "IN singers"
+	live.Tables["albums"] = la
+	plan := PlanSchemaUpdate(conv, live)
+	assert.Equal(t, []string{
+		`ALTER TABLE albums SET INTERLEAVE IN PARENT singers ON DELETE CASCADE`,
+		`ALTER TABLE singers ALTER COLUMN name DROP NOT NULL`,
+		`ALTER TABLE singers ALTER COLUMN name TYPE VARCHAR(100)`,
+	}, plan.DDL())
+	assert.Empty(t, plan.Unapplied)
+}
+
+func TestParenthesize(t *testing.T) {
+	assert.Equal(t, "(id > 0)", parenthesize("id > 0"))
+	assert.Equal(t, "(id > 0)", parenthesize(" (id > 0) "))
+	assert.Equal(t, "((a > 0) AND (b > 0))", parenthesize("(a > 0) AND (b > 0)"))
+}
+
+func TestPlanSchemaUpdateNamedSchemasAndViews(t *testing.T) {
+	conv := schemaConv()
+	for _, id := range []string{"t1", "t2"} {
+		ct := conv.SpSchema[id]
+		ct.SchemaName = "music"
+		conv.SpSchema[id] = ct
+	}
+	singers := conv.SpSchema["t1"]
+	singers.SearchIndexes = []ddl.CreateSearchIndex{{Name: "by_name_tokens", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2"}}}}
+	singers.RowDeletionPolicy = &ddl.RowDeletionPolicy{ColId: "c2", Days: 30}
+	conv.SpSchema["t1"] = singers
+	conv.SpViews["v1"] = ddl.CreateView{Name: "singer_names", SchemaName: "music", Query: "SELECT name FROM music.singers", RefIds: []string{"t1"}, Id: "v1"}
+	conv.SpViews["v2"] = ddl.CreateView{Name: "all_names", Query: "SELECT name FROM music.singer_names", RefIds: []string{"v1"}, Id: "v2"}
+
+	// The database lacks the music schema and everything in it.
+	live := conformingSchema()
+	delete(live.Tables, "singers")
+	delete(live.Tables, "albums")
+	live.Views = map[string]ddl.CreateView{"old_names": {Name: "old_names", Query: "SELECT 1"}}
+	plan := PlanSchemaUpdate(conv, live)
+	assert.Equal(t, []string{
+		"DROP VIEW `old_names`",
+		"CREATE SCHEMA `music`",
+		"CREATE TABLE `music`.`singers` (\n\t`id` INT64 NOT NULL ,\n\t`name` STRING(100),\n\tCONSTRAINT positive CHECK (id > 0)\n) PRIMARY KEY (`id`),\nROW DELETION POLICY (OLDER_THAN(`name`, INTERVAL 30 DAY))",
+		"CREATE INDEX `by_name` ON `music`.`singers` (`name`)",
+		"CREATE SEARCH INDEX `music`.`by_name_tokens` ON `music`.`singers` (`name`)",
+		"CREATE TABLE `music`.`albums` (\n\t`singer_id` INT64 NOT NULL ,\n\t`album_id` INT64 NOT NULL ,\n) PRIMARY KEY (`singer_id`, `album_id`),\nINTERLEAVE IN PARENT `music`.`singers` ON DELETE CASCADE",
+		"ALTER TABLE `music`.`albums` ADD CONSTRAINT `fk_singer` FOREIGN KEY (`singer_id`) REFERENCES `music`.`singers` (`id`)",
+		"CREATE VIEW `music`.`singer_names` SQL SECURITY INVOKER AS SELECT name FROM music.singers",
+		"CREATE VIEW `all_names` SQL SECURITY INVOKER AS SELECT name FROM music.singer_names",
+	}, plan.DDL())
+	assert.Empty(t, plan.Unapplied)
+
+	// Tables of the music schema are altered in place.
+	live = conformingSchema()
+	for _, name := range []string{"singers", "albums"} {
+		lt := live.Tables[name]
+		lt.Name = "music." + name
+		live.Tables["music."+name] = lt
+		delete(live.Tables, name)
+	}
+	la := live.Tables["music.albums"]
+	la.Interleave = "IN PARENT music.singers ON DELETE CASCADE"
+	la.ForeignKeys[0].ReferTableName = "music.singers"
+	live.Tables["music.albums"] = la
+	ls := live.Tables["music.singers"]
+	ls.Columns[1].Type = schema.Type{Name: "STRING", Mods: []int64{50}}
+	ls.RowDeletionPolicy = "OLDER_THAN(name, INTERVAL 7 DAY)"
+	live.Tables["music.singers"] = ls
+	live.Views = map[string]ddl.CreateView{
+		"music.singer_names": {Name: "singer_names", SchemaName: "music", Query: "SELECT id FROM music.singers"},
+		"all_names":          {Name: "all_names", Query: "SELECT name FROM music.singer_names"},
+	}
+	plan = PlanSchemaUpdate(conv, live)
+	assert.Equal(t, []string{
+		"ALTER TABLE `music`.`singers` ALTER COLUMN `name` STRING(100)",
+		"ALTER TABLE `music`.`singers` REPLACE ROW DELETION POLICY (OLDER_THAN(`name`, INTERVAL 30 DAY))",
+		"CREATE SEARCH INDEX `music`.`by_name_tokens` ON `music`.`singers` (`name`)",
+		"CREATE OR REPLACE VIEW `music`.`singer_names` SQL SECURITY INVOKER AS SELECT name FROM music.singers",
+	}, plan.DDL())
+	assert.Empty(t, plan.Unapplied)
+}
@@ -0,0 +1,403 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validation compares the rows of a source database with the rows
+// of the Spanner database they were migrated to.
+//
+// Source rows are read and converted by the same code as data migrations,
+// driven by the schema mapping of internal.Conv, so that a converted row is
+// exactly the row a migration writes. Each converted row is then looked up
+// in Spanner by its primary key, and the two rows are compared by a hash of
+// their values, and column by column if the hashes differ.
+//
+// The package also compares the schema of a Spanner database with the
+// schema of a session file, and plans the DDL that updates the database to
+// the session file.
+package validation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/common"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// DefaultBatchSize is the default number of rows looked up in Spanner at a time.
+const DefaultBatchSize = 1000
+
+// Config configures a RowValidator.
+type Config struct {
+	// BatchSize is the number of rows looked up in Spanner at a time.
+	// Defaults to DefaultBatchSize.
+	BatchSize int
+	// SampleRate is the fraction of rows compared, between 0 and 1. All rows
+	// are still read from the source, since not every source can sample
+	// rows. 0 compares every row.
+	SampleRate float64
+	// Read reads the rows with the given keys and columns of a Spanner table.
+	// Rows that don't exist are left out.
+	Read func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error)
+	// Mismatches, if set, receives a JSON object for each row that doesn't
+	// match, one per line.
+	Mismatches io.Writer
+}
+
+// TableResult is the outcome of comparing the rows of a table.
+type TableResult struct {
+	Table      string `json:"table"`
+	Compared   int64  `json:"compared"`
+	Matched    int64  `json:"matched"`
+	Mismatched int64  `json:"mismatched"`
+	Missing    int64  `json:"missing"`
+	Errors     int64  `json:"errors"`
+	// BadRows is the number of source rows that couldn't be converted, and
+	// so weren't compared.
+	BadRows int64 `json:"badRows"`
+	// Error is the last error that rows couldn't be compared because of.
+	Error string `json:"error,omitempty"`
+	// Skipped is set to the reason if the rows of the table weren't compared.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Ok returns whether every row of the table was converted and every
+// compared row matches.
+func (r TableResult) Ok() bool {
+	return r.Matched == r.Compared && r.BadRows == 0 && r.Skipped == ""
+}
+
+// RowMismatch describes a converted source row that doesn't match Spanner.
+type RowMismatch struct {
+	Table string        `json:"table"`
+	Key   []interface{} `json:"key"`
+	// Missing is set if the row doesn't exist in Spanner.
+	Missing bool             `json:"missing,omitempty"`
+	Columns []ColumnMismatch `json:"columns,omitempty"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// ColumnMismatch describes a column whose converted source value differs
+// from its Spanner value. Values are shown as their canonical form.
+type ColumnMismatch struct {
+	Column  string      `json:"column"`
+	Source  interface{} `json:"source"`
+	Spanner interface{} `json:"spanner"`
+}
+
+// RowValidator compares converted source rows with Spanner rows. Rows are
+// added with AddRow, which can be used as the data sink of internal.Conv,
+// and are compared in batches.
+type RowValidator struct {
+	conv    *internal.Conv
+	config  Config
+	rand    *rand.Rand
+	lock    sync.Mutex
+	tables  map[string]*tableState
+	results map[string]*TableResult
+}
+
+type tableState struct {
+	types    map[string]ddl.Type
+	keyCols  []string
+	cols     []string
+	pending  []pendingRow
+	synthPK  bool
+	unmapped bool
+}
+
+type pendingRow struct {
+	key     sp.Key
+	keyVals []interface{}
+	keyHash string
+	rowHash string
+	values  []interface{}
+}
+
+// NewRowValidator returns a RowValidator for the tables of conv.
+func NewRowValidator(conv *internal.Conv, config Config) *RowValidator {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	v := &RowValidator{
+		conv:    conv,
+		config:  config,
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		tables:  make(map[string]*tableState),
+		results: make(map[string]*TableResult),
+	}
+	for tableId, t := range conv.SpSchema {
+		if _, ok := conv.SrcSchema[tableId]; !ok {
+			continue
+		}
+		r := &TableResult{Table: t.QualifiedName()}
+		if _, ok := conv.SyntheticPKeys[tableId]; ok {
+			// Synthetic primary keys are generated during the migration, so
+			// converted rows get different keys than the migrated rows.
+			r.Skipped = "synthetic primary key"
+		}
+		v.results[t.QualifiedName()] = r
+	}
+	return v
+}
+
+// ValidateRows reads every table of conv from the source with infoSchema,
+// converts the rows as a data migration does and compares them with Spanner.
+func ValidateRows(conv *internal.Conv, infoSchema common.InfoSchema, config Config) []TableResult {
+	v := NewRowValidator(conv, config)
+	conv.SetDataMode()
+	conv.SetDataSink(v.AddRow)
+	conv.DataFlush = v.Flush
+	(&common.InfoSchemaImpl{}).ProcessData(conv, infoSchema, internal.AdditionalDataAttributes{})
+	v.Flush()
+	v.addBadRows(conv.Stats.BadRows)
+	return v.Results()
+}
+
+// AddRow adds a converted row of a Spanner table, and compares the rows
+// added so far if the batch is full.
+func (v *RowValidator) AddRow(table string, cols []string, vals []interface{}) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	t := v.table(table)
+	r := v.results[table]
+	if t.synthPK || t.unmapped || r == nil {
+		return
+	}
+	if v.config.SampleRate > 0 && v.config.SampleRate < 1 && v.rand.Float64() >= v.config.SampleRate {
+		return
+	}
+	if t.cols != nil && !equalStrings(t.cols, cols) {
+		v.compare(table, t)
+	}
+	t.cols = cols
+	row, err := v.pendingRow(t, cols, vals)
+	if err != nil {
+		r.Compared++
+		r.Errors++
+		r.Error = err.Error()
+		v.writeMismatch(RowMismatch{Table: table, Key: row.key, Error: err.Error()})
+		return
+	}
+	t.pending = append(t.pending, row)
+	if len(t.pending) >= v.config.BatchSize {
+		v.compare(table, t)
+	}
+}
+
+// Flush compares the rows added so far.
+func (v *RowValidator) Flush() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	for table, t := range v.tables {
+		v.compare(table, t)
+	}
+}
+
+// Results returns the outcome of every table, sorted by table name.
+func (v *RowValidator) Results() []TableResult {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	var results []TableResult
+	for _, r := range v.results {
+		results = append(results, *r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Table < results[j].Table })
+	return results
+}
+
+// addBadRows adds the counts of source rows that couldn't be converted,
+// broken down by source table, to the results of their Spanner tables.
+func (v *RowValidator) addBadRows(badRows map[string]int64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	for tableId, t := range v.conv.SpSchema {
+		srcTable, ok := v.conv.SrcSchema[tableId]
+		if r := v.results[t.QualifiedName()]; ok && r != nil {
+			r.BadRows += badRows[srcTable.Name]
+		}
+	}
+}
+
+func (v *RowValidator) table(table string) *tableState {
+	if t, ok := v.tables[table]; ok {
+		return t
+	}
+	t := &tableState{types: make(map[string]ddl.Type)}
+	v.tables[table] = t
+	tableId, err := internal.GetTableIdFromSpQualifiedName(v.conv.SpSchema, table)
+	if err != nil {
+		t.unmapped = true
+		return t
+	}
+	_, t.synthPK = v.conv.SyntheticPKeys[tableId]
+	spSchema := v.conv.SpSchema[tableId]
+	for _, c := range spSchema.ColDefs {
+		t.types[c.Name] = c.T
+	}
+	pks := append([]ddl.IndexKey{}, spSchema.PrimaryKeys...)
+	sort.SliceStable(pks, func(i, j int) bool { return pks[i].Order < pks[j].Order })
+	for _, pk := range pks {
+		t.keyCols = append(t.keyCols, spSchema.ColDefs[pk.ColId].Name)
+	}
+	return t
+}
+
+// pendingRow computes the key and the hashes of a converted row.
+func (v *RowValidator) pendingRow(t *tableState, cols []string, vals []interface{}) (pendingRow, error) {
+	var row pendingRow
+	for _, kc := range t.keyCols {
+		i := indexOf(cols, kc)
+		if i < 0 {
+			return row, fmt.Errorf("primary key column %s isn't migrated", kc)
+		}
+		row.key = append(row.key, vals[i])
+	}
+	values, err := canonicalValues(t.types, cols, vals)
+	if err != nil {
+		return row, err
+	}
+	row.values = values
+	row.keyVals = keyValues(t.keyCols, cols, values)
+	row.keyHash = hash(row.keyVals)
+	row.rowHash = hash(values)
+	return row, nil
+}
+
+// compare looks up the pending rows of a table in Spanner and compares them.
+func (v *RowValidator) compare(table string, t *tableState) {
+	if len(t.pending) == 0 {
+		return
+	}
+	pending := t.pending
+	t.pending = nil
+	r := v.results[table]
+	r.Compared += int64(len(pending))
+	keys := make([]sp.Key, len(pending))
+	for i, p := range pending {
+		keys[i] = p.key
+	}
+	spRows, err := v.config.Read(table, keys, t.cols)
+	if err != nil {
+		logger.Log.Debug(fmt.Sprintf("Can't read %d rows of table %s from Spanner: %v", len(pending), table, err))
+		r.Errors += int64(len(pending))
+		r.Error = err.Error()
+		return
+	}
+	found := make(map[string][]interface{})
+	for _, spRow := range spRows {
+		values, err := canonicalRow(t.types, t.cols, spRow)
+		if err != nil {
+			r.Error = err.Error()
+			continue
+		}
+		found[hash(keyValues(t.keyCols, t.cols, values))] = values
+	}
+	for _, p := range pending {
+		values, ok := found[p.keyHash]
+		switch {
+		case !ok:
+			r.Missing++
+			v.writeMismatch(RowMismatch{Table: table, Key: p.keyVals, Missing: true})
+		case hash(values) == p.rowHash:
+			r.Matched++
+		default:
+			r.Mismatched++
+			v.writeMismatch(RowMismatch{Table: table, Key: p.keyVals, Columns: diffColumns(t.cols, p.values, values)})
+		}
+	}
+}
+
+func (v *RowValidator) writeMismatch(m RowMismatch) {
+	if v.config.Mismatches == nil {
+		return
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		b, _ = json.Marshal(RowMismatch{Table: m.Table, Error: fmt.Sprintf("can't encode row: %v", err)})
+	}
+	v.config.Mismatches.Write(append(b, '\n'))
+}
+
+// SpannerReader returns a Config.Read function that reads rows with client.
+func SpannerReader(ctx context.Context, client *sp.Client) func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error) {
+	return func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error) {
+		var rows []*sp.Row
+		err := client.Single().Read(ctx, table, sp.KeySetFromKeys(keys...), cols).Do(func(r *sp.Row) error {
+			rows = append(rows, r)
+			return nil
+		})
+		return rows, err
+	}
+}
+
+// diffColumns returns the columns whose canonical values differ.
+func diffColumns(cols []string, source, spanner []interface{}) []ColumnMismatch {
+	var diffs []ColumnMismatch
+	for i, c := range cols {
+		if hash(source[i]) != hash(spanner[i]) {
+			diffs = append(diffs, ColumnMismatch{Column: c, Source: source[i], Spanner: spanner[i]})
+		}
+	}
+	return diffs
+}
+
+func keyValues(keyCols, cols []string, values []interface{}) []interface{} {
+	key := make([]interface{}, len(keyCols))
+	for i, kc := range keyCols {
+		key[i] = values[indexOf(cols, kc)]
+	}
+	return key
+}
+
+// hash returns a hash of the JSON encoding of canonical values.
+func hash(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		b = []byte(fmt.Sprintf("%#v", v))
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func indexOf(s []string, x string) int {
+	for i, y := range s {
+		if y == x {
+			return i
+		}
+	}
+	return -1
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	sp "cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/logger"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+func validationConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SrcSchema = map[string]schema.Table{
+		"t1": {Name: "orders", Id: "t1"},
+		"t2": {Name: "logs", Id: "t2"},
+	}
+	conv.SpSchema = map[string]ddl.CreateTable{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "price", Id: "c2", T: ddl.Type{Name: ddl.Numeric}},
+				"c3": {Name: "doc", Id: "c3", T: ddl.Type{Name: ddl.JSON}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		"t2": {
+			Name:        "logs",
+			Id:          "t2",
+			ColIds:      []string{"c4", "c5"},
+			ColDefs:     map[string]ddl.ColumnDef{"c4": {Name: "msg", Id: "c4", T: ddl.Type{Name: ddl.String}}, "c5": {Name: "synth_id", Id: "c5", T: ddl.Type{Name: ddl.String}}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c5", Order: 1}},
+		},
+	}
+	conv.SyntheticPKeys = map[string]internal.SyntheticPKey{"t2": {ColId: "c5"}}
+	return conv
+}
+
+// spannerRow returns an orders row the way Spanner returns it.
+func spannerRow(id int64, price, doc string) *sp.Row {
+	row, err := sp.NewRow([]string{"id", "price", "doc"}, []interface{}{
+		id,
+		sp.GenericColumnValue{Type: &sppb.Type{Code: sppb.TypeCode_NUMERIC}, Value: structpb.NewStringValue(price)},
+		sp.GenericColumnValue{Type: &sppb.Type{Code: sppb.TypeCode_JSON}, Value: structpb.NewStringValue(doc)},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return row
+}
+
+func TestRowValidator(t *testing.T) {
+	spRows := map[int64]*sp.Row{
+		1: spannerRow(1, "1.5", `{"a":1,"b":[true]}`),
+		2: spannerRow(2, "2", `{"a":2}`),
+	}
+	var reads [][]sp.Key
+	var mismatches bytes.Buffer
+	v := NewRowValidator(validationConv(), Config{
+		BatchSize: 2,
+		Read: func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error) {
+			assert.Equal(t, "orders", table)
+			assert.Equal(t, []string{"id", "price", "doc"}, cols)
+			reads = append(reads, keys)
+			var rows []*sp.Row
+			for _, k := range keys {
+				if r, ok := spRows[k[0].(int64)]; ok {
+					rows = append(rows, r)
+				}
+			}
+			return rows, nil
+		},
+		Mismatches: &mismatches,
+	})
+	cols := []string{"id", "price", "doc"}
+	// Numeric values are written with 9 decimal digits and Spanner
+	// normalizes JSON documents, which isn't a mismatch.
+	v.AddRow("orders", cols, []interface{}{int64(1), *big.NewRat(3, 2), `{"b": [true], "a": 1}`})
+	v.AddRow("orders", cols, []interface{}{int64(2), *big.NewRat(5, 2), `{"a":2}`})
+	v.AddRow("orders", cols, []interface{}{int64(3), *big.NewRat(1, 1), `{}`})
+	v.AddRow("logs", []string{"msg", "synth_id"}, []interface{}{"hello", "1"})
+	assert.Len(t, reads, 1)
+	v.Flush()
+	assert.Equal(t, [][]sp.Key{{{int64(1)}, {int64(2)}}, {{int64(3)}}}, reads)
+	assert.Equal(t, []TableResult{
+		{Table: "logs", Skipped: "synthetic primary key"},
+		{Table: "orders", Compared: 3, Matched: 1, Mismatched: 1, Missing: 1},
+	}, v.Results())
+	assert.Equal(t, `{"table":"orders","key":["2"],"columns":[{"column":"price","source":"5/2","spanner":"2"}]}
+{"table":"orders","key":["3"],"missing":true}
+`, mismatches.String())
+}
+
+func TestRowValidatorErrors(t *testing.T) {
+	v := NewRowValidator(validationConv(), Config{
+		Read: func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error) {
+			return nil, errors.New("deadline exceeded")
+		},
+	})
+	v.AddRow("orders", []string{"id", "price", "doc"}, []interface{}{int64(1), *big.NewRat(3, 2), `{}`})
+	// Rows without their primary key can't be looked up.
+	v.AddRow("orders", []string{"price"}, []interface{}{*big.NewRat(3, 2)})
+	v.Flush()
+	r := v.Results()[1]
+	assert.Equal(t, int64(2), r.Compared)
+	assert.Equal(t, int64(2), r.Errors)
+	assert.Equal(t, "primary key column id isn't migrated", r.Error)
+	assert.False(t, r.Ok())
+}
+
+func TestRowValidatorBadRows(t *testing.T) {
+	v := NewRowValidator(validationConv(), Config{
+		Read: func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error) {
+			return []*sp.Row{spannerRow(1, "1", "{}")}, nil
+		},
+	})
+	v.AddRow("orders", []string{"id", "price", "doc"}, []interface{}{int64(1), *big.NewRat(1, 1), `{}`})
+	v.Flush()
+	// Bad rows are counted by source table.
+	v.addBadRows(map[string]int64{"orders": 2, "unknown": 1})
+	r := v.Results()[1]
+	assert.Equal(t, TableResult{Table: "orders", Compared: 1, Matched: 1, BadRows: 2}, r)
+	assert.False(t, r.Ok())
+}
+
+func TestRowValidatorSampling(t *testing.T) {
+	reads := 0
+	v := NewRowValidator(validationConv(), Config{
+		SampleRate: 0.5,
+		Read: func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error) {
+			reads += len(keys)
+			var rows []*sp.Row
+			for _, k := range keys {
+				rows = append(rows, spannerRow(k[0].(int64), "1", "{}"))
+			}
+			return rows, nil
+		},
+	})
+	for i := 0; i < 1000; i++ {
+		v.AddRow("orders", []string{"id", "price", "doc"}, []interface{}{int64(i), *big.NewRat(1, 1), `{}`})
+	}
+	v.Flush()
+	r := v.Results()[1]
+	assert.True(t, r.Ok())
+	assert.Equal(t, int64(reads), r.Compared)
+	assert.True(t, reads > 300 && reads < 700, fmt.Sprintf("compared %d rows", reads))
+	assert.Empty(t, r.Error)
+}
+
+func TestRowValidatorNamedSchema(t *testing.T) {
+	conv := validationConv()
+	orders := conv.SpSchema["t1"]
+	orders.SchemaName = "sales"
+	conv.SpSchema["t1"] = orders
+	v := NewRowValidator(conv, Config{
+		Read: func(table string, keys []sp.Key, cols []string) ([]*sp.Row, error) {
+			assert.Equal(t, "sales.orders", table)
+			return []*sp.Row{spannerRow(1, "1", "{}")}, nil
+		},
+	})
+	// Rows of tables in named schemas are written with qualified names.
+	v.AddRow("sales.orders", []string{"id", "price", "doc"}, []interface{}{int64(1), *big.NewRat(1, 1), `{}`})
+	v.Flush()
+	r := v.Results()[1]
+	assert.Equal(t, "sales.orders", r.Table)
+	assert.Equal(t, int64(1), r.Compared)
+	assert.True(t, r.Ok())
+}
@@ -0,0 +1,604 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+)
+
+// Kinds of schema objects compared by DiffSchema.
+const (
+	KindTable             = "table"
+	KindColumn            = "column"
+	KindPrimaryKey        = "primary key"
+	KindInterleave        = "interleave"
+	KindRowDeletionPolicy = "row deletion policy"
+	KindIndex             = "index"
+	KindSearchIndex       = "search index"
+	KindVectorIndex       = "vector index"
+	KindForeignKey        = "foreign key"
+	KindCheckConstraint   = "check constraint"
+	KindSequence          = "sequence"
+	KindView              = "view"
+)
+
+// SchemaDifference is a difference between the schema of the session file
+// and the schema of the Spanner database.
+type SchemaDifference struct {
+	Kind  string `json:"kind"`
+	Table string `json:"table,omitempty"`
+	Name  string `json:"name"`
+	// Property is the property of the object that differs, e.g. the type of
+	// a column. It is empty if the object is missing on one side.
+	Property string `json:"property,omitempty"`
+	// Expected is the object or property according to the session file,
+	// and is empty if the object isn't in the session file.
+	Expected string `json:"expected"`
+	// Actual is the object or property in the Spanner database, and is empty
+	// if the object isn't in the database.
+	Actual string `json:"actual"`
+}
+
+// String returns a one line description of the difference.
+func (d SchemaDifference) String() string {
+	s := fmt.Sprintf("%s %q", d.Kind, d.Name)
+	if d.Table != "" && d.Kind != KindTable {
+		s = fmt.Sprintf("%s: %s", d.Table, s)
+	}
+	switch {
+	case d.Actual == "" && d.Property == "":
+		return s + " is missing from the database"
+	case d.Expected == "" && d.Property == "":
+		return s + " isn't in the session file"
+	}
+	return fmt.Sprintf("%s %s: expected %s, found %s", s, d.Property, orNone(d.Expected), orNone(d.Actual))
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// LiveSchema is the schema of a Spanner database, in the form compared by
+// DiffSchema.
+type LiveSchema struct {
+	Tables    map[string]LiveTable
+	Sequences map[string]ddl.Sequence
+	Views     map[string]ddl.CreateView
+}
+
+// LiveTable is a table of a Spanner database. Columns are referred to by
+// name throughout.
+type LiveTable struct {
+	Name        string
+	Columns     []LiveColumn
+	PrimaryKeys []string
+	Interleave  string
+	// RowDeletionPolicy is the expression of the row deletion policy of the
+	// table, e.g. OLDER_THAN(col, INTERVAL 30 DAY), if it has one.
+	RowDeletionPolicy string
+	Indexes           []LiveIndex
+	SearchIndexes     []schema.Index
+	VectorIndexes     []schema.Index
+	ForeignKeys       []schema.ForeignKey
+	CheckConstraints  []schema.CheckConstraint
+}
+
+// LiveIndex is a secondary index of a Spanner table.
+type LiveIndex struct {
+	schema.Index
+	NullFiltered bool
+	// InterleaveIn is the name of the table the index is interleaved in.
+	InterleaveIn string
+}
+
+// LiveColumn is a column of a Spanner table.
+type LiveColumn struct {
+	Name    string
+	Type    schema.Type
+	NotNull bool
+}
+
+// ReadLiveSchema reads the schema of a Spanner database from its
+// information schema.
+func ReadLiveSchema(isi spanner.InfoSchemaImpl) (LiveSchema, error) {
+	live := LiveSchema{Tables: make(map[string]LiveTable)}
+	// The conv is only used to record unexpected conditions.
+	conv := internal.MakeConv()
+	tables, err := isi.GetTables()
+	if err != nil {
+		return live, err
+	}
+	// GetInterleaveTables looks up parent tables in a schema; a schema keyed
+	// by table name makes it return the names of the parents.
+	byName := make(ddl.Schema)
+	for _, table := range tables {
+		name := isi.GetTableName(table.Schema, table.Name)
+		t := LiveTable{Name: name}
+		colDefs, colIds, err := isi.GetColumns(conv, table, nil, nil)
+		if err != nil {
+			return live, err
+		}
+		colNames := make(map[string]string)
+		for _, id := range colIds {
+			c := colDefs[id]
+			t.Columns = append(t.Columns, LiveColumn{Name: c.Name, Type: c.Type, NotNull: c.NotNull})
+			colNames[c.Name] = c.Name
+		}
+		if t.PrimaryKeys, _, _, err = isi.GetConstraints(conv, table); err != nil {
+			return live, err
+		}
+		indexes, err := isi.GetIndexes(conv, table, colNames)
+		if err != nil {
+			return live, err
+		}
+		attributes, err := isi.GetIndexAttributes(table)
+		if err != nil {
+			return live, err
+		}
+		for _, idx := range indexes {
+			a := attributes[idx.Name]
+			t.Indexes = append(t.Indexes, LiveIndex{Index: idx, NullFiltered: a.NullFiltered, InterleaveIn: a.InterleaveIn})
+		}
+		if t.SearchIndexes, err = isi.GetSearchIndexes(conv, table, colNames); err != nil {
+			return live, err
+		}
+		if t.VectorIndexes, err = isi.GetVectorIndexes(conv, table, colNames); err != nil {
+			return live, err
+		}
+		if t.ForeignKeys, err = isi.GetForeignKeys(conv, table); err != nil {
+			return live, err
+		}
+		if t.CheckConstraints, err = isi.GetCheckConstraints(table); err != nil {
+			return live, err
+		}
+		live.Tables[name] = t
+		byName[name] = ddl.CreateTable{Name: name, Id: name}
+	}
+	parents, err := isi.GetInterleaveTables(byName)
+	if err != nil {
+		return live, err
+	}
+	for name, p := range parents {
+		t, ok := live.Tables[name]
+		if !ok {
+			continue
+		}
+		t.Interleave = interleaveClause(p.InterleaveType, p.Id, p.OnDelete)
+		live.Tables[name] = t
+	}
+	policies, err := isi.GetRowDeletionPolicies()
+	if err != nil {
+		return live, err
+	}
+	for name, expr := range policies {
+		if t, ok := live.Tables[name]; ok {
+			t.RowDeletionPolicy = expr
+			live.Tables[name] = t
+		}
+	}
+	if live.Sequences, err = isi.GetSequences(); err != nil {
+		return live, err
+	}
+	if live.Views, err = isi.GetSpannerViews(); err != nil {
+		return live, err
+	}
+	return live, nil
+}
+
+// DiffSchema compares the tables, sequences and views of conv with the live
+// schema of the Spanner database. Differences are sorted by table, kind and
+// name. The options of vector indexes, such as their distance type, aren't
+// compared.
+func DiffSchema(conv *internal.Conv, live LiveSchema) []SchemaDifference {
+	var diffs []SchemaDifference
+	pg := conv.SpDialect == constants.DIALECT_POSTGRESQL
+	expected := make(map[string]ddl.CreateTable)
+	for _, ct := range conv.SpSchema {
+		expected[ct.QualifiedName()] = ct
+	}
+	for name, ct := range expected {
+		lt, ok := live.Tables[name]
+		if !ok {
+			diffs = append(diffs, SchemaDifference{Kind: KindTable, Table: name, Name: name, Expected: name})
+			continue
+		}
+		diffs = append(diffs, diffTable(conv, ct, lt, pg)...)
+	}
+	for name := range live.Tables {
+		if _, ok := expected[name]; !ok {
+			diffs = append(diffs, SchemaDifference{Kind: KindTable, Table: name, Name: name, Actual: name})
+		}
+	}
+	expectedSeqs := make(map[string]ddl.Sequence)
+	for _, seq := range conv.SpSequences {
+		expectedSeqs[ddl.QualifiedName(seq.SchemaName, seq.Name)] = seq
+	}
+	for name, seq := range expectedSeqs {
+		ls, ok := live.Sequences[name]
+		if !ok {
+			diffs = append(diffs, SchemaDifference{Kind: KindSequence, Name: name, Expected: name})
+			continue
+		}
+		if pg {
+			// Options of PostgreSQL sequences aren't read.
+			continue
+		}
+		props := []struct{ name, expected, actual string }{
+			{"kind", seq.SequenceKind, ls.SequenceKind},
+			{"skip range min", seq.SkipRangeMin, ls.SkipRangeMin},
+			{"skip range max", seq.SkipRangeMax, ls.SkipRangeMax},
+			{"start with counter", seq.StartWithCounter, ls.StartWithCounter},
+		}
+		for _, p := range props {
+			if p.expected != p.actual {
+				diffs = append(diffs, SchemaDifference{Kind: KindSequence, Name: name, Property: p.name, Expected: p.expected, Actual: p.actual})
+			}
+		}
+	}
+	for name := range live.Sequences {
+		if _, ok := expectedSeqs[name]; !ok {
+			diffs = append(diffs, SchemaDifference{Kind: KindSequence, Name: name, Actual: name})
+		}
+	}
+	diffs = append(diffs, diffViews(conv, live.Views)...)
+	sort.Slice(diffs, func(i, j int) bool {
+		a, b := diffs[i], diffs[j]
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.Kind != b.Kind {
+			return kindOrder(a.Kind) < kindOrder(b.Kind)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Property < b.Property
+	})
+	return diffs
+}
+
+func kindOrder(kind string) int {
+	for i, k := range []string{KindTable, KindColumn, KindPrimaryKey, KindInterleave, KindRowDeletionPolicy, KindIndex, KindSearchIndex, KindVectorIndex, KindForeignKey, KindCheckConstraint, KindSequence, KindView} {
+		if k == kind {
+			return i
+		}
+	}
+	return -1
+}
+
+func diffTable(conv *internal.Conv, ct ddl.CreateTable, lt LiveTable, pg bool) []SchemaDifference {
+	var diffs []SchemaDifference
+	add := func(kind, name, property, expected, actual string) {
+		if expected != actual {
+			diffs = append(diffs, SchemaDifference{Kind: kind, Table: ct.QualifiedName(), Name: name, Property: property, Expected: expected, Actual: actual})
+		}
+	}
+	colName := func(id string) string { return ct.ColDefs[id].Name }
+
+	liveCols := make(map[string]LiveColumn)
+	for _, c := range lt.Columns {
+		liveCols[c.Name] = c
+	}
+	for _, id := range ct.ColIds {
+		cd := ct.ColDefs[id]
+		lc, ok := liveCols[cd.Name]
+		if !ok {
+			add(KindColumn, cd.Name, "", cd.Name, "")
+			continue
+		}
+		add(KindColumn, cd.Name, "type", typeString(cd.T, pg), liveTypeString(lc.Type, pg))
+		add(KindColumn, cd.Name, "nullability", nullability(cd.NotNull), nullability(lc.NotNull))
+		delete(liveCols, cd.Name)
+	}
+	for name := range liveCols {
+		add(KindColumn, name, "", "", name)
+	}
+
+	pks := append([]ddl.IndexKey{}, ct.PrimaryKeys...)
+	sort.SliceStable(pks, func(i, j int) bool { return pks[i].Order < pks[j].Order })
+	var pkCols []string
+	for _, pk := range pks {
+		pkCols = append(pkCols, colName(pk.ColId))
+	}
+	add(KindPrimaryKey, ct.QualifiedName(), "columns", columnList(pkCols), columnList(lt.PrimaryKeys))
+
+	expectedInterleave := ""
+	if parent, ok := conv.SpSchema[ct.ParentTable.Id]; ok && ct.ParentTable.Id != "" {
+		interleaveType := "IN PARENT"
+		if ct.ParentTable.InterleaveType == "IN" {
+			interleaveType = "IN"
+		}
+		expectedInterleave = interleaveClause(interleaveType, parent.QualifiedName(), ct.ParentTable.OnDelete)
+	}
+	add(KindInterleave, ct.QualifiedName(), "parent", expectedInterleave, lt.Interleave)
+
+	// Row deletion policies are compared regardless of case and white
+	// space, since Spanner returns them as written.
+	expectedPolicy := ""
+	if rdp := ct.RowDeletionPolicy; rdp != nil {
+		if pg {
+			expectedPolicy = fmt.Sprintf("INTERVAL '%d DAYS' ON %s", rdp.Days, colName(rdp.ColId))
+		} else {
+			expectedPolicy = fmt.Sprintf("OLDER_THAN(%s, INTERVAL %d DAY)", colName(rdp.ColId), rdp.Days)
+		}
+	}
+	if normalizePolicy(expectedPolicy) != normalizePolicy(lt.RowDeletionPolicy) {
+		add(KindRowDeletionPolicy, ct.QualifiedName(), "expression", expectedPolicy, lt.RowDeletionPolicy)
+	}
+
+	liveIndexes := make(map[string]string)
+	for _, idx := range lt.Indexes {
+		var keys []string
+		for _, k := range idx.Keys {
+			keys = append(keys, keyString(k.ColId, k.Desc))
+		}
+		liveIndexes[idx.Name] = indexString(idx.Unique, idx.NullFiltered, keys, idx.StoredColumnIds, idx.InterleaveIn)
+	}
+	for _, idx := range ct.Indexes {
+		ks := append([]ddl.IndexKey{}, idx.Keys...)
+		sort.SliceStable(ks, func(i, j int) bool { return ks[i].Order < ks[j].Order })
+		var keys, stored []string
+		for _, k := range ks {
+			keys = append(keys, keyString(colName(k.ColId), k.Desc))
+		}
+		for _, id := range idx.StoredColumnIds {
+			stored = append(stored, colName(id))
+		}
+		interleaveIn := ""
+		if parent, ok := conv.SpSchema[idx.InterleaveIn]; ok && idx.InterleaveIn != "" {
+			interleaveIn = parent.QualifiedName()
+		}
+		compareObjects(add, KindIndex, idx.Name, "definition", indexString(idx.Unique, idx.NullFiltered, keys, stored, interleaveIn), liveIndexes)
+	}
+	addExtra(add, KindIndex, liveIndexes)
+
+	liveSearchIndexes := make(map[string]string)
+	for _, idx := range lt.SearchIndexes {
+		liveSearchIndexes[idx.Name] = liveKeyList(idx.Keys)
+	}
+	for _, idx := range ct.SearchIndexes {
+		compareObjects(add, KindSearchIndex, idx.Name, "columns", keyList(idx.Keys, colName), liveSearchIndexes)
+	}
+	addExtra(add, KindSearchIndex, liveSearchIndexes)
+
+	liveVectorIndexes := make(map[string]string)
+	for _, idx := range lt.VectorIndexes {
+		liveVectorIndexes[idx.Name] = liveKeyList(idx.Keys)
+	}
+	for _, idx := range ct.VectorIndexes {
+		compareObjects(add, KindVectorIndex, idx.Name, "columns", keyList(idx.Keys, colName), liveVectorIndexes)
+	}
+	addExtra(add, KindVectorIndex, liveVectorIndexes)
+
+	liveFks := make(map[string]string)
+	for _, fk := range lt.ForeignKeys {
+		liveFks[fk.Name] = foreignKeyString(fk.ColumnNames, fk.ReferTableName, fk.ReferColumnNames)
+	}
+	for _, fk := range ct.ForeignKeys {
+		refer := conv.SpSchema[fk.ReferTableId]
+		var cols, referCols []string
+		for _, id := range fk.ColIds {
+			cols = append(cols, colName(id))
+		}
+		for _, id := range fk.ReferColumnIds {
+			referCols = append(referCols, refer.ColDefs[id].Name)
+		}
+		compareObjects(add, KindForeignKey, fk.Name, "definition", foreignKeyString(cols, refer.QualifiedName(), referCols), liveFks)
+	}
+	addExtra(add, KindForeignKey, liveFks)
+
+	liveChecks := make(map[string]string)
+	for _, cc := range lt.CheckConstraints {
+		liveChecks[cc.Name] = normalizeExpr(cc.Expr)
+	}
+	for _, cc := range ct.CheckConstraints {
+		compareObjects(add, KindCheckConstraint, cc.Name, "expression", normalizeExpr(cc.Expr), liveChecks)
+	}
+	addExtra(add, KindCheckConstraint, liveChecks)
+	return diffs
+}
+
+// diffViews compares the views of conv with the live views. Queries are
+// compared without white space.
+func diffViews(conv *internal.Conv, live map[string]ddl.CreateView) []SchemaDifference {
+	var diffs []SchemaDifference
+	expected := make(map[string]ddl.CreateView)
+	for _, v := range conv.SpViews {
+		expected[v.QualifiedName()] = v
+	}
+	for name, v := range expected {
+		lv, ok := live[name]
+		if !ok {
+			diffs = append(diffs, SchemaDifference{Kind: KindView, Name: name, Expected: name})
+			continue
+		}
+		if normalizeExpr(v.Query) != normalizeExpr(lv.Query) {
+			diffs = append(diffs, SchemaDifference{Kind: KindView, Name: name, Property: "query", Expected: v.Query, Actual: lv.Query})
+		}
+		if sqlSecurity(v.SqlSecurity) != sqlSecurity(lv.SqlSecurity) {
+			diffs = append(diffs, SchemaDifference{Kind: KindView, Name: name, Property: "sql security", Expected: sqlSecurity(v.SqlSecurity), Actual: sqlSecurity(lv.SqlSecurity)})
+		}
+	}
+	for name := range live {
+		if _, ok := expected[name]; !ok {
+			diffs = append(diffs, SchemaDifference{Kind: KindView, Name: name, Actual: name})
+		}
+	}
+	return diffs
+}
+
+// sqlSecurity returns the SQL security of a view, which is INVOKER unless
+// set.
+func sqlSecurity(s string) string {
+	if s == "" {
+		return ddl.SqlSecurityInvoker
+	}
+	return strings.ToUpper(s)
+}
+
+// compareObjects compares the definition of a named object with the live
+// object of the same name, which is removed from live.
+func compareObjects(add func(kind, name, property, expected, actual string), kind, name, property, expected string, live map[string]string) {
+	actual, ok := live[name]
+	if !ok {
+		add(kind, name, "", expected, "")
+		return
+	}
+	add(kind, name, property, expected, actual)
+	delete(live, name)
+}
+
+// addExtra adds the live objects that aren't in the session file.
+func addExtra(add func(kind, name, property, expected, actual string), kind string, live map[string]string) {
+	for name, def := range live {
+		add(kind, name, "", "", def)
+	}
+}
+
+// typeString returns the GoogleSQL name of a type. Lengths that don't
+// apply to PostgreSQL-dialect databases are left out.
+func typeString(t ddl.Type, pg bool) string {
+	if pg && (t.Name == ddl.Bytes || t.Name == ddl.String && t.Len == ddl.PGMaxLength) {
+		t.Len = ddl.MaxLength
+	}
+	return t.PrintColumnDefType()
+}
+
+func liveTypeString(t schema.Type, pg bool) string {
+	ty, issues := spanner.ToDdlImpl{}.ToSpannerType(nil, "", t, false)
+	if len(issues) > 0 {
+		return strings.ToUpper(t.Name)
+	}
+	return typeString(ty, pg)
+}
+
+func nullability(notNull bool) string {
+	if notNull {
+		return "NOT NULL"
+	}
+	return "NULL"
+}
+
+func interleaveClause(interleaveType, parent, onDelete string) string {
+	s := interleaveType + " " + parent
+	if interleaveType == "IN PARENT" {
+		if onDelete == "" {
+			onDelete = constants.FK_NO_ACTION
+		}
+		s += " ON DELETE " + onDelete
+	}
+	return s
+}
+
+func keyString(col string, desc bool) string {
+	if desc {
+		return col + " DESC"
+	}
+	return col
+}
+
+func columnList(cols []string) string {
+	return "(" + strings.Join(cols, ", ") + ")"
+}
+
+func indexString(unique, nullFiltered bool, keys, stored []string, interleaveIn string) string {
+	s := columnList(keys)
+	if nullFiltered {
+		s = "NULL_FILTERED " + s
+	}
+	if unique {
+		s = "UNIQUE " + s
+	}
+	if len(stored) > 0 {
+		s += " STORING " + columnList(stored)
+	}
+	if interleaveIn != "" {
+		s += " INTERLEAVE IN " + interleaveIn
+	}
+	return s
+}
+
+// keyList returns the list of the columns of keys of the session file.
+func keyList(keys []ddl.IndexKey, colName func(id string) string) string {
+	var cols []string
+	for _, k := range keys {
+		cols = append(cols, colName(k.ColId))
+	}
+	return columnList(cols)
+}
+
+// liveKeyList returns the list of the columns of keys read from the
+// database, which refer to columns by name.
+func liveKeyList(keys []schema.Key) string {
+	var cols []string
+	for _, k := range keys {
+		cols = append(cols, k.ColId)
+	}
+	return columnList(cols)
+}
+
+// normalizePolicy removes white space from a row deletion policy and
+// converts it to upper case.
+func normalizePolicy(expr string) string {
+	return strings.ToUpper(normalizeExpr(expr))
+}
+
+func foreignKeyString(cols []string, referTable string, referCols []string) string {
+	return fmt.Sprintf("%s REFERENCES %s %s", columnList(cols), referTable, columnList(referCols))
+}
+
+// normalizeExpr removes white space and enclosing parentheses, which
+// Spanner doesn't necessarily keep, from an expression.
+func normalizeExpr(expr string) string {
+	s := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, expr)
+	for strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") && balanced(s[1:len(s)-1]) {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
+
+// balanced returns whether the parentheses of s are balanced.
+func balanced(s string) bool {
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
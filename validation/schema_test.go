@@ -0,0 +1,331 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	sp "cloud.google.com/go/spanner"
+	spannerclient "github.com/GoogleCloudPlatform/spanner-migration-tool/accessors/clients/spanner/client"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/schema"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/sources/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/iterator"
+)
+
+func schemaConv() *internal.Conv {
+	conv := internal.MakeConv()
+	conv.SpDialect = constants.DIALECT_GOOGLESQL
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "singers",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c2": {Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: 100}},
+			},
+			PrimaryKeys:      []ddl.IndexKey{{ColId: "c1", Order: 1}},
+			Indexes:          []ddl.CreateIndex{{Name: "by_name", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}}}},
+			CheckConstraints: []ddl.CheckConstraint{{Name: "positive", Expr: "(id > 0)"}},
+		},
+		"t2": {
+			Name:   "albums",
+			Id:     "t2",
+			ColIds: []string{"c3", "c4"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c3": {Name: "singer_id", Id: "c3", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+				"c4": {Name: "album_id", Id: "c4", T: ddl.Type{Name: ddl.Int64}, NotNull: true},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c3", Order: 1}, {ColId: "c4", Order: 2}},
+			ParentTable: ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE},
+			ForeignKeys: []ddl.Foreignkey{{Name: "fk_singer", ColIds: []string{"c3"}, ReferTableId: "t1", ReferColumnIds: []string{"c1"}}},
+		},
+		"t3": {Name: "concerts", Id: "t3"},
+	}
+	conv.SpSequences = map[string]ddl.Sequence{
+		"s1": {Id: "s1", Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "10"},
+	}
+	return conv
+}
+
+func conformingSchema() LiveSchema {
+	return LiveSchema{
+		Tables: map[string]LiveTable{
+			"singers": {
+				Name: "singers",
+				Columns: []LiveColumn{
+					{Name: "id", Type: schema.Type{Name: "INT64"}, NotNull: true},
+					{Name: "name", Type: schema.Type{Name: "STRING", Mods: []int64{100}}},
+				},
+				PrimaryKeys:      []string{"id"},
+				Indexes:          []LiveIndex{{Index: schema.Index{Name: "by_name", Keys: []schema.Key{{ColId: "name"}}}}},
+				CheckConstraints: []schema.CheckConstraint{{Name: "positive", Expr: "id>0"}},
+			},
+			"albums": {
+				Name: "albums",
+				Columns: []LiveColumn{
+					{Name: "singer_id", Type: schema.Type{Name: "INT64"}, NotNull: true},
+					{Name: "album_id", Type: schema.Type{Name: "INT64"}, NotNull: true},
+				},
+				PrimaryKeys: []string{"singer_id", "album_id"},
+				Interleave:  "IN PARENT singers ON DELETE CASCADE",
+				ForeignKeys: []schema.ForeignKey{{Name: "fk_singer", ColumnNames: []string{"singer_id"}, ReferTableName: "singers", ReferColumnNames: []string{"id"}}},
+			},
+			"concerts": {Name: "concerts"},
+		},
+		Sequences: map[string]ddl.Sequence{
+			"seq": {Name: "seq", SequenceKind: "BIT REVERSED POSITIVE", SkipRangeMin: "1", SkipRangeMax: "10"},
+		},
+	}
+}
+
+func TestDiffSchema(t *testing.T) {
+	conv := schemaConv()
+	assert.Empty(t, DiffSchema(conv, conformingSchema()))
+
+	live := conformingSchema()
+	singers := live.Tables["singers"]
+	singers.Columns = []LiveColumn{
+		{Name: "id", Type: schema.Type{Name: "INT64"}, NotNull: true},
+		{Name: "name", Type: schema.Type{Name: "STRING", Mods: []int64{ddl.MaxLength}}, NotNull: true},
+		{Name: "extra", Type: schema.Type{Name: "BOOL"}},
+	}
+	singers.Indexes = []LiveIndex{{Index: schema.Index{Name: "by_name", Unique: true, Keys: []schema.Key{{ColId: "name"}}}}}
+	singers.CheckConstraints = nil
+	live.Tables["singers"] = singers
+	albums := live.Tables["albums"]
+	albums.Interleave = ""
+	albums.ForeignKeys = nil
+	live.Tables["albums"] = albums
+	delete(live.Tables, "concerts")
+	live.Tables["tickets"] = LiveTable{Name: "tickets"}
+	live.Sequences["seq"] = ddl.Sequence{Name: "seq", SequenceKind: "BIT REVERSED POSITIVE"}
+
+	var got []string
+	for _, d := range DiffSchema(conv, live) {
+		got = append(got, d.String())
+	}
+	assert.Equal(t, []string{
+		`sequence "seq" skip range max: expected 10, found none`,
+		`sequence "seq" skip range min: expected 1, found none`,
+		`albums: interleave "albums" parent: expected IN PARENT singers ON DELETE CASCADE, found none`,
+		`albums: foreign key "fk_singer" is missing from the database`,
+		`table "concerts" is missing from the database`,
+		`singers: column "extra" isn't in the session file`,
+		`singers: column "name" nullability: expected NULL, found NOT NULL`,
+		`singers: column "name" type: expected STRING(100), found STRING(MAX)`,
+		`singers: index "by_name" definition: expected (name), found UNIQUE (name)`,
+		`singers: check constraint "positive" is missing from the database`,
+		`table "tickets" isn't in the session file`,
+	}, got)
+}
+
+func TestDiffSchemaPostgreSQL(t *testing.T) {
+	conv := schemaConv()
+	conv.SpDialect = constants.DIALECT_POSTGRESQL
+	singers := conv.SpSchema["t1"]
+	singers.ColDefs["c2"] = ddl.ColumnDef{Name: "name", Id: "c2", T: ddl.Type{Name: ddl.String, Len: ddl.PGMaxLength}}
+	live := conformingSchema()
+	live.Tables["singers"].Columns[1] = LiveColumn{Name: "name", Type: schema.Type{Name: "character varying"}}
+	// Sequence options aren't read for PostgreSQL.
+	live.Sequences["seq"] = ddl.Sequence{Name: "seq"}
+	assert.Empty(t, DiffSchema(conv, live))
+}
+
+func TestNormalizeExpr(t *testing.T) {
+	assert.Equal(t, "id>0", normalizeExpr("((id > 0))"))
+	assert.Equal(t, "(a>0)AND(b>0)", normalizeExpr("(a > 0) AND (b > 0)"))
+}
+
+func TestReadLiveSchema(t *testing.T) {
+	results := []struct {
+		query string
+		cols  []string
+		rows  [][]interface{}
+	}{
+		{"interleave_type", []string{"table_schema", "table_name", "parent_table_name", "on_delete_action", "interleave_type"}, [][]interface{}{{"", "albums", "singers", "CASCADE", "IN PARENT"}}},
+		{"row_deletion_policy_expression", []string{"table_schema", "table_name", "row_deletion_policy_expression"}, nil},
+		{"information_schema.tables", []string{"table_schema", "table_name"}, [][]interface{}{{"", "singers"}, {"", "albums"}, {"", "concerts"}}},
+		{"information_schema.columns", []string{"column_name", "spanner_type", "is_nullable"}, nil},
+		{"check_constraints", []string{"constraint_name", "check_clause"}, nil},
+		{"FOREIGN KEY", []string{"constraint_name", "column_name", "table_schema", "table_name", "column_name"}, nil},
+		{"KEY_COLUMN_USAGE", []string{"column_name", "constraint_type"}, nil},
+		{"index_columns", []string{"INDEX_NAME", "COLUMN_NAME", "ORDINAL_POSITION", "COLUMN_ORDERING", "IS_UNIQUE"}, nil},
+		{"IS_NULL_FILTERED", []string{"INDEX_NAME", "IS_NULL_FILTERED", "PARENT_TABLE_NAME"}, nil},
+		{"information_schema.views", []string{"table_schema", "table_name", "view_definition", "security_type"}, [][]interface{}{
+			{"", "singer_names", "SELECT name\n  FROM singers", sp.NullString{StringVal: "INVOKER", Valid: true}},
+		}},
+		{"sequences", []string{"schema", "name", "option_name", "option_value"}, [][]interface{}{
+			{"", "seq", sp.NullString{StringVal: "sequence_kind", Valid: true}, sp.NullString{StringVal: "bit_reversed_positive", Valid: true}},
+			{"", "seq", sp.NullString{StringVal: "skip_range_min", Valid: true}, sp.NullString{StringVal: "1", Valid: true}},
+			{"", "seq", sp.NullString{StringVal: "skip_range_max", Valid: true}, sp.NullString{StringVal: "10", Valid: true}},
+		}},
+	}
+	// Rows of per-table queries, keyed by query and table, and by index type
+	// for index queries.
+	tableRows := map[string]map[string][][]interface{}{
+		"information_schema.columns": {
+			"singers":  {{"id", "INT64", "NO"}, {"name", "STRING(100)", "YES"}},
+			"albums":   {{"singer_id", "INT64", "NO"}, {"album_id", "INT64", "NO"}},
+			"concerts": {},
+		},
+		"check_constraints": {"singers": {{"CK_IS_NOT_NULL_singers_id", "id IS NOT NULL"}, {"positive", "id > 0"}}},
+		"FOREIGN KEY":       {"albums": {{"fk_singer", "singer_id", "", "singers", "id"}}},
+		"KEY_COLUMN_USAGE": {
+			"singers": {{"id", "PRIMARY KEY"}},
+			"albums":  {{"singer_id", "PRIMARY KEY"}, {"album_id", "PRIMARY KEY"}},
+		},
+		"index_columns": {"singers/INDEX": {
+			{"by_name", "name", sp.NullInt64{Int64: 1, Valid: true}, sp.NullString{StringVal: "ASC", Valid: true}, false},
+		}},
+		"IS_NULL_FILTERED": {"singers": {{"by_name", false, sp.NullString{}}}},
+	}
+	client := spannerclient.SpannerClientMock{
+		SingleMock: func() spannerclient.ReadOnlyTransaction {
+			return spannerclient.ReadOnlyTransactionMock{
+				QueryMock: func(ctx context.Context, stmt sp.Statement) spannerclient.RowIterator {
+					var cols []string
+					var rows [][]interface{}
+					for _, r := range results {
+						if strings.Contains(stmt.SQL, r.query) {
+							cols, rows = r.cols, r.rows
+							if byTable, ok := tableRows[r.query]; ok {
+								key := stmt.Params["p1"].(string)
+								if indexType, ok := stmt.Params["p3"]; ok {
+									key += "/" + indexType.(string)
+								}
+								rows = byTable[key]
+							}
+							break
+						}
+					}
+					i := 0
+					return &spannerclient.RowIteratorMock{
+						NextMock: func() (*sp.Row, error) {
+							if i == len(rows) {
+								return nil, iterator.Done
+							}
+							i++
+							return sp.NewRow(cols, rows[i-1])
+						},
+						StopMock: func() {},
+					}
+				},
+			}
+		},
+	}
+	live, err := ReadLiveSchema(spanner.InfoSchemaImpl{SpannerClient: client, Ctx: context.Background(), SpDialect: constants.DIALECT_GOOGLESQL})
+	assert.Nil(t, err)
+	conv := schemaConv()
+	conv.SpViews["v1"] = ddl.CreateView{Name: "singer_names", Query: "SELECT name FROM singers", Id: "v1"}
+	assert.Empty(t, DiffSchema(conv, live))
+}
+
+func TestDiffSchemaNamedSchemas(t *testing.T) {
+	conv := schemaConv()
+	for _, id := range []string{"t1", "t2"} {
+		ct := conv.SpSchema[id]
+		ct.SchemaName = "music"
+		conv.SpSchema[id] = ct
+	}
+	seq := conv.SpSequences["s1"]
+	seq.SchemaName = "music"
+	conv.SpSequences["s1"] = seq
+
+	live := conformingSchema()
+	for _, name := range []string{"singers", "albums"} {
+		lt := live.Tables[name]
+		lt.Name = "music." + name
+		live.Tables["music."+name] = lt
+		delete(live.Tables, name)
+	}
+	albums := live.Tables["music.albums"]
+	albums.Interleave = "IN PARENT music.singers ON DELETE CASCADE"
+	albums.ForeignKeys[0].ReferTableName = "music.singers"
+	live.Tables["music.albums"] = albums
+	live.Sequences["music.seq"] = live.Sequences["seq"]
+	delete(live.Sequences, "seq")
+	assert.Empty(t, DiffSchema(conv, live))
+
+	// A table of the default schema doesn't match a table of a named schema.
+	live.Tables["singers"] = live.Tables["music.singers"]
+	delete(live.Tables, "music.singers")
+	var got []string
+	for _, d := range DiffSchema(conv, live) {
+		got = append(got, d.String())
+	}
+	assert.Equal(t, []string{
+		`table "music.singers" is missing from the database`,
+		`table "singers" isn't in the session file`,
+	}, got)
+}
+
+func TestDiffSchemaIndexOptionsPoliciesAndViews(t *testing.T) {
+	conv := schemaConv()
+	singers := conv.SpSchema["t1"]
+	singers.Indexes[0].NullFiltered = true
+	singers.Indexes[0].InterleaveIn = "t1"
+	singers.SearchIndexes = []ddl.CreateSearchIndex{{Name: "by_name_tokens", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2"}}}}
+	singers.VectorIndexes = []ddl.CreateVectorIndex{{Name: "by_embedding", TableId: "t1", Keys: []ddl.IndexKey{{ColId: "c2"}}, DistanceType: ddl.CosineDistance}}
+	singers.RowDeletionPolicy = &ddl.RowDeletionPolicy{ColId: "c2", Days: 30}
+	conv.SpSchema["t1"] = singers
+	conv.SpViews["v1"] = ddl.CreateView{Name: "singer_names", Query: "SELECT name FROM singers", Id: "v1"}
+
+	live := conformingSchema()
+	ls := live.Tables["singers"]
+	ls.Indexes[0].NullFiltered = true
+	ls.Indexes[0].InterleaveIn = "singers"
+	ls.SearchIndexes = []schema.Index{{Name: "by_name_tokens", FullText: true, Keys: []schema.Key{{ColId: "name"}}}}
+	ls.VectorIndexes = []schema.Index{{Name: "by_embedding", Keys: []schema.Key{{ColId: "name"}}}}
+	ls.RowDeletionPolicy = "OLDER_THAN(name, INTERVAL 30 DAY)"
+	live.Tables["singers"] = ls
+	live.Views = map[string]ddl.CreateView{"singer_names": {Name: "singer_names", Query: "SELECT name\n FROM singers", SqlSecurity: "INVOKER"}}
+	assert.Empty(t, DiffSchema(conv, live))
+
+	ls.Indexes[0].NullFiltered = false
+	ls.Indexes[0].InterleaveIn = ""
+	ls.SearchIndexes = nil
+	ls.VectorIndexes = append(ls.VectorIndexes, schema.Index{Name: "by_id", Keys: []schema.Key{{ColId: "id"}}})
+	ls.RowDeletionPolicy = "OLDER_THAN(name, INTERVAL 7 DAY)"
+	live.Tables["singers"] = ls
+	live.Views = map[string]ddl.CreateView{
+		"singer_names": {Name: "singer_names", Query: "SELECT id FROM singers", SqlSecurity: "DEFINER"},
+		"old_singers":  {Name: "old_singers", Query: "SELECT id FROM singers"},
+	}
+	var got []string
+	for _, d := range DiffSchema(conv, live) {
+		got = append(got, d.String())
+	}
+	assert.Equal(t, []string{
+		`view "old_singers" isn't in the session file`,
+		`view "singer_names" query: expected SELECT name FROM singers, found SELECT id FROM singers`,
+		`view "singer_names" sql security: expected INVOKER, found DEFINER`,
+		`singers: row deletion policy "singers" expression: expected OLDER_THAN(name, INTERVAL 30 DAY), found OLDER_THAN(name, INTERVAL 7 DAY)`,
+		`singers: index "by_name" definition: expected NULL_FILTERED (name) INTERLEAVE IN singers, found (name)`,
+		`singers: search index "by_name_tokens" is missing from the database`,
+		`singers: vector index "by_id" isn't in the session file`,
+	}, got)
+
+	ls.RowDeletionPolicy = "INTERVAL '30 days' ON name"
+	for _, d := range diffTable(conv, singers, ls, true) {
+		assert.NotEqual(t, KindRowDeletionPolicy, d.Kind)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// canonicalValues encodes converted values the way they are written to
+// Spanner, and returns their canonical form.
+func canonicalValues(types map[string]ddl.Type, cols []string, vals []interface{}) ([]interface{}, error) {
+	row, err := sp.NewRow(cols, vals)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode row: %v", err)
+	}
+	return canonicalRow(types, cols, row)
+}
+
+func canonicalRow(types map[string]ddl.Type, cols []string, row *sp.Row) ([]interface{}, error) {
+	values := make([]interface{}, len(cols))
+	for i, c := range cols {
+		var gcv sp.GenericColumnValue
+		if err := row.ColumnByName(c, &gcv); err != nil {
+			return nil, err
+		}
+		values[i] = canonical(gcv.Value, types[c])
+	}
+	return values, nil
+}
+
+// canonical returns the canonical form of a value of Spanner type t, as
+// encoded for the Spanner API. Values that Spanner stores in a normalized
+// form, such as NUMERIC, TIMESTAMP and JSON values, are normalized so that a
+// converted value and the value Spanner returns for it are equal. Other
+// values are returned as the JSON value of their encoding, so that two
+// values are equal if their canonical forms have the same JSON encoding.
+func canonical(v *structpb.Value, t ddl.Type) interface{} {
+	if v == nil {
+		return nil
+	}
+	if _, ok := v.GetKind().(*structpb.Value_NullValue); ok {
+		return nil
+	}
+	if t.IsArray {
+		l := v.GetListValue()
+		if l == nil {
+			return v.AsInterface()
+		}
+		elem := ddl.Type{Name: t.Name}
+		values := make([]interface{}, len(l.GetValues()))
+		for i, e := range l.GetValues() {
+			values[i] = canonical(e, elem)
+		}
+		return values
+	}
+	s, isString := v.GetKind().(*structpb.Value_StringValue)
+	if !isString {
+		return v.AsInterface()
+	}
+	switch t.Name {
+	case ddl.Numeric:
+		// Converted values are encoded with 9 decimal digits, while Spanner
+		// returns as few digits as needed.
+		if r, ok := new(big.Rat).SetString(s.StringValue); ok {
+			return r.RatString()
+		}
+	case ddl.Timestamp:
+		if ts, err := time.Parse(time.RFC3339Nano, s.StringValue); err == nil {
+			return ts.UTC().Format(time.RFC3339Nano)
+		}
+	case ddl.JSON:
+		// Spanner normalizes JSON documents, e.g. it sorts object keys.
+		var j interface{}
+		if err := json.Unmarshal([]byte(s.StringValue), &j); err == nil {
+			if b, err := json.Marshal(j); err == nil {
+				return string(b)
+			}
+		}
+	}
+	return s.StringValue
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	sp "cloud.google.com/go/spanner"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCanonical(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 600000000, time.FixedZone("IST", 5*3600+1800))
+	tests := []struct {
+		name     string
+		t        ddl.Type
+		value    interface{}
+		expected interface{}
+	}{
+		{"int64", ddl.Type{Name: ddl.Int64}, int64(42), "42"},
+		{"null", ddl.Type{Name: ddl.Int64}, sp.NullInt64{}, nil},
+		{"float64", ddl.Type{Name: ddl.Float64}, 1.25, 1.25},
+		{"nan", ddl.Type{Name: ddl.Float64}, math.NaN(), "NaN"},
+		{"bool", ddl.Type{Name: ddl.Bool}, true, true},
+		{"bytes", ddl.Type{Name: ddl.Bytes}, []byte("ab"), "YWI="},
+		{"date", ddl.Type{Name: ddl.Date}, civil.Date{Year: 2024, Month: 1, Day: 2}, "2024-01-02"},
+		{"timestamp", ddl.Type{Name: ddl.Timestamp}, ts, "2024-01-01T21:34:05.6Z"},
+		{"numeric", ddl.Type{Name: ddl.Numeric}, *big.NewRat(10, 4), "5/2"},
+		{"numeric string", ddl.Type{Name: ddl.Numeric}, "2.500", "5/2"},
+		{"json", ddl.Type{Name: ddl.JSON}, `{"b": 1, "a": [null]}`, `{"a":[null],"b":1}`},
+		{"invalid json", ddl.Type{Name: ddl.JSON}, `{`, `{`},
+		{"array", ddl.Type{Name: ddl.Numeric, IsArray: true}, []sp.NullNumeric{{Numeric: *big.NewRat(1, 2), Valid: true}, {}}, []interface{}{"1/2", nil}},
+	}
+	for _, tc := range tests {
+		row, err := sp.NewRow([]string{"c"}, []interface{}{tc.value})
+		assert.Nil(t, err, tc.name)
+		var gcv sp.GenericColumnValue
+		assert.Nil(t, row.Column(0, &gcv), tc.name)
+		assert.Equal(t, tc.expected, canonical(gcv.Value, tc.t), tc.name)
+	}
+	assert.Equal(t, "1.5", canonical(structpb.NewStringValue("1.5"), ddl.Type{Name: ddl.String}))
+}
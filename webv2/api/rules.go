@@ -271,6 +271,9 @@ func addIndex(newIndex ddl.CreateIndex) (ddl.CreateIndex, error) {
 	}
 
 	sessionState := session.GetSessionState()
+	if err := index.CheckInterleaveIn(sessionState.Conv.SpSchema, newIndex); err != nil {
+		return ddl.CreateIndex{}, err
+	}
 	sp := sessionState.Conv.SpSchema[newIndex.TableId]
 
 	newIndexes := []ddl.CreateIndex{newIndex}
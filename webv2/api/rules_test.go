@@ -129,6 +129,34 @@ func TestApplyRule(t *testing.T) {
 				UsedNames: map[string]bool{"table1": true, "idx1": true, "idx2": true},
 			},
 		},
+		{
+			name: "Index interleaved in a table that isn't an ancestor",
+			input: internal.Rule{
+				Name:              "rule-index1",
+				ObjectType:        "Table",
+				AssociatedObjects: "t1",
+				Enabled:           true,
+				Type:              constants.AddIndex,
+				Data: map[string]interface{}{
+					"Name":         "idx3",
+					"TableId":      "t1",
+					"Unique":       false,
+					"InterleaveIn": "t2",
+					"Keys":         []interface{}{map[string]interface{}{"ColId": "c2", "Desc": false}},
+				},
+			},
+			statusCode: http.StatusInternalServerError,
+			conv: &internal.Conv{
+				SpSchema: map[string]ddl.CreateTable{
+					"t1": {Name: "table1", Id: "t1"},
+					"t2": {Name: "table2", Id: "t2"},
+				},
+				Audit: internal.Audit{
+					MigrationType: migration.MigrationData_SCHEMA_ONLY.Enum(),
+				},
+				UsedNames: map[string]bool{"table1": true, "table2": true},
+			},
+		},
 		{
 			name: "Invalid input",
 			input: internal.Rule{
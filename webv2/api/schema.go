@@ -254,7 +254,7 @@ func GetDDL(w http.ResponseWriter, r *http.Request) {
 			tableDdl = tableDdl + "\n"
 		}
 		for _, index := range table.Indexes {
-			tableDdl = tableDdl + "\n" + index.PrintCreateIndex(table, c) + ";"
+			tableDdl = tableDdl + "\n" + index.PrintCreateIndex(table, sessionState.Conv.SpSchema, c) + ";"
 		}
 		if len(table.ForeignKeys) > 0 {
 			tableDdl = tableDdl + "\n"
@@ -417,6 +417,16 @@ func (tableHandler *TableAPIHandler) GetTableWithErrors(w http.ResponseWriter, r
 						sessionState.Conv.SchemaIssues[tableId].ColumnLevelIssues[columnId] = issues
 					}
 				}
+			case constants.GENERATED_EXPRESSION:
+				{
+					if !exp.Result {
+						tableId := exp.ExpressionDetail.Metadata["TableId"]
+						columnId := exp.ExpressionDetail.Metadata["ColId"]
+						issues := sessionState.Conv.SchemaIssues[tableId].ColumnLevelIssues[columnId]
+						issues = append(issues, internal.GeneratedColumnError)
+						sessionState.Conv.SchemaIssues[tableId].ColumnLevelIssues[columnId] = issues
+					}
+				}
 			}
 		}
 	} else if err != nil {
@@ -967,6 +977,7 @@ func RemoveParentTable(w http.ResponseWriter, r *http.Request) {
 	spTable.ParentTable.OnDelete = ""
 	spTable.ParentTable.InterleaveType = ""
 	conv.SpSchema[tableId] = spTable
+	index.RemoveInvalidInterleaves(conv.SpSchema)
 
 	sessionState.Conv = conv
 
@@ -1006,6 +1017,10 @@ func UpdateIndexes(w http.ResponseWriter, r *http.Request) {
 	sessionState := session.GetSessionState()
 	sessionState.Conv.ConvLock.Lock()
 	defer sessionState.Conv.ConvLock.Unlock()
+	if err = index.CheckInterleaveIn(sessionState.Conv.SpSchema, newIndexes[0]); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	sp := sessionState.Conv.SpSchema[table]
 
 	st := sessionState.Conv.SrcSchema[table]
@@ -1019,6 +1034,8 @@ func UpdateIndexes(w http.ResponseWriter, r *http.Request) {
 			sp.Indexes[i].Name = newIndexes[0].Name
 			sp.Indexes[i].TableId = newIndexes[0].TableId
 			sp.Indexes[i].Unique = newIndexes[0].Unique
+			sp.Indexes[i].NullFiltered = newIndexes[0].NullFiltered
+			sp.Indexes[i].InterleaveIn = newIndexes[0].InterleaveIn
 			sp.Indexes[i].Id = newIndexes[0].Id
 
 			break
@@ -1337,6 +1354,11 @@ func dropTableHelper(w http.ResponseWriter, tableId string) session.ConvWithMeta
 		delete(usedNames, fk.Name)
 	}
 
+	// Views that query the table can't be created without it.
+	internal.InvalidateViews(sessionState.Conv, func(v ddl.CreateView) bool {
+		return internal.Contains(v.RefIds, tableId)
+	}, fmt.Sprintf("the view refers to table %s, which was dropped", spSchema[tableId].Name))
+
 	delete(spSchema, tableId)
 	issues[tableId] = internal.TableIssues{
 		TableLevelIssues:  []internal.SchemaIssue{},
@@ -1367,6 +1389,7 @@ func dropTableHelper(w http.ResponseWriter, tableId string) session.ConvWithMeta
 			spSchema[id] = spTable
 		}
 	}
+	index.RemoveInvalidInterleaves(spSchema)
 
 	// remove interleavable suggestion on droping the parent table
 	for tableName, tableIssues := range issues {
@@ -1424,24 +1447,30 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID, ddl.Interval} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
 		if srcTypeName == "tinyint" {
 			l = append(l, types.TypeIssue{T: ddl.Bool, Brief: "Only tinyint(1) can be converted to BOOL, for any other mods it will be converted to INT64"})
 		}
+		if srcTypeName == "binary" {
+			l = append(l, types.TypeIssue{T: ddl.UUID, Brief: "Only binary(16) can be converted to UUID, for any other mods it will be converted to BYTES"})
+		}
+		if srcTypeName == "char" {
+			l = append(l, types.TypeIssue{T: ddl.UUID, Brief: "Only char(36) can be converted to UUID, for any other mods it will be converted to STRING"})
+		}
 		ty, _ := toddl.ToSpannerType(sessionState.Conv, "", srcType, false)
 		mysqlDefaultTypeMap[srcTypeName] = ty
 		mysqlTypeMap[srcTypeName] = l
 	}
 	// Initialize postgresTypeMap.
 	toddl = postgres.InfoSchemaImpl{}.GetToDdl()
-	for _, srcTypeName := range []string{"bool", "boolean", "bigserial", "bpchar", "character", "bytea", "date", "float8", "double precision", "float4", "real", "int8", "bigint", "int4", "integer", "int2", "smallint", "numeric", "serial", "smallserial", "text", "timestamptz", "timestamp with time zone", "timestamp", "timestamp without time zone", "varchar", "character varying", "path"} {
+	for _, srcTypeName := range []string{"bool", "boolean", "bigserial", "bpchar", "character", "bytea", "date", "float8", "double precision", "float4", "real", "int8", "bigint", "int4", "integer", "int2", "smallint", "numeric", "serial", "smallserial", "text", "timestamptz", "timestamp with time zone", "timestamp", "timestamp without time zone", "uuid", "interval", "varchar", "character varying", "path"} {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID, ddl.Interval} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1456,7 +1485,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID, ddl.Interval} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1471,7 +1500,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID, ddl.Interval} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1486,7 +1515,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = srcTypeName
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID, ddl.Interval} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList(ty.Name, spType, issues, l)
 		}
@@ -1501,7 +1530,7 @@ func initializeTypeMap() {
 		var l []types.TypeIssue
 		srcType := schema.MakeType()
 		srcType.Name = listType
-		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+		for _, spType := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID, ddl.Interval} {
 			ty, issues := toddl.ToSpannerType(sessionState.Conv, spType, srcType, false)
 			l = addTypeToList("ARRAY<"+ty.Name+">", "ARRAY<"+spType+">", issues, l)
 		}
@@ -1606,7 +1635,7 @@ func initializeAutoGenMap(supportsUuidGeneration bool) {
 }
 
 func makePostgresDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsUuidGeneration bool) {
-	for _, srcTypeName := range []string{ddl.Bool, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.PGBytea, ddl.PGFloat4, ddl.PGFloat8, ddl.PGInt8, ddl.PGJSONB, ddl.PGTimestamptz, ddl.PGVarchar, ddl.Numeric} {
+	for _, srcTypeName := range []string{ddl.Bool, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.PGBytea, ddl.PGFloat4, ddl.PGFloat8, ddl.PGInt8, ddl.PGJSONB, ddl.PGTimestamptz, ddl.PGVarchar, ddl.Numeric, ddl.UUID, ddl.Interval} {
 		autoGenMap[srcTypeName] = []types.AutoGen{
 			{
 				Name:           "",
@@ -1641,7 +1670,7 @@ func makePostgresDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsUu
 }
 
 func makeGoogleSqlDialectAutoGenMap(sequences map[string]ddl.Sequence, supportsUuidGeneration bool) {
-	for _, srcTypeName := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON} {
+	for _, srcTypeName := range []string{ddl.Bool, ddl.Bytes, ddl.Date, ddl.Float32, ddl.Float64, ddl.Int64, ddl.String, ddl.Timestamp, ddl.Numeric, ddl.JSON, ddl.UUID, ddl.Interval} {
 		autoGenMap[srcTypeName] = []types.AutoGen{
 			{
 				Name:           "",
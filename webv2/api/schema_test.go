@@ -191,7 +191,8 @@ func TestGetTypeMapMySQL(t *testing.T) {
 			{T: ddl.JSON, DisplayT: ddl.JSON}},
 		"binary": {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
-			{T: ddl.String, DisplayT: ddl.String}},
+			{T: ddl.String, DisplayT: ddl.String},
+			{T: ddl.UUID, Brief: "Only binary(16) can be converted to UUID, for any other mods it will be converted to BYTES", DisplayT: ddl.UUID}},
 		"blob": {
 			{T: ddl.Bytes, DisplayT: ddl.Bytes},
 			{T: ddl.String, DisplayT: ddl.String}},
@@ -221,7 +222,8 @@ func TestGetTypeMapMySQL(t *testing.T) {
 			{T: ddl.String, Brief: reports.IssueDB[internal.Widened].Brief, DisplayT: ddl.String},
 			{T: ddl.Timestamp, DisplayT: ddl.Timestamp}},
 		"time": {
-			{T: ddl.String, Brief: reports.IssueDB[internal.Time].Brief, DisplayT: ddl.String}},
+			{T: ddl.String, Brief: reports.IssueDB[internal.Time].Brief, DisplayT: ddl.String},
+			{T: ddl.Interval, DisplayT: ddl.Interval}},
 	}
 	assert.Equal(t, expectedTypemap, typemap)
 
@@ -686,6 +688,77 @@ func TestUpdateIndexes(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "Interleave an index in the parent table",
+			tableId:    "t2",
+			input:      []ddl.CreateIndex{{Name: "idx", Id: "i1", TableId: "t2", NullFiltered: true, InterleaveIn: "t1", Keys: []ddl.IndexKey{{ColId: "c2", Desc: false, Order: 1}, {ColId: "c3", Desc: false, Order: 2}}}},
+			statusCode: http.StatusOK,
+			conv: &internal.Conv{
+				SpSchema: map[string]ddl.CreateTable{
+					"t1": {
+						Name:        "t1",
+						Id:          "t1",
+						ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1"}},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+					},
+					"t2": {
+						Name:        "t2",
+						Id:          "t2",
+						ColDefs:     map[string]ddl.ColumnDef{"c2": {Name: "a", Id: "c2"}, "c3": {Name: "b", Id: "c3"}},
+						ParentTable: ddl.InterleavedParent{Id: "t1"},
+						Indexes:     []ddl.CreateIndex{{Name: "idx", Id: "i1", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c2", Desc: false, Order: 1}, {ColId: "c3", Desc: false, Order: 2}}}},
+					}},
+				SrcSchema: map[string]schema.Table{"t2": {}},
+				Audit: internal.Audit{
+					MigrationType: migration.MigrationData_SCHEMA_ONLY.Enum(),
+				},
+				UsedNames: map[string]bool{"t1": true, "t2": true, "idx": true},
+			},
+			expectedConv: &internal.Conv{
+				SpSchema: map[string]ddl.CreateTable{
+					"t1": {
+						Name:        "t1",
+						Id:          "t1",
+						ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1"}},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+					},
+					"t2": {
+						Name:        "t2",
+						Id:          "t2",
+						ColDefs:     map[string]ddl.ColumnDef{"c2": {Name: "a", Id: "c2"}, "c3": {Name: "b", Id: "c3"}},
+						ParentTable: ddl.InterleavedParent{Id: "t1"},
+						Indexes:     []ddl.CreateIndex{{Name: "idx", Id: "i1", TableId: "t2", NullFiltered: true, InterleaveIn: "t1", Keys: []ddl.IndexKey{{ColId: "c2", Desc: false, Order: 1}, {ColId: "c3", Desc: false, Order: 2}}}},
+					}},
+				SrcSchema: map[string]schema.Table{"t2": {}},
+			},
+		},
+		{
+			name:       "Index keys must start with the primary key of the table the index is interleaved in",
+			tableId:    "t2",
+			input:      []ddl.CreateIndex{{Name: "idx", Id: "i1", TableId: "t2", InterleaveIn: "t1", Keys: []ddl.IndexKey{{ColId: "c3", Desc: false, Order: 1}}}},
+			statusCode: http.StatusBadRequest,
+			conv: &internal.Conv{
+				SpSchema: map[string]ddl.CreateTable{
+					"t1": {
+						Name:        "t1",
+						Id:          "t1",
+						ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "a", Id: "c1"}},
+						PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+					},
+					"t2": {
+						Name:        "t2",
+						Id:          "t2",
+						ColDefs:     map[string]ddl.ColumnDef{"c2": {Name: "a", Id: "c2"}, "c3": {Name: "b", Id: "c3"}},
+						ParentTable: ddl.InterleavedParent{Id: "t1"},
+						Indexes:     []ddl.CreateIndex{{Name: "idx", Id: "i1", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c3", Desc: false, Order: 1}}}},
+					}},
+				SrcSchema: map[string]schema.Table{"t2": {}},
+				Audit: internal.Audit{
+					MigrationType: migration.MigrationData_SCHEMA_ONLY.Enum(),
+				},
+				UsedNames: map[string]bool{"t1": true, "t2": true, "idx": true},
+			},
+		},
 		{
 			name:       "Two Index key columns can not have same order",
 			tableId:    "t1",
@@ -1589,6 +1662,16 @@ func TestDropTable(t *testing.T) {
 				ParentTable: ddl.InterleavedParent{Id: "t1", OnDelete: constants.FK_CASCADE},
 				Id:          "t2",
 			}},
+		SrcViews: map[string]schema.View{
+			"v1": {Name: "vn1", Id: "v1"},
+			"v2": {Name: "vn2", Id: "v2"},
+			"v3": {Name: "vn3", Id: "v3"},
+		},
+		SpViews: map[string]ddl.CreateView{
+			"v1": {Name: "vn1", Query: "SELECT cn2 FROM tn1", RefIds: []string{"t1"}, Id: "v1"},
+			"v2": {Name: "vn2", Query: "SELECT cn2 FROM vn1", RefIds: []string{"v1"}, Id: "v2"},
+			"v3": {Name: "vn3", Query: "SELECT cn5 FROM tn2", RefIds: []string{"t2"}, Id: "v3"},
+		},
 		Audit: internal.Audit{
 			MigrationType: migration.MigrationData_MIGRATION_TYPE_UNSPECIFIED.Enum(),
 		},
@@ -1633,6 +1716,15 @@ func TestDropTable(t *testing.T) {
 	}
 
 	assert.Equal(t, expectedConv.SpSchema, res.SpSchema)
+	// Views that query the dropped table, directly or through other views,
+	// are no longer migrated.
+	assert.Equal(t, map[string]ddl.CreateView{
+		"v3": {Name: "vn3", Query: "SELECT cn5 FROM tn2", RefIds: []string{"t2"}, Id: "v3"},
+	}, res.SpViews)
+	assert.Equal(t, map[string]string{
+		"v1": "the view refers to table tn1, which was dropped",
+		"v2": "the view refers to view vn1, which wasn't migrated",
+	}, res.InvalidViews)
 }
 
 func TestRestoreTable(t *testing.T) {
@@ -2558,9 +2650,11 @@ func TestGetAutoGenMapMySQL(t *testing.T) {
 		"FLOAT8":      {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}, types.AutoGen{Name: "Sequence1", GenerationType: "Sequence"}},
 		"INT64":       {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}, types.AutoGen{Name: "Sequence1", GenerationType: "Sequence"}},
 		"INT8":        {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}, types.AutoGen{Name: "Sequence1", GenerationType: "Sequence"}},
+		"INTERVAL":    {types.AutoGen{Name: "", GenerationType: ""}},
 		"JSONB":       {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":     {types.AutoGen{Name: "", GenerationType: ""}},
 		"TIMESTAMPTZ": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":        {types.AutoGen{Name: "", GenerationType: ""}},
 		"VARCHAR":     {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "UUID", GenerationType: "Pre-defined"}}}
 
 	expectedAutoGenMapMySql := map[string][]types.AutoGen{
@@ -2570,10 +2664,12 @@ func TestGetAutoGenMapMySQL(t *testing.T) {
 		"FLOAT32":   {types.AutoGen{Name: "", GenerationType: ""}},
 		"FLOAT64":   {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}, types.AutoGen{Name: "Sequence1", GenerationType: "Sequence"}},
 		"INT64":     {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}, types.AutoGen{Name: "Sequence1", GenerationType: "Sequence"}},
+		"INTERVAL":  {types.AutoGen{Name: "", GenerationType: ""}},
 		"JSON":      {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":   {types.AutoGen{Name: "", GenerationType: ""}},
 		"STRING":    {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "UUID", GenerationType: "Pre-defined"}},
-		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}}}
+		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":      {types.AutoGen{Name: "", GenerationType: ""}}}
 	tests := []struct {
 		dialect            string
 		driver             string
@@ -2639,9 +2735,11 @@ func TestGetAutoGenMapPostgres(t *testing.T) {
 		"FLOAT8":      {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}},
 		"INT64":       {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}},
 		"INT8":        {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}},
+		"INTERVAL":    {types.AutoGen{Name: "", GenerationType: ""}},
 		"JSONB":       {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":     {types.AutoGen{Name: "", GenerationType: ""}},
 		"TIMESTAMPTZ": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":        {types.AutoGen{Name: "", GenerationType: ""}},
 		"VARCHAR":     {types.AutoGen{Name: "", GenerationType: ""}}}
 
 	expectedAutoGenMapMySql := map[string][]types.AutoGen{
@@ -2651,10 +2749,12 @@ func TestGetAutoGenMapPostgres(t *testing.T) {
 		"FLOAT32":   {types.AutoGen{Name: "", GenerationType: ""}},
 		"FLOAT64":   {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}},
 		"INT64":     {types.AutoGen{Name: "", GenerationType: ""}, types.AutoGen{Name: "Identity", GenerationType: "Identity"}},
+		"INTERVAL":  {types.AutoGen{Name: "", GenerationType: ""}},
 		"JSON":      {types.AutoGen{Name: "", GenerationType: ""}},
 		"NUMERIC":   {types.AutoGen{Name: "", GenerationType: ""}},
 		"STRING":    {types.AutoGen{Name: "", GenerationType: ""}},
-		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}}}
+		"TIMESTAMP": {types.AutoGen{Name: "", GenerationType: ""}},
+		"UUID":      {types.AutoGen{Name: "", GenerationType: ""}}}
 	tests := []struct {
 		dialect            string
 		driver             string
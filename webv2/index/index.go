@@ -15,6 +15,9 @@
 package index
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
@@ -54,6 +57,7 @@ func CheckIndexSuggestion(index []ddl.CreateIndex, spannerTable ddl.CreateTable)
 
 	checkRedundantIndex(index, spannerTable)
 	checkInterleaveIndex(index, spannerTable)
+	checkParentKeyIndex(index, spannerTable)
 }
 
 // redundantIndex check for redundant Index.
@@ -152,6 +156,112 @@ func checkInterleaveIndex(index []ddl.CreateIndex, spannerTable ddl.CreateTable)
 	}
 }
 
+// checkParentKeyIndex suggests interleaving indexes whose keys start with
+// the primary key of the parent table, so that writes to the index don't
+// span splits. If possible it gets added as a suggestion.
+func checkParentKeyIndex(index []ddl.CreateIndex, spannerTable ddl.CreateTable) {
+	if spannerTable.ParentTable.Id == "" {
+		return
+	}
+	sessionState := session.GetSessionState()
+	parentTable, ok := sessionState.Conv.SpSchema[spannerTable.ParentTable.Id]
+	if !ok || len(parentTable.PrimaryKeys) == 0 {
+		return
+	}
+	// Interleaved tables share the primary key of their parent as a
+	// prefix of their own primary key.
+	pks := sortedKeys(spannerTable.PrimaryKeys)
+	if len(pks) < len(parentTable.PrimaryKeys) {
+		return
+	}
+	parentKeys := pks[:len(parentTable.PrimaryKeys)]
+
+	for i := 0; i < len(index); i++ {
+		if index[i].InterleaveIn != "" {
+			continue
+		}
+		keys := sortedKeys(index[i].Keys)
+		if len(keys) < len(parentKeys) {
+			continue
+		}
+		isPrefix := true
+		for j := range parentKeys {
+			if keys[j].ColId != parentKeys[j].ColId {
+				isPrefix = false
+				break
+			}
+		}
+		if !isPrefix {
+			continue
+		}
+		columnId := keys[0].ColId
+		schemaissue := sessionState.Conv.SchemaIssues[spannerTable.Id].ColumnLevelIssues[columnId]
+		if !utilities.IsSchemaIssuePresent(schemaissue, internal.InterleaveIndex) {
+			schemaissue = append(schemaissue, internal.InterleaveIndex)
+			sessionState.Conv.SchemaIssues[spannerTable.Id].ColumnLevelIssues[columnId] = schemaissue
+		}
+	}
+}
+
+// RemoveInvalidInterleaves stops interleaving indexes in tables that are no
+// longer ancestors of the indexed table.
+// This is called when a table is dropped or stops being interleaved.
+func RemoveInvalidInterleaves(spSchema ddl.Schema) {
+	for tableId, spTable := range spSchema {
+		for i, idx := range spTable.Indexes {
+			if idx.InterleaveIn != "" && !isAncestor(spSchema, tableId, idx.InterleaveIn) {
+				spTable.Indexes[i].InterleaveIn = ""
+			}
+		}
+		spSchema[tableId] = spTable
+	}
+}
+
+// CheckInterleaveIn checks that idx can be interleaved in the table
+// idx.InterleaveIn: the table must be an ancestor of the indexed table, and
+// the keys of the index must start with the primary key of that table.
+func CheckInterleaveIn(spSchema ddl.Schema, idx ddl.CreateIndex) error {
+	if idx.InterleaveIn == "" {
+		return nil
+	}
+	spTable := spSchema[idx.TableId]
+	if !isAncestor(spSchema, idx.TableId, idx.InterleaveIn) {
+		return fmt.Errorf("index %s can't be interleaved in table %s, which isn't an ancestor of table %s", idx.Name, idx.InterleaveIn, spTable.Name)
+	}
+	ancestor := spSchema[idx.InterleaveIn]
+	ancestorKeys := sortedKeys(ancestor.PrimaryKeys)
+	keys := sortedKeys(idx.Keys)
+	if len(keys) < len(ancestorKeys) {
+		return fmt.Errorf("index %s can't be interleaved in table %s: the keys of the index must start with the primary key of table %s", idx.Name, ancestor.Name, ancestor.Name)
+	}
+	for i := range ancestorKeys {
+		if spTable.ColDefs[keys[i].ColId].Name != ancestor.ColDefs[ancestorKeys[i].ColId].Name {
+			return fmt.Errorf("index %s can't be interleaved in table %s: the keys of the index must start with the primary key of table %s", idx.Name, ancestor.Name, ancestor.Name)
+		}
+	}
+	return nil
+}
+
+// isAncestor reports whether the table ancestorId is a parent of the table
+// tableId, or a parent of one of its parents.
+func isAncestor(spSchema ddl.Schema, tableId, ancestorId string) bool {
+	for parentId := spSchema[tableId].ParentTable.Id; parentId != ""; parentId = spSchema[parentId].ParentTable.Id {
+		if parentId == ancestorId {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns a copy of keys sorted by their order.
+func sortedKeys(keys []ddl.IndexKey) []ddl.IndexKey {
+	sorted := append([]ddl.IndexKey{}, keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+	return sorted
+}
+
 // RemoveIndexIssues removes the issues in a column which is part of the passed Index.
 // This is called when we drop an index or make changes in the primarykey of the current table.
 // Editing the primary key can affect the issues in an index (eg. Changing pk order affects Redundant index issue).
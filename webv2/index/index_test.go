@@ -0,0 +1,165 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/webv2/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckParentKeyIndex(t *testing.T) {
+	parent := ddl.CreateTable{
+		Name:        "singers",
+		Id:          "t1",
+		ColIds:      []string{"c1"},
+		ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "singer_id", Id: "c1", T: ddl.Type{Name: ddl.Int64}}},
+		PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+	}
+	child := ddl.CreateTable{
+		Name:   "albums",
+		Id:     "t2",
+		ColIds: []string{"c2", "c3", "c4"},
+		ColDefs: map[string]ddl.ColumnDef{
+			"c2": {Name: "singer_id", Id: "c2", T: ddl.Type{Name: ddl.Int64}},
+			"c3": {Name: "album_id", Id: "c3", T: ddl.Type{Name: ddl.Int64}},
+			"c4": {Name: "title", Id: "c4", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+		},
+		PrimaryKeys: []ddl.IndexKey{{ColId: "c2", Order: 1}, {ColId: "c3", Order: 2}},
+		ParentTable: ddl.InterleavedParent{Id: "t1"},
+	}
+	testCases := []struct {
+		name     string
+		index    ddl.CreateIndex
+		expected []internal.SchemaIssue
+	}{
+		{
+			name:     "key starts with parent key",
+			index:    ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}, {ColId: "c4", Order: 2}}},
+			expected: []internal.SchemaIssue{internal.InterleaveIndex},
+		},
+		{
+			name:     "key doesn't start with parent key",
+			index:    ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c4", Order: 1}, {ColId: "c2", Order: 2}}},
+			expected: nil,
+		},
+		{
+			name:     "already interleaved",
+			index:    ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}, {ColId: "c4", Order: 2}}, InterleaveIn: "t1"},
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		sessionState := session.GetSessionState()
+		sessionState.Conv = internal.MakeConv()
+		sessionState.Conv.SpSchema = ddl.Schema{"t1": parent, "t2": child}
+		sessionState.Conv.SchemaIssues = map[string]internal.TableIssues{
+			"t2": {ColumnLevelIssues: map[string][]internal.SchemaIssue{}},
+		}
+		checkParentKeyIndex([]ddl.CreateIndex{tc.index}, child)
+		assert.Equal(t, tc.expected, sessionState.Conv.SchemaIssues["t2"].ColumnLevelIssues["c2"], tc.name)
+	}
+}
+
+func TestRemoveInvalidInterleaves(t *testing.T) {
+	spSchema := ddl.Schema{
+		"t1": {Name: "singers", Id: "t1"},
+		"t2": {Name: "albums", Id: "t2", ParentTable: ddl.InterleavedParent{Id: "t1"}},
+		"t3": {
+			Name:        "songs",
+			Id:          "t3",
+			ParentTable: ddl.InterleavedParent{Id: "t2"},
+			Indexes: []ddl.CreateIndex{
+				{Name: "in_singers", TableId: "t3", InterleaveIn: "t1"},
+				{Name: "in_albums", TableId: "t3", InterleaveIn: "t2"},
+			},
+		},
+	}
+	RemoveInvalidInterleaves(spSchema)
+	assert.Equal(t, "t1", spSchema["t3"].Indexes[0].InterleaveIn)
+	assert.Equal(t, "t2", spSchema["t3"].Indexes[1].InterleaveIn)
+
+	// Once albums stops being interleaved, singers is no longer an
+	// ancestor of songs.
+	albums := spSchema["t2"]
+	albums.ParentTable = ddl.InterleavedParent{}
+	spSchema["t2"] = albums
+	RemoveInvalidInterleaves(spSchema)
+	assert.Equal(t, "", spSchema["t3"].Indexes[0].InterleaveIn)
+	assert.Equal(t, "t2", spSchema["t3"].Indexes[1].InterleaveIn)
+}
+
+func TestCheckInterleaveIn(t *testing.T) {
+	spSchema := ddl.Schema{
+		"t1": {
+			Name:        "singers",
+			Id:          "t1",
+			ColDefs:     map[string]ddl.ColumnDef{"c1": {Name: "singer_id", Id: "c1"}},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+		"t2": {
+			Name: "albums",
+			Id:   "t2",
+			ColDefs: map[string]ddl.ColumnDef{
+				"c2": {Name: "singer_id", Id: "c2"},
+				"c3": {Name: "album_id", Id: "c3"},
+				"c4": {Name: "title", Id: "c4"},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c2", Order: 1}, {ColId: "c3", Order: 2}},
+			ParentTable: ddl.InterleavedParent{Id: "t1"},
+		},
+		"t3": {Name: "venues", Id: "t3"},
+	}
+	testCases := []struct {
+		name  string
+		index ddl.CreateIndex
+		err   string
+	}{
+		{
+			name:  "not interleaved",
+			index: ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c4", Order: 1}}},
+		},
+		{
+			name:  "key starts with ancestor key",
+			index: ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c4", Order: 2}, {ColId: "c2", Order: 1}}, InterleaveIn: "t1"},
+		},
+		{
+			name:  "key doesn't start with ancestor key",
+			index: ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c4", Order: 1}, {ColId: "c2", Order: 2}}, InterleaveIn: "t1"},
+			err:   "the keys of the index must start with the primary key of table singers",
+		},
+		{
+			name:  "interleaved in its own table",
+			index: ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}}, InterleaveIn: "t2"},
+			err:   "which isn't an ancestor of table albums",
+		},
+		{
+			name:  "not an ancestor",
+			index: ddl.CreateIndex{Name: "idx", TableId: "t2", Keys: []ddl.IndexKey{{ColId: "c2", Order: 1}}, InterleaveIn: "t3"},
+			err:   "which isn't an ancestor of table albums",
+		},
+	}
+	for _, tc := range testCases {
+		err := CheckInterleaveIn(spSchema, tc.index)
+		if tc.err == "" {
+			assert.Nil(t, err, tc.name)
+		} else {
+			assert.ErrorContains(t, err, tc.err, tc.name)
+		}
+	}
+}
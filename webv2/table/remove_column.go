@@ -15,6 +15,8 @@
 package table
 
 import (
+	"fmt"
+
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/common/constants"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
@@ -43,6 +45,10 @@ func RemoveColumn(tableId string, colId string, conv *internal.Conv) {
 		conv.SpSchema[id] = sp
 	}
 
+	if col, ok := conv.SpSchema[tableId].ColDefs[colId]; ok {
+		invalidateViewsOfColumn(conv, tableId, col.Name, fmt.Sprintf("the view refers to column %s of table %s, which was removed", col.Name, conv.SpSchema[tableId].Name))
+	}
+
 	//remove column from the table.
 	removeColumnFromTableSchema(conv, tableId, colId)
 
@@ -66,6 +72,8 @@ func removeColumnFromTableSchema(conv *internal.Conv, tableId string, colId stri
 
 	sp = removeColumnFromSpannerColNames(sp, colId)
 
+	sp = removeColumnFromRowDeletionPolicy(sp, colId)
+
 	removeSpannerSchemaIssue(tableId, colId, conv)
 
 	conv.SpSchema[tableId] = sp
@@ -88,6 +96,15 @@ func removeColumnFromSpannerColNames(sp ddl.CreateTable, colId string) ddl.Creat
 	return sp
 }
 
+// removeColumnFromRowDeletionPolicy removes the row deletion policy that
+// uses the given column.
+func removeColumnFromRowDeletionPolicy(sp ddl.CreateTable, colId string) ddl.CreateTable {
+	if sp.RowDeletionPolicy != nil && sp.RowDeletionPolicy.ColId == colId {
+		sp.RowDeletionPolicy = nil
+	}
+	return sp
+}
+
 // removeColumnFromSpannerPK remove given column from Primary Key List.
 func removeColumnFromSpannerPK(sp ddl.CreateTable, colId string) ddl.CreateTable {
 
@@ -15,7 +15,11 @@
 package table
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/GoogleCloudPlatform/spanner-migration-tool/internal"
+	"github.com/GoogleCloudPlatform/spanner-migration-tool/spanner/ddl"
 )
 
 // renameColumn renames given column to newname and update in schema.
@@ -26,6 +30,8 @@ func renameColumn(newName, tableId, colId string, conv *internal.Conv) {
 
 	if ok {
 
+		invalidateViewsOfColumn(conv, tableId, spColumn.Name, fmt.Sprintf("the view refers to column %s of table %s, which was renamed to %s", spColumn.Name, spTable.Name, newName))
+
 		spColumn.Name = newName
 
 		spTable.ColDefs[colId] = spColumn
@@ -41,4 +47,14 @@ func renameColumn(newName, tableId, colId string, conv *internal.Conv) {
 		}
 
 	}
-}
\ No newline at end of file
+}
+
+// invalidateViewsOfColumn moves the views whose queries refer to column
+// colName of table tableId to conv.InvalidViews, since their queries were
+// verified against the column.
+func invalidateViewsOfColumn(conv *internal.Conv, tableId, colName, reason string) {
+	re := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(colName) + `\b`)
+	internal.InvalidateViews(conv, func(v ddl.CreateView) bool {
+		return internal.Contains(v.RefIds, tableId) && re.MatchString(v.Query)
+	}, reason)
+}
@@ -113,5 +113,11 @@ func UpdateColumnTypeChangeTableSchema(conv *internal.Conv, tableId string, colI
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return err
 	}
+	// Row deletion policies need a timestamp column.
+	sp := conv.SpSchema[tableId]
+	if sp.RowDeletionPolicy != nil && sp.RowDeletionPolicy.ColId == colId && sp.ColDefs[colId].T.Name != ddl.Timestamp {
+		sp.RowDeletionPolicy = nil
+		conv.SpSchema[tableId] = sp
+	}
 	return nil
 }
@@ -1311,3 +1311,33 @@ func TestUpdateTableSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestRenameAndRemoveColumnInvalidateViews(t *testing.T) {
+	conv := internal.MakeConv()
+	conv.SpSchema = ddl.Schema{
+		"t1": {
+			Name:   "orders",
+			Id:     "t1",
+			ColIds: []string{"c1", "c2", "c3"},
+			ColDefs: map[string]ddl.ColumnDef{
+				"c1": {Name: "id", Id: "c1", T: ddl.Type{Name: ddl.Int64}},
+				"c2": {Name: "total", Id: "c2", T: ddl.Type{Name: ddl.Float64}},
+				"c3": {Name: "note", Id: "c3", T: ddl.Type{Name: ddl.String, Len: ddl.MaxLength}},
+			},
+			PrimaryKeys: []ddl.IndexKey{{ColId: "c1", Order: 1}},
+		},
+	}
+	conv.SpViews = map[string]ddl.CreateView{
+		"v1": {Name: "totals", Query: "SELECT id, TOTAL FROM orders", RefIds: []string{"t1"}, Id: "v1"},
+		"v2": {Name: "ids", Query: "SELECT id FROM orders", RefIds: []string{"t1"}, Id: "v2"},
+		"v3": {Name: "notes", Query: "SELECT note FROM orders", RefIds: []string{"t1"}, Id: "v3"},
+	}
+
+	renameColumn("amount", "t1", "c2", conv)
+	assert.Equal(t, map[string]string{"v1": "the view refers to column total of table orders, which was renamed to amount"}, conv.InvalidViews)
+
+	RemoveColumn("t1", "c3", conv)
+	assert.Equal(t, "the view refers to column note of table orders, which was removed", conv.InvalidViews["v3"])
+	assert.Len(t, conv.SpViews, 1)
+	assert.Contains(t, conv.SpViews, "v2")
+}
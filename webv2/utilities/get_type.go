@@ -57,9 +57,7 @@ func GetType(conv *internal.Conv, newType, tableId, colId string) (ddl.CreateTab
 	default:
 		return sp, ty, fmt.Errorf("driver : '%s' is not supported", sessionState.Driver)
 	}
-	if len(srcCol.Type.ArrayBounds) > 0 && conv.SpDialect == constants.DIALECT_POSTGRESQL {
-		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
-	} else if len(srcCol.Type.ArrayBounds) > 1 {
+	if len(srcCol.Type.ArrayBounds) > 1 {
 		ty = ddl.Type{Name: ddl.String, Len: ddl.MaxLength}
 		issues = append(issues, internal.MultiDimensionalArray)
 	}
@@ -60,7 +60,7 @@ func TestUpdateDataType(t *testing.T) {
 			dialect:  constants.DIALECT_GOOGLESQL,
 			srcCol:   schema.Column{Name: "col1", Type: schema.Type{Name: "uuid"}},
 			spColDef: ddl.ColumnDef{Name: "col1", T: ddl.Type{Name: ddl.String, Len: 36}},
-			newType:  "TEXT",
+			newType:  "STRING",
 			wantType: ddl.Type{Name: ddl.String, Len: ddl.MaxLength},
 			wantOpts: map[string]string{"cassandra_type": "uuid"},
 			wantErr:  false,